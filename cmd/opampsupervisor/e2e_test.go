@@ -228,16 +228,16 @@ func TestSupervisorStartsCollectorWithRemoteConfig(t *testing.T) {
 	}, 10*time.Second, 500*time.Millisecond, "Log never appeared in output")
 }
 
-func TestSupervisorRestartsCollectorAfterBadConfig(t *testing.T) {
-	var healthReport atomic.Value
+func TestSupervisorRollsBackBadConfig(t *testing.T) {
+	var remoteConfigStatus atomic.Value
 	var agentConfig atomic.Value
 	server := newOpAMPServer(
 		t,
 		defaultConnectingHandler,
 		server.ConnectionCallbacksStruct{
 			OnMessageFunc: func(_ context.Context, _ types.Connection, message *protobufs.AgentToServer) *protobufs.ServerToAgent {
-				if message.Health != nil {
-					healthReport.Store(message.Health)
+				if message.RemoteConfigStatus != nil {
+					remoteConfigStatus.Store(message.RemoteConfigStatus)
 				}
 				if message.EffectiveConfig != nil {
 					config := message.EffectiveConfig.ConfigMap.ConfigMap[""]
@@ -268,28 +268,69 @@ func TestSupervisorRestartsCollectorAfterBadConfig(t *testing.T) {
 		},
 	})
 
+	require.Eventually(t, func() bool {
+		status, ok := remoteConfigStatus.Load().(*protobufs.RemoteConfigStatus)
+
+		return ok && bytes.Equal(status.LastRemoteConfigHash, hash) &&
+			status.Status == protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED &&
+			status.ErrorMessage != ""
+	}, 10*time.Second, 250*time.Millisecond, "Supervisor never reported that the remote config failed")
+
 	require.Eventually(t, func() bool {
 		cfg, ok := agentConfig.Load().(string)
-		if ok {
-			// The effective config may be structurally different compared to what was sent,
-			// so just check that it includes some strings we know to be unique to the remote config.
-			return strings.Contains(cfg, "doesntexist")
-		}
 
-		return false
-	}, 5*time.Second, 500*time.Millisecond, "Collector was not started with remote config")
+		return ok && !strings.Contains(cfg, "doesntexist")
+	}, 5*time.Second, 250*time.Millisecond, "Supervisor never rolled back the bad config")
+
+	cfg, hash, _, _ = createSimplePipelineCollectorConf(t)
+
+	server.sendToSupervisor(&protobufs.ServerToAgent{
+		RemoteConfig: &protobufs.AgentRemoteConfig{
+			Config: &protobufs.AgentConfigMap{
+				ConfigMap: map[string]*protobufs.AgentConfigFile{
+					"": {Body: cfg.Bytes()},
+				},
+			},
+			ConfigHash: hash,
+		},
+	})
 
 	require.Eventually(t, func() bool {
-		health := healthReport.Load().(*protobufs.ComponentHealth)
+		status, ok := remoteConfigStatus.Load().(*protobufs.RemoteConfigStatus)
 
-		if health != nil {
-			return !health.Healthy && health.LastError != ""
-		}
+		return ok && bytes.Equal(status.LastRemoteConfigHash, hash) &&
+			status.Status == protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED
+	}, 10*time.Second, 250*time.Millisecond, "Supervisor never reported that the remote config was applied")
+}
 
-		return false
-	}, 5*time.Second, 250*time.Millisecond, "Supervisor never reported that the Collector was unhealthy")
+func TestSupervisorPersistsLastAppliedRemoteConfig(t *testing.T) {
+	var remoteConfigStatus atomic.Value
+	var agentConfig atomic.Value
+	server := newOpAMPServer(
+		t,
+		defaultConnectingHandler,
+		server.ConnectionCallbacksStruct{
+			OnMessageFunc: func(_ context.Context, _ types.Connection, message *protobufs.AgentToServer) *protobufs.ServerToAgent {
+				if message.RemoteConfigStatus != nil {
+					remoteConfigStatus.Store(message.RemoteConfigStatus)
+				}
+				if message.EffectiveConfig != nil {
+					config := message.EffectiveConfig.ConfigMap.ConfigMap[""]
+					if config != nil {
+						agentConfig.Store(string(config.Body))
+					}
+				}
 
-	cfg, hash, _, _ = createSimplePipelineCollectorConf(t)
+				return &protobufs.ServerToAgent{}
+			},
+		})
+
+	storageDir := t.TempDir()
+	s := newSupervisor(t, "storage", map[string]string{"url": server.addr, "storage_dir": storageDir})
+
+	waitForSupervisorConnection(server.supervisorConnected, true)
+
+	cfg, hash, inputFile, _ := createSimplePipelineCollectorConf(t)
 
 	server.sendToSupervisor(&protobufs.ServerToAgent{
 		RemoteConfig: &protobufs.AgentRemoteConfig{
@@ -303,14 +344,36 @@ func TestSupervisorRestartsCollectorAfterBadConfig(t *testing.T) {
 	})
 
 	require.Eventually(t, func() bool {
-		health := healthReport.Load().(*protobufs.ComponentHealth)
+		status, ok := remoteConfigStatus.Load().(*protobufs.RemoteConfigStatus)
 
-		if health != nil {
-			return health.Healthy && health.LastError == ""
-		}
+		return ok && bytes.Equal(status.LastRemoteConfigHash, hash) &&
+			status.Status == protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED
+	}, 10*time.Second, 250*time.Millisecond, "Supervisor never reported that the remote config was applied")
 
-		return false
-	}, 5*time.Second, 250*time.Millisecond, "Supervisor never reported that the Collector became healthy")
+	s.Shutdown()
+	waitForSupervisorConnection(server.supervisorConnected, false)
+	remoteConfigStatus.Store(&protobufs.RemoteConfigStatus{})
+	agentConfig.Store("")
+
+	// The restarted Supervisor reports the persisted remote config as applied and runs
+	// the Collector with it without receiving it again.
+	s = newSupervisor(t, "storage", map[string]string{"url": server.addr, "storage_dir": storageDir})
+	defer s.Shutdown()
+
+	waitForSupervisorConnection(server.supervisorConnected, true)
+
+	require.Eventually(t, func() bool {
+		status, ok := remoteConfigStatus.Load().(*protobufs.RemoteConfigStatus)
+
+		return ok && bytes.Equal(status.LastRemoteConfigHash, hash) &&
+			status.Status == protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED
+	}, 10*time.Second, 250*time.Millisecond, "Supervisor did not report the persisted remote config")
+
+	require.Eventually(t, func() bool {
+		cfg, ok := agentConfig.Load().(string)
+
+		return ok && strings.Contains(cfg, inputFile.Name())
+	}, 5*time.Second, 250*time.Millisecond, "Collector was not started with the persisted remote config")
 }
 
 func TestSupervisorConfiguresCapabilities(t *testing.T) {
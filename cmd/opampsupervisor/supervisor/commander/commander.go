@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"strings"
 	"sync/atomic"
 	"syscall"
 	"time"
@@ -21,12 +22,14 @@ import (
 // Commander can start/stop/restart the Agent executable and also watch for a signal
 // for the Agent process to finish.
 type Commander struct {
-	logger  *zap.Logger
-	cfg     *config.Agent
-	args    []string
-	cmd     *exec.Cmd
-	doneCh  chan struct{}
-	running *atomic.Int64
+	logger      *zap.Logger
+	cfg         *config.Agent
+	executable  string
+	args        []string
+	logFilePath string
+	cmd         *exec.Cmd
+	doneCh      chan struct{}
+	running     *atomic.Int64
 }
 
 func NewCommander(logger *zap.Logger, cfg *config.Agent, args ...string) (*Commander, error) {
@@ -35,13 +38,20 @@ func NewCommander(logger *zap.Logger, cfg *config.Agent, args ...string) (*Comma
 	}
 
 	return &Commander{
-		logger:  logger,
-		cfg:     cfg,
-		args:    args,
-		running: &atomic.Int64{},
+		logger:      logger,
+		cfg:         cfg,
+		executable:  cfg.Executable,
+		args:        args,
+		logFilePath: "agent.log",
+		running:     &atomic.Int64{},
 	}, nil
 }
 
+// SetExecutable changes the Agent executable run the next time the Agent is started.
+func (c *Commander) SetExecutable(executable string) {
+	c.executable = executable
+}
+
 // Start the Agent and begin watching the process.
 // Agent's stdout and stderr are written to a file.
 // Calling this method when a command is already running
@@ -52,15 +62,14 @@ func (c *Commander) Start(ctx context.Context) error {
 		return nil
 	}
 
-	c.logger.Debug("Starting agent", zap.String("agent", c.cfg.Executable))
+	c.logger.Debug("Starting agent", zap.String("agent", c.executable))
 
-	logFilePath := "agent.log"
-	logFile, err := os.Create(logFilePath)
+	logFile, err := os.Create(c.logFilePath)
 	if err != nil {
-		return fmt.Errorf("cannot create %s: %w", logFilePath, err)
+		return fmt.Errorf("cannot create %s: %w", c.logFilePath, err)
 	}
 
-	c.cmd = exec.CommandContext(ctx, c.cfg.Executable, c.args...) // #nosec G204
+	c.cmd = exec.CommandContext(ctx, c.executable, c.args...) // #nosec G204
 
 	// Capture standard output and standard error.
 	// https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/21072
@@ -125,6 +134,18 @@ func (c *Commander) ExitCode() int {
 	return c.cmd.ProcessState.ExitCode()
 }
 
+// LastLogLine returns the last line written by the Agent process to its standard output
+// or standard error since it was last started. When the Agent exits unexpectedly, this
+// is usually the reason it failed.
+func (c *Commander) LastLogLine() string {
+	logs, err := os.ReadFile(c.logFilePath)
+	if err != nil {
+		return ""
+	}
+	lines := strings.Split(strings.TrimSpace(string(logs)), "\n")
+	return lines[len(lines)-1]
+}
+
 func (c *Commander) IsRunning() bool {
 	return c.running.Load() != 0
 }
@@ -4,6 +4,8 @@
 package config
 
 import (
+	"time"
+
 	"go.opentelemetry.io/collector/config/configtls"
 )
 
@@ -12,6 +14,8 @@ type Supervisor struct {
 	Server       *OpAMPServer
 	Agent        *Agent
 	Capabilities *Capabilities `mapstructure:"capabilities"`
+	Storage      *Storage      `mapstructure:"storage"`
+	Packages     *Packages     `mapstructure:"packages"`
 }
 
 // Capabilities is the set of capabilities that the Supervisor supports.
@@ -21,6 +25,7 @@ type Capabilities struct {
 	ReportsOwnMetrics      *bool `mapstructure:"reports_own_metrics"`
 	ReportsHealth          *bool `mapstructure:"reports_health"`
 	ReportsRemoteConfig    *bool `mapstructure:"reports_remote_config"`
+	AcceptsPackages        *bool `mapstructure:"accepts_packages"`
 }
 
 type OpAMPServer struct {
@@ -30,4 +35,26 @@ type OpAMPServer struct {
 
 type Agent struct {
 	Executable string
+	// ConfigFile is the path to a local base config for the Collector. The remote
+	// config received from the OpAMP server is merged on top of it.
+	ConfigFile string `mapstructure:"config_file"`
+	// ConfigApplyTimeout is how long the Collector has to become healthy after a new
+	// config is applied before the Supervisor rolls back to the last known good config.
+	ConfigApplyTimeout time.Duration `mapstructure:"config_apply_timeout"`
+}
+
+// Storage is the location where the Supervisor persists its state across restarts.
+type Storage struct {
+	Directory string `mapstructure:"directory"`
+}
+
+// Packages configures how the Supervisor installs the Collector binaries offered by the
+// OpAMP server.
+type Packages struct {
+	// PublicKeyFile is the path to a PEM encoded Ed25519 public key. When set, the
+	// Collector binaries must be signed with the matching private key.
+	PublicKeyFile string `mapstructure:"public_key_file"`
+	// AllowUnsigned installs the Collector binaries without verifying their signature
+	// when no public key is set. By default, they are refused.
+	AllowUnsigned bool `mapstructure:"allow_unsigned"`
 }
@@ -0,0 +1,500 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/open-telemetry/opamp-go/client/types"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/opampsupervisor/supervisor/config"
+)
+
+const (
+	// packagesDir is the directory of the storage directory holding the Collector binaries.
+	packagesDir = "packages"
+	// packagesStateFile is the file of the storage directory holding the state of the packages.
+	packagesStateFile = "packages.json"
+	// packageStatusesFile is the file of the storage directory holding the last package
+	// statuses reported to the server.
+	packageStatusesFile = "package_statuses.dat"
+)
+
+var _ types.PackagesStateProvider = (*packageManager)(nil)
+
+// packagesState is the state of the packages persisted in the storage directory.
+type packagesState struct {
+	AllPackagesHash []byte                   `json:"all_packages_hash,omitempty"`
+	Packages        map[string]*localPackage `json:"packages,omitempty"`
+}
+
+// localPackage is a package installed by the Supervisor.
+type localPackage struct {
+	Type    protobufs.PackageType `json:"type"`
+	Hash    []byte                `json:"hash,omitempty"`
+	Version string                `json:"version,omitempty"`
+	// ContentHash is the SHA-256 hash of the Collector binary stored in Executable.
+	ContentHash []byte `json:"content_hash,omitempty"`
+	Executable  string `json:"executable,omitempty"`
+}
+
+// stagedPackage is a downloaded and verified Collector binary. It is installed once the
+// Collector is healthy when run from it.
+type stagedPackage struct {
+	name        string
+	version     string
+	hash        []byte
+	contentHash []byte
+	executable  string
+	// The package before the Collector binary was downloaded, restored on rollback.
+	previous localPackage
+}
+
+// packageManager stores the packages offered by the OpAMP server in the storage directory.
+// It is used by the packages syncer of the OpAMP client to download them. The only
+// supported package is the top-level package, which is the Collector binary.
+type packageManager struct {
+	logger        *zap.Logger
+	dir           string
+	publicKey     ed25519.PublicKey
+	allowUnsigned bool
+
+	mu    sync.Mutex
+	state packagesState
+	// Signatures of the package files offered in the last PackagesAvailable message.
+	signatures map[string][]byte
+	// Collector binaries downloaded but not yet reported as installed by the syncer.
+	downloaded map[string]*stagedPackage
+
+	staged chan *stagedPackage
+}
+
+func newPackageManager(logger *zap.Logger, storageDir string, cfg config.Packages) (*packageManager, error) {
+	p := &packageManager{
+		logger:        logger,
+		dir:           storageDir,
+		allowUnsigned: cfg.AllowUnsigned,
+		state:         packagesState{Packages: map[string]*localPackage{}},
+		signatures:    map[string][]byte{},
+		downloaded:    map[string]*stagedPackage{},
+		staged:        make(chan *stagedPackage, 1),
+	}
+
+	switch {
+	case cfg.PublicKeyFile != "":
+		publicKey, err := loadPublicKey(cfg.PublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		p.publicKey = publicKey
+	case cfg.AllowUnsigned:
+		logger.Warn("The Collector binaries are installed without verifying their signature")
+	default:
+		logger.Warn("No public key is set to verify the Collector binaries, they will be refused")
+	}
+
+	if err := os.MkdirAll(filepath.Join(storageDir, packagesDir), 0700); err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(storageDir, packagesStateFile))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, err
+	default:
+		if err = json.Unmarshal(data, &p.state); err != nil {
+			return nil, fmt.Errorf("cannot parse %s: %w", packagesStateFile, err)
+		}
+		if p.state.Packages == nil {
+			p.state.Packages = map[string]*localPackage{}
+		}
+	}
+
+	return p, nil
+}
+
+// loadPublicKey loads a PEM encoded Ed25519 public key.
+func loadPublicKey(publicKeyFile string) (ed25519.PublicKey, error) {
+	data, err := os.ReadFile(publicKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in %s", publicKeyFile)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse public key %s: %w", publicKeyFile, err)
+	}
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("public key %s is not an Ed25519 key", publicKeyFile)
+	}
+	return publicKey, nil
+}
+
+// Staged returns a channel receiving the Collector binaries ready to be run.
+func (p *packageManager) Staged() <-chan *stagedPackage {
+	if p == nil {
+		return nil
+	}
+	return p.staged
+}
+
+// executable returns the Collector binary installed from the top-level package, or an
+// empty string if none was installed.
+func (p *packageManager) executable() string {
+	if p == nil {
+		return ""
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, pkg := range p.state.Packages {
+		if pkg.Type == protobufs.PackageType_PackageType_TopLevel && pkg.Executable != "" {
+			return pkg.Executable
+		}
+	}
+	return ""
+}
+
+// setAvailable records the signatures of the package files offered by the server.
+func (p *packageManager) setAvailable(available *protobufs.PackagesAvailable) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.signatures = map[string][]byte{}
+	for name, pkg := range available.GetPackages() {
+		p.signatures[name] = pkg.GetFile().GetSignature()
+	}
+}
+
+// install records the staged Collector binary as the installed one once the Collector is
+// healthy when run from it, and removes the previous binary.
+func (p *packageManager) install(staged *stagedPackage) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.state.Packages[staged.name] = &localPackage{
+		Type:        protobufs.PackageType_PackageType_TopLevel,
+		Hash:        staged.hash,
+		Version:     staged.version,
+		ContentHash: staged.contentHash,
+		Executable:  staged.executable,
+	}
+	if err := p.saveState(); err != nil {
+		return err
+	}
+
+	if staged.previous.Executable != "" && staged.previous.Executable != staged.executable {
+		return os.Remove(staged.previous.Executable)
+	}
+	return nil
+}
+
+// rollback restores the package as it was before the staged Collector binary was
+// downloaded, and returns the package statuses reporting the failed installation.
+func (p *packageManager) rollback(staged *stagedPackage, cause error) (*protobufs.PackageStatuses, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	previous := staged.previous
+	p.state.Packages[staged.name] = &previous
+	// Keep the hash of all packages so that the same offer is not installed again.
+	if err := p.saveState(); err != nil {
+		return nil, err
+	}
+	if staged.executable != previous.Executable {
+		if err := os.Remove(staged.executable); err != nil && !errors.Is(err, os.ErrNotExist) {
+			p.logger.Error("Could not remove the Collector binary", zap.String("path", staged.executable), zap.Error(err))
+		}
+	}
+
+	statuses, err := p.loadStatuses()
+	if err != nil {
+		return nil, err
+	}
+	if statuses == nil {
+		return nil, errors.New("no package statuses were reported")
+	}
+	if statuses.Packages == nil {
+		statuses.Packages = map[string]*protobufs.PackageStatus{}
+	}
+	status, ok := statuses.Packages[staged.name]
+	if !ok {
+		status = &protobufs.PackageStatus{Name: staged.name}
+		statuses.Packages[staged.name] = status
+	}
+	status.AgentHasHash = previous.Hash
+	status.AgentHasVersion = previous.Version
+	status.Status = protobufs.PackageStatusEnum_PackageStatusEnum_InstallFailed
+	status.ErrorMessage = cause.Error()
+
+	return statuses, p.saveStatuses(statuses)
+}
+
+// AllPackagesHash implements types.PackagesStateProvider.
+func (p *packageManager) AllPackagesHash() ([]byte, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.state.AllPackagesHash, nil
+}
+
+// SetAllPackagesHash implements types.PackagesStateProvider.
+func (p *packageManager) SetAllPackagesHash(hash []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.state.AllPackagesHash = hash
+	return p.saveState()
+}
+
+// Packages implements types.PackagesStateProvider.
+func (p *packageManager) Packages() ([]string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	names := make([]string, 0, len(p.state.Packages))
+	for name := range p.state.Packages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// PackageState implements types.PackagesStateProvider.
+func (p *packageManager) PackageState(packageName string) (types.PackageState, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pkg, ok := p.state.Packages[packageName]
+	if !ok {
+		return types.PackageState{Exists: false}, nil
+	}
+	return types.PackageState{
+		Exists:  true,
+		Type:    pkg.Type,
+		Hash:    pkg.Hash,
+		Version: pkg.Version,
+	}, nil
+}
+
+// SetPackageState implements types.PackagesStateProvider. The Collector binary downloaded
+// for the package is staged to be run by the Supervisor.
+func (p *packageManager) SetPackageState(packageName string, state types.PackageState) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pkg, ok := p.state.Packages[packageName]
+	if !ok {
+		return fmt.Errorf("package %s does not exist", packageName)
+	}
+
+	staged, ok := p.downloaded[packageName]
+	if !ok {
+		pkg.Type = state.Type
+		pkg.Hash = state.Hash
+		pkg.Version = state.Version
+		return p.saveState()
+	}
+	delete(p.downloaded, packageName)
+
+	staged.hash = state.Hash
+	staged.version = state.Version
+	// Replace a staged binary which was not run yet.
+	select {
+	case <-p.staged:
+	default:
+	}
+	p.staged <- staged
+	return nil
+}
+
+// CreatePackage implements types.PackagesStateProvider.
+func (p *packageManager) CreatePackage(packageName string, typ protobufs.PackageType) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.state.Packages[packageName]; ok {
+		return fmt.Errorf("package %s already exists", packageName)
+	}
+	if typ != protobufs.PackageType_PackageType_TopLevel {
+		return errors.New("only the top-level package is supported")
+	}
+	for name, pkg := range p.state.Packages {
+		if pkg.Type == protobufs.PackageType_PackageType_TopLevel {
+			return fmt.Errorf("the top-level package already exists with the name %q", name)
+		}
+	}
+
+	p.state.Packages[packageName] = &localPackage{Type: typ}
+	return p.saveState()
+}
+
+// FileContentHash implements types.PackagesStateProvider.
+func (p *packageManager) FileContentHash(packageName string) ([]byte, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pkg, ok := p.state.Packages[packageName]
+	if !ok {
+		return nil, nil
+	}
+	return pkg.ContentHash, nil
+}
+
+// UpdateContent implements types.PackagesStateProvider. The Collector binary is verified
+// against its content hash and its signature, unless unsigned binaries are allowed.
+func (p *packageManager) UpdateContent(ctx context.Context, packageName string, data io.Reader, contentHash []byte) error {
+	if len(contentHash) == 0 {
+		return errors.New("the package file has no content hash")
+	}
+
+	p.mu.Lock()
+	pkg, ok := p.state.Packages[packageName]
+	signature := p.signatures[packageName]
+	p.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("package %s does not exist", packageName)
+	}
+
+	executable := filepath.Join(p.dir, packagesDir, fmt.Sprintf("otelcol-%x", contentHash[:min(len(contentHash), 8)]))
+	if runtime.GOOS == "windows" {
+		executable += ".exe"
+	}
+	if err := p.writeExecutable(ctx, executable, data, contentHash, signature); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.downloaded[packageName] = &stagedPackage{
+		name:        packageName,
+		contentHash: contentHash,
+		executable:  executable,
+		previous:    *pkg,
+	}
+	return nil
+}
+
+// writeExecutable writes the Collector binary once it is verified.
+func (p *packageManager) writeExecutable(ctx context.Context, executable string, data io.Reader, contentHash []byte, signature []byte) error {
+	if p.publicKey == nil && !p.allowUnsigned {
+		return errors.New("the package file cannot be verified, no public key is set and unsigned packages are not allowed")
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(executable), filepath.Base(executable)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	h := sha256.New()
+	_, err = io.Copy(io.MultiWriter(tmpFile, h), data)
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("cannot write the package file: %w", err)
+	}
+	if err = ctx.Err(); err != nil {
+		return err
+	}
+
+	digest := h.Sum(nil)
+	if !bytes.Equal(digest, contentHash) {
+		return fmt.Errorf("the package file hash %x does not match the content hash %x", digest, contentHash)
+	}
+	if p.publicKey != nil {
+		if len(signature) == 0 {
+			return errors.New("the package file is not signed")
+		}
+		if !ed25519.Verify(p.publicKey, digest, signature) {
+			return errors.New("the package file signature is invalid")
+		}
+	}
+
+	if err = os.Chmod(tmpFile.Name(), 0700); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile.Name(), executable)
+}
+
+// DeletePackage implements types.PackagesStateProvider. The Collector binary of the
+// package is kept until the Supervisor restarts the Collector from another binary.
+func (p *packageManager) DeletePackage(packageName string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.state.Packages, packageName)
+	return p.saveState()
+}
+
+// LastReportedStatuses implements types.PackagesStateProvider.
+func (p *packageManager) LastReportedStatuses() (*protobufs.PackageStatuses, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.loadStatuses()
+}
+
+// SetLastReportedStatuses implements types.PackagesStateProvider.
+func (p *packageManager) SetLastReportedStatuses(statuses *protobufs.PackageStatuses) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.saveStatuses(statuses)
+}
+
+func (p *packageManager) loadStatuses() (*protobufs.PackageStatuses, error) {
+	data, err := os.ReadFile(filepath.Join(p.dir, packageStatusesFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := &protobufs.PackageStatuses{}
+	if err = proto.Unmarshal(data, statuses); err != nil {
+		return nil, fmt.Errorf("cannot parse %s: %w", packageStatusesFile, err)
+	}
+	return statuses, nil
+}
+
+func (p *packageManager) saveStatuses(statuses *protobufs.PackageStatuses) error {
+	data, err := proto.Marshal(statuses)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomically(filepath.Join(p.dir, packageStatusesFile), data)
+}
+
+func (p *packageManager) saveState() error {
+	data, err := json.Marshal(p.state)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomically(filepath.Join(p.dir, packagesStateFile), data)
+}
@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/open-telemetry/opamp-go/client/types"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/opampsupervisor/supervisor/config"
+)
+
+func writePublicKey(t *testing.T, publicKey ed25519.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+	publicKeyFile := filepath.Join(t.TempDir(), "packages.pub")
+	require.NoError(t, os.WriteFile(publicKeyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600))
+	return publicKeyFile
+}
+
+func offerPackage(p *packageManager, content []byte, signature []byte) []byte {
+	contentHash := sha256.Sum256(content)
+	p.setAvailable(&protobufs.PackagesAvailable{
+		Packages: map[string]*protobufs.PackageAvailable{
+			"": {
+				Type: protobufs.PackageType_PackageType_TopLevel,
+				File: &protobufs.DownloadableFile{ContentHash: contentHash[:], Signature: signature},
+			},
+		},
+	})
+	return contentHash[:]
+}
+
+func TestPackageManagerVerifiesContent(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	p, err := newPackageManager(zap.NewNop(), t.TempDir(), config.Packages{PublicKeyFile: writePublicKey(t, publicKey)})
+	require.NoError(t, err)
+	require.NoError(t, p.CreatePackage("", protobufs.PackageType_PackageType_TopLevel))
+
+	content := []byte("collector binary")
+	contentHash := offerPackage(p, content, nil)
+
+	err = p.UpdateContent(context.Background(), "", bytes.NewReader([]byte("tampered binary")), contentHash)
+	require.ErrorContains(t, err, "does not match the content hash")
+
+	err = p.UpdateContent(context.Background(), "", bytes.NewReader(content), contentHash)
+	require.EqualError(t, err, "the package file is not signed")
+
+	offerPackage(p, content, []byte("invalid signature"))
+	err = p.UpdateContent(context.Background(), "", bytes.NewReader(content), contentHash)
+	require.EqualError(t, err, "the package file signature is invalid")
+
+	offerPackage(p, content, ed25519.Sign(privateKey, contentHash))
+	require.NoError(t, p.UpdateContent(context.Background(), "", bytes.NewReader(content), contentHash))
+
+	entries, err := os.ReadDir(filepath.Join(p.dir, packagesDir))
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "temporary files must be removed")
+}
+
+func TestPackageManagerRefusesUnsigned(t *testing.T) {
+	p, err := newPackageManager(zap.NewNop(), t.TempDir(), config.Packages{})
+	require.NoError(t, err)
+	require.NoError(t, p.CreatePackage("", protobufs.PackageType_PackageType_TopLevel))
+
+	content := []byte("collector binary")
+	contentHash := offerPackage(p, content, []byte("signature"))
+	err = p.UpdateContent(context.Background(), "", bytes.NewReader(content), contentHash)
+	require.EqualError(t, err, "the package file cannot be verified, no public key is set and unsigned packages are not allowed")
+
+	entries, err := os.ReadDir(filepath.Join(p.dir, packagesDir))
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestPackageManagerRejectsAddons(t *testing.T) {
+	p, err := newPackageManager(zap.NewNop(), t.TempDir(), config.Packages{AllowUnsigned: true})
+	require.NoError(t, err)
+
+	require.EqualError(t, p.CreatePackage("addon", protobufs.PackageType_PackageType_Addon), "only the top-level package is supported")
+	require.NoError(t, p.CreatePackage("", protobufs.PackageType_PackageType_TopLevel))
+	require.ErrorContains(t, p.CreatePackage("other", protobufs.PackageType_PackageType_TopLevel), "the top-level package already exists")
+}
+
+func TestPackageManagerInstall(t *testing.T) {
+	storageDir := t.TempDir()
+	p, err := newPackageManager(zap.NewNop(), storageDir, config.Packages{AllowUnsigned: true})
+	require.NoError(t, err)
+	require.Empty(t, p.executable())
+
+	require.NoError(t, p.CreatePackage("", protobufs.PackageType_PackageType_TopLevel))
+	content := []byte("collector binary v2")
+	contentHash := offerPackage(p, content, nil)
+	require.NoError(t, p.UpdateContent(context.Background(), "", bytes.NewReader(content), contentHash))
+	require.NoError(t, p.SetPackageState("", types.PackageState{
+		Exists:  true,
+		Type:    protobufs.PackageType_PackageType_TopLevel,
+		Hash:    []byte("v2"),
+		Version: "v2",
+	}))
+
+	staged := <-p.Staged()
+	require.Equal(t, "v2", staged.version)
+	require.FileExists(t, staged.executable)
+	written, err := os.ReadFile(staged.executable)
+	require.NoError(t, err)
+	require.Equal(t, content, written)
+	// The binary is only installed once the Collector is healthy when run from it.
+	require.Empty(t, p.executable())
+
+	require.NoError(t, p.install(staged))
+	require.Equal(t, staged.executable, p.executable())
+
+	// The installed binary is used again when the Supervisor restarts.
+	restarted, err := newPackageManager(zap.NewNop(), storageDir, config.Packages{AllowUnsigned: true})
+	require.NoError(t, err)
+	require.Equal(t, staged.executable, restarted.executable())
+	state, err := restarted.PackageState("")
+	require.NoError(t, err)
+	require.Equal(t, "v2", state.Version)
+	fileContentHash, err := restarted.FileContentHash("")
+	require.NoError(t, err)
+	require.Equal(t, contentHash, fileContentHash)
+}
+
+func TestPackageManagerRollback(t *testing.T) {
+	p, err := newPackageManager(zap.NewNop(), t.TempDir(), config.Packages{AllowUnsigned: true})
+	require.NoError(t, err)
+
+	require.NoError(t, p.CreatePackage("", protobufs.PackageType_PackageType_TopLevel))
+	content := []byte("broken collector binary")
+	contentHash := offerPackage(p, content, nil)
+	require.NoError(t, p.UpdateContent(context.Background(), "", bytes.NewReader(content), contentHash))
+	require.NoError(t, p.SetPackageState("", types.PackageState{
+		Exists:  true,
+		Type:    protobufs.PackageType_PackageType_TopLevel,
+		Hash:    []byte("v2"),
+		Version: "v2",
+	}))
+	require.NoError(t, p.SetLastReportedStatuses(&protobufs.PackageStatuses{
+		Packages: map[string]*protobufs.PackageStatus{
+			"": {
+				AgentHasHash:         []byte("v2"),
+				AgentHasVersion:      "v2",
+				ServerOfferedHash:    []byte("v2"),
+				ServerOfferedVersion: "v2",
+				Status:               protobufs.PackageStatusEnum_PackageStatusEnum_Installed,
+			},
+		},
+		ServerProvidedAllPackagesHash: []byte("all"),
+	}))
+
+	staged := <-p.Staged()
+	statuses, err := p.rollback(staged, errors.New("agent process exited with code 1"))
+	require.NoError(t, err)
+
+	status := statuses.Packages[""]
+	require.Equal(t, protobufs.PackageStatusEnum_PackageStatusEnum_InstallFailed, status.Status)
+	require.Equal(t, "agent process exited with code 1", status.ErrorMessage)
+	require.Empty(t, status.AgentHasVersion)
+	require.Equal(t, []byte("all"), statuses.ServerProvidedAllPackagesHash)
+	require.NoFileExists(t, staged.executable)
+	require.Empty(t, p.executable())
+
+	lastReported, err := p.LastReportedStatuses()
+	require.NoError(t, err)
+	require.Equal(t, protobufs.PackageStatusEnum_PackageStatusEnum_InstallFailed, lastReported.Packages[""].Status)
+	state, err := p.PackageState("")
+	require.NoError(t, err)
+	require.Empty(t, state.Version)
+}
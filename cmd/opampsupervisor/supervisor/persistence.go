@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"google.golang.org/protobuf/proto"
+)
+
+// lastAppliedRemoteConfigFile is the file of the storage directory holding the last remote
+// config the Collector became healthy with, including its hash.
+const lastAppliedRemoteConfigFile = "last_applied_remote_config.dat"
+
+// loadLastAppliedRemoteConfig returns the remote config persisted in the storage directory,
+// or nil if no remote config was applied yet.
+func loadLastAppliedRemoteConfig(dir string) (*protobufs.AgentRemoteConfig, error) {
+	filePath := filepath.Join(dir, lastAppliedRemoteConfigFile)
+	data, err := os.ReadFile(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	remoteConfig := &protobufs.AgentRemoteConfig{}
+	if err = proto.Unmarshal(data, remoteConfig); err != nil {
+		return nil, fmt.Errorf("cannot parse %s: %w", filePath, err)
+	}
+	return remoteConfig, nil
+}
+
+// saveLastAppliedRemoteConfig persists the remote config in the storage directory.
+func saveLastAppliedRemoteConfig(dir string, remoteConfig *protobufs.AgentRemoteConfig) error {
+	data, err := proto.Marshal(remoteConfig)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomically(filepath.Join(dir, lastAppliedRemoteConfigFile), data)
+}
+
+// writeFileAtomically writes to a temporary file first so that a partially written file
+// never replaces the persisted state.
+func writeFileAtomically(filePath string, data []byte) error {
+	if err := os.WriteFile(filePath+".tmp", data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(filePath+".tmp", filePath)
+}
@@ -38,6 +38,10 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/opampsupervisor/supervisor/healthchecker"
 )
 
+// defaultConfigApplyTimeout is how long the agent has to become healthy with a new config
+// before the Supervisor rolls back to the last known good config.
+const defaultConfigApplyTimeout = 30 * time.Second
+
 var (
 	//go:embed templates/bootstrap.yaml
 	bootstrapConfTpl string
@@ -94,6 +98,31 @@ type Supervisor struct {
 	// Last received remote config.
 	remoteConfig *protobufs.AgentRemoteConfig
 
+	// Last remote config the Collector became healthy with. It is persisted in the
+	// storage directory to be used again when the Supervisor restarts.
+	lastAppliedRemoteConfig *protobufs.AgentRemoteConfig
+
+	// Guards remoteConfig and lastAppliedRemoteConfig, which are rolled back when the
+	// Collector fails to start with a new config.
+	remoteConfigMu sync.Mutex
+
+	// Last effective config the Collector became healthy with.
+	lastGoodEffectiveConfig string
+
+	// The effective config being applied to the Collector and the remote config it was
+	// composed from. They are validated once the Collector becomes healthy, or rolled back
+	// when configApplyTimer expires first.
+	pendingEffectiveConfig string
+	pendingRemoteConfig    *protobufs.AgentRemoteConfig
+	configApplyTimer       *time.Timer
+
+	// Collector binaries offered by the server, or nil when packages are not accepted.
+	packages *packageManager
+
+	// The Collector binary being run by the agent. It is validated like a new config, and
+	// rolled back to the previous binary when the agent does not become healthy with it.
+	pendingPackage *stagedPackage
+
 	// A channel to indicate there is a new config to apply.
 	hasNewConfig chan struct{}
 
@@ -124,6 +153,10 @@ func NewSupervisor(logger *zap.Logger, configFile string) (*Supervisor, error) {
 		return nil, fmt.Errorf("error loading config: %w", err)
 	}
 
+	if err := s.loadPersistentState(); err != nil {
+		return nil, fmt.Errorf("error loading persistent state: %w", err)
+	}
+
 	id, err := s.createInstanceID()
 	if err != nil {
 		return nil, err
@@ -146,7 +179,9 @@ func NewSupervisor(logger *zap.Logger, configFile string) (*Supervisor, error) {
 	logger.Debug("Supervisor starting",
 		zap.String("id", s.instanceID.String()))
 
-	s.loadAgentEffectiveConfig()
+	if err = s.loadAgentEffectiveConfig(); err != nil {
+		return nil, fmt.Errorf("error composing the agent effective config: %w", err)
+	}
 
 	if err = s.startOpAMP(); err != nil {
 		return nil, fmt.Errorf("cannot start OpAMP client: %w", err)
@@ -160,6 +195,7 @@ func NewSupervisor(logger *zap.Logger, configFile string) (*Supervisor, error) {
 	if err != nil {
 		return nil, err
 	}
+	s.commander.SetExecutable(s.agentExecutable())
 
 	s.startHealthCheckTicker()
 
@@ -209,6 +245,64 @@ func (s *Supervisor) loadConfig(configFile string) error {
 	return nil
 }
 
+// loadPersistentState loads the last applied remote config and the installed packages
+// from the storage directory.
+func (s *Supervisor) loadPersistentState() error {
+	if s.config.Storage == nil || s.config.Storage.Directory == "" {
+		if s.acceptsPackages() {
+			return errors.New("storage::directory must be set to accept packages")
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(s.config.Storage.Directory, 0700); err != nil {
+		return err
+	}
+
+	remoteConfig, err := loadLastAppliedRemoteConfig(s.config.Storage.Directory)
+	if err != nil {
+		return err
+	}
+
+	if remoteConfig != nil {
+		s.logger.Debug("Loaded the last applied remote config", zap.String("hash", fmt.Sprintf("%x", remoteConfig.ConfigHash)))
+	}
+	s.remoteConfig = remoteConfig
+	s.lastAppliedRemoteConfig = remoteConfig
+
+	if !s.acceptsPackages() {
+		return nil
+	}
+
+	var packagesConfig config.Packages
+	if s.config.Packages != nil {
+		packagesConfig = *s.config.Packages
+	}
+	s.packages, err = newPackageManager(s.logger, s.config.Storage.Directory, packagesConfig)
+	if err != nil {
+		return err
+	}
+	if executable := s.packages.executable(); executable != "" {
+		s.logger.Debug("Loaded the installed Collector binary", zap.String("executable", executable))
+	}
+
+	return nil
+}
+
+func (s *Supervisor) acceptsPackages() bool {
+	c := s.config.Capabilities
+	return c != nil && c.AcceptsPackages != nil && *c.AcceptsPackages
+}
+
+// agentExecutable returns the Collector binary installed from the packages offered by the
+// server, or the configured one if none was installed.
+func (s *Supervisor) agentExecutable() string {
+	if executable := s.packages.executable(); executable != "" {
+		return executable
+	}
+	return s.config.Agent.Executable
+}
+
 func (s *Supervisor) getBootstrapInfo() (err error) {
 	port, err := s.findRandomPort()
 	if err != nil {
@@ -286,6 +380,7 @@ func (s *Supervisor) getBootstrapInfo() (err error) {
 	if err != nil {
 		return err
 	}
+	cmd.SetExecutable(s.agentExecutable())
 
 	if err = cmd.Start(context.Background()); err != nil {
 		return err
@@ -341,6 +436,11 @@ func (s *Supervisor) Capabilities() protobufs.AgentCapabilities {
 		if c.ReportsRemoteConfig != nil && *c.ReportsRemoteConfig {
 			supportedCapabilities |= protobufs.AgentCapabilities_AgentCapabilities_ReportsRemoteConfig
 		}
+
+		if c.AcceptsPackages != nil && *c.AcceptsPackages {
+			supportedCapabilities |= protobufs.AgentCapabilities_AgentCapabilities_AcceptsPackages |
+				protobufs.AgentCapabilities_AgentCapabilities_ReportsPackageStatuses
+		}
 	}
 	return supportedCapabilities
 }
@@ -394,6 +494,16 @@ func (s *Supervisor) startOpAMP() error {
 		},
 		Capabilities: s.Capabilities(),
 	}
+	if s.lastAppliedRemoteConfig != nil {
+		// Let the server know the remote config was already applied before the Supervisor restarted.
+		settings.RemoteConfigStatus = &protobufs.RemoteConfigStatus{
+			LastRemoteConfigHash: s.lastAppliedRemoteConfig.ConfigHash,
+			Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED,
+		}
+	}
+	if s.packages != nil {
+		settings.PackagesStateProvider = s.packages
+	}
 	err = s.opampClient.SetAgentDescription(s.agentDescription)
 	if err != nil {
 		return err
@@ -454,7 +564,17 @@ func (s *Supervisor) composeExtraLocalConfig() []byte {
 	return cfg.Bytes()
 }
 
-func (s *Supervisor) loadAgentEffectiveConfig() {
+func (s *Supervisor) loadAgentEffectiveConfig() error {
+	if s.baseConfigFile() != "" || s.remoteConfig != nil {
+		// Compose the effective config from the base config and the last applied remote config.
+		if _, err := s.composeEffectiveConfig(s.remoteConfig); err != nil {
+			return err
+		}
+		s.lastGoodEffectiveConfig = s.effectiveConfig.Load().(string)
+		s.writeEffectiveConfigToFile(s.lastGoodEffectiveConfig, s.effectiveConfigFilePath)
+		return nil
+	}
+
 	var effectiveConfigBytes []byte
 
 	effFromFile, err := os.ReadFile(s.effectiveConfigFilePath)
@@ -467,6 +587,17 @@ func (s *Supervisor) loadAgentEffectiveConfig() {
 	}
 
 	s.effectiveConfig.Store(string(effectiveConfigBytes))
+	s.lastGoodEffectiveConfig = string(effectiveConfigBytes)
+
+	return nil
+}
+
+// baseConfigFile returns the path to the local config the remote config is merged with.
+func (s *Supervisor) baseConfigFile() string {
+	if s.config.Agent == nil {
+		return ""
+	}
+	return s.config.Agent.ConfigFile
 }
 
 // createEffectiveConfigMsg create an EffectiveConfig with the content of the
@@ -528,20 +659,25 @@ func (s *Supervisor) setupOwnMetrics(_ context.Context, settings *protobufs.Tele
 }
 
 // composeEffectiveConfig composes the effective config from multiple sources:
-// 1) the remote config from OpAMP Server
-// 2) the own metrics config section
-// 3) the local override config that is hard-coded in the Supervisor.
+// 1) the local base config file
+// 2) the remote config from OpAMP Server
+// 3) the own metrics config section
+// 4) the local override config that is hard-coded in the Supervisor.
 func (s *Supervisor) composeEffectiveConfig(config *protobufs.AgentRemoteConfig) (configChanged bool, err error) {
 	var k = koanf.New(".")
 
-	// Begin with empty config. We will merge received configs on top of it.
-	if err = k.Load(rawbytes.Provider([]byte{}), yaml.Parser()); err != nil {
+	// Begin with the base config, or an empty config. We will merge received configs on top of it.
+	if baseConfigFile := s.baseConfigFile(); baseConfigFile != "" {
+		if err = k.Load(file.Provider(baseConfigFile), yaml.Parser()); err != nil {
+			return false, fmt.Errorf("cannot load base config %s: %w", baseConfigFile, err)
+		}
+	} else if err = k.Load(rawbytes.Provider([]byte{}), yaml.Parser()); err != nil {
 		return false, err
 	}
 
 	// Sort to make sure the order of merging is stable.
 	var names []string
-	for name := range config.Config.ConfigMap {
+	for name := range config.GetConfig().GetConfigMap() {
 		if name == "" {
 			// skip instance config
 			continue
@@ -556,7 +692,10 @@ func (s *Supervisor) composeEffectiveConfig(config *protobufs.AgentRemoteConfig)
 
 	// Merge received configs.
 	for _, name := range names {
-		item := config.Config.ConfigMap[name]
+		item, ok := config.GetConfig().GetConfigMap()[name]
+		if !ok {
+			continue
+		}
 		var k2 = koanf.New(".")
 		err = k2.Load(rawbytes.Provider(item.Body), yaml.Parser())
 		if err != nil {
@@ -590,7 +729,7 @@ func (s *Supervisor) composeEffectiveConfig(config *protobufs.AgentRemoteConfig)
 	// Check if effective config is changed.
 	newEffectiveConfig := string(effectiveConfigBytes)
 	configChanged = false
-	if s.effectiveConfig.Load().(string) != newEffectiveConfig {
+	if currentEffectiveConfig, _ := s.effectiveConfig.Load().(string); currentEffectiveConfig != newEffectiveConfig {
 		s.logger.Debug("Effective config changed.")
 		s.effectiveConfig.Store(newEffectiveConfig)
 		configChanged = true
@@ -602,7 +741,11 @@ func (s *Supervisor) composeEffectiveConfig(config *protobufs.AgentRemoteConfig)
 // Recalculate the Agent's effective config and if the config changes, signal to the
 // background goroutine that the config needs to be applied to the Agent.
 func (s *Supervisor) recalcEffectiveConfig() (configChanged bool, err error) {
-	configChanged, err = s.composeEffectiveConfig(s.remoteConfig)
+	s.remoteConfigMu.Lock()
+	remoteConfig := s.remoteConfig
+	s.remoteConfigMu.Unlock()
+
+	configChanged, err = s.composeEffectiveConfig(remoteConfig)
 	if err != nil {
 		s.logger.Error("Error composing effective config. Ignoring received config", zap.Error(err))
 		return configChanged, err
@@ -653,6 +796,11 @@ func (s *Supervisor) healthCheck() {
 	err := s.healthChecker.Check(ctx)
 	cancel()
 
+	if err == nil && s.configApplyTimer != nil {
+		s.onPackageApplied()
+		s.onConfigApplied()
+	}
+
 	if errors.Is(err, s.lastHealthCheckErr) {
 		// No difference from last check. Nothing new to report.
 		return
@@ -700,14 +848,18 @@ func (s *Supervisor) runAgentProcess() {
 		select {
 		case <-s.hasNewConfig:
 			restartTimer.Stop()
-			s.stopAgentApplyConfig()
-			s.startAgent()
+			s.applyConfig()
 
 		case <-s.commander.Done():
 			if s.shuttingDown {
 				return
 			}
 
+			if s.configApplyTimer != nil {
+				s.rollback(fmt.Errorf("agent process exited with code %d: %s", s.commander.ExitCode(), s.commander.LastLogLine()))
+				continue
+			}
+
 			s.logger.Debug("Agent process exited unexpectedly. Will restart in a bit...", zap.Int("pid", s.commander.Pid()), zap.Int("exit_code", s.commander.ExitCode()))
 			errMsg := fmt.Sprintf(
 				"Agent process PID=%d exited unexpectedly, exit code=%d. Will restart in a bit...",
@@ -731,11 +883,189 @@ func (s *Supervisor) runAgentProcess() {
 
 		case <-s.healthCheckTicker.C:
 			s.healthCheck()
+
+		case pkg := <-s.packages.Staged():
+			restartTimer.Stop()
+			s.applyPackage(pkg)
+
+		case <-s.configApplyDeadline():
+			s.rollback(fmt.Errorf("agent did not become healthy within %s: %w", s.configApplyTimeout(), s.lastHealthCheckErr))
 		}
 	}
 }
 
-func (s *Supervisor) stopAgentApplyConfig() {
+// applyConfig restarts the agent with the current effective config. The config is
+// validated by waiting for the agent to become healthy with it.
+func (s *Supervisor) applyConfig() {
+	s.remoteConfigMu.Lock()
+	s.pendingRemoteConfig = s.remoteConfig
+	s.remoteConfigMu.Unlock()
+
+	s.pendingEffectiveConfig = s.stopAgentApplyConfig()
+	s.startAgent()
+
+	if s.configApplyTimer != nil {
+		s.configApplyTimer.Stop()
+	}
+	s.configApplyTimer = time.NewTimer(s.configApplyTimeout())
+}
+
+// applyPackage restarts the agent with a new Collector binary. The binary is validated
+// like a new config, by waiting for the agent to become healthy with it.
+func (s *Supervisor) applyPackage(pkg *stagedPackage) {
+	if s.pendingPackage != nil {
+		// The previous binary was not validated yet, keep the one to roll back to.
+		pkg.previous = s.pendingPackage.previous
+	}
+	s.logger.Debug("Restarting the agent with a new Collector binary", zap.String("executable", pkg.executable), zap.String("version", pkg.version))
+	s.pendingPackage = pkg
+	s.commander.SetExecutable(pkg.executable)
+	s.applyConfig()
+}
+
+// onPackageApplied is called once the agent is healthy with the Collector binary being
+// run, which is then installed.
+func (s *Supervisor) onPackageApplied() {
+	if s.pendingPackage == nil {
+		return
+	}
+	pkg := s.pendingPackage
+	s.pendingPackage = nil
+
+	s.logger.Debug("Agent is healthy with the new Collector binary", zap.String("version", pkg.version))
+	if err := s.packages.install(pkg); err != nil {
+		s.logger.Error("Could not install the Collector binary", zap.Error(err))
+	}
+}
+
+// rollback rolls back the Collector binary and the config being applied after the agent
+// failed to become healthy with them.
+func (s *Supervisor) rollback(cause error) {
+	s.rollbackPackage(cause)
+	s.rollbackConfig(cause)
+}
+
+// rollbackPackage restores the previous Collector binary, which is run the next time the
+// agent starts, and reports the package of the failed binary as failed to install.
+func (s *Supervisor) rollbackPackage(cause error) {
+	if s.pendingPackage == nil {
+		return
+	}
+	pkg := s.pendingPackage
+	s.pendingPackage = nil
+
+	s.logger.Error("Agent failed to start with the new Collector binary. Rolling back to the previous binary", zap.Error(cause))
+	if pkg.previous.Executable != "" {
+		s.commander.SetExecutable(pkg.previous.Executable)
+	} else {
+		s.commander.SetExecutable(s.config.Agent.Executable)
+	}
+
+	statuses, err := s.packages.rollback(pkg, cause)
+	if err != nil {
+		s.logger.Error("Could not roll back the package", zap.String("package", pkg.name), zap.Error(err))
+		return
+	}
+	if err = s.opampClient.SetPackageStatuses(statuses); err != nil {
+		s.logger.Error("Could not report OpAMP package statuses", zap.Error(err))
+	}
+}
+
+// onConfigApplied is called once the agent is healthy with the config being applied. The
+// config becomes the last known good config, and the remote config it was composed from
+// is reported as applied and persisted.
+func (s *Supervisor) onConfigApplied() {
+	s.stopConfigValidation()
+	s.lastGoodEffectiveConfig = s.pendingEffectiveConfig
+
+	s.remoteConfigMu.Lock()
+	remoteConfig := s.pendingRemoteConfig
+	if remoteConfig == nil || remoteConfig == s.lastAppliedRemoteConfig {
+		s.remoteConfigMu.Unlock()
+		return
+	}
+	s.lastAppliedRemoteConfig = remoteConfig
+	s.remoteConfigMu.Unlock()
+
+	s.logger.Debug("Agent is healthy with the new remote config", zap.String("hash", fmt.Sprintf("%x", remoteConfig.ConfigHash)))
+	s.reportRemoteConfigStatus(remoteConfig.ConfigHash, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED, "")
+	s.persistLastAppliedRemoteConfig(remoteConfig)
+}
+
+func (s *Supervisor) persistLastAppliedRemoteConfig(remoteConfig *protobufs.AgentRemoteConfig) {
+	if s.config.Storage == nil || s.config.Storage.Directory == "" {
+		return
+	}
+	if err := saveLastAppliedRemoteConfig(s.config.Storage.Directory, remoteConfig); err != nil {
+		s.logger.Error("Could not persist the last applied remote config", zap.Error(err))
+	}
+}
+
+// rollbackConfig restarts the agent with the last known good config after it failed to
+// become healthy with the config being applied, and reports the remote config the failed
+// config was composed from as failed.
+func (s *Supervisor) rollbackConfig(cause error) {
+	s.stopConfigValidation()
+	s.logger.Error("Agent failed to start with the new config. Rolling back to the last known good config", zap.Error(cause))
+
+	s.remoteConfigMu.Lock()
+	failedRemoteConfig := s.pendingRemoteConfig
+	if failedRemoteConfig == s.lastAppliedRemoteConfig {
+		failedRemoteConfig = nil
+	}
+	s.remoteConfig = s.lastAppliedRemoteConfig
+	s.remoteConfigMu.Unlock()
+
+	if failedRemoteConfig != nil {
+		s.reportRemoteConfigStatus(failedRemoteConfig.ConfigHash, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, cause.Error())
+	}
+
+	s.effectiveConfig.Store(s.lastGoodEffectiveConfig)
+	if err := s.opampClient.UpdateEffectiveConfig(context.Background()); err != nil {
+		s.logger.Error("The OpAMP client failed to update the effective config", zap.Error(err))
+	}
+
+	s.stopAgentApplyConfig()
+	s.startAgent()
+}
+
+func (s *Supervisor) stopConfigValidation() {
+	if s.configApplyTimer != nil {
+		s.configApplyTimer.Stop()
+		s.configApplyTimer = nil
+	}
+}
+
+// configApplyDeadline returns a channel receiving when the agent did not become healthy
+// in time with the config being applied, or nil when no config is being applied.
+func (s *Supervisor) configApplyDeadline() <-chan time.Time {
+	if s.configApplyTimer == nil {
+		return nil
+	}
+	return s.configApplyTimer.C
+}
+
+func (s *Supervisor) configApplyTimeout() time.Duration {
+	if s.config.Agent == nil || s.config.Agent.ConfigApplyTimeout <= 0 {
+		return defaultConfigApplyTimeout
+	}
+	return s.config.Agent.ConfigApplyTimeout
+}
+
+func (s *Supervisor) reportRemoteConfigStatus(hash []byte, status protobufs.RemoteConfigStatuses, errorMessage string) {
+	err := s.opampClient.SetRemoteConfigStatus(&protobufs.RemoteConfigStatus{
+		LastRemoteConfigHash: hash,
+		Status:               status,
+		ErrorMessage:         errorMessage,
+	})
+	if err != nil {
+		s.logger.Error("Could not report OpAMP remote config status", zap.Error(err))
+	}
+}
+
+// stopAgentApplyConfig stops the agent and writes the current effective config to the
+// file the agent is started with. It returns the written effective config.
+func (s *Supervisor) stopAgentApplyConfig() string {
 	s.logger.Debug("Stopping the agent to apply new config")
 	cfg := s.effectiveConfig.Load().(string)
 	err := s.commander.Stop(context.Background())
@@ -745,6 +1075,8 @@ func (s *Supervisor) stopAgentApplyConfig() {
 	}
 
 	s.writeEffectiveConfigToFile(cfg, s.effectiveConfigFilePath)
+
+	return cfg
 }
 
 func (s *Supervisor) writeEffectiveConfigToFile(cfg string, filePath string) {
@@ -800,35 +1132,22 @@ func (s *Supervisor) Shutdown() {
 func (s *Supervisor) onMessage(ctx context.Context, msg *types.MessageData) {
 	configChanged := false
 	if msg.RemoteConfig != nil {
-		s.remoteConfig = msg.RemoteConfig
-		s.logger.Debug("Received remote config from server", zap.String("hash", fmt.Sprintf("%x", s.remoteConfig.ConfigHash)))
-
-		var err error
-		configChanged, err = s.recalcEffectiveConfig()
-		if err != nil {
-			err = s.opampClient.SetRemoteConfigStatus(&protobufs.RemoteConfigStatus{
-				LastRemoteConfigHash: msg.RemoteConfig.ConfigHash,
-				Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED,
-				ErrorMessage:         err.Error(),
-			})
-			if err != nil {
-				s.logger.Error("Could not report failed OpAMP remote config status", zap.Error(err))
-			}
-		} else {
-			err = s.opampClient.SetRemoteConfigStatus(&protobufs.RemoteConfigStatus{
-				LastRemoteConfigHash: msg.RemoteConfig.ConfigHash,
-				Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED,
-			})
-			if err != nil {
-				s.logger.Error("Could not report applied OpAMP remote config status", zap.Error(err))
-			}
-		}
+		configChanged = s.processRemoteConfigMessage(msg.RemoteConfig)
 	}
 
 	if msg.OwnMetricsConnSettings != nil {
 		configChanged = s.setupOwnMetrics(ctx, msg.OwnMetricsConnSettings) || configChanged
 	}
 
+	if msg.PackageSyncer != nil {
+		s.packages.setAvailable(msg.PackagesAvailable)
+		// The syncer downloads the packages in the background and stages the Collector
+		// binary, which is then run by the agent process goroutine.
+		if err := msg.PackageSyncer.Sync(ctx); err != nil {
+			s.logger.Error("Failed to sync the packages offered by the server", zap.Error(err))
+		}
+	}
+
 	if msg.AgentIdentification != nil {
 		newInstanceID, err := ulid.Parse(msg.AgentIdentification.NewInstanceUid)
 		if err != nil {
@@ -862,6 +1181,37 @@ func (s *Supervisor) onMessage(ctx context.Context, msg *types.MessageData) {
 	}
 }
 
+// processRemoteConfigMessage composes the effective config with a remote config received
+// from the server. When the effective config changes, the remote config is only reported
+// as applied once the agent becomes healthy with it.
+func (s *Supervisor) processRemoteConfigMessage(remoteConfig *protobufs.AgentRemoteConfig) bool {
+	s.logger.Debug("Received remote config from server", zap.String("hash", fmt.Sprintf("%x", remoteConfig.ConfigHash)))
+
+	configChanged, err := s.composeEffectiveConfig(remoteConfig)
+	if err != nil {
+		s.logger.Error("Error composing effective config. Ignoring received config", zap.Error(err))
+		s.reportRemoteConfigStatus(remoteConfig.ConfigHash, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, err.Error())
+		return false
+	}
+
+	s.remoteConfigMu.Lock()
+	s.remoteConfig = remoteConfig
+	if !configChanged {
+		// The agent is already running with this config.
+		s.lastAppliedRemoteConfig = remoteConfig
+	}
+	s.remoteConfigMu.Unlock()
+
+	if !configChanged {
+		s.reportRemoteConfigStatus(remoteConfig.ConfigHash, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED, "")
+		s.persistLastAppliedRemoteConfig(remoteConfig)
+		return false
+	}
+
+	s.reportRemoteConfigStatus(remoteConfig.ConfigHash, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLYING, "")
+	return true
+}
+
 func (s *Supervisor) findRandomPort() (int, error) {
 	l, err := net.Listen("tcp", "localhost:0")
 
@@ -8,10 +8,14 @@ import (
 	"os"
 	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/open-telemetry/opamp-go/client"
 	"github.com/open-telemetry/opamp-go/protobufs"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/opampsupervisor/supervisor/config"
 )
 
 func Test_composeEffectiveConfig(t *testing.T) {
@@ -38,7 +42,7 @@ func Test_composeEffectiveConfig(t *testing.T) {
 	}
 
 	require.NoError(t, s.createTemplates())
-	s.loadAgentEffectiveConfig()
+	require.NoError(t, s.loadAgentEffectiveConfig())
 
 	configChanged, err := s.composeEffectiveConfig(&protobufs.AgentRemoteConfig{
 		Config: &protobufs.AgentConfigMap{
@@ -58,3 +62,92 @@ func Test_composeEffectiveConfig(t *testing.T) {
 	require.True(t, configChanged)
 	require.Equal(t, string(expectedConfig), s.effectiveConfig.Load().(string))
 }
+
+func Test_composeEffectiveConfigWithBaseConfig(t *testing.T) {
+	s := Supervisor{
+		logger:                       zap.NewNop(),
+		hasNewConfig:                 make(chan struct{}, 1),
+		effectiveConfigFilePath:      t.TempDir() + "/effective.yaml",
+		agentConfigOwnMetricsSection: &atomic.Value{},
+		effectiveConfig:              &atomic.Value{},
+		agentHealthCheckEndpoint:     "localhost:8000",
+		agentDescription:             &protobufs.AgentDescription{},
+		config: config.Supervisor{
+			Agent: &config.Agent{ConfigFile: "../testdata/collector/base_config.yaml"},
+		},
+	}
+
+	require.NoError(t, s.createTemplates())
+	require.NoError(t, s.loadAgentEffectiveConfig())
+
+	// Without remote config, the effective config is the base config.
+	effectiveConfig := s.effectiveConfig.Load().(string)
+	require.Contains(t, effectiveConfig, "otlp")
+	require.Contains(t, effectiveConfig, "health_check")
+	require.Equal(t, effectiveConfig, s.lastGoodEffectiveConfig)
+	written, err := os.ReadFile(s.effectiveConfigFilePath)
+	require.NoError(t, err)
+	require.Equal(t, effectiveConfig, string(written))
+
+	configChanged, err := s.composeEffectiveConfig(&protobufs.AgentRemoteConfig{
+		Config: &protobufs.AgentConfigMap{
+			ConfigMap: map[string]*protobufs.AgentConfigFile{
+				"": {
+					Body: []byte("exporters:\n  debug:\n    verbosity: detailed\n"),
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.True(t, configChanged)
+
+	// The remote config is merged on top of the base config.
+	effectiveConfig = s.effectiveConfig.Load().(string)
+	require.Contains(t, effectiveConfig, "verbosity: detailed")
+	require.NotContains(t, effectiveConfig, "verbosity: basic")
+	require.Contains(t, effectiveConfig, "otlp")
+
+	s.config.Agent.ConfigFile = "../testdata/collector/missing.yaml"
+	_, err = s.composeEffectiveConfig(nil)
+	require.ErrorContains(t, err, "cannot load base config")
+}
+
+func Test_onConfigApplied(t *testing.T) {
+	storageDir := t.TempDir()
+	s := Supervisor{
+		logger:      zap.NewNop(),
+		opampClient: client.NewWebSocket(newLoggerFromZap(zap.NewNop())),
+		config: config.Supervisor{
+			Storage: &config.Storage{Directory: storageDir},
+		},
+	}
+
+	remoteConfig := &protobufs.AgentRemoteConfig{
+		Config: &protobufs.AgentConfigMap{
+			ConfigMap: map[string]*protobufs.AgentConfigFile{
+				"": {Body: []byte("receivers: {}")},
+			},
+		},
+		ConfigHash: []byte("hash"),
+	}
+	s.remoteConfig = remoteConfig
+	s.pendingRemoteConfig = remoteConfig
+	s.pendingEffectiveConfig = "effective config"
+	s.configApplyTimer = time.NewTimer(time.Hour)
+
+	s.onConfigApplied()
+
+	require.Nil(t, s.configApplyTimer)
+	require.Equal(t, "effective config", s.lastGoodEffectiveConfig)
+	require.Same(t, remoteConfig, s.lastAppliedRemoteConfig)
+
+	// The last applied remote config is loaded again when the Supervisor restarts.
+	restarted := Supervisor{
+		logger: zap.NewNop(),
+		config: s.config,
+	}
+	require.NoError(t, restarted.loadPersistentState())
+	require.NotNil(t, restarted.lastAppliedRemoteConfig)
+	require.Equal(t, []byte("hash"), restarted.lastAppliedRemoteConfig.ConfigHash)
+	require.Equal(t, "receivers: {}", string(restarted.remoteConfig.Config.ConfigMap[""].Body))
+}
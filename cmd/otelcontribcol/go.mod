@@ -537,6 +537,7 @@ require (
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/k8s v0.96.0 // indirect
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/metrics v0.96.0 // indirect
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/proxy v0.96.0 // indirect
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/s3partition v0.96.0 // indirect
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray v0.96.0 // indirect
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/collectd v0.96.0 // indirect
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal v0.96.0 // indirect
@@ -847,6 +848,8 @@ replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/jaege
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray => ../../internal/aws/xray
 
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/s3partition => ../../internal/aws/s3partition
+
 replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/k8s => ../../internal/aws/k8s
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mysqlreceiver => ../../receiver/mysqlreceiver
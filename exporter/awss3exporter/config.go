@@ -5,25 +5,32 @@ package awss3exporter // import "github.com/open-telemetry/opentelemetry-collect
 
 import (
 	"errors"
+	"fmt"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configcompression"
 	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/s3partition"
 )
 
 // S3UploaderConfig contains aws s3 uploader related config to controls things
 // like bucket, prefix, batching, connections, retries, etc.
 type S3UploaderConfig struct {
-	Region           string                 `mapstructure:"region"`
-	S3Bucket         string                 `mapstructure:"s3_bucket"`
-	S3Prefix         string                 `mapstructure:"s3_prefix"`
-	S3Partition      string                 `mapstructure:"s3_partition"`
-	FilePrefix       string                 `mapstructure:"file_prefix"`
-	Endpoint         string                 `mapstructure:"endpoint"`
-	RoleArn          string                 `mapstructure:"role_arn"`
-	S3ForcePathStyle bool                   `mapstructure:"s3_force_path_style"`
-	DisableSSL       bool                   `mapstructure:"disable_ssl"`
-	Compression      configcompression.Type `mapstructure:"compression"`
+	Region      string `mapstructure:"region"`
+	S3Bucket    string `mapstructure:"s3_bucket"`
+	S3Prefix    string `mapstructure:"s3_prefix"`
+	S3Partition string `mapstructure:"s3_partition"`
+	// S3PartitionTemplate is a text/template rendered per resource that replaces
+	// the time partition of the object key, e.g.
+	// tenant={{ .Resource "tenant" }}/dt={{ .Time.Format "2006-01-02" }}
+	S3PartitionTemplate string                 `mapstructure:"s3_partition_template"`
+	FilePrefix          string                 `mapstructure:"file_prefix"`
+	Endpoint            string                 `mapstructure:"endpoint"`
+	RoleArn             string                 `mapstructure:"role_arn"`
+	S3ForcePathStyle    bool                   `mapstructure:"s3_force_path_style"`
+	DisableSSL          bool                   `mapstructure:"disable_ssl"`
+	Compression         configcompression.Type `mapstructure:"compression"`
 }
 
 type MarshalerType string
@@ -53,6 +60,11 @@ func (c *Config) Validate() error {
 	if c.S3Uploader.S3Bucket == "" {
 		errs = multierr.Append(errs, errors.New("bucket is required"))
 	}
+	if c.S3Uploader.S3PartitionTemplate != "" {
+		if _, err := s3partition.NewPartitioner(c.S3Uploader.S3Partition, c.S3Uploader.S3PartitionTemplate); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("invalid s3_partition_template: %w", err))
+		}
+	}
 	compression := c.S3Uploader.Compression
 	if compression.IsCompressed() {
 		if compression != configcompression.TypeGzip {
@@ -159,6 +159,17 @@ func TestConfig_Validate(t *testing.T) {
 	}
 }
 
+func TestConfig_ValidatePartitionTemplate(t *testing.T) {
+	c := createDefaultConfig().(*Config)
+	c.S3Uploader.S3Bucket = "bar"
+
+	c.S3Uploader.S3PartitionTemplate = `tenant={{ .Resource "tenant" | default "none" }}/dt={{ .Time.Format "2006-01-02" }}`
+	assert.NoError(t, c.Validate())
+
+	c.S3Uploader.S3PartitionTemplate = `tenant={{ .Resource "tenant" `
+	assert.ErrorContains(t, c.Validate(), "invalid s3_partition_template")
+}
+
 func TestMarshallerName(t *testing.T) {
 	factories, err := otelcoltest.NopFactories()
 	assert.NoError(t, err)
@@ -6,5 +6,5 @@ package awss3exporter // import "github.com/open-telemetry/opentelemetry-collect
 import "context"
 
 type dataWriter interface {
-	writeBuffer(ctx context.Context, buf []byte, config *Config, metadata string, format string) error
+	writeBuffer(ctx context.Context, buf []byte, config *Config, partitionKey string, metadata string, format string) error
 }
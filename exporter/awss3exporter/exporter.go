@@ -5,10 +5,13 @@ package awss3exporter // import "github.com/open-telemetry/opentelemetry-collect
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -17,10 +20,13 @@ import (
 )
 
 type s3Exporter struct {
-	config     *Config
-	dataWriter dataWriter
-	logger     *zap.Logger
-	marshaler  marshaler
+	config      *Config
+	dataWriter  dataWriter
+	logger      *zap.Logger
+	marshaler   marshaler
+	partitioner *partitioner
+	// now returns the export time, which is the time of the partitions.
+	now func() time.Time
 }
 
 func newS3Exporter(config *Config,
@@ -30,6 +36,7 @@ func newS3Exporter(config *Config,
 		config:     config,
 		dataWriter: &s3Writer{},
 		logger:     params.Logger,
+		now:        time.Now,
 	}
 	return s3Exporter
 }
@@ -49,6 +56,10 @@ func (e *s3Exporter) start(_ context.Context, host component.Host) error {
 	}
 
 	e.marshaler = m
+
+	if e.partitioner, err = newPartitioner(e.config); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -57,30 +68,74 @@ func (e *s3Exporter) Capabilities() consumer.Capabilities {
 }
 
 func (e *s3Exporter) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
-	buf, err := e.marshaler.MarshalMetrics(md)
-
+	partitions, err := e.partitioner.partitionMetrics(e.now(), md)
 	if err != nil {
 		return err
 	}
 
-	return e.dataWriter.writeBuffer(ctx, buf, e.config, "metrics", e.marshaler.format())
+	failed := pmetric.NewMetrics()
+	err = uploadPartitions(ctx, e, "metrics", partitions, e.marshaler.MarshalMetrics, func(partition pmetric.Metrics) {
+		for i := 0; i < partition.ResourceMetrics().Len(); i++ {
+			partition.ResourceMetrics().At(i).CopyTo(failed.ResourceMetrics().AppendEmpty())
+		}
+	})
+	if err != nil {
+		return consumererror.NewMetrics(err, failed)
+	}
+	return nil
 }
 
 func (e *s3Exporter) ConsumeLogs(ctx context.Context, logs plog.Logs) error {
-	buf, err := e.marshaler.MarshalLogs(logs)
-
+	partitions, err := e.partitioner.partitionLogs(e.now(), logs)
 	if err != nil {
 		return err
 	}
 
-	return e.dataWriter.writeBuffer(ctx, buf, e.config, "logs", e.marshaler.format())
+	failed := plog.NewLogs()
+	err = uploadPartitions(ctx, e, "logs", partitions, e.marshaler.MarshalLogs, func(partition plog.Logs) {
+		for i := 0; i < partition.ResourceLogs().Len(); i++ {
+			partition.ResourceLogs().At(i).CopyTo(failed.ResourceLogs().AppendEmpty())
+		}
+	})
+	if err != nil {
+		return consumererror.NewLogs(err, failed)
+	}
+	return nil
 }
 
 func (e *s3Exporter) ConsumeTraces(ctx context.Context, traces ptrace.Traces) error {
-	buf, err := e.marshaler.MarshalTraces(traces)
+	partitions, err := e.partitioner.partitionTraces(e.now(), traces)
 	if err != nil {
 		return err
 	}
 
-	return e.dataWriter.writeBuffer(ctx, buf, e.config, "traces", e.marshaler.format())
+	failed := ptrace.NewTraces()
+	err = uploadPartitions(ctx, e, "traces", partitions, e.marshaler.MarshalTraces, func(partition ptrace.Traces) {
+		for i := 0; i < partition.ResourceSpans().Len(); i++ {
+			partition.ResourceSpans().At(i).CopyTo(failed.ResourceSpans().AppendEmpty())
+		}
+	})
+	if err != nil {
+		return consumererror.NewTraces(err, failed)
+	}
+	return nil
+}
+
+// uploadPartitions marshals and uploads each partition of a signal to its own object.
+// The partitions that could not be uploaded are passed to appendFailed, so that only
+// they are retried and the others are not uploaded twice.
+func uploadPartitions[T any](ctx context.Context, e *s3Exporter, signal string, partitions map[string]T,
+	marshal func(T) ([]byte, error), appendFailed func(T)) error {
+	var errs error
+	for partitionKey, partition := range partitions {
+		buf, err := marshal(partition)
+		if err == nil {
+			err = e.dataWriter.writeBuffer(ctx, buf, e.config, partitionKey, signal, e.marshaler.format())
+		}
+		if err != nil {
+			errs = errors.Join(errs, err)
+			appendFailed(partition)
+		}
+	}
+	return errs
 }
@@ -5,9 +5,21 @@ package awss3exporter
 
 import (
 	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.uber.org/zap"
 )
@@ -18,7 +30,7 @@ type TestWriter struct {
 	t *testing.T
 }
 
-func (testWriter *TestWriter) writeBuffer(_ context.Context, buf []byte, _ *Config, _ string, _ string) error {
+func (testWriter *TestWriter) writeBuffer(_ context.Context, buf []byte, _ *Config, _ string, _ string, _ string) error {
 	assert.Equal(testWriter.t, testLogs, buf)
 	return nil
 }
@@ -32,11 +44,15 @@ func getTestLogs(tb testing.TB) plog.Logs {
 
 func getLogExporter(t *testing.T) *s3Exporter {
 	marshaler, _ := newMarshaler("otlp_json", zap.NewNop())
+	config := createDefaultConfig().(*Config)
+	partitioner, _ := newPartitioner(config)
 	exporter := &s3Exporter{
-		config:     createDefaultConfig().(*Config),
-		dataWriter: &TestWriter{t},
-		logger:     zap.NewNop(),
-		marshaler:  marshaler,
+		config:      config,
+		dataWriter:  &TestWriter{t},
+		logger:      zap.NewNop(),
+		marshaler:   marshaler,
+		partitioner: partitioner,
+		now:         time.Now,
 	}
 	return exporter
 }
@@ -46,3 +62,133 @@ func TestLog(t *testing.T) {
 	exporter := getLogExporter(t)
 	assert.NoError(t, exporter.ConsumeLogs(context.Background(), logs))
 }
+
+// fakeS3 is a minimal S3-compatible server that records uploaded objects.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	f.mu.Lock()
+	f.objects[r.URL.Path] = body
+	f.mu.Unlock()
+	w.Header().Set("ETag", `"etag"`)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (f *fakeS3) keys() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	keys := make([]string, 0, len(f.objects))
+	for k := range f.objects {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestPartitionedUploadToS3CompatibleEndpoint(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+
+	fake := &fakeS3{objects: map[string][]byte{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	config := createDefaultConfig().(*Config)
+	config.S3Uploader.S3Bucket = "bucket"
+	config.S3Uploader.S3Prefix = "archive"
+	config.S3Uploader.Endpoint = server.URL
+	config.S3Uploader.S3ForcePathStyle = true
+	config.S3Uploader.DisableSSL = true
+	config.S3Uploader.S3PartitionTemplate = `tenant={{ .Resource "tenant" | default "unknown" }}/service={{ .Resource "service.name" }}/dt={{ .Time.Format "2006-01-02" }}`
+
+	exp := newS3Exporter(config, exportertest.NewNopCreateSettings())
+	now := time.Date(2024, 3, 15, 23, 59, 59, 0, time.UTC)
+	exp.now = func() time.Time { return now }
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+
+	logs := plog.NewLogs()
+	for _, res := range []struct{ tenant, service string }{{"a", "checkout"}, {"b", "checkout"}, {"a", "checkout"}, {"", "cart"}} {
+		rl := logs.ResourceLogs().AppendEmpty()
+		if res.tenant != "" {
+			rl.Resource().Attributes().PutStr("tenant", res.tenant)
+		}
+		rl.Resource().Attributes().PutStr("service.name", res.service)
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("log from " + res.service)
+	}
+	require.NoError(t, exp.ConsumeLogs(context.Background(), logs))
+
+	keys := fake.keys()
+	require.Len(t, keys, 3)
+	dt := "2024-03-15"
+	prefixes := []string{
+		"/bucket/archive/tenant=a/service=checkout/dt=" + dt + "/logs_",
+		"/bucket/archive/tenant=b/service=checkout/dt=" + dt + "/logs_",
+		"/bucket/archive/tenant=unknown/service=cart/dt=" + dt + "/logs_",
+	}
+	for i, key := range keys {
+		assert.True(t, strings.HasPrefix(key, prefixes[i]), "unexpected key %q", key)
+		assert.True(t, strings.HasSuffix(key, ".json"), "unexpected key %q", key)
+	}
+
+	// both resources of tenant a are batched into a single object
+	got, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs(fake.objects[keys[0]])
+	require.NoError(t, err)
+	assert.Equal(t, 2, got.ResourceLogs().Len())
+}
+
+type failingWriter struct {
+	failKey string
+	keys    []string
+}
+
+func (w *failingWriter) writeBuffer(_ context.Context, _ []byte, _ *Config, partitionKey string, _ string, _ string) error {
+	if partitionKey == w.failKey {
+		return errors.New("upload failed")
+	}
+	w.keys = append(w.keys, partitionKey)
+	return nil
+}
+
+func TestConsumeLogsPartialFailure(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	config.S3Uploader.S3PartitionTemplate = `tenant={{ .Resource "tenant" }}`
+	exp := getLogExporter(t)
+	exp.config = config
+	var err error
+	exp.partitioner, err = newPartitioner(config)
+	require.NoError(t, err)
+	writer := &failingWriter{failKey: "tenant=b"}
+	exp.dataWriter = writer
+
+	logs := plog.NewLogs()
+	for _, tenant := range []string{"a", "b", "c"} {
+		rl := logs.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("tenant", tenant)
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("log from " + tenant)
+	}
+
+	err = exp.ConsumeLogs(context.Background(), logs)
+	require.Error(t, err)
+	assert.ElementsMatch(t, []string{"tenant=a", "tenant=c"}, writer.keys)
+
+	// only the failed partition is returned for retry
+	var logsErr consumererror.Logs
+	require.True(t, errors.As(err, &logsErr))
+	failed := logsErr.Data()
+	require.Equal(t, 1, failed.ResourceLogs().Len())
+	tenant, _ := failed.ResourceLogs().At(0).Resource().Attributes().Get("tenant")
+	assert.Equal(t, "b", tenant.Str())
+	assert.Equal(t, 3, logs.ResourceLogs().Len())
+}
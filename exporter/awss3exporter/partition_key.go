@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package awss3exporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awss3exporter"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/s3partition"
+)
+
+// partitioner splits the data by the partition key of its resources, rendered
+// between s3_prefix and file_prefix.
+type partitioner struct {
+	*s3partition.Partitioner
+}
+
+func newPartitioner(config *Config) (*partitioner, error) {
+	p, err := s3partition.NewPartitioner(config.S3Uploader.S3Partition, config.S3Uploader.S3PartitionTemplate)
+	if err != nil {
+		return nil, err
+	}
+	return &partitioner{Partitioner: p}, nil
+}
+
+func (p *partitioner) partitionLogs(now time.Time, ld plog.Logs) (map[string]plog.Logs, error) {
+	if !p.Templated() {
+		key, err := p.ListKey(now, "logs")
+		if err != nil {
+			return nil, err
+		}
+		return map[string]plog.Logs{key: ld}, nil
+	}
+
+	partitions := make(map[string]plog.Logs)
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+		key, err := p.Key(now, "logs", rl.Resource())
+		if err != nil {
+			return nil, err
+		}
+		partition, ok := partitions[key]
+		if !ok {
+			partition = plog.NewLogs()
+			partitions[key] = partition
+		}
+		rl.CopyTo(partition.ResourceLogs().AppendEmpty())
+	}
+	return partitions, nil
+}
+
+func (p *partitioner) partitionMetrics(now time.Time, md pmetric.Metrics) (map[string]pmetric.Metrics, error) {
+	if !p.Templated() {
+		key, err := p.ListKey(now, "metrics")
+		if err != nil {
+			return nil, err
+		}
+		return map[string]pmetric.Metrics{key: md}, nil
+	}
+
+	partitions := make(map[string]pmetric.Metrics)
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		key, err := p.Key(now, "metrics", rm.Resource())
+		if err != nil {
+			return nil, err
+		}
+		partition, ok := partitions[key]
+		if !ok {
+			partition = pmetric.NewMetrics()
+			partitions[key] = partition
+		}
+		rm.CopyTo(partition.ResourceMetrics().AppendEmpty())
+	}
+	return partitions, nil
+}
+
+func (p *partitioner) partitionTraces(now time.Time, td ptrace.Traces) (map[string]ptrace.Traces, error) {
+	if !p.Templated() {
+		key, err := p.ListKey(now, "traces")
+		if err != nil {
+			return nil, err
+		}
+		return map[string]ptrace.Traces{key: td}, nil
+	}
+
+	partitions := make(map[string]ptrace.Traces)
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		key, err := p.Key(now, "traces", rs.Resource())
+		if err != nil {
+			return nil, err
+		}
+		partition, ok := partitions[key]
+		if !ok {
+			partition = ptrace.NewTraces()
+			partitions[key] = partition
+		}
+		rs.CopyTo(partition.ResourceSpans().AppendEmpty())
+	}
+	return partitions, nil
+}
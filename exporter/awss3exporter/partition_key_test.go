@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package awss3exporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestPartitionTraces(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	config.S3Uploader.S3PartitionTemplate = `service={{ .Resource "service.name" }}`
+	p, err := newPartitioner(config)
+	require.NoError(t, err)
+
+	td := ptrace.NewTraces()
+	for _, service := range []string{"a", "b", "a"} {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("service.name", service)
+		rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName(service)
+	}
+
+	partitions, err := p.partitionTraces(time.Now(), td)
+	require.NoError(t, err)
+	require.Len(t, partitions, 2)
+	assert.Equal(t, 2, partitions["service=a"].SpanCount())
+	assert.Equal(t, 1, partitions["service=b"].SpanCount())
+}
+
+func TestPartitionMetricsWithoutTemplate(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	p, err := newPartitioner(config)
+	require.NoError(t, err)
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().Resource().Attributes().PutStr("service.name", "a")
+	md.ResourceMetrics().AppendEmpty().Resource().Attributes().PutStr("service.name", "b")
+
+	now := time.Date(2024, 3, 1, 10, 37, 12, 0, time.UTC)
+	partitions, err := p.partitionMetrics(now, md)
+	require.NoError(t, err)
+	require.Len(t, partitions, 1)
+	assert.Equal(t, 2, partitions["year=2024/month=03/day=01/hour=10/minute=37"].ResourceMetrics().Len())
+}
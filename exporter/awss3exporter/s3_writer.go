@@ -7,7 +7,6 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
-	"fmt"
 	"math/rand"
 	"strconv"
 	"time"
@@ -17,34 +16,25 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"go.opentelemetry.io/collector/config/configcompression"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/s3partition"
 )
 
 type s3Writer struct {
 }
 
-// generate the s3 time key based on partition configuration
-func getTimeKey(time time.Time, partition string) string {
-	var timeKey string
-	year, month, day := time.Date()
-	hour, minute, _ := time.Clock()
-
-	if partition == "hour" {
-		timeKey = fmt.Sprintf("year=%d/month=%02d/day=%02d/hour=%02d", year, month, day, hour)
-	} else {
-		timeKey = fmt.Sprintf("year=%d/month=%02d/day=%02d/hour=%02d/minute=%02d", year, month, day, hour, minute)
-	}
-	return timeKey
-}
-
 func randomInRange(low, hi int) int {
 	return low + rand.Intn(hi-low)
 }
 
 func getS3Key(time time.Time, keyPrefix string, partition string, filePrefix string, metadata string, fileformat string, compression configcompression.Type) string {
-	timeKey := getTimeKey(time, partition)
+	return buildS3Key(keyPrefix, s3partition.TimeKey(time, partition), filePrefix, metadata, fileformat, compression)
+}
+
+func buildS3Key(keyPrefix string, partitionKey string, filePrefix string, metadata string, fileformat string, compression configcompression.Type) string {
 	randomID := randomInRange(100000000, 999999999)
 
-	s3Key := keyPrefix + "/" + timeKey + "/" + filePrefix + metadata + "_" + strconv.Itoa(randomID) + "." + fileformat
+	s3Key := keyPrefix + "/" + partitionKey + "/" + filePrefix + metadata + "_" + strconv.Itoa(randomID) + "." + fileformat
 
 	// add ".gz" extension to files if compression is enabled
 	if compression == configcompression.TypeGzip {
@@ -80,10 +70,8 @@ func getSession(config *Config, sessionConfig *aws.Config) (*session.Session, er
 	return sess, err
 }
 
-func (s3writer *s3Writer) writeBuffer(_ context.Context, buf []byte, config *Config, metadata string, format string) error {
-	now := time.Now()
-	key := getS3Key(now,
-		config.S3Uploader.S3Prefix, config.S3Uploader.S3Partition,
+func (s3writer *s3Writer) writeBuffer(_ context.Context, buf []byte, config *Config, partitionKey string, metadata string, format string) error {
+	key := buildS3Key(config.S3Uploader.S3Prefix, partitionKey,
 		config.S3Uploader.FilePrefix, metadata, format, config.S3Uploader.Compression)
 
 	encoding := ""
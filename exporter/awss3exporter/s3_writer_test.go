@@ -11,19 +11,21 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/s3partition"
 )
 
 func TestS3TimeKey(t *testing.T) {
 	const layout = "2006-01-02"
 
 	tm, err := time.Parse(layout, "2022-06-05")
-	timeKey := getTimeKey(tm, "hour")
+	timeKey := s3partition.TimeKey(tm, "hour")
 
 	assert.NoError(t, err)
 	require.NotNil(t, tm)
 	assert.Equal(t, "year=2022/month=06/day=05/hour=00", timeKey)
 
-	timeKey = getTimeKey(tm, "minute")
+	timeKey = s3partition.TimeKey(tm, "minute")
 	assert.Equal(t, "year=2022/month=06/day=05/hour=00/minute=00", timeKey)
 }
 
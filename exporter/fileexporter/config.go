@@ -38,8 +38,12 @@ type Config struct {
 	// Options:
 	// - json[default]:  OTLP json bytes.
 	// - proto:  OTLP binary protobuf bytes.
+	// - parquet:  Apache Parquet files, one file per signal.
 	FormatType string `mapstructure:"format"`
 
+	// Parquet defines settings specific to the parquet format.
+	Parquet *ParquetConfig `mapstructure:"parquet"`
+
 	// Compression Codec used to export telemetry data
 	// Supported compression algorithms:`zstd`
 	Compression string `mapstructure:"compression"`
@@ -90,6 +94,17 @@ type GroupBy struct {
 	MaxOpenFiles int `mapstructure:"max_open_files"`
 }
 
+// ParquetConfig defines how telemetry is laid out in Parquet files.
+type ParquetConfig struct {
+	// PromotedResourceAttributes lists resource attributes that are copied
+	// into their own top-level string column named "resource_<attribute>".
+	PromotedResourceAttributes []string `mapstructure:"promoted_resource_attributes"`
+
+	// PromotedAttributes lists log record, span or data point attributes that
+	// are copied into their own top-level string column named "attr_<attribute>".
+	PromotedAttributes []string `mapstructure:"promoted_attributes"`
+}
+
 var _ component.Config = (*Config)(nil)
 
 // Validate checks if the exporter configuration is valid
@@ -103,9 +118,12 @@ func (cfg *Config) Validate() error {
 	if cfg.Append && cfg.Rotation != nil {
 		return fmt.Errorf("append and rotation enabled at the same time is not supported")
 	}
-	if cfg.FormatType != formatTypeJSON && cfg.FormatType != formatTypeProto {
+	if cfg.FormatType != formatTypeJSON && cfg.FormatType != formatTypeProto && cfg.FormatType != formatTypeParquet {
 		return errors.New("format type is not supported")
 	}
+	if cfg.FormatType == formatTypeParquet && cfg.Append {
+		return errors.New("append is not supported with the parquet format")
+	}
 	if cfg.Compression != "" && cfg.Compression != compressionZSTD {
 		return errors.New("compression is not supported")
 	}
@@ -128,6 +146,30 @@ func (cfg *Config) Validate() error {
 		}
 	}
 
+	if cfg.Parquet != nil {
+		fixedColumns := fixedColumnNames()
+		columns := make(map[string]string)
+		promoted := make([]promotedColumn, 0, len(cfg.Parquet.PromotedResourceAttributes)+len(cfg.Parquet.PromotedAttributes))
+		for _, attr := range cfg.Parquet.PromotedResourceAttributes {
+			promoted = append(promoted, promotedColumn{attribute: attr, resource: true})
+		}
+		for _, attr := range cfg.Parquet.PromotedAttributes {
+			promoted = append(promoted, promotedColumn{attribute: attr})
+		}
+		for _, p := range promoted {
+			if p.attribute == "" {
+				return errors.New("promoted attribute names must not be empty")
+			}
+			if _, ok := fixedColumns[p.columnName()]; ok {
+				return fmt.Errorf("promoted attribute %q maps to the reserved column %q", p.attribute, p.columnName())
+			}
+			if other, ok := columns[p.columnName()]; ok {
+				return fmt.Errorf("promoted attributes %q and %q map to the same column %q", other, p.attribute, p.columnName())
+			}
+			columns[p.columnName()] = p.attribute
+		}
+	}
+
 	return nil
 }
 
@@ -185,6 +185,39 @@ func TestLoadConfig(t *testing.T) {
 			id:           component.NewIDWithName(metadata.Type, "group_by_empty_resource_attribute"),
 			errorMessage: "resource_attribute must not be empty when group_by is enabled",
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "parquet"),
+			expected: &Config{
+				Path:        "./data/telemetry.parquet",
+				FormatType:  formatTypeParquet,
+				Compression: compressionZSTD,
+				Rotation: &Rotation{
+					MaxMegabytes: 64,
+					MaxBackups:   defaultMaxBackups,
+				},
+				Parquet: &ParquetConfig{
+					PromotedResourceAttributes: []string{"service.name"},
+					PromotedAttributes:         []string{"http.method"},
+				},
+				FlushInterval: time.Second,
+				GroupBy: &GroupBy{
+					MaxOpenFiles:      defaultMaxOpenFiles,
+					ResourceAttribute: defaultResourceAttribute,
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "parquet_append"),
+			errorMessage: "append is not supported with the parquet format",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "parquet_duplicate_column"),
+			errorMessage: `promoted attributes "http.method" and "http_method" map to the same column "attr_http_method"`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "parquet_reserved_column"),
+			errorMessage: `promoted attribute "schema_url" maps to the reserved column "resource_schema_url"`,
+		},
 	}
 
 	for _, tt := range tests {
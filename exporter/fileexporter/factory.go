@@ -28,8 +28,9 @@ const (
 	defaultMaxBackups = 100
 
 	// the format of encoded telemetry data
-	formatTypeJSON  = "json"
-	formatTypeProto = "proto"
+	formatTypeJSON    = "json"
+	formatTypeProto   = "proto"
+	formatTypeParquet = "parquet"
 
 	// the type of compression codec
 	compressionZSTD = "zstd"
@@ -133,6 +134,13 @@ func getOrCreateFileExporter(cfg component.Config, logger *zap.Logger) FileExpor
 }
 
 func newFileExporter(conf *Config, logger *zap.Logger) FileExporter {
+	if conf.FormatType == formatTypeParquet {
+		return &parquetExporter{
+			conf:   conf,
+			logger: logger,
+		}
+	}
+
 	if conf.GroupBy == nil || !conf.GroupBy.Enabled {
 		return &fileExporter{
 			conf: conf,
@@ -202,19 +202,25 @@ func cleanPathPrefix(pathPrefix string) string {
 }
 
 func (e *groupingFileExporter) fullPath(pathSegment string) string {
+	return resolveGroupPath(e.pathPrefix, pathSegment, e.pathSuffix)
+}
+
+// resolveGroupPath substitutes pathSegment between the prefix and suffix of the
+// configured path, making sure the result does not escape pathPrefix.
+func resolveGroupPath(pathPrefix, pathSegment, pathSuffix string) string {
 	if strings.HasPrefix(pathSegment, "./") {
 		pathSegment = pathSegment[1:]
 	} else if strings.HasPrefix(pathSegment, "../") {
 		pathSegment = pathSegment[2:]
 	}
 
-	fullPath := path.Clean(e.pathPrefix + pathSegment + e.pathSuffix)
-	if strings.HasPrefix(fullPath, e.pathPrefix) {
-		return fullPath
+	p := path.Clean(pathPrefix + pathSegment + pathSuffix)
+	if strings.HasPrefix(p, pathPrefix) {
+		return p
 	}
 
 	// avoid path traversal vulnerability
-	return path.Join(e.pathPrefix, path.Join("/", pathSegment+e.pathSuffix))
+	return path.Join(pathPrefix, path.Join("/", pathSegment+pathSuffix))
 }
 
 func (e *groupingFileExporter) onEvict(_ string, writer *fileWriter) {
@@ -0,0 +1,207 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+// parquetExporter writes telemetry as Parquet files, one file per signal.
+// When group_by is enabled, a set of files is kept per resource attribute value.
+type parquetExporter struct {
+	conf   *Config
+	logger *zap.Logger
+
+	tracesBuilder  *parquetRowBuilder
+	metricsBuilder *parquetRowBuilder
+	logsBuilder    *parquetRowBuilder
+
+	pathPrefix string
+	pathSuffix string
+
+	mutex   sync.Mutex
+	writers *simplelru.LRU[string, *parquetWriter]
+}
+
+func (e *parquetExporter) consumeTraces(_ context.Context, td ptrace.Traces) error {
+	groups := make(map[string]ptrace.Traces)
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		p, ok := e.pathFor(rs.Resource())
+		if !ok {
+			continue
+		}
+		if _, ok = groups[p]; !ok {
+			groups[p] = ptrace.NewTraces()
+		}
+		rs.CopyTo(groups[p].ResourceSpans().AppendEmpty())
+	}
+
+	var errs error
+	for p, traces := range groups {
+		errs = errors.Join(errs, e.write(signalPath(p, signalTraces), e.tracesBuilder, tracesToParquetRows(traces, e.tracesBuilder)))
+	}
+	return errs
+}
+
+func (e *parquetExporter) consumeMetrics(_ context.Context, md pmetric.Metrics) error {
+	groups := make(map[string]pmetric.Metrics)
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		p, ok := e.pathFor(rm.Resource())
+		if !ok {
+			continue
+		}
+		if _, ok = groups[p]; !ok {
+			groups[p] = pmetric.NewMetrics()
+		}
+		rm.CopyTo(groups[p].ResourceMetrics().AppendEmpty())
+	}
+
+	var errs error
+	for p, metrics := range groups {
+		errs = errors.Join(errs, e.write(signalPath(p, signalMetrics), e.metricsBuilder, metricsToParquetRows(metrics, e.metricsBuilder)))
+	}
+	return errs
+}
+
+func (e *parquetExporter) consumeLogs(_ context.Context, ld plog.Logs) error {
+	groups := make(map[string]plog.Logs)
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+		p, ok := e.pathFor(rl.Resource())
+		if !ok {
+			continue
+		}
+		if _, ok = groups[p]; !ok {
+			groups[p] = plog.NewLogs()
+		}
+		rl.CopyTo(groups[p].ResourceLogs().AppendEmpty())
+	}
+
+	var errs error
+	for p, logs := range groups {
+		errs = errors.Join(errs, e.write(signalPath(p, signalLogs), e.logsBuilder, logsToParquetRows(logs, e.logsBuilder)))
+	}
+	return errs
+}
+
+func (e *parquetExporter) groupingEnabled() bool {
+	return e.conf.GroupBy != nil && e.conf.GroupBy.Enabled
+}
+
+// pathFor returns the base path that the given resource is written to.
+func (e *parquetExporter) pathFor(resource pcommon.Resource) (string, bool) {
+	if !e.groupingEnabled() {
+		return e.conf.Path, true
+	}
+
+	v, ok := resource.Attributes().Get(e.conf.GroupBy.ResourceAttribute)
+	if !ok || v.Type() != pcommon.ValueTypeStr {
+		e.logger.Debug(fmt.Sprintf("Resource does not contain %s attribute, dropping it", e.conf.GroupBy.ResourceAttribute))
+		return "", false
+	}
+	return resolveGroupPath(e.pathPrefix, v.Str(), e.pathSuffix), true
+}
+
+func (e *parquetExporter) write(p string, builder *parquetRowBuilder, rows []any) error {
+	writer, err := e.getWriter(p, builder)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	if err = writer.write(rows); err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return nil
+}
+
+func (e *parquetExporter) getWriter(p string, builder *parquetRowBuilder) (*parquetWriter, error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if e.writers == nil {
+		return nil, errors.New("exporter is not started")
+	}
+
+	writer, ok := e.writers.Get(p)
+	if ok {
+		return writer, nil
+	}
+
+	if err := os.MkdirAll(path.Dir(p), 0755); err != nil {
+		return nil, err
+	}
+
+	rotation := e.conf.Rotation
+	if e.groupingEnabled() {
+		rotation = nil
+	}
+	writer = newParquetWriter(p, rotation, e.conf.Compression, e.conf.FlushInterval, builder)
+	e.writers.Add(p, writer)
+	writer.start()
+
+	return writer, nil
+}
+
+func (e *parquetExporter) onEvict(_ string, writer *parquetWriter) {
+	if err := writer.shutdown(); err != nil {
+		e.logger.Warn("Failed to close file", zap.Error(err), zap.String("path", writer.path))
+	}
+}
+
+// Start initializes the exporter.
+func (e *parquetExporter) Start(context.Context, component.Host) error {
+	e.tracesBuilder = newParquetRowBuilder(parquetSpanRow{}, e.conf.Parquet)
+	e.metricsBuilder = newParquetRowBuilder(parquetMetricRow{}, e.conf.Parquet)
+	e.logsBuilder = newParquetRowBuilder(parquetLogRow{}, e.conf.Parquet)
+
+	// Without grouping there is at most one open file per signal.
+	maxOpenFiles := 3
+	if e.groupingEnabled() {
+		pathParts := strings.Split(e.conf.Path, "*")
+		e.pathPrefix = cleanPathPrefix(pathParts[0])
+		e.pathSuffix = pathParts[1]
+		maxOpenFiles = e.conf.GroupBy.MaxOpenFiles
+	}
+
+	writers, err := simplelru.NewLRU(maxOpenFiles, e.onEvict)
+	if err != nil {
+		return err
+	}
+
+	e.mutex.Lock()
+	e.writers = writers
+	e.mutex.Unlock()
+	return nil
+}
+
+// Shutdown writes the footers of all open files and closes them.
+func (e *parquetExporter) Shutdown(context.Context) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if e.writers == nil {
+		return nil
+	}
+
+	e.writers.Purge()
+	e.writers = nil
+
+	return nil
+}
@@ -0,0 +1,182 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
+)
+
+func readParquetRows[T any](t *testing.T, path string) []T {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	fi, err := f.Stat()
+	require.NoError(t, err)
+	rows, err := parquet.Read[T](f, fi.Size())
+	require.NoError(t, err)
+	return rows
+}
+
+func TestParquetExporter(t *testing.T) {
+	tests := []struct {
+		name        string
+		compression string
+	}{
+		{name: "default compression"},
+		{name: "zstd compression", compression: compressionZSTD},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &Config{
+				Path:        filepath.Join(t.TempDir(), "telemetry.parquet"),
+				FormatType:  formatTypeParquet,
+				Compression: tt.compression,
+			}
+			fe := newFileExporter(conf, zap.NewNop())
+			require.IsType(t, &parquetExporter{}, fe)
+
+			require.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+			require.NoError(t, fe.consumeLogs(context.Background(), testdata.GenerateLogsTwoLogRecordsSameResource()))
+			require.NoError(t, fe.consumeTraces(context.Background(), testdata.GenerateTracesTwoSpansSameResourceOneDifferent()))
+			require.NoError(t, fe.consumeMetrics(context.Background(), testdata.GeneratMetricsAllTypesWithSampleDatapoints()))
+			require.NoError(t, fe.Shutdown(context.Background()))
+
+			logs := readParquetRows[parquetLogRow](t, signalPath(conf.Path, signalLogs))
+			require.Len(t, logs, 2)
+			assert.Equal(t, "resource-attr-val-1", logs[0].ResourceAttributes["resource-attr"])
+			assert.Equal(t, "This is a log message", logs[0].Body)
+			assert.Equal(t, "Info", logs[0].SeverityText)
+			assert.Equal(t, "server", logs[0].Attributes["app"])
+
+			spans := readParquetRows[parquetSpanRow](t, signalPath(conf.Path, signalTraces))
+			require.Len(t, spans, 3)
+			assert.Equal(t, "operationA", spans[0].Name)
+			require.Len(t, spans[0].Events, 2)
+			assert.Equal(t, "event-with-attr", spans[0].Events[0].Name)
+			assert.Equal(t, "Error", spans[0].StatusCode)
+
+			metrics := readParquetRows[parquetMetricRow](t, signalPath(conf.Path, signalMetrics))
+			require.NotEmpty(t, metrics)
+			var bucketCounts, quantileValues int
+			for _, m := range metrics {
+				switch m.MetricType {
+				case "Gauge", "Sum":
+					assert.True(t, m.ValueInt != nil || m.ValueDouble != nil)
+				case "Histogram":
+					require.NotNil(t, m.Count)
+					bucketCounts += len(m.BucketCounts)
+				case "Summary":
+					quantileValues += len(m.QuantileValues)
+				}
+			}
+			// only some of the generated data points have buckets and quantiles
+			assert.Positive(t, bucketCounts)
+			assert.Positive(t, quantileValues)
+		})
+	}
+}
+
+func TestParquetExporterPromotedColumns(t *testing.T) {
+	conf := &Config{
+		Path:       filepath.Join(t.TempDir(), "telemetry.parquet"),
+		FormatType: formatTypeParquet,
+		Parquet: &ParquetConfig{
+			PromotedResourceAttributes: []string{"resource-attr"},
+			PromotedAttributes:         []string{"app", "missing"},
+		},
+	}
+	fe := newFileExporter(conf, zap.NewNop())
+	require.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, fe.consumeLogs(context.Background(), testdata.GenerateLogsTwoLogRecordsSameResource()))
+	require.NoError(t, fe.Shutdown(context.Background()))
+
+	type promotedLogRow struct {
+		Body     string  `parquet:"body"`
+		Resource *string `parquet:"resource_resource_attr,optional"`
+		App      *string `parquet:"attr_app,optional"`
+		Missing  *string `parquet:"attr_missing,optional"`
+	}
+	rows := readParquetRows[promotedLogRow](t, signalPath(conf.Path, signalLogs))
+	require.Len(t, rows, 2)
+	require.NotNil(t, rows[0].Resource)
+	assert.Equal(t, "resource-attr-val-1", *rows[0].Resource)
+	require.NotNil(t, rows[0].App)
+	assert.Equal(t, "server", *rows[0].App)
+	assert.Nil(t, rows[0].Missing)
+}
+
+func TestParquetExporterGroupBy(t *testing.T) {
+	tmpDir := t.TempDir()
+	conf := &Config{
+		Path:       tmpDir + "/*.parquet",
+		FormatType: formatTypeParquet,
+		GroupBy: &GroupBy{
+			Enabled:           true,
+			ResourceAttribute: defaultResourceAttribute,
+			MaxOpenFiles:      1,
+		},
+	}
+	fe := newFileExporter(conf, zap.NewNop())
+	require.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+
+	td := testdata.GenerateTracesTwoSpansSameResourceOneDifferent()
+	td.ResourceSpans().At(0).Resource().Attributes().PutStr(defaultResourceAttribute, "one")
+	td.ResourceSpans().At(1).Resource().Attributes().PutStr(defaultResourceAttribute, "../two")
+	require.NoError(t, fe.consumeTraces(context.Background(), td))
+	require.NoError(t, fe.Shutdown(context.Background()))
+
+	assert.Len(t, readParquetRows[parquetSpanRow](t, tmpDir+"/one.traces.parquet"), 2)
+	assert.Len(t, readParquetRows[parquetSpanRow](t, tmpDir+"/two.traces.parquet"), 1)
+}
+
+func TestParquetWriterRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs.parquet")
+	now := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	w := newParquetWriter(path, &Rotation{MaxMegabytes: 1, MaxBackups: 2}, "", 0, newParquetRowBuilder(parquetLogRow{}, nil))
+	w.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+	// roll after every batch
+	w.maxBytes = 1
+
+	for i := 0; i < 4; i++ {
+		ld := testdata.GenerateLogsManyLogRecordsSameResource(10)
+		require.NoError(t, w.write(logsToParquetRows(ld, w.builder)))
+	}
+	require.NoError(t, w.shutdown())
+
+	backups, err := filepath.Glob(filepath.Join(filepath.Dir(path), "logs-*.parquet"))
+	require.NoError(t, err)
+	assert.Len(t, backups, 2)
+	for _, backup := range append(backups, path) {
+		assert.Len(t, readParquetRows[parquetLogRow](t, backup), 10)
+	}
+}
+
+func TestParquetWriterDefaultMaxMegabytes(t *testing.T) {
+	w := newParquetWriter("logs.parquet", &Rotation{}, "", 0, newParquetRowBuilder(parquetLogRow{}, nil))
+	assert.Equal(t, int64(defaultMaxMegabytes*megabyte), w.maxBytes)
+
+	w = newParquetWriter("logs.parquet", nil, "", 0, newParquetRowBuilder(parquetLogRow{}, nil))
+	assert.Zero(t, w.maxBytes)
+}
+
+func TestSignalPath(t *testing.T) {
+	assert.Equal(t, "/data/telemetry.logs.parquet", signalPath("/data/telemetry.parquet", signalLogs))
+	assert.Equal(t, "/data/telemetry.traces", signalPath("/data/telemetry", signalTraces))
+}
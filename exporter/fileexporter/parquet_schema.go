@@ -0,0 +1,518 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// The structs below define the Parquet schema written by the exporter. Column
+// names are part of the documented file format, do not rename them.
+
+type parquetLogRow struct {
+	ResourceAttributes     map[string]string `parquet:"resource_attributes"`
+	ResourceSchemaURL      string            `parquet:"resource_schema_url"`
+	ScopeName              string            `parquet:"scope_name"`
+	ScopeVersion           string            `parquet:"scope_version"`
+	ScopeAttributes        map[string]string `parquet:"scope_attributes"`
+	ScopeSchemaURL         string            `parquet:"scope_schema_url"`
+	TimeUnixNano           int64             `parquet:"time_unix_nano,timestamp(nanosecond)"`
+	ObservedTimeUnixNano   int64             `parquet:"observed_time_unix_nano,timestamp(nanosecond)"`
+	SeverityNumber         int32             `parquet:"severity_number"`
+	SeverityText           string            `parquet:"severity_text"`
+	Body                   string            `parquet:"body"`
+	Attributes             map[string]string `parquet:"attributes"`
+	DroppedAttributesCount uint32            `parquet:"dropped_attributes_count"`
+	Flags                  uint32            `parquet:"flags"`
+	TraceID                string            `parquet:"trace_id"`
+	SpanID                 string            `parquet:"span_id"`
+}
+
+type parquetSpanRow struct {
+	ResourceAttributes     map[string]string     `parquet:"resource_attributes"`
+	ResourceSchemaURL      string                `parquet:"resource_schema_url"`
+	ScopeName              string                `parquet:"scope_name"`
+	ScopeVersion           string                `parquet:"scope_version"`
+	ScopeAttributes        map[string]string     `parquet:"scope_attributes"`
+	ScopeSchemaURL         string                `parquet:"scope_schema_url"`
+	TraceID                string                `parquet:"trace_id"`
+	SpanID                 string                `parquet:"span_id"`
+	ParentSpanID           string                `parquet:"parent_span_id"`
+	TraceState             string                `parquet:"trace_state"`
+	Name                   string                `parquet:"name"`
+	Kind                   string                `parquet:"kind"`
+	StartTimeUnixNano      int64                 `parquet:"start_time_unix_nano,timestamp(nanosecond)"`
+	EndTimeUnixNano        int64                 `parquet:"end_time_unix_nano,timestamp(nanosecond)"`
+	DurationNano           int64                 `parquet:"duration_nano"`
+	StatusCode             string                `parquet:"status_code"`
+	StatusMessage          string                `parquet:"status_message"`
+	Attributes             map[string]string     `parquet:"attributes"`
+	DroppedAttributesCount uint32                `parquet:"dropped_attributes_count"`
+	Events                 []parquetSpanEventRow `parquet:"events"`
+	DroppedEventsCount     uint32                `parquet:"dropped_events_count"`
+	Links                  []parquetSpanLinkRow  `parquet:"links"`
+	DroppedLinksCount      uint32                `parquet:"dropped_links_count"`
+}
+
+type parquetSpanEventRow struct {
+	TimeUnixNano int64             `parquet:"time_unix_nano,timestamp(nanosecond)"`
+	Name         string            `parquet:"name"`
+	Attributes   map[string]string `parquet:"attributes"`
+}
+
+type parquetSpanLinkRow struct {
+	TraceID    string            `parquet:"trace_id"`
+	SpanID     string            `parquet:"span_id"`
+	TraceState string            `parquet:"trace_state"`
+	Attributes map[string]string `parquet:"attributes"`
+}
+
+// parquetMetricRow holds a single data point. Only the value columns relevant
+// to the metric type are populated, the others are left null or empty.
+type parquetMetricRow struct {
+	ResourceAttributes     map[string]string `parquet:"resource_attributes"`
+	ResourceSchemaURL      string            `parquet:"resource_schema_url"`
+	ScopeName              string            `parquet:"scope_name"`
+	ScopeVersion           string            `parquet:"scope_version"`
+	ScopeAttributes        map[string]string `parquet:"scope_attributes"`
+	ScopeSchemaURL         string            `parquet:"scope_schema_url"`
+	MetricName             string            `parquet:"metric_name"`
+	MetricDescription      string            `parquet:"metric_description"`
+	MetricUnit             string            `parquet:"metric_unit"`
+	MetricType             string            `parquet:"metric_type"`
+	AggregationTemporality string            `parquet:"aggregation_temporality"`
+	IsMonotonic            bool              `parquet:"is_monotonic"`
+	Attributes             map[string]string `parquet:"attributes"`
+	StartTimeUnixNano      int64             `parquet:"start_time_unix_nano,timestamp(nanosecond)"`
+	TimeUnixNano           int64             `parquet:"time_unix_nano,timestamp(nanosecond)"`
+	Flags                  uint32            `parquet:"flags"`
+	ValueDouble            *float64          `parquet:"value_double,optional"`
+	ValueInt               *int64            `parquet:"value_int,optional"`
+	Count                  *uint64           `parquet:"count,optional"`
+	Sum                    *float64          `parquet:"sum,optional"`
+	Min                    *float64          `parquet:"min,optional"`
+	Max                    *float64          `parquet:"max,optional"`
+	BucketCounts           []uint64          `parquet:"bucket_counts,list"`
+	ExplicitBounds         []float64         `parquet:"explicit_bounds,list"`
+	Scale                  *int32            `parquet:"scale,optional"`
+	ZeroCount              *uint64           `parquet:"zero_count,optional"`
+	PositiveOffset         *int32            `parquet:"positive_offset,optional"`
+	PositiveBucketCounts   []uint64          `parquet:"positive_bucket_counts,list"`
+	NegativeOffset         *int32            `parquet:"negative_offset,optional"`
+	NegativeBucketCounts   []uint64          `parquet:"negative_bucket_counts,list"`
+	QuantileValues         []parquetQuantile `parquet:"quantile_values,list"`
+}
+
+type parquetQuantile struct {
+	Quantile float64 `parquet:"quantile"`
+	Value    float64 `parquet:"value"`
+}
+
+const (
+	promotedResourceColumnPrefix  = "resource_"
+	promotedAttributeColumnPrefix = "attr_"
+)
+
+// fixedColumnNames returns the top-level columns of the fixed row structs,
+// which promoted columns must not shadow.
+func fixedColumnNames() map[string]struct{} {
+	names := make(map[string]struct{})
+	for _, row := range []any{parquetLogRow{}, parquetSpanRow{}, parquetMetricRow{}} {
+		rowType := reflect.TypeOf(row)
+		for i := 0; i < rowType.NumField(); i++ {
+			name, _, _ := strings.Cut(rowType.Field(i).Tag.Get("parquet"), ",")
+			names[name] = struct{}{}
+		}
+	}
+	return names
+}
+
+// promotedColumn describes an attribute copied into a top-level column.
+type promotedColumn struct {
+	attribute string
+	resource  bool
+}
+
+// parquetRowBuilder extends one of the fixed row structs above with optional
+// string columns holding promoted attributes. When no attribute is promoted
+// the fixed row struct is written as is.
+type parquetRowBuilder struct {
+	baseType reflect.Type
+	rowType  reflect.Type
+	promoted []promotedColumn
+}
+
+func newParquetRowBuilder(base any, cfg *ParquetConfig) *parquetRowBuilder {
+	baseType := reflect.TypeOf(base)
+	b := &parquetRowBuilder{baseType: baseType, rowType: baseType}
+	if cfg == nil {
+		return b
+	}
+	for _, attr := range cfg.PromotedResourceAttributes {
+		b.promoted = append(b.promoted, promotedColumn{attribute: attr, resource: true})
+	}
+	for _, attr := range cfg.PromotedAttributes {
+		b.promoted = append(b.promoted, promotedColumn{attribute: attr})
+	}
+	if len(b.promoted) == 0 {
+		return b
+	}
+
+	fields := make([]reflect.StructField, 0, baseType.NumField()+len(b.promoted))
+	for i := 0; i < baseType.NumField(); i++ {
+		fields = append(fields, baseType.Field(i))
+	}
+	for i, p := range b.promoted {
+		fields = append(fields, reflect.StructField{
+			Name: fmt.Sprintf("Promoted%d", i),
+			Type: reflect.TypeOf((*string)(nil)),
+			Tag:  reflect.StructTag(fmt.Sprintf(`parquet:"%s,optional"`, p.columnName())),
+		})
+	}
+	b.rowType = reflect.StructOf(fields)
+	return b
+}
+
+// model returns a zero value of the row type, used to derive the schema.
+func (b *parquetRowBuilder) model() any {
+	return reflect.New(b.rowType).Elem().Interface()
+}
+
+// build converts a fixed row into the (possibly extended) row type.
+func (b *parquetRowBuilder) build(base any, resourceAttrs, attrs pcommon.Map) any {
+	if len(b.promoted) == 0 {
+		return base
+	}
+	baseValue := reflect.ValueOf(base)
+	row := reflect.New(b.rowType).Elem()
+	for i := 0; i < b.baseType.NumField(); i++ {
+		row.Field(i).Set(baseValue.Field(i))
+	}
+	for i, p := range b.promoted {
+		source := attrs
+		if p.resource {
+			source = resourceAttrs
+		}
+		if v, ok := source.Get(p.attribute); ok {
+			s := v.AsString()
+			row.Field(b.baseType.NumField() + i).Set(reflect.ValueOf(&s))
+		}
+	}
+	return row.Interface()
+}
+
+func (p promotedColumn) columnName() string {
+	prefix := promotedAttributeColumnPrefix
+	if p.resource {
+		prefix = promotedResourceColumnPrefix
+	}
+	return prefix + sanitizeColumnName(p.attribute)
+}
+
+// sanitizeColumnName replaces every character that is not a letter, a digit
+// or an underscore so that column paths stay unambiguous.
+func sanitizeColumnName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
+
+func attributesToMap(attrs pcommon.Map) map[string]string {
+	if attrs.Len() == 0 {
+		return nil
+	}
+	m := make(map[string]string, attrs.Len())
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		m[k] = v.AsString()
+		return true
+	})
+	return m
+}
+
+func traceIDToString(id pcommon.TraceID) string {
+	if id.IsEmpty() {
+		return ""
+	}
+	return id.String()
+}
+
+func spanIDToString(id pcommon.SpanID) string {
+	if id.IsEmpty() {
+		return ""
+	}
+	return id.String()
+}
+
+func logsToParquetRows(ld plog.Logs, b *parquetRowBuilder) []any {
+	rows := make([]any, 0, ld.LogRecordCount())
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+		resourceAttrs := attributesToMap(rl.Resource().Attributes())
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			scopeAttrs := attributesToMap(sl.Scope().Attributes())
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				lr := sl.LogRecords().At(k)
+				rows = append(rows, b.build(parquetLogRow{
+					ResourceAttributes:     resourceAttrs,
+					ResourceSchemaURL:      rl.SchemaUrl(),
+					ScopeName:              sl.Scope().Name(),
+					ScopeVersion:           sl.Scope().Version(),
+					ScopeAttributes:        scopeAttrs,
+					ScopeSchemaURL:         sl.SchemaUrl(),
+					TimeUnixNano:           int64(lr.Timestamp()),
+					ObservedTimeUnixNano:   int64(lr.ObservedTimestamp()),
+					SeverityNumber:         int32(lr.SeverityNumber()),
+					SeverityText:           lr.SeverityText(),
+					Body:                   lr.Body().AsString(),
+					Attributes:             attributesToMap(lr.Attributes()),
+					DroppedAttributesCount: lr.DroppedAttributesCount(),
+					Flags:                  uint32(lr.Flags()),
+					TraceID:                traceIDToString(lr.TraceID()),
+					SpanID:                 spanIDToString(lr.SpanID()),
+				}, rl.Resource().Attributes(), lr.Attributes()))
+			}
+		}
+	}
+	return rows
+}
+
+func tracesToParquetRows(td ptrace.Traces, b *parquetRowBuilder) []any {
+	rows := make([]any, 0, td.SpanCount())
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		resourceAttrs := attributesToMap(rs.Resource().Attributes())
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			scopeAttrs := attributesToMap(ss.Scope().Attributes())
+			for k := 0; k < ss.Spans().Len(); k++ {
+				span := ss.Spans().At(k)
+				rows = append(rows, b.build(parquetSpanRow{
+					ResourceAttributes:     resourceAttrs,
+					ResourceSchemaURL:      rs.SchemaUrl(),
+					ScopeName:              ss.Scope().Name(),
+					ScopeVersion:           ss.Scope().Version(),
+					ScopeAttributes:        scopeAttrs,
+					ScopeSchemaURL:         ss.SchemaUrl(),
+					TraceID:                traceIDToString(span.TraceID()),
+					SpanID:                 spanIDToString(span.SpanID()),
+					ParentSpanID:           spanIDToString(span.ParentSpanID()),
+					TraceState:             span.TraceState().AsRaw(),
+					Name:                   span.Name(),
+					Kind:                   span.Kind().String(),
+					StartTimeUnixNano:      int64(span.StartTimestamp()),
+					EndTimeUnixNano:        int64(span.EndTimestamp()),
+					DurationNano:           int64(span.EndTimestamp()) - int64(span.StartTimestamp()),
+					StatusCode:             span.Status().Code().String(),
+					StatusMessage:          span.Status().Message(),
+					Attributes:             attributesToMap(span.Attributes()),
+					DroppedAttributesCount: span.DroppedAttributesCount(),
+					Events:                 spanEventsToParquet(span.Events()),
+					DroppedEventsCount:     span.DroppedEventsCount(),
+					Links:                  spanLinksToParquet(span.Links()),
+					DroppedLinksCount:      span.DroppedLinksCount(),
+				}, rs.Resource().Attributes(), span.Attributes()))
+			}
+		}
+	}
+	return rows
+}
+
+func spanEventsToParquet(events ptrace.SpanEventSlice) []parquetSpanEventRow {
+	if events.Len() == 0 {
+		return nil
+	}
+	rows := make([]parquetSpanEventRow, 0, events.Len())
+	for i := 0; i < events.Len(); i++ {
+		event := events.At(i)
+		rows = append(rows, parquetSpanEventRow{
+			TimeUnixNano: int64(event.Timestamp()),
+			Name:         event.Name(),
+			Attributes:   attributesToMap(event.Attributes()),
+		})
+	}
+	return rows
+}
+
+func spanLinksToParquet(links ptrace.SpanLinkSlice) []parquetSpanLinkRow {
+	if links.Len() == 0 {
+		return nil
+	}
+	rows := make([]parquetSpanLinkRow, 0, links.Len())
+	for i := 0; i < links.Len(); i++ {
+		link := links.At(i)
+		rows = append(rows, parquetSpanLinkRow{
+			TraceID:    traceIDToString(link.TraceID()),
+			SpanID:     spanIDToString(link.SpanID()),
+			TraceState: link.TraceState().AsRaw(),
+			Attributes: attributesToMap(link.Attributes()),
+		})
+	}
+	return rows
+}
+
+func metricsToParquetRows(md pmetric.Metrics, b *parquetRowBuilder) []any {
+	rows := make([]any, 0, md.DataPointCount())
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		resourceAttrs := attributesToMap(rm.Resource().Attributes())
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			scopeAttrs := attributesToMap(sm.Scope().Attributes())
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				m := sm.Metrics().At(k)
+				base := parquetMetricRow{
+					ResourceAttributes: resourceAttrs,
+					ResourceSchemaURL:  rm.SchemaUrl(),
+					ScopeName:          sm.Scope().Name(),
+					ScopeVersion:       sm.Scope().Version(),
+					ScopeAttributes:    scopeAttrs,
+					ScopeSchemaURL:     sm.SchemaUrl(),
+					MetricName:         m.Name(),
+					MetricDescription:  m.Description(),
+					MetricUnit:         m.Unit(),
+					MetricType:         m.Type().String(),
+				}
+				emit := func(row parquetMetricRow, attrs pcommon.Map) {
+					rows = append(rows, b.build(row, rm.Resource().Attributes(), attrs))
+				}
+				switch m.Type() {
+				case pmetric.MetricTypeGauge:
+					numberDataPointsToParquet(base, m.Gauge().DataPoints(), emit)
+				case pmetric.MetricTypeSum:
+					base.AggregationTemporality = m.Sum().AggregationTemporality().String()
+					base.IsMonotonic = m.Sum().IsMonotonic()
+					numberDataPointsToParquet(base, m.Sum().DataPoints(), emit)
+				case pmetric.MetricTypeHistogram:
+					base.AggregationTemporality = m.Histogram().AggregationTemporality().String()
+					histogramDataPointsToParquet(base, m.Histogram().DataPoints(), emit)
+				case pmetric.MetricTypeExponentialHistogram:
+					base.AggregationTemporality = m.ExponentialHistogram().AggregationTemporality().String()
+					exponentialHistogramDataPointsToParquet(base, m.ExponentialHistogram().DataPoints(), emit)
+				case pmetric.MetricTypeSummary:
+					summaryDataPointsToParquet(base, m.Summary().DataPoints(), emit)
+				}
+			}
+		}
+	}
+	return rows
+}
+
+func numberDataPointsToParquet(base parquetMetricRow, dps pmetric.NumberDataPointSlice, emit func(parquetMetricRow, pcommon.Map)) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		row := base
+		row.Attributes = attributesToMap(dp.Attributes())
+		row.StartTimeUnixNano = int64(dp.StartTimestamp())
+		row.TimeUnixNano = int64(dp.Timestamp())
+		row.Flags = uint32(dp.Flags())
+		switch dp.ValueType() {
+		case pmetric.NumberDataPointValueTypeDouble:
+			v := dp.DoubleValue()
+			row.ValueDouble = &v
+		case pmetric.NumberDataPointValueTypeInt:
+			v := dp.IntValue()
+			row.ValueInt = &v
+		}
+		emit(row, dp.Attributes())
+	}
+}
+
+func histogramDataPointsToParquet(base parquetMetricRow, dps pmetric.HistogramDataPointSlice, emit func(parquetMetricRow, pcommon.Map)) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		row := base
+		row.Attributes = attributesToMap(dp.Attributes())
+		row.StartTimeUnixNano = int64(dp.StartTimestamp())
+		row.TimeUnixNano = int64(dp.Timestamp())
+		row.Flags = uint32(dp.Flags())
+		count := dp.Count()
+		row.Count = &count
+		if dp.HasSum() {
+			sum := dp.Sum()
+			row.Sum = &sum
+		}
+		if dp.HasMin() {
+			minimum := dp.Min()
+			row.Min = &minimum
+		}
+		if dp.HasMax() {
+			maximum := dp.Max()
+			row.Max = &maximum
+		}
+		row.BucketCounts = dp.BucketCounts().AsRaw()
+		row.ExplicitBounds = dp.ExplicitBounds().AsRaw()
+		emit(row, dp.Attributes())
+	}
+}
+
+func exponentialHistogramDataPointsToParquet(base parquetMetricRow, dps pmetric.ExponentialHistogramDataPointSlice, emit func(parquetMetricRow, pcommon.Map)) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		row := base
+		row.Attributes = attributesToMap(dp.Attributes())
+		row.StartTimeUnixNano = int64(dp.StartTimestamp())
+		row.TimeUnixNano = int64(dp.Timestamp())
+		row.Flags = uint32(dp.Flags())
+		count := dp.Count()
+		row.Count = &count
+		if dp.HasSum() {
+			sum := dp.Sum()
+			row.Sum = &sum
+		}
+		if dp.HasMin() {
+			minimum := dp.Min()
+			row.Min = &minimum
+		}
+		if dp.HasMax() {
+			maximum := dp.Max()
+			row.Max = &maximum
+		}
+		scale := dp.Scale()
+		row.Scale = &scale
+		zeroCount := dp.ZeroCount()
+		row.ZeroCount = &zeroCount
+		positiveOffset := dp.Positive().Offset()
+		row.PositiveOffset = &positiveOffset
+		row.PositiveBucketCounts = dp.Positive().BucketCounts().AsRaw()
+		negativeOffset := dp.Negative().Offset()
+		row.NegativeOffset = &negativeOffset
+		row.NegativeBucketCounts = dp.Negative().BucketCounts().AsRaw()
+		emit(row, dp.Attributes())
+	}
+}
+
+func summaryDataPointsToParquet(base parquetMetricRow, dps pmetric.SummaryDataPointSlice, emit func(parquetMetricRow, pcommon.Map)) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		row := base
+		row.Attributes = attributesToMap(dp.Attributes())
+		row.StartTimeUnixNano = int64(dp.StartTimestamp())
+		row.TimeUnixNano = int64(dp.Timestamp())
+		row.Flags = uint32(dp.Flags())
+		count := dp.Count()
+		row.Count = &count
+		sum := dp.Sum()
+		row.Sum = &sum
+		quantiles := dp.QuantileValues()
+		row.QuantileValues = make([]parquetQuantile, 0, quantiles.Len())
+		for j := 0; j < quantiles.Len(); j++ {
+			q := quantiles.At(j)
+			row.QuantileValues = append(row.QuantileValues, parquetQuantile{Quantile: q.Quantile(), Value: q.Value()})
+		}
+		emit(row, dp.Attributes())
+	}
+}
@@ -0,0 +1,262 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/compress"
+)
+
+const (
+	signalTraces  = "traces"
+	signalMetrics = "metrics"
+	signalLogs    = "logs"
+
+	megabyte = 1024 * 1024
+
+	// defaultMaxMegabytes is the size lumberjack rotates at when
+	// max_megabytes is not set.
+	defaultMaxMegabytes = 100
+
+	// backupTimeFormat matches the layout used by lumberjack for rotated files.
+	backupTimeFormat = "2006-01-02T15-04-05.000"
+)
+
+var parquetCodecs = map[string]compress.Codec{
+	"":              &parquet.Snappy,
+	compressionZSTD: &parquet.Zstd,
+}
+
+// parquetWriter writes the rows of one signal into a Parquet file. Rows are
+// buffered into row groups that are flushed every flushInterval; the file
+// footer is only written when the file is rolled or the writer is shut down,
+// so a file is only readable once it has been closed.
+type parquetWriter struct {
+	path     string
+	rotation *Rotation
+	maxBytes int64
+	codec    compress.Codec
+	builder  *parquetRowBuilder
+	now      func() time.Time
+
+	mutex  sync.Mutex
+	file   *os.File
+	writer *parquet.Writer
+	size   *countingWriter
+
+	flushInterval time.Duration
+	flushTicker   *time.Ticker
+	stopTicker    chan struct{}
+}
+
+type countingWriter struct {
+	file    *os.File
+	written int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.file.Write(p)
+	c.written += int64(n)
+	return n, err
+}
+
+func newParquetWriter(path string, rotation *Rotation, compression string, flushInterval time.Duration, builder *parquetRowBuilder) *parquetWriter {
+	var maxBytes int64
+	if rotation != nil {
+		maxMegabytes := rotation.MaxMegabytes
+		if maxMegabytes <= 0 {
+			maxMegabytes = defaultMaxMegabytes
+		}
+		maxBytes = int64(maxMegabytes) * megabyte
+	}
+	return &parquetWriter{
+		path:          path,
+		rotation:      rotation,
+		maxBytes:      maxBytes,
+		codec:         parquetCodecs[compression],
+		builder:       builder,
+		now:           time.Now,
+		flushInterval: flushInterval,
+	}
+}
+
+// signalPath inserts the signal name before the extension of path, so that
+// "data.parquet" becomes "data.logs.parquet".
+func signalPath(path string, signal string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + signal + ext
+}
+
+func (w *parquetWriter) write(rows []any) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.writer == nil {
+		if err := w.open(); err != nil {
+			return err
+		}
+	}
+	for _, row := range rows {
+		if err := w.writer.Write(row); err != nil {
+			return err
+		}
+	}
+	if w.flushInterval <= 0 {
+		if err := w.writer.Flush(); err != nil {
+			return err
+		}
+	}
+	return w.rollIfNeeded()
+}
+
+// open creates the output file. An existing non-empty file is moved aside as
+// a backup first, since a Parquet file cannot be appended to.
+func (w *parquetWriter) open() error {
+	if fi, err := os.Stat(w.path); err == nil && fi.Size() > 0 {
+		if err = os.Rename(w.path, w.backupName()); err != nil {
+			return err
+		}
+	}
+	f, err := os.OpenFile(w.path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	w.file = f
+	w.size = &countingWriter{file: f}
+	// The write buffer is disabled so that flushed row groups reach the file
+	// and are counted towards max_megabytes.
+	w.writer = parquet.NewWriter(w.size, parquet.SchemaOf(w.builder.model()), parquet.Compression(w.codec), parquet.WriteBufferSize(0))
+	return w.removeOldBackups()
+}
+
+// close writes the Parquet footer and closes the underlying file.
+func (w *parquetWriter) close() error {
+	if w.writer == nil {
+		return nil
+	}
+	err := w.writer.Close()
+	err = errors.Join(err, w.file.Close())
+	w.writer = nil
+	w.file = nil
+	w.size = nil
+	return err
+}
+
+func (w *parquetWriter) rollIfNeeded() error {
+	if w.maxBytes <= 0 || w.size.written < w.maxBytes {
+		return nil
+	}
+	if err := w.writer.Flush(); err != nil {
+		return err
+	}
+	// Writing the footer and renaming is deferred to the next write: the
+	// next call to open moves the closed file aside.
+	return w.close()
+}
+
+func (w *parquetWriter) backupName() string {
+	t := w.now()
+	if w.rotation == nil || !w.rotation.LocalTime {
+		t = t.UTC()
+	}
+	ext := filepath.Ext(w.path)
+	return fmt.Sprintf("%s-%s%s", strings.TrimSuffix(w.path, ext), t.Format(backupTimeFormat), ext)
+}
+
+// removeOldBackups enforces max_backups and max_days on the rolled files.
+func (w *parquetWriter) removeOldBackups() error {
+	if w.rotation == nil || (w.rotation.MaxBackups <= 0 && w.rotation.MaxDays <= 0) {
+		return nil
+	}
+	ext := filepath.Ext(w.path)
+	prefix := strings.TrimSuffix(filepath.Base(w.path), ext) + "-"
+	entries, err := os.ReadDir(filepath.Dir(w.path))
+	if err != nil {
+		return err
+	}
+
+	type backup struct {
+		name string
+		t    time.Time
+	}
+	var backups []backup
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		ts := strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext)
+		t, parseErr := time.Parse(backupTimeFormat, ts)
+		if parseErr != nil {
+			continue
+		}
+		backups = append(backups, backup{name: name, t: t})
+	}
+	// newest first
+	sort.Slice(backups, func(i, j int) bool { return backups[i].t.After(backups[j].t) })
+
+	var errs error
+	cutoff := w.now().Add(-time.Duration(w.rotation.MaxDays) * 24 * time.Hour)
+	for i, b := range backups {
+		expired := w.rotation.MaxDays > 0 && b.t.Before(cutoff)
+		tooMany := w.rotation.MaxBackups > 0 && i >= w.rotation.MaxBackups
+		if expired || tooMany {
+			errs = errors.Join(errs, os.Remove(filepath.Join(filepath.Dir(w.path), b.name)))
+		}
+	}
+	return errs
+}
+
+func (w *parquetWriter) flush() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.writer == nil {
+		return nil
+	}
+	return w.writer.Flush()
+}
+
+// start starts the row group flusher if a flush interval is set.
+func (w *parquetWriter) start() {
+	if w.flushInterval <= 0 {
+		return
+	}
+	w.stopTicker = make(chan struct{})
+	w.flushTicker = time.NewTicker(w.flushInterval)
+	go func() {
+		for {
+			select {
+			case <-w.flushTicker.C:
+				_ = w.flush()
+			case <-w.stopTicker:
+				w.flushTicker.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// shutdown stops the flusher and finalizes the current file.
+func (w *parquetWriter) shutdown() error {
+	if w.stopTicker != nil {
+		close(w.stopTicker)
+		w.stopTicker = nil
+	}
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.close()
+}
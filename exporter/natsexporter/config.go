@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/natsexporter"
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.uber.org/multierr"
+)
+
+// Config defines configuration for the NATS exporter.
+type Config struct {
+	exporterhelper.TimeoutSettings `mapstructure:",squash"`
+	exporterhelper.QueueSettings   `mapstructure:"sending_queue"`
+	configretry.BackOffConfig      `mapstructure:"retry_on_failure"`
+
+	// Endpoint is the URL of the NATS server (default nats://localhost:4222).
+	Endpoint       string                     `mapstructure:"endpoint"`
+	Authentication Authentication             `mapstructure:"auth"`
+	TLS            configtls.TLSClientSetting `mapstructure:"tls,omitempty"`
+
+	// Subject is a template of the subject the telemetry of a resource is published
+	// to (default otlp.logs, otlp.metrics or otlp.traces).
+	Subject string `mapstructure:"subject"`
+	// Encoding of the payloads, otlp_proto or otlp_json (default otlp_proto).
+	Encoding string `mapstructure:"encoding"`
+}
+
+// Authentication defines the credentials sent to the NATS server.
+type Authentication struct {
+	Username string              `mapstructure:"username"`
+	Password configopaque.String `mapstructure:"password"`
+	Token    configopaque.String `mapstructure:"token"`
+	// CredentialsFile is a file holding a user JWT and NKey seed.
+	CredentialsFile string `mapstructure:"credentials_file"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	var errs error
+	if cfg.Endpoint == "" {
+		errs = multierr.Append(errs, errors.New("endpoint must be specified"))
+	}
+	if cfg.Subject != "" {
+		if _, err := newSubjectRenderer(cfg.Subject); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("invalid subject: %w", err))
+		}
+	}
+	if _, ok := marshalers[cfg.Encoding]; !ok {
+		errs = multierr.Append(errs, fmt.Errorf("unsupported encoding %q", cfg.Encoding))
+	}
+	return errs
+}
+
+func (cfg *Config) connectOptions() ([]nats.Option, error) {
+	options := []nats.Option{
+		nats.Name("otelcol"),
+		// The client reconnects in the background until the exporter is shut down
+		nats.MaxReconnects(-1),
+		nats.RetryOnFailedConnect(true),
+	}
+	tlsConfig, err := cfg.TLS.LoadTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		options = append(options, nats.Secure(tlsConfig))
+	}
+	auth := cfg.Authentication
+	if auth.Username != "" {
+		options = append(options, nats.UserInfo(auth.Username, string(auth.Password)))
+	}
+	if auth.Token != "" {
+		options = append(options, nats.Token(string(auth.Token)))
+	}
+	if auth.CredentialsFile != "" {
+		options = append(options, nats.UserCredentials(auth.CredentialsFile))
+	}
+	return options, nil
+}
@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/natsexporter/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id           component.ID
+		expected     component.Config
+		errorMessage string
+	}{
+		{
+			id: component.NewID(metadata.Type),
+			expected: &Config{
+				TimeoutSettings: exporterhelper.TimeoutSettings{
+					Timeout: 10 * time.Second,
+				},
+				QueueSettings: exporterhelper.QueueSettings{
+					Enabled:      true,
+					NumConsumers: 2,
+					QueueSize:    10,
+				},
+				BackOffConfig: configretry.BackOffConfig{
+					Enabled:             true,
+					InitialInterval:     10 * time.Second,
+					MaxInterval:         1 * time.Minute,
+					MaxElapsedTime:      10 * time.Minute,
+					RandomizationFactor: 0.5,
+					Multiplier:          1.5,
+				},
+				Endpoint: "nats://nats:4222",
+				Authentication: Authentication{
+					Token: "secret",
+				},
+				TLS: configtls.TLSClientSetting{
+					TLSSetting: configtls.TLSSetting{
+						CAFile: "ca.pem",
+					},
+				},
+				Subject:  `telemetry.{{ .Resource "service.name" | default "unknown" }}.{{ .Signal }}`,
+				Encoding: "otlp_json",
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "bad_template"),
+			errorMessage: "invalid subject: template: subject:1: unclosed action",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "bad_subject"),
+			errorMessage: "invalid subject: subject must not contain wildcards",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "bad_encoding"),
+			errorMessage: `unsupported encoding "jaeger_proto"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			if tt.errorMessage != "" {
+				assert.ErrorContains(t, component.ValidateConfig(cfg), tt.errorMessage)
+				return
+			}
+
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
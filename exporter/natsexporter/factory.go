@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/natsexporter"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/natsexporter/internal/metadata"
+)
+
+const (
+	defaultEndpoint       = "nats://localhost:4222"
+	defaultLogsSubject    = "otlp.logs"
+	defaultMetricsSubject = "otlp.metrics"
+	defaultTracesSubject  = "otlp.traces"
+)
+
+// NewFactory creates a factory for the NATS exporter.
+func NewFactory() exporter.Factory {
+	return exporter.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		exporter.WithTraces(createTracesExporter, metadata.TracesStability),
+		exporter.WithMetrics(createMetricsExporter, metadata.MetricsStability),
+		exporter.WithLogs(createLogsExporter, metadata.LogsStability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		TimeoutSettings: exporterhelper.NewDefaultTimeoutSettings(),
+		QueueSettings:   exporterhelper.NewDefaultQueueSettings(),
+		BackOffConfig:   configretry.NewDefaultBackOffConfig(),
+		Endpoint:        defaultEndpoint,
+		TLS: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+		// using an empty subject to track when it has not been set by user, default is based on the signal.
+		Subject:  "",
+		Encoding: encodingOTLPProto,
+	}
+}
+
+func createTracesExporter(ctx context.Context, set exporter.CreateSettings, cfg component.Config) (exporter.Traces, error) {
+	oCfg := *(cfg.(*Config))
+	if oCfg.Subject == "" {
+		oCfg.Subject = defaultTracesSubject
+	}
+	exp, err := newNATSExporter(&oCfg, set)
+	if err != nil {
+		return nil, err
+	}
+	return exporterhelper.NewTracesExporter(
+		ctx,
+		set,
+		cfg,
+		exp.pushTraces,
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+		exporterhelper.WithTimeout(oCfg.TimeoutSettings),
+		exporterhelper.WithRetry(oCfg.BackOffConfig),
+		exporterhelper.WithQueue(oCfg.QueueSettings),
+		exporterhelper.WithStart(exp.start),
+		exporterhelper.WithShutdown(exp.shutdown))
+}
+
+func createMetricsExporter(ctx context.Context, set exporter.CreateSettings, cfg component.Config) (exporter.Metrics, error) {
+	oCfg := *(cfg.(*Config))
+	if oCfg.Subject == "" {
+		oCfg.Subject = defaultMetricsSubject
+	}
+	exp, err := newNATSExporter(&oCfg, set)
+	if err != nil {
+		return nil, err
+	}
+	return exporterhelper.NewMetricsExporter(
+		ctx,
+		set,
+		cfg,
+		exp.pushMetrics,
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+		exporterhelper.WithTimeout(oCfg.TimeoutSettings),
+		exporterhelper.WithRetry(oCfg.BackOffConfig),
+		exporterhelper.WithQueue(oCfg.QueueSettings),
+		exporterhelper.WithStart(exp.start),
+		exporterhelper.WithShutdown(exp.shutdown))
+}
+
+func createLogsExporter(ctx context.Context, set exporter.CreateSettings, cfg component.Config) (exporter.Logs, error) {
+	oCfg := *(cfg.(*Config))
+	if oCfg.Subject == "" {
+		oCfg.Subject = defaultLogsSubject
+	}
+	exp, err := newNATSExporter(&oCfg, set)
+	if err != nil {
+		return nil, err
+	}
+	return exporterhelper.NewLogsExporter(
+		ctx,
+		set,
+		cfg,
+		exp.pushLogs,
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+		exporterhelper.WithTimeout(oCfg.TimeoutSettings),
+		exporterhelper.WithRetry(oCfg.BackOffConfig),
+		exporterhelper.WithQueue(oCfg.QueueSettings),
+		exporterhelper.WithStart(exp.start),
+		exporterhelper.WithShutdown(exp.shutdown))
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig()
+	assert.Equal(t, &Config{
+		TimeoutSettings: exporterhelper.NewDefaultTimeoutSettings(),
+		QueueSettings:   exporterhelper.NewDefaultQueueSettings(),
+		BackOffConfig:   configretry.NewDefaultBackOffConfig(),
+		Endpoint:        "nats://localhost:4222",
+		TLS: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+		Encoding: "otlp_proto",
+	}, cfg)
+	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
+}
+
+func TestCreateLogsExporter(t *testing.T) {
+	s := startServer(t)
+	stream := createStream(t, s)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = s.ClientURL()
+	cfg.Subject = "telemetry.logs"
+	cfg.QueueSettings.Enabled = false
+	exp, err := NewFactory().CreateLogsExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, exp.Shutdown(context.Background())) }()
+
+	require.NoError(t, exp.ConsumeLogs(context.Background(), testLogs("checkout")))
+	ld, err := (&plog.ProtoUnmarshaler{}).UnmarshalLogs(lastMessage(t, stream, "telemetry.logs"))
+	require.NoError(t, err)
+	assert.Equal(t, testLogs("checkout"), ld)
+}
+
+func TestCreateExportersWithDefaultSubjects(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	set := exportertest.NewNopCreateSettings()
+
+	logs, err := factory.CreateLogsExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+	assert.NotNil(t, logs)
+	metrics, err := factory.CreateMetricsExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+	assert.NotNil(t, metrics)
+	traces, err := factory.CreateTracesExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+	assert.NotNil(t, traces)
+	// the default subject depends on the signal
+	assert.Equal(t, "", cfg.(*Config).Subject)
+}
@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/natsexporter"
+
+import (
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	encodingOTLPProto = "otlp_proto"
+	encodingOTLPJSON  = "otlp_json"
+)
+
+// payloadMarshaler encodes the payloads of the messages.
+type payloadMarshaler struct {
+	logsMarshaler    plog.Marshaler
+	metricsMarshaler pmetric.Marshaler
+	tracesMarshaler  ptrace.Marshaler
+}
+
+// marshalers are the supported encodings.
+var marshalers = map[string]*payloadMarshaler{
+	encodingOTLPProto: {
+		logsMarshaler:    &plog.ProtoMarshaler{},
+		metricsMarshaler: &pmetric.ProtoMarshaler{},
+		tracesMarshaler:  &ptrace.ProtoMarshaler{},
+	},
+	encodingOTLPJSON: {
+		logsMarshaler:    &plog.JSONMarshaler{},
+		metricsMarshaler: &pmetric.JSONMarshaler{},
+		tracesMarshaler:  &ptrace.JSONMarshaler{},
+	},
+}
@@ -0,0 +1,202 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/natsexporter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// natsExporter publishes telemetry to the subjects of JetStream streams.
+type natsExporter struct {
+	config    *Config
+	logger    *zap.Logger
+	subjects  *subjectRenderer
+	marshaler *payloadMarshaler
+
+	conn *nats.Conn
+	js   jetstream.JetStream
+}
+
+func newNATSExporter(config *Config, set exporter.CreateSettings) (*natsExporter, error) {
+	subjects, err := newSubjectRenderer(config.Subject)
+	if err != nil {
+		return nil, err
+	}
+	return &natsExporter{
+		config:    config,
+		logger:    set.Logger,
+		subjects:  subjects,
+		marshaler: marshalers[config.Encoding],
+	}, nil
+}
+
+func (e *natsExporter) start(_ context.Context, _ component.Host) error {
+	options, err := e.config.connectOptions()
+	if err != nil {
+		return err
+	}
+	if e.conn, err = nats.Connect(e.config.Endpoint, options...); err != nil {
+		return err
+	}
+	e.js, err = jetstream.New(e.conn)
+	return err
+}
+
+func (e *natsExporter) shutdown(_ context.Context) error {
+	if e.conn != nil {
+		e.conn.Close()
+	}
+	return nil
+}
+
+// checkDropped logs the resources left out because of an invalid subject, and returns a
+// permanent error when there is nothing left to publish.
+func (e *natsExporter) checkDropped(dropped error, groups int) error {
+	switch {
+	case dropped == nil:
+		return nil
+	case groups == 0:
+		return consumererror.NewPermanent(dropped)
+	}
+	e.logger.Error("Dropping telemetry with an invalid subject", zap.Error(dropped))
+	return nil
+}
+
+// publishAll publishes the payload of every subject and returns the subjects which
+// failed with a retryable error. Permanent errors are only returned when no subject
+// can be retried.
+func (e *natsExporter) publishAll(ctx context.Context, payloads map[string][]byte) ([]string, error) {
+	var failed []string
+	var errs, permanentErrs error
+	for subject, data := range payloads {
+		err := e.publish(ctx, subject, data)
+		switch {
+		case err == nil:
+		case consumererror.IsPermanent(err):
+			permanentErrs = multierr.Append(permanentErrs, err)
+		default:
+			failed = append(failed, subject)
+			errs = multierr.Append(errs, err)
+		}
+	}
+	if len(failed) == 0 {
+		return nil, permanentErrs
+	}
+	if permanentErrs != nil {
+		e.logger.Error("Dropping telemetry rejected by the NATS server", zap.Error(permanentErrs))
+	}
+	return failed, errs
+}
+
+// publish publishes a message and waits for the stream to acknowledge it.
+func (e *natsExporter) publish(ctx context.Context, subject string, data []byte) error {
+	_, err := e.js.Publish(ctx, subject, data)
+	switch {
+	case errors.Is(err, nats.ErrMaxPayload):
+		return consumererror.NewPermanent(fmt.Errorf("failed to publish to subject %q: %w", subject, err))
+	case err != nil:
+		return fmt.Errorf("failed to publish to subject %q: %w", subject, err)
+	}
+	return nil
+}
+
+func (e *natsExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
+	groups, dropped := e.subjects.groupLogs(ld)
+	if err := e.checkDropped(dropped, len(groups)); err != nil {
+		return err
+	}
+	payloads := make(map[string][]byte, len(groups))
+	for subject, group := range groups {
+		data, err := e.marshaler.logsMarshaler.MarshalLogs(group)
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		payloads[subject] = data
+	}
+
+	failed, err := e.publishAll(ctx, payloads)
+	switch {
+	case len(failed) == 0:
+		return err
+	case len(failed) == len(groups) && dropped == nil:
+		return consumererror.NewLogs(err, ld)
+	}
+	// Only the telemetry of the subjects which failed is retried
+	retry := plog.NewLogs()
+	for _, subject := range failed {
+		groups[subject].ResourceLogs().MoveAndAppendTo(retry.ResourceLogs())
+	}
+	return consumererror.NewLogs(err, retry)
+}
+
+func (e *natsExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) error {
+	groups, dropped := e.subjects.groupMetrics(md)
+	if err := e.checkDropped(dropped, len(groups)); err != nil {
+		return err
+	}
+	payloads := make(map[string][]byte, len(groups))
+	for subject, group := range groups {
+		data, err := e.marshaler.metricsMarshaler.MarshalMetrics(group)
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		payloads[subject] = data
+	}
+
+	failed, err := e.publishAll(ctx, payloads)
+	switch {
+	case len(failed) == 0:
+		return err
+	case len(failed) == len(groups) && dropped == nil:
+		return consumererror.NewMetrics(err, md)
+	}
+	// Only the telemetry of the subjects which failed is retried
+	retry := pmetric.NewMetrics()
+	for _, subject := range failed {
+		groups[subject].ResourceMetrics().MoveAndAppendTo(retry.ResourceMetrics())
+	}
+	return consumererror.NewMetrics(err, retry)
+}
+
+func (e *natsExporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
+	groups, dropped := e.subjects.groupTraces(td)
+	if err := e.checkDropped(dropped, len(groups)); err != nil {
+		return err
+	}
+	payloads := make(map[string][]byte, len(groups))
+	for subject, group := range groups {
+		data, err := e.marshaler.tracesMarshaler.MarshalTraces(group)
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		payloads[subject] = data
+	}
+
+	failed, err := e.publishAll(ctx, payloads)
+	switch {
+	case len(failed) == 0:
+		return err
+	case len(failed) == len(groups) && dropped == nil:
+		return consumererror.NewTraces(err, td)
+	}
+	// Only the telemetry of the subjects which failed is retried
+	retry := ptrace.NewTraces()
+	for _, subject := range failed {
+		groups[subject].ResourceSpans().MoveAndAppendTo(retry.ResourceSpans())
+	}
+	return consumererror.NewTraces(err, retry)
+}
@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsexporter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const testStream = "telemetry"
+
+// startServer starts an in-process NATS server with JetStream enabled.
+func startServer(t *testing.T) *server.Server {
+	s, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      server.RANDOM_PORT,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+		NoLog:     true,
+		NoSigs:    true,
+	})
+	require.NoError(t, err)
+	s.Start()
+	require.True(t, s.ReadyForConnections(5*time.Second))
+	t.Cleanup(func() {
+		s.Shutdown()
+		s.WaitForShutdown()
+	})
+	return s
+}
+
+// createStream creates a stream storing the subjects starting with telemetry.
+func createStream(t *testing.T, s *server.Server) jetstream.Stream {
+	conn, err := nats.Connect(s.ClientURL())
+	require.NoError(t, err)
+	t.Cleanup(conn.Close)
+	js, err := jetstream.New(conn)
+	require.NoError(t, err)
+	stream, err := js.CreateStream(context.Background(), jetstream.StreamConfig{
+		Name:     testStream,
+		Subjects: []string{"telemetry.>"},
+	})
+	require.NoError(t, err)
+	return stream
+}
+
+// lastMessage returns the payload of the last message published to a subject.
+func lastMessage(t *testing.T, stream jetstream.Stream, subject string) []byte {
+	msg, err := stream.GetLastMsgForSubject(context.Background(), subject)
+	require.NoError(t, err)
+	return msg.Data
+}
+
+func startExporter(t *testing.T, s *server.Server, cfg *Config) *natsExporter {
+	cfg.Endpoint = s.ClientURL()
+	exp, err := newNATSExporter(cfg, exportertest.NewNopCreateSettings())
+	require.NoError(t, err)
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, exp.shutdown(context.Background())) })
+	return exp
+}
+
+func testConfig(subject string) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Subject = subject
+	return cfg
+}
+
+func testLogs(services ...string) plog.Logs {
+	ld := plog.NewLogs()
+	for _, service := range services {
+		rl := ld.ResourceLogs().AppendEmpty()
+		if service != "" {
+			rl.Resource().Attributes().PutStr("service.name", service)
+		}
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr(service)
+	}
+	return ld
+}
+
+func TestPublishLogs(t *testing.T) {
+	s := startServer(t)
+	stream := createStream(t, s)
+	exp := startExporter(t, s, testConfig(`telemetry.{{ .Resource "service.name" | default "unknown" }}.{{ .Signal }}`))
+
+	require.NoError(t, exp.pushLogs(context.Background(), testLogs("checkout", "", "shop.cart", "checkout")))
+
+	info, err := stream.Info(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), info.State.Msgs)
+
+	ld, err := (&plog.ProtoUnmarshaler{}).UnmarshalLogs(lastMessage(t, stream, "telemetry.checkout.logs"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, ld.ResourceLogs().Len())
+
+	ld, err = (&plog.ProtoUnmarshaler{}).UnmarshalLogs(lastMessage(t, stream, "telemetry.unknown.logs"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, ld.LogRecordCount())
+
+	ld, err = (&plog.ProtoUnmarshaler{}).UnmarshalLogs(lastMessage(t, stream, "telemetry.shop_cart.logs"))
+	require.NoError(t, err)
+	assert.Equal(t, "shop.cart", ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+}
+
+func TestPublishMetricsAndTraces(t *testing.T) {
+	s := startServer(t)
+	stream := createStream(t, s)
+
+	cfg := testConfig("telemetry.{{ .Signal }}")
+	cfg.Encoding = encodingOTLPJSON
+	exp := startExporter(t, s, cfg)
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+	require.NoError(t, exp.pushMetrics(context.Background(), md))
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	require.NoError(t, exp.pushTraces(context.Background(), td))
+
+	md, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(lastMessage(t, stream, "telemetry.metrics"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, md.DataPointCount())
+
+	td, err = (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(lastMessage(t, stream, "telemetry.traces"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, td.SpanCount())
+}
+
+func TestPublishInvalidSubject(t *testing.T) {
+	s := startServer(t)
+	stream := createStream(t, s)
+	exp := startExporter(t, s, testConfig(`telemetry.{{ .Resource "service.name" }}.logs`))
+
+	// Only the resource rendering to an invalid subject is dropped
+	require.NoError(t, exp.pushLogs(context.Background(), testLogs("checkout", "")))
+	info, err := stream.Info(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), info.State.Msgs)
+	ld, err := (&plog.ProtoUnmarshaler{}).UnmarshalLogs(lastMessage(t, stream, "telemetry.checkout.logs"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, ld.ResourceLogs().Len())
+
+	err = exp.pushLogs(context.Background(), testLogs(""))
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.ErrorContains(t, err, `invalid subject "telemetry..logs"`)
+}
+
+func TestPublishRetriesFailedSubjects(t *testing.T) {
+	s := startServer(t)
+	stream := createStream(t, s)
+	// subjects outside of the stream are not acknowledged
+	exp := startExporter(t, s, testConfig(`{{ .Resource "service.name" }}.logs`))
+
+	ld := testLogs("telemetry", "orders", "telemetry")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := exp.pushLogs(ctx, ld)
+	require.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+
+	var logsErr consumererror.Logs
+	require.True(t, errors.As(err, &logsErr))
+	assert.Equal(t, testLogs("orders"), logsErr.Data())
+	// the telemetry being exported is not modified
+	assert.Equal(t, 3, ld.ResourceLogs().Len())
+
+	info, err := stream.Info(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), info.State.Msgs)
+}
+
+func TestPublishRetriesAllTelemetry(t *testing.T) {
+	s := startServer(t)
+	createStream(t, s)
+	exp := startExporter(t, s, testConfig("orders.logs"))
+
+	ld := testLogs("orders")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := exp.pushLogs(ctx, ld)
+
+	var logsErr consumererror.Logs
+	require.True(t, errors.As(err, &logsErr))
+	assert.Equal(t, ld, logsErr.Data())
+}
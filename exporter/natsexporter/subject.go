@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/natsexporter"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
+)
+
+// subjectData is the data available to the subject template.
+type subjectData struct {
+	// Signal is one of "logs", "metrics" or "traces".
+	Signal string
+
+	resource pcommon.Resource
+}
+
+// Resource returns the string value of the resource attribute key, usable as a
+// single token of a subject, or an empty string when the resource does not have it.
+func (d subjectData) Resource(key string) string {
+	if v, ok := d.resource.Attributes().Get(key); ok {
+		return sanitizeToken(v.AsString())
+	}
+	return ""
+}
+
+// sanitizeToken replaces the token separators, wildcards and whitespaces of value.
+func sanitizeToken(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '.' || r == '*' || r == '>' || unicode.IsSpace(r) {
+			return '_'
+		}
+		return r
+	}, value)
+}
+
+var subjectFuncs = template.FuncMap{
+	// default returns value, or def when value is empty:
+	// {{ .Resource "service.name" | default "unknown" }}
+	"default": func(def string, value string) string {
+		if value == "" {
+			return def
+		}
+		return value
+	},
+}
+
+func parseSubjectTemplate(text string) (*template.Template, error) {
+	return template.New("subject").Option("missingkey=error").Funcs(subjectFuncs).Parse(text)
+}
+
+// validateSubject checks a subject is valid for publishing.
+func validateSubject(subject string) error {
+	if subject == "" {
+		return errors.New("subject must not be empty")
+	}
+	if strings.IndexFunc(subject, unicode.IsSpace) >= 0 {
+		return errors.New("subject must not contain whitespaces")
+	}
+	for _, token := range strings.Split(subject, ".") {
+		switch token {
+		case "":
+			return errors.New("subject must not contain empty tokens")
+		case "*", ">":
+			return errors.New("subject must not contain wildcards")
+		}
+	}
+	return nil
+}
+
+// subjectRenderer renders the subject of the telemetry of a resource. Without
+// template actions all the telemetry is published to the same subject.
+type subjectRenderer struct {
+	tmpl   *template.Template
+	static string
+}
+
+func newSubjectRenderer(text string) (*subjectRenderer, error) {
+	if !strings.Contains(text, "{{") {
+		if err := validateSubject(text); err != nil {
+			return nil, err
+		}
+		return &subjectRenderer{static: text}, nil
+	}
+	tmpl, err := parseSubjectTemplate(text)
+	if err != nil {
+		return nil, err
+	}
+	return &subjectRenderer{tmpl: tmpl}, nil
+}
+
+func (r *subjectRenderer) subject(signal string, resource pcommon.Resource) (string, error) {
+	var sb strings.Builder
+	if err := r.tmpl.Execute(&sb, subjectData{Signal: signal, resource: resource}); err != nil {
+		return "", fmt.Errorf("failed to render subject: %w", err)
+	}
+	subject := sb.String()
+	if err := validateSubject(subject); err != nil {
+		return "", fmt.Errorf("invalid subject %q: %w", subject, err)
+	}
+	return subject, nil
+}
+
+// groupLogs groups the resources by subject. The resources rendering to an invalid
+// subject are left out, and their errors returned along with the other groups.
+func (r *subjectRenderer) groupLogs(ld plog.Logs) (map[string]plog.Logs, error) {
+	if r.tmpl == nil {
+		return map[string]plog.Logs{r.static: ld}, nil
+	}
+
+	groups := make(map[string]plog.Logs)
+	var errs error
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+		subject, err := r.subject("logs", rl.Resource())
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+		group, ok := groups[subject]
+		if !ok {
+			group = plog.NewLogs()
+			groups[subject] = group
+		}
+		rl.CopyTo(group.ResourceLogs().AppendEmpty())
+	}
+	return groups, errs
+}
+
+func (r *subjectRenderer) groupMetrics(md pmetric.Metrics) (map[string]pmetric.Metrics, error) {
+	if r.tmpl == nil {
+		return map[string]pmetric.Metrics{r.static: md}, nil
+	}
+
+	groups := make(map[string]pmetric.Metrics)
+	var errs error
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		subject, err := r.subject("metrics", rm.Resource())
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+		group, ok := groups[subject]
+		if !ok {
+			group = pmetric.NewMetrics()
+			groups[subject] = group
+		}
+		rm.CopyTo(group.ResourceMetrics().AppendEmpty())
+	}
+	return groups, errs
+}
+
+func (r *subjectRenderer) groupTraces(td ptrace.Traces) (map[string]ptrace.Traces, error) {
+	if r.tmpl == nil {
+		return map[string]ptrace.Traces{r.static: td}, nil
+	}
+
+	groups := make(map[string]ptrace.Traces)
+	var errs error
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		subject, err := r.subject("traces", rs.Resource())
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+		group, ok := groups[subject]
+		if !ok {
+			group = ptrace.NewTraces()
+			groups[subject] = group
+		}
+		rs.CopyTo(group.ResourceSpans().AppendEmpty())
+	}
+	return groups, errs
+}
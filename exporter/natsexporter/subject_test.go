@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestValidateSubject(t *testing.T) {
+	testCases := []struct {
+		subject  string
+		expected string
+	}{
+		{subject: "telemetry.logs"},
+		{subject: "", expected: "subject must not be empty"},
+		{subject: "telemetry logs", expected: "subject must not contain whitespaces"},
+		{subject: "telemetry..logs", expected: "subject must not contain empty tokens"},
+		{subject: "telemetry.*", expected: "subject must not contain wildcards"},
+		{subject: "telemetry.>", expected: "subject must not contain wildcards"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.subject, func(t *testing.T) {
+			err := validateSubject(tc.subject)
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expected)
+			}
+		})
+	}
+}
+
+func TestRenderSubject(t *testing.T) {
+	testCases := []struct {
+		name       string
+		template   string
+		attributes map[string]any
+		expected   string
+		err        string
+	}{
+		{
+			name:     "signal",
+			template: "otlp.{{ .Signal }}",
+			expected: "otlp.logs",
+		},
+		{
+			name:       "resource",
+			template:   `telemetry.{{ .Resource "service.name" }}`,
+			attributes: map[string]any{"service.name": "checkout"},
+			expected:   "telemetry.checkout",
+		},
+		{
+			name:       "sanitized",
+			template:   `telemetry.{{ .Resource "service.name" }}`,
+			attributes: map[string]any{"service.name": "shop.checkout *>v2"},
+			expected:   "telemetry.shop_checkout___v2",
+		},
+		{
+			name:       "non string",
+			template:   `telemetry.{{ .Resource "shard" }}`,
+			attributes: map[string]any{"shard": 3},
+			expected:   "telemetry.3",
+		},
+		{
+			name:     "default",
+			template: `telemetry.{{ .Resource "service.name" | default "unknown" }}`,
+			expected: "telemetry.unknown",
+		},
+		{
+			name:     "missing",
+			template: `telemetry.{{ .Resource "service.name" }}`,
+			err:      `invalid subject "telemetry.": subject must not contain empty tokens`,
+		},
+		{
+			name:     "unknown field",
+			template: "telemetry.{{ .Tenant }}",
+			err:      "failed to render subject",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			renderer, err := newSubjectRenderer(tc.template)
+			require.NoError(t, err)
+			resource := pcommon.NewResource()
+			require.NoError(t, resource.Attributes().FromRaw(tc.attributes))
+
+			subject, err := renderer.subject("logs", resource)
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, subject)
+		})
+	}
+}
+
+func TestGroupLogs(t *testing.T) {
+	ld := plog.NewLogs()
+	for _, name := range []string{"checkout", "cart", "checkout"} {
+		ld.ResourceLogs().AppendEmpty().Resource().Attributes().PutStr("service.name", name)
+	}
+
+	renderer, err := newSubjectRenderer(`telemetry.{{ .Resource "service.name" }}`)
+	require.NoError(t, err)
+	groups, err := renderer.groupLogs(ld)
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+	assert.Equal(t, 2, groups["telemetry.checkout"].ResourceLogs().Len())
+	assert.Equal(t, 1, groups["telemetry.cart"].ResourceLogs().Len())
+	// the telemetry is copied
+	assert.Equal(t, 3, ld.ResourceLogs().Len())
+
+	// resources rendering to an invalid subject are left out
+	ld.ResourceLogs().AppendEmpty()
+	groups, err = renderer.groupLogs(ld)
+	assert.ErrorContains(t, err, `invalid subject "telemetry."`)
+	require.Len(t, groups, 2)
+	assert.Equal(t, 2, groups["telemetry.checkout"].ResourceLogs().Len())
+
+	renderer, err = newSubjectRenderer("telemetry.logs")
+	require.NoError(t, err)
+	groups, err = renderer.groupLogs(ld)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]plog.Logs{"telemetry.logs": ld}, groups)
+}
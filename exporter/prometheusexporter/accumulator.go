@@ -85,6 +85,8 @@ func (a *lastValueAccumulator) addMetric(metric pmetric.Metric, il pcommon.Instr
 		return a.accumulateSum(metric, il, resourceAttrs, now)
 	case pmetric.MetricTypeHistogram:
 		return a.accumulateHistogram(metric, il, resourceAttrs, now)
+	case pmetric.MetricTypeExponentialHistogram:
+		return a.accumulateExponentialHistogram(metric, il, resourceAttrs, now)
 	case pmetric.MetricTypeSummary:
 		return a.accumulateSummary(metric, il, resourceAttrs, now)
 	default:
@@ -291,6 +293,72 @@ func (a *lastValueAccumulator) accumulateHistogram(metric pmetric.Metric, il pco
 	return
 }
 
+func (a *lastValueAccumulator) accumulateExponentialHistogram(metric pmetric.Metric, il pcommon.InstrumentationScope, resourceAttrs pcommon.Map, now time.Time) (n int) {
+	histogram := metric.ExponentialHistogram()
+	dps := histogram.DataPoints()
+
+	for i := 0; i < dps.Len(); i++ {
+		ip := dps.At(i)
+
+		signature := timeseriesSignature(il.Name(), metric, ip.Attributes(), resourceAttrs)
+		if ip.Flags().NoRecordedValue() {
+			a.registeredMetrics.Delete(signature)
+			return 0
+		}
+
+		v, ok := a.registeredMetrics.Load(signature)
+		if !ok {
+			// first data point
+			m := copyMetricMetadata(metric)
+			ip.CopyTo(m.SetEmptyExponentialHistogram().DataPoints().AppendEmpty())
+			m.ExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+			a.registeredMetrics.Store(signature, &accumulatedValue{value: m, resourceAttrs: resourceAttrs, scope: il, updated: now})
+			n++
+			continue
+		}
+		mv := v.(*accumulatedValue)
+
+		m := copyMetricMetadata(metric)
+		m.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+
+		switch histogram.AggregationTemporality() {
+		case pmetric.AggregationTemporalityDelta:
+			pp := mv.value.ExponentialHistogram().DataPoints().At(0) // previous aggregated value for time range
+			if ip.StartTimestamp().AsTime() != pp.Timestamp().AsTime() {
+				// treat misalignment as restart and reset, or violation of single-writer principle and drop
+				a.logger.With(
+					zap.String("ip_start_time", ip.StartTimestamp().String()),
+					zap.String("pp_start_time", pp.StartTimestamp().String()),
+					zap.String("pp_timestamp", pp.Timestamp().String()),
+					zap.String("ip_timestamp", ip.Timestamp().String()),
+				).Warn("Misaligned starting timestamps")
+				if !ip.StartTimestamp().AsTime().After(pp.Timestamp().AsTime()) {
+					a.logger.With(
+						zap.String("metric_name", metric.Name()),
+					).Warn("Dropped misaligned exponential histogram datapoint")
+					continue
+				}
+				ip.CopyTo(m.ExponentialHistogram().DataPoints().AppendEmpty())
+			} else {
+				accumulateExponentialHistogramValues(pp, ip, m.ExponentialHistogram().DataPoints().AppendEmpty())
+			}
+		case pmetric.AggregationTemporalityCumulative:
+			if ip.Timestamp().AsTime().Before(mv.value.ExponentialHistogram().DataPoints().At(0).Timestamp().AsTime()) {
+				// only keep datapoint with latest timestamp
+				continue
+			}
+
+			ip.CopyTo(m.ExponentialHistogram().DataPoints().AppendEmpty())
+		default:
+			// unsupported temporality
+			continue
+		}
+		a.registeredMetrics.Store(signature, &accumulatedValue{value: m, resourceAttrs: resourceAttrs, scope: il, updated: now})
+		n++
+	}
+	return
+}
+
 // Collect returns a slice with relevant aggregated metrics and their resource attributes.
 func (a *lastValueAccumulator) Collect() ([]pmetric.Metric, []pcommon.Map) {
 	a.logger.Debug("Accumulator collect called")
@@ -383,3 +451,70 @@ func accumulateHistogramValues(prev, current, dest pmetric.HistogramDataPoint) {
 
 	dest.ExplicitBounds().FromRaw(newer.ExplicitBounds().AsRaw())
 }
+
+func accumulateExponentialHistogramValues(prev, current, dest pmetric.ExponentialHistogramDataPoint) {
+	dest.SetStartTimestamp(prev.StartTimestamp())
+
+	older := prev
+	newer := current
+	if current.Timestamp().AsTime().Before(prev.Timestamp().AsTime()) {
+		older = current
+		newer = prev
+	}
+
+	newer.Attributes().CopyTo(dest.Attributes())
+	dest.SetTimestamp(newer.Timestamp())
+
+	// buckets are merged at the coarser of both scales.
+	scale := min(older.Scale(), newer.Scale())
+	dest.SetScale(scale)
+	dest.SetCount(newer.Count() + older.Count())
+	dest.SetSum(newer.Sum() + older.Sum())
+	dest.SetZeroCount(newer.ZeroCount() + older.ZeroCount())
+	dest.SetZeroThreshold(max(newer.ZeroThreshold(), older.ZeroThreshold()))
+	if newer.HasMin() && older.HasMin() {
+		dest.SetMin(min(newer.Min(), older.Min()))
+	}
+	if newer.HasMax() && older.HasMax() {
+		dest.SetMax(max(newer.Max(), older.Max()))
+	}
+
+	mergeExponentialBuckets(dest.Positive(), scale, older.Positive(), older.Scale(), newer.Positive(), newer.Scale())
+	mergeExponentialBuckets(dest.Negative(), scale, older.Negative(), older.Scale(), newer.Negative(), newer.Scale())
+}
+
+// mergeExponentialBuckets adds the bucket counts of a and b, downscaled to scale, into dest.
+func mergeExponentialBuckets(dest pmetric.ExponentialHistogramDataPointBuckets, scale int32,
+	a pmetric.ExponentialHistogramDataPointBuckets, aScale int32,
+	b pmetric.ExponentialHistogramDataPointBuckets, bScale int32) {
+	counts := make(map[int32]uint64)
+	var low, high int32
+	add := func(buckets pmetric.ExponentialHistogramDataPointBuckets, scaleDown int32) {
+		for i := 0; i < buckets.BucketCounts().Len(); i++ {
+			count := buckets.BucketCounts().At(i)
+			if count == 0 {
+				continue
+			}
+			index := (buckets.Offset() + int32(i)) >> scaleDown
+			if len(counts) == 0 || index < low {
+				low = index
+			}
+			if len(counts) == 0 || index > high {
+				high = index
+			}
+			counts[index] += count
+		}
+	}
+	add(a, aScale-scale)
+	add(b, bScale-scale)
+	if len(counts) == 0 {
+		return
+	}
+
+	raw := make([]uint64, high-low+1)
+	for index, count := range counts {
+		raw[index-low] = count
+	}
+	dest.SetOffset(low)
+	dest.BucketCounts().FromRaw(raw)
+}
@@ -568,6 +568,89 @@ func TestAccumulateDeltaToCumulativeHistogram(t *testing.T) {
 	})
 }
 
+func TestAccumulateDeltaToCumulativeExponentialHistogram(t *testing.T) {
+	appendDeltaExponentialHistogram := func(startTs time.Time, ts time.Time, scale int32, offset int32, counts []uint64, metrics pmetric.MetricSlice) {
+		metric := metrics.AppendEmpty()
+		metric.SetName("test_metric")
+		metric.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		dp := metric.ExponentialHistogram().DataPoints().AppendEmpty()
+		dp.SetScale(scale)
+		dp.Positive().SetOffset(offset)
+		dp.Positive().BucketCounts().FromRaw(counts)
+		var count uint64
+		for _, c := range counts {
+			count += c
+		}
+		dp.SetCount(count + 1)
+		dp.SetZeroCount(1)
+		dp.SetSum(float64(count))
+		dp.Attributes().PutStr("label_1", "1")
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+		dp.SetStartTimestamp(pcommon.NewTimestampFromTime(startTs))
+	}
+
+	t.Run("AccumulateSameScale", func(t *testing.T) {
+		startTs := time.Now().Add(-5 * time.Second)
+		ts1 := time.Now().Add(-4 * time.Second)
+		ts2 := time.Now().Add(-3 * time.Second)
+		resourceMetrics := pmetric.NewResourceMetrics()
+		ilm := resourceMetrics.ScopeMetrics().AppendEmpty()
+		appendDeltaExponentialHistogram(startTs, ts1, 2, 0, []uint64{1, 2}, ilm.Metrics())
+		appendDeltaExponentialHistogram(ts1, ts2, 2, 1, []uint64{3, 4}, ilm.Metrics())
+
+		a := newAccumulator(zap.NewNop(), 1*time.Hour).(*lastValueAccumulator)
+		require.Equal(t, 2, a.Accumulate(resourceMetrics))
+
+		signature := timeseriesSignature(ilm.Scope().Name(), ilm.Metrics().At(0), ilm.Metrics().At(0).ExponentialHistogram().DataPoints().At(0).Attributes(), pcommon.NewMap())
+		m, ok := a.registeredMetrics.Load(signature)
+		require.True(t, ok)
+		v := m.(*accumulatedValue).value.ExponentialHistogram().DataPoints().At(0)
+
+		require.Equal(t, int32(2), v.Scale())
+		require.Equal(t, uint64(12), v.Count())
+		require.Equal(t, uint64(2), v.ZeroCount())
+		require.Equal(t, 10.0, v.Sum())
+		require.Equal(t, int32(0), v.Positive().Offset())
+		require.Equal(t, []uint64{1, 5, 4}, v.Positive().BucketCounts().AsRaw())
+		require.Equal(t, pcommon.NewTimestampFromTime(startTs), v.StartTimestamp())
+		require.Equal(t, pcommon.NewTimestampFromTime(ts2), v.Timestamp())
+	})
+	t.Run("AccumulateDifferentScales", func(t *testing.T) {
+		startTs := time.Now().Add(-5 * time.Second)
+		ts1 := time.Now().Add(-4 * time.Second)
+		ts2 := time.Now().Add(-3 * time.Second)
+		resourceMetrics := pmetric.NewResourceMetrics()
+		ilm := resourceMetrics.ScopeMetrics().AppendEmpty()
+		appendDeltaExponentialHistogram(startTs, ts1, 2, 0, []uint64{1, 2, 3, 4}, ilm.Metrics())
+		appendDeltaExponentialHistogram(ts1, ts2, 1, 0, []uint64{5}, ilm.Metrics())
+
+		a := newAccumulator(zap.NewNop(), 1*time.Hour).(*lastValueAccumulator)
+		require.Equal(t, 2, a.Accumulate(resourceMetrics))
+
+		signature := timeseriesSignature(ilm.Scope().Name(), ilm.Metrics().At(0), ilm.Metrics().At(0).ExponentialHistogram().DataPoints().At(0).Attributes(), pcommon.NewMap())
+		m, ok := a.registeredMetrics.Load(signature)
+		require.True(t, ok)
+		v := m.(*accumulatedValue).value.ExponentialHistogram().DataPoints().At(0)
+
+		// scale 2 buckets are merged pairwise into scale 1 buckets.
+		require.Equal(t, int32(1), v.Scale())
+		require.Equal(t, int32(0), v.Positive().Offset())
+		require.Equal(t, []uint64{8, 7}, v.Positive().BucketCounts().AsRaw())
+	})
+	t.Run("MisalignedIsDropped", func(t *testing.T) {
+		startTs := time.Now().Add(-5 * time.Second)
+		ts1 := time.Now().Add(-3 * time.Second)
+		ts2 := time.Now().Add(-4 * time.Second)
+		resourceMetrics := pmetric.NewResourceMetrics()
+		ilm := resourceMetrics.ScopeMetrics().AppendEmpty()
+		appendDeltaExponentialHistogram(startTs, ts1, 2, 0, []uint64{1, 2}, ilm.Metrics())
+		appendDeltaExponentialHistogram(startTs, ts2, 2, 0, []uint64{3, 4}, ilm.Metrics())
+
+		a := newAccumulator(zap.NewNop(), 1*time.Hour).(*lastValueAccumulator)
+		require.Equal(t, 1, a.Accumulate(resourceMetrics))
+	})
+}
+
 func TestAccumulateDroppedMetrics(t *testing.T) {
 	tests := []struct {
 		name       string
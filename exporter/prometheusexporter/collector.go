@@ -102,6 +102,8 @@ func (c *collector) convertMetric(metric pmetric.Metric, resourceAttrs pcommon.M
 		return c.convertSum(metric, resourceAttrs)
 	case pmetric.MetricTypeHistogram:
 		return c.convertDoubleHistogram(metric, resourceAttrs)
+	case pmetric.MetricTypeExponentialHistogram:
+		return c.convertExponentialHistogram(metric, resourceAttrs)
 	case pmetric.MetricTypeSummary:
 		return c.convertSummary(metric, resourceAttrs)
 	}
@@ -271,6 +273,33 @@ func (c *collector) convertDoubleHistogram(metric pmetric.Metric, resourceAttrs
 	return m, nil
 }
 
+// convertExponentialHistogram exposes an exponential histogram as a native
+// histogram, with classic buckets at the exponential bucket boundaries for the
+// text formats.
+func (c *collector) convertExponentialHistogram(metric pmetric.Metric, resourceAttrs pcommon.Map) (prometheus.Metric, error) {
+	ip := metric.ExponentialHistogram().DataPoints().At(0)
+	desc, attributes := c.getMetricMetadata(metric, ip.Attributes(), resourceAttrs)
+
+	m, err := prometheus.NewConstHistogram(desc, ip.Count(), ip.Sum(), exponentialToClassicBuckets(ip), attributes...)
+	if err != nil {
+		return nil, err
+	}
+	m = newNativeHistogram(m, ip)
+
+	exemplars := convertExemplars(ip.Exemplars())
+	if len(exemplars) > 0 {
+		m, err = prometheus.NewMetricWithExemplars(m, exemplars...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if c.sendTimestamps {
+		return prometheus.NewMetricWithTimestamp(ip.Timestamp().AsTime(), m), nil
+	}
+	return m, nil
+}
+
 func (c *collector) createTargetInfoMetrics(resourceAttrs []pcommon.Map) ([]prometheus.Metric, error) {
 	var lastErr error
 
@@ -0,0 +1,195 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusexporter"
+
+import (
+	"math"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+const (
+	// Prometheus native histograms support schemas from -4 to 8.
+	minNativeHistogramSchema = -4
+	maxNativeHistogramSchema = 8
+
+	defaultZeroThreshold = 1e-128
+)
+
+// nativeHistogram decorates a classic const histogram with the buckets of a
+// Prometheus native histogram. The text exposition formats only render the
+// classic buckets, the native buckets are served to scrapers negotiating the
+// protobuf format through the Accept header.
+type nativeHistogram struct {
+	prometheus.Metric
+
+	schema         int32
+	zeroThreshold  float64
+	zeroCount      uint64
+	positiveSpans  []*dto.BucketSpan
+	positiveDeltas []int64
+	negativeSpans  []*dto.BucketSpan
+	negativeDeltas []int64
+}
+
+func (h *nativeHistogram) Write(out *dto.Metric) error {
+	if err := h.Metric.Write(out); err != nil {
+		return err
+	}
+	hist := out.Histogram
+	hist.Schema = &h.schema
+	hist.ZeroThreshold = &h.zeroThreshold
+	hist.ZeroCount = &h.zeroCount
+	hist.PositiveSpan = h.positiveSpans
+	hist.PositiveDelta = h.positiveDeltas
+	hist.NegativeSpan = h.negativeSpans
+	hist.NegativeDelta = h.negativeDeltas
+	return nil
+}
+
+// newNativeHistogram adds the native buckets of dp to the classic histogram m.
+// m is returned unchanged when the scale of dp cannot be represented by a
+// native histogram.
+func newNativeHistogram(m prometheus.Metric, dp pmetric.ExponentialHistogramDataPoint) prometheus.Metric {
+	scale := dp.Scale()
+	if scale < minNativeHistogramSchema {
+		return m
+	}
+	var scaleDown int32
+	if scale > maxNativeHistogramSchema {
+		scaleDown = scale - maxNativeHistogramSchema
+		scale = maxNativeHistogramSchema
+	}
+
+	h := &nativeHistogram{
+		Metric:        m,
+		schema:        scale,
+		zeroThreshold: dp.ZeroThreshold(),
+		zeroCount:     dp.ZeroCount(),
+	}
+	// A zero threshold identifies the histogram as native even when it has
+	// no buckets.
+	if h.zeroThreshold == 0 {
+		h.zeroThreshold = defaultZeroThreshold
+	}
+	h.positiveSpans, h.positiveDeltas = convertBucketsLayout(dp.Positive(), scaleDown)
+	h.negativeSpans, h.negativeDeltas = convertBucketsLayout(dp.Negative(), scaleDown)
+	return h
+}
+
+// convertBucketsLayout translates the dense buckets of an exponential
+// histogram into the sparse spans and deltas of a native histogram.
+//
+// The logic follows the remote write translator in
+// pkg/translator/prometheusremotewrite: OTel bucket index 0 covers (1, base]
+// while Prometheus bucket index 0 covers (1/base, 1], hence the +1 on the
+// indexes. 2^scaleDown buckets are merged into one.
+func convertBucketsLayout(buckets pmetric.ExponentialHistogramDataPointBuckets, scaleDown int32) ([]*dto.BucketSpan, []int64) {
+	bucketCounts := buckets.BucketCounts()
+	if bucketCounts.Len() == 0 {
+		return nil, nil
+	}
+
+	var (
+		spans     []*dto.BucketSpan
+		deltas    []int64
+		count     int64
+		prevCount int64
+	)
+
+	newSpan := func(offset int32) {
+		length := uint32(0)
+		spans = append(spans, &dto.BucketSpan{Offset: &offset, Length: &length})
+	}
+	appendDelta := func(count int64) {
+		*spans[len(spans)-1].Length++
+		deltas = append(deltas, count-prevCount)
+		prevCount = count
+	}
+	// appendBucket adds count at nextBucketIdx, a gap of more than two empty
+	// buckets starts a new span as in client_golang.
+	appendBucket := func(gap int32, count int64) {
+		if gap > 2 {
+			newSpan(gap)
+		} else {
+			for j := int32(0); j < gap; j++ {
+				appendDelta(0)
+			}
+		}
+		appendDelta(count)
+	}
+
+	numBuckets := bucketCounts.Len()
+	bucketIdx := buckets.Offset()>>scaleDown + 1
+	newSpan(bucketIdx)
+
+	for i := 0; i < numBuckets; i++ {
+		nextBucketIdx := (int32(i)+buckets.Offset())>>scaleDown + 1
+		if bucketIdx == nextBucketIdx {
+			// not enough buckets collected to merge yet.
+			count += int64(bucketCounts.At(i))
+			continue
+		}
+		if count == 0 {
+			count = int64(bucketCounts.At(i))
+			continue
+		}
+		appendBucket(nextBucketIdx-bucketIdx-1, count)
+		count = int64(bucketCounts.At(i))
+		bucketIdx = nextBucketIdx
+	}
+	lastBucketIdx := (int32(numBuckets)+buckets.Offset()-1)>>scaleDown + 1
+	appendBucket(lastBucketIdx-bucketIdx, count)
+
+	return spans, deltas
+}
+
+// exponentialBucketBound returns base^index, the lower bound of the positive
+// bucket index, with base = 2^(2^-scale).
+func exponentialBucketBound(index int32, scale int32) float64 {
+	if scale <= 0 {
+		return math.Ldexp(1, int(index)<<-scale)
+	}
+	return math.Exp2(float64(index) / float64(int64(1)<<scale))
+}
+
+// exponentialToClassicBuckets returns the cumulative counts of the non-empty
+// buckets of dp keyed by their upper bound, as expected by
+// prometheus.NewConstHistogram.
+func exponentialToClassicBuckets(dp pmetric.ExponentialHistogramDataPoint) map[float64]uint64 {
+	scale := dp.Scale()
+	buckets := make(map[float64]uint64)
+	var cumCount uint64
+
+	// negative buckets cover [-base^(index+1), -base^index), from the lowest
+	// values up.
+	negative := dp.Negative()
+	for i := negative.BucketCounts().Len() - 1; i >= 0; i-- {
+		count := negative.BucketCounts().At(i)
+		if count == 0 {
+			continue
+		}
+		cumCount += count
+		buckets[-exponentialBucketBound(negative.Offset()+int32(i), scale)] = cumCount
+	}
+
+	if dp.ZeroCount() > 0 {
+		cumCount += dp.ZeroCount()
+		buckets[dp.ZeroThreshold()] = cumCount
+	}
+
+	// positive buckets cover (base^index, base^(index+1)].
+	positive := dp.Positive()
+	for i := 0; i < positive.BucketCounts().Len(); i++ {
+		count := positive.BucketCounts().At(i)
+		if count == 0 {
+			continue
+		}
+		cumCount += count
+		buckets[exponentialBucketBound(positive.Offset()+int32(i)+1, scale)] = cumCount
+	}
+	return buckets
+}
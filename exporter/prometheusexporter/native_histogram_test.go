@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusexporter
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	io_prometheus_client "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+type bucketSpan struct {
+	offset int32
+	length uint32
+}
+
+func spansOf(spans []*io_prometheus_client.BucketSpan) []bucketSpan {
+	var result []bucketSpan
+	for _, s := range spans {
+		result = append(result, bucketSpan{offset: s.GetOffset(), length: s.GetLength()})
+	}
+	return result
+}
+
+func TestConvertBucketsLayout(t *testing.T) {
+	tests := []struct {
+		name      string
+		offset    int32
+		counts    []uint64
+		scaleDown int32
+		spans     []bucketSpan
+		deltas    []int64
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:   "dense",
+			offset: 0,
+			counts: []uint64{4, 3, 2, 1},
+			spans:  []bucketSpan{{offset: 1, length: 4}},
+			deltas: []int64{4, -1, -1, -1},
+		},
+		{
+			name:   "negative offset",
+			offset: -3,
+			counts: []uint64{1, 2},
+			spans:  []bucketSpan{{offset: -2, length: 2}},
+			deltas: []int64{1, 1},
+		},
+		{
+			name:   "small gap is filled",
+			offset: 0,
+			counts: []uint64{5, 0, 0, 3},
+			spans:  []bucketSpan{{offset: 1, length: 4}},
+			deltas: []int64{5, -5, 0, 3},
+		},
+		{
+			name:   "large gap starts a new span",
+			offset: 0,
+			counts: []uint64{5, 0, 0, 0, 0, 3},
+			spans:  []bucketSpan{{offset: 1, length: 1}, {offset: 4, length: 1}},
+			deltas: []int64{5, -2},
+		},
+		{
+			name:      "scale down merges buckets",
+			offset:    0,
+			counts:    []uint64{1, 2, 3, 4},
+			scaleDown: 1,
+			spans:     []bucketSpan{{offset: 1, length: 2}},
+			deltas:    []int64{3, 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buckets := pmetric.NewExponentialHistogramDataPointBuckets()
+			buckets.SetOffset(tt.offset)
+			buckets.BucketCounts().FromRaw(tt.counts)
+
+			spans, deltas := convertBucketsLayout(buckets, tt.scaleDown)
+			assert.Equal(t, tt.spans, spansOf(spans))
+			assert.Equal(t, tt.deltas, deltas)
+		})
+	}
+}
+
+func TestExponentialToClassicBuckets(t *testing.T) {
+	dp := pmetric.NewExponentialHistogramDataPoint()
+	dp.SetScale(1)
+	dp.SetZeroCount(2)
+	dp.Positive().SetOffset(1)
+	dp.Positive().BucketCounts().FromRaw([]uint64{1, 0, 3})
+	dp.Negative().SetOffset(0)
+	dp.Negative().BucketCounts().FromRaw([]uint64{4})
+
+	// base is sqrt(2): the negative bucket covers [-sqrt(2), -1), the
+	// positive ones (sqrt(2), 2] and (2*sqrt(2), 4].
+	buckets := exponentialToClassicBuckets(dp)
+	require.Len(t, buckets, 4)
+	assert.Equal(t, uint64(4), buckets[-1])
+	assert.Equal(t, uint64(6), buckets[0])
+	assert.Equal(t, uint64(7), buckets[2])
+	assert.Equal(t, uint64(10), buckets[4])
+}
+
+func TestNativeHistogramUnsupportedScale(t *testing.T) {
+	desc := prometheus.NewDesc("test_metric", "", nil, nil)
+	classic, err := prometheus.NewConstHistogram(desc, 0, 0, nil)
+	require.NoError(t, err)
+
+	dp := pmetric.NewExponentialHistogramDataPoint()
+	dp.SetScale(-5)
+	assert.Equal(t, classic, newNativeHistogram(classic, dp))
+
+	dp.SetScale(10)
+	dp.Positive().BucketCounts().FromRaw([]uint64{1, 1, 1, 1, 1})
+	pb := io_prometheus_client.Metric{}
+	require.NoError(t, newNativeHistogram(classic, dp).Write(&pb))
+	assert.Equal(t, int32(8), pb.GetHistogram().GetSchema())
+	assert.Equal(t, []bucketSpan{{offset: 1, length: 2}}, spansOf(pb.GetHistogram().GetPositiveSpan()))
+	assert.Equal(t, []int64{4, -3}, pb.GetHistogram().GetPositiveDelta())
+}
+
+func TestConvertExponentialHistogram(t *testing.T) {
+	metric := pmetric.NewMetric()
+	metric.SetName("test_metric")
+	metric.SetDescription("this is test metric")
+	dp := metric.SetEmptyExponentialHistogram().DataPoints().AppendEmpty()
+	dp.SetScale(0)
+	dp.SetCount(10)
+	dp.SetSum(12.5)
+	dp.SetZeroCount(4)
+	dp.Positive().BucketCounts().FromRaw([]uint64{1, 2})
+	dp.Negative().BucketCounts().FromRaw([]uint64{3})
+	dp.Attributes().PutStr("label_1", "1")
+	setTestExemplarWithDoubleValue(dp.Exemplars().AppendEmpty(), 3.0)
+
+	c := collector{logger: zap.NewNop()}
+	m, err := c.convertMetric(metric, pcommon.NewMap())
+	require.NoError(t, err)
+
+	pb := io_prometheus_client.Metric{}
+	require.NoError(t, m.Write(&pb))
+	h := pb.GetHistogram()
+	require.NotNil(t, h)
+
+	assert.Equal(t, uint64(10), h.GetSampleCount())
+	assert.Equal(t, 12.5, h.GetSampleSum())
+
+	// classic buckets
+	bounds := map[float64]uint64{}
+	for _, b := range h.GetBucket() {
+		bounds[b.GetUpperBound()] = b.GetCumulativeCount()
+	}
+	assert.Equal(t, map[float64]uint64{-1: 3, 0: 7, 2: 8, 4: 10}, bounds)
+	assert.Equal(t, 3.0, h.GetBucket()[3].GetExemplar().GetValue())
+
+	// native buckets
+	assert.Equal(t, int32(0), h.GetSchema())
+	assert.Equal(t, defaultZeroThreshold, h.GetZeroThreshold())
+	assert.Equal(t, uint64(4), h.GetZeroCount())
+	assert.Equal(t, []bucketSpan{{offset: 1, length: 2}}, spansOf(h.GetPositiveSpan()))
+	assert.Equal(t, []int64{1, 1}, h.GetPositiveDelta())
+	assert.Equal(t, []bucketSpan{{offset: 1, length: 1}}, spansOf(h.GetNegativeSpan()))
+	assert.Equal(t, []int64{3}, h.GetNegativeDelta())
+}
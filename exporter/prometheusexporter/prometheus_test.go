@@ -5,6 +5,7 @@ package prometheusexporter
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,6 +13,8 @@ import (
 	"testing"
 	"time"
 
+	io_prometheus_client "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
@@ -470,6 +473,85 @@ func TestPrometheusExporter_endToEndWithResource(t *testing.T) {
 	}
 }
 
+func TestPrometheusExporter_endToEndNativeHistogram(t *testing.T) {
+	cfg := &Config{
+		ServerConfig: confighttp.ServerConfig{
+			Endpoint: "localhost:7778",
+		},
+		MetricExpiration: 120 * time.Minute,
+	}
+
+	factory := NewFactory()
+	set := exportertest.NewNopCreateSettings()
+	exp, err := factory.CreateMetricsExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, exp.Shutdown(context.Background()))
+		// trigger a get so that the server cleans up our keepalive socket
+		_, err = http.Get("http://localhost:7778/metrics")
+		require.NoError(t, err)
+	})
+
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	md := pmetric.NewMetrics()
+	metric := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("request_duration")
+	metric.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := metric.ExponentialHistogram().DataPoints().AppendEmpty()
+	dp.SetScale(0)
+	dp.SetCount(3)
+	dp.SetSum(5)
+	dp.Positive().BucketCounts().FromRaw([]uint64{1, 2})
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(time.Now()))
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), md))
+
+	t.Run("text", func(t *testing.T) {
+		res, err := http.Get("http://localhost:7778/metrics")
+		require.NoError(t, err)
+		blob, _ := io.ReadAll(res.Body)
+		_ = res.Body.Close()
+		for _, w := range []string{
+			`# TYPE request_duration histogram`,
+			`request_duration_bucket{le="2"} 1`,
+			`request_duration_bucket{le="4"} 3`,
+			`request_duration_bucket{le="+Inf"} 3`,
+			`request_duration_count 3`,
+		} {
+			assert.Contains(t, string(blob), w)
+		}
+	})
+
+	t.Run("protobuf", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "http://localhost:7778/metrics", nil)
+		require.NoError(t, err)
+		req.Header.Set("Accept", "application/vnd.google.protobuf;proto=io.prometheus.client.MetricFamily;encoding=delimited")
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		decoder := expfmt.NewDecoder(res.Body, expfmt.ResponseFormat(res.Header))
+		var found bool
+		for {
+			mf := &io_prometheus_client.MetricFamily{}
+			if err = decoder.Decode(mf); errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err)
+			if mf.GetName() != "request_duration" {
+				continue
+			}
+			found = true
+			h := mf.GetMetric()[0].GetHistogram()
+			assert.Equal(t, int32(0), h.GetSchema())
+			assert.Equal(t, []int64{1, 1}, h.GetPositiveDelta())
+			assert.Len(t, h.GetBucket(), 2)
+		}
+		assert.True(t, found)
+	})
+}
+
 func metricBuilder(delta int64, prefix, job, instance string) pmetric.Metrics {
 	md := pmetric.NewMetrics()
 	rms := md.ResourceMetrics().AppendEmpty()
@@ -52,8 +52,18 @@ type Config struct {
 
 	// SendMetadata controls whether prometheus metadata will be generated and sent
 	SendMetadata bool `mapstructure:"send_metadata"`
+
+	// ProtobufMessage selects the remote write protocol version by the name of
+	// its protobuf message: "prometheus.WriteRequest" for Remote Write 1.0 or
+	// "io.prometheus.write.v2.Request" for Remote Write 2.0.
+	ProtobufMessage string `mapstructure:"protobuf_message"`
 }
 
+const (
+	protobufMessageV1 = "prometheus.WriteRequest"
+	protobufMessageV2 = "io.prometheus.write.v2.Request"
+)
+
 type CreatedMetric struct {
 	// Enabled if true the _created metrics could be exported
 	Enabled bool `mapstructure:"enabled"`
@@ -115,5 +125,17 @@ func (cfg *Config) Validate() error {
 		cfg.MaxBatchSizeBytes = 3000000
 	}
 
+	switch cfg.ProtobufMessage {
+	case "":
+		cfg.ProtobufMessage = protobufMessageV1
+	case protobufMessageV1:
+	case protobufMessageV2:
+		if cfg.WAL != nil {
+			return fmt.Errorf("wal is not supported with protobuf_message %q", protobufMessageV2)
+		}
+	default:
+		return fmt.Errorf("unsupported protobuf_message %q, must be %q or %q", cfg.ProtobufMessage, protobufMessageV1, protobufMessageV2)
+	}
+
 	return nil
 }
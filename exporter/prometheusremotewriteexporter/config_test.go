@@ -78,7 +78,8 @@ func TestLoadConfig(t *testing.T) {
 				TargetInfo: &TargetInfo{
 					Enabled: true,
 				},
-				CreatedMetric: &CreatedMetric{Enabled: true},
+				CreatedMetric:   &CreatedMetric{Enabled: true},
+				ProtobufMessage: protobufMessageV1,
 			},
 		},
 		{
@@ -89,6 +90,14 @@ func TestLoadConfig(t *testing.T) {
 			id:           component.NewIDWithName(metadata.Type, "negative_num_consumers"),
 			errorMessage: "remote write consumer number can't be negative",
 		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "unknown_protobuf_message"),
+			errorMessage: `unsupported protobuf_message "prometheus.WriteRequestV3", must be "prometheus.WriteRequest" or "io.prometheus.write.v2.Request"`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "remote_write_v2_wal"),
+			errorMessage: `wal is not supported with protobuf_message "io.prometheus.write.v2.Request"`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -135,3 +144,17 @@ func TestDisabledTargetInfo(t *testing.T) {
 
 	assert.False(t, cfg.(*Config).TargetInfo.Enabled)
 }
+
+func TestRemoteWriteV2(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+
+	sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "remote_write_v2").String())
+	require.NoError(t, err)
+	require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+	assert.NoError(t, component.ValidateConfig(cfg))
+	assert.Equal(t, protobufMessageV2, cfg.(*Config).ProtobufMessage)
+}
@@ -16,7 +16,6 @@ import (
 	"sync"
 
 	"github.com/cenkalti/backoff/v4"
-	"github.com/gogo/protobuf/proto"
 	"github.com/golang/snappy"
 	"github.com/prometheus/prometheus/prompb"
 	"go.opentelemetry.io/collector/component"
@@ -69,6 +68,12 @@ type prwExporter struct {
 	wal               *prweWAL
 	exporterSettings  prometheusremotewrite.Settings
 	telemetry         prwTelemetry
+	protobufMessage   string
+}
+
+// writeRequest is a remote write request of either protocol version.
+type writeRequest interface {
+	Marshal() ([]byte, error)
 }
 
 func newPRWTelemetry(set exporter.CreateSettings) (prwTelemetry, error) {
@@ -132,7 +137,8 @@ func newPRWExporter(cfg *Config, set exporter.CreateSettings) (*prwExporter, err
 			AddMetricSuffixes:   cfg.AddMetricSuffixes,
 			SendMetadata:        cfg.SendMetadata,
 		},
-		telemetry: prwTelemetry,
+		telemetry:       prwTelemetry,
+		protobufMessage: cfg.ProtobufMessage,
 	}
 
 	prwe.wal = newWAL(cfg.WAL, prwe.export)
@@ -179,6 +185,9 @@ func (prwe *prwExporter) PushMetrics(ctx context.Context, md pmetric.Metrics) er
 	case <-prwe.closeChan:
 		return errors.New("shutdown has been called")
 	default:
+		if prwe.protobufMessage == protobufMessageV2 {
+			return prwe.pushMetricsV2(ctx, md)
+		}
 
 		tsMap, err := prometheusremotewrite.FromMetrics(md, prwe.exporterSettings)
 		if err != nil {
@@ -198,6 +207,34 @@ func (prwe *prwExporter) PushMetrics(ctx context.Context, md pmetric.Metrics) er
 	}
 }
 
+// pushMetricsV2 converts metrics to Remote Write 2.0 time series, which carry
+// their metadata inline, and sends them to the remote endpoint.
+func (prwe *prwExporter) pushMetricsV2(ctx context.Context, md pmetric.Metrics) error {
+	tsMap, err := prometheusremotewrite.FromMetricsV2(md, prwe.exporterSettings)
+	if err != nil {
+		prwe.telemetry.recordTranslationFailure(ctx)
+		prwe.settings.Logger.Debug("failed to translate metrics, exporting remaining metrics", zap.Error(err), zap.Int("translated", len(tsMap)))
+	}
+
+	prwe.telemetry.recordTranslatedTimeSeries(ctx, len(tsMap))
+
+	// There are no metrics to export, so return.
+	if len(tsMap) == 0 {
+		return nil
+	}
+
+	// Call export even if a conversion error, since there may be points that were successfully converted.
+	requests, err := batchTimeSeriesV2(tsMap, prwe.maxBatchSizeBytes)
+	if err != nil {
+		return err
+	}
+	writeRequests := make([]writeRequest, 0, len(requests))
+	for _, request := range requests {
+		writeRequests = append(writeRequests, request)
+	}
+	return prwe.exportRequests(ctx, writeRequests)
+}
+
 func validateAndSanitizeExternalLabels(cfg *Config) (map[string]string, error) {
 	sanitizedLabels := make(map[string]string)
 	for key, value := range cfg.ExternalLabels {
@@ -236,7 +273,16 @@ func (prwe *prwExporter) handleExport(ctx context.Context, tsMap map[string]*pro
 
 // export sends a Snappy-compressed WriteRequest containing TimeSeries to a remote write endpoint in order
 func (prwe *prwExporter) export(ctx context.Context, requests []*prompb.WriteRequest) error {
-	input := make(chan *prompb.WriteRequest, len(requests))
+	writeRequests := make([]writeRequest, 0, len(requests))
+	for _, request := range requests {
+		writeRequests = append(writeRequests, request)
+	}
+	return prwe.exportRequests(ctx, writeRequests)
+}
+
+// exportRequests sends the requests concurrently, using up to concurrency workers.
+func (prwe *prwExporter) exportRequests(ctx context.Context, requests []writeRequest) error {
+	input := make(chan writeRequest, len(requests))
 	for _, request := range requests {
 		input <- request
 	}
@@ -277,9 +323,9 @@ func (prwe *prwExporter) export(ctx context.Context, requests []*prompb.WriteReq
 	return errs
 }
 
-func (prwe *prwExporter) execute(ctx context.Context, writeReq *prompb.WriteRequest) error {
-	// Uses proto.Marshal to convert the WriteRequest into bytes array
-	data, errMarshal := proto.Marshal(writeReq)
+func (prwe *prwExporter) execute(ctx context.Context, writeReq writeRequest) error {
+	// Convert the request into bytes array
+	data, errMarshal := writeReq.Marshal()
 	if errMarshal != nil {
 		return consumererror.NewPermanent(errMarshal)
 	}
@@ -306,8 +352,14 @@ func (prwe *prwExporter) execute(ctx context.Context, writeReq *prompb.WriteRequ
 		// Add necessary headers specified by:
 		// https://cortexmetrics.io/docs/apis/#remote-api
 		req.Header.Add("Content-Encoding", "snappy")
-		req.Header.Set("Content-Type", "application/x-protobuf")
-		req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+		if prwe.protobufMessage == protobufMessageV2 {
+			// https://prometheus.io/docs/specs/remote_write_spec_2_0/#protocol
+			req.Header.Set("Content-Type", "application/x-protobuf;proto="+protobufMessageV2)
+			req.Header.Set("X-Prometheus-Remote-Write-Version", "2.0.0")
+		} else {
+			req.Header.Set("Content-Type", "application/x-protobuf")
+			req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+		}
 		req.Header.Set("User-Agent", prwe.userAgentHeader)
 
 		resp, err := prwe.client.Do(req)
@@ -28,9 +28,11 @@ import (
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite/writev2"
 )
 
 // Test_NewPRWExporter checks that a new exporter instance with non-nil fields is initialized
@@ -1117,3 +1119,66 @@ func TestRetries(t *testing.T) {
 		})
 	}
 }
+
+func TestPushMetricsRemoteWriteV2(t *testing.T) {
+	var received []writev2.Request
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/x-protobuf;proto=io.prometheus.write.v2.Request", r.Header.Get("Content-Type"))
+		assert.Equal(t, "2.0.0", r.Header.Get("X-Prometheus-Remote-Write-Version"))
+		assert.Equal(t, "snappy", r.Header.Get("Content-Encoding"))
+
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		data, err := snappy.Decode(nil, body)
+		assert.NoError(t, err)
+		var req writev2.Request
+		assert.NoError(t, req.Unmarshal(data))
+
+		mu.Lock()
+		received = append(received, req)
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.ClientConfig.Endpoint = server.URL
+	cfg.ProtobufMessage = protobufMessageV2
+	cfg.TargetInfo.Enabled = false
+	require.NoError(t, cfg.Validate())
+
+	prwe, err := newPRWExporter(cfg, exportertest.NewNopCreateSettings())
+	require.NoError(t, err)
+	require.NoError(t, prwe.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, prwe.Shutdown(context.Background()))
+	}()
+
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("requests")
+	m.SetDescription("number of requests")
+	m.SetEmptySum().SetIsMonotonic(true)
+	m.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := m.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(pcommon.Timestamp(1 * time.Second))
+	dp.SetTimestamp(pcommon.Timestamp(2 * time.Second))
+	dp.SetIntValue(5)
+
+	require.NoError(t, prwe.PushMetrics(context.Background(), md))
+
+	require.Len(t, received, 1)
+	req := received[0]
+	require.Len(t, req.Timeseries, 1)
+	ts := req.Timeseries[0]
+	labels := make(map[string]string)
+	for i := 0; i+1 < len(ts.LabelsRefs); i += 2 {
+		labels[req.Symbols[ts.LabelsRefs[i]]] = req.Symbols[ts.LabelsRefs[i+1]]
+	}
+	assert.Equal(t, map[string]string{"__name__": "requests_total"}, labels)
+	assert.Equal(t, []writev2.Sample{{Value: 5, Timestamp: 2000}}, ts.Samples)
+	assert.Equal(t, int64(1000), ts.CreatedTimestamp)
+	assert.Equal(t, writev2.MetricTypeCounter, ts.Metadata.Type)
+	assert.Equal(t, "number of requests", req.Symbols[ts.Metadata.HelpRef])
+}
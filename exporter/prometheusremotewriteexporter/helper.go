@@ -8,6 +8,9 @@ import (
 	"sort"
 
 	"github.com/prometheus/prometheus/prompb"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite/writev2"
 )
 
 // batchTimeSeries splits series into multiple batch write requests.
@@ -69,6 +72,52 @@ func batchTimeSeries(tsMap map[string]*prompb.TimeSeries, maxBatchByteSize int,
 	return requests, nil
 }
 
+// batchTimeSeriesV2 splits series into multiple Remote Write 2.0 requests,
+// each with its own symbols table.
+func batchTimeSeriesV2(tsMap map[string]*prometheusremotewrite.TimeSeriesV2, maxBatchByteSize int) ([]*writev2.Request, error) {
+	if len(tsMap) == 0 {
+		return nil, errors.New("invalid tsMap: cannot be empty map")
+	}
+
+	var requests []*writev2.Request
+	tsArray := make([]*prometheusremotewrite.TimeSeriesV2, 0, len(tsMap))
+	sizeOfCurrentBatch := 0
+
+	for _, v := range tsMap {
+		// The size of a request holding only this series is an upper bound of
+		// what the series adds to a batch, since symbols are shared in a batch.
+		sizeOfSeries := prometheusremotewrite.ToWriteRequestV2([]*prometheusremotewrite.TimeSeriesV2{v}).Size()
+
+		if len(tsArray) > 0 && sizeOfCurrentBatch+sizeOfSeries >= maxBatchByteSize {
+			requests = append(requests, convertTimeseriesToRequestV2(tsArray))
+			tsArray = nil
+			sizeOfCurrentBatch = 0
+		}
+
+		tsArray = append(tsArray, v)
+		sizeOfCurrentBatch += sizeOfSeries
+	}
+
+	if len(tsArray) != 0 {
+		requests = append(requests, convertTimeseriesToRequestV2(tsArray))
+	}
+
+	return requests, nil
+}
+
+func convertTimeseriesToRequestV2(tsArray []*prometheusremotewrite.TimeSeriesV2) *writev2.Request {
+	// Prometheus requires time series to be sorted by Timestamp to avoid out of order problems.
+	for _, ts := range tsArray {
+		sort.Slice(ts.Samples, func(i, j int) bool {
+			return ts.Samples[i].Timestamp < ts.Samples[j].Timestamp
+		})
+		sort.Slice(ts.Histograms, func(i, j int) bool {
+			return ts.Histograms[i].Timestamp < ts.Histograms[j].Timestamp
+		})
+	}
+	return prometheusremotewrite.ToWriteRequestV2(tsArray)
+}
+
 func convertTimeseriesToRequest(tsArray []prompb.TimeSeries) *prompb.WriteRequest {
 	// the remote_write endpoint only requires the timeseries.
 	// otlp defines it's own way to handle metric metadata
@@ -8,6 +8,9 @@ import (
 
 	"github.com/prometheus/prometheus/prompb"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite"
 )
 
 // Test_batchTimeSeries checks batchTimeSeries return the correct number of requests
@@ -68,6 +71,35 @@ func Test_batchTimeSeries(t *testing.T) {
 	}
 }
 
+func Test_batchTimeSeriesV2(t *testing.T) {
+	labels := getPromLabels(label11, value11, label12, value12, label21, value21, label22, value22)
+	newSeries := func(name string) *prometheusremotewrite.TimeSeriesV2 {
+		return &prometheusremotewrite.TimeSeriesV2{
+			Labels:  append(getPromLabels("__name__", name), labels...),
+			Samples: []prompb.Sample{getSample(floatVal2, msTime2), getSample(floatVal1, msTime1)},
+		}
+	}
+
+	_, err := batchTimeSeriesV2(map[string]*prometheusremotewrite.TimeSeriesV2{}, 100)
+	assert.Error(t, err)
+
+	tsMap := map[string]*prometheusremotewrite.TimeSeriesV2{
+		"a": newSeries("a"),
+		"b": newSeries("b"),
+	}
+	requests, err := batchTimeSeriesV2(tsMap, 3000000)
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+	require.Len(t, requests[0].Timeseries, 2)
+	// Label names and values shared by both series are only interned once.
+	assert.Len(t, requests[0].Symbols, 1+len(labels)*2+2+1)
+	assert.Equal(t, msTime1, requests[0].Timeseries[0].Samples[0].Timestamp)
+
+	requests, err = batchTimeSeriesV2(tsMap, 10)
+	require.NoError(t, err)
+	assert.Len(t, requests, 2)
+}
+
 // Ensure that before a prompb.WriteRequest is created, that the points per TimeSeries
 // are sorted by Timestamp value, to prevent Prometheus from barfing when it gets poorly
 // sorted values. See issues:
@@ -0,0 +1,378 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package avroencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/avroencodingextension"
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+	"time"
+
+	"github.com/hamba/avro/v2"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+const dateLayout = "2006-01-02"
+
+// avroLogCodec converts each Avro datum to a log record whose body is the value of the datum.
+// Logs are marshaled to the concatenation of the binary encoding of the log bodies.
+type avroLogCodec struct {
+	schema avro.Schema
+}
+
+func (c *avroLogCodec) UnmarshalLogs(buf []byte) (plog.Logs, error) {
+	p := plog.NewLogs()
+	logRecords := p.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	observedTime := pcommon.NewTimestampFromTime(time.Now())
+
+	decoder := avro.NewDecoderForSchema(c.schema, bytes.NewReader(buf))
+	for {
+		var datum any
+		if err := decoder.Decode(&datum); err != nil {
+			if errors.Is(err, io.EOF) {
+				return p, nil
+			}
+			return p, err
+		}
+		value, err := fromAvro(c.schema, datum)
+		if err != nil {
+			return p, err
+		}
+
+		logRecord := logRecords.AppendEmpty()
+		logRecord.SetObservedTimestamp(observedTime)
+		if err = logRecord.Body().FromRaw(value); err != nil {
+			return p, err
+		}
+	}
+}
+
+func (c *avroLogCodec) MarshalLogs(ld plog.Logs) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	encoder := avro.NewEncoderForSchema(c.schema, buf)
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		scopeLogs := ld.ResourceLogs().At(i).ScopeLogs()
+		for j := 0; j < scopeLogs.Len(); j++ {
+			logRecords := scopeLogs.At(j).LogRecords()
+			for k := 0; k < logRecords.Len(); k++ {
+				datum, err := toAvro(c.schema, logRecords.At(k).Body().AsRaw())
+				if err != nil {
+					return nil, err
+				}
+				if err = encoder.Encode(datum); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// fromAvro converts a datum decoded with the schema to a value supported by pcommon.Value.
+// Union values are unwrapped, and logical types are converted to their usual representation:
+// RFC 3339 strings for timestamps and dates, milliseconds or microseconds for times and
+// strings for decimals.
+func fromAvro(schema avro.Schema, datum any) (any, error) {
+	if datum == nil {
+		return nil, nil
+	}
+
+	switch s := schema.(type) {
+	case *avro.RefSchema:
+		return fromAvro(s.Schema(), datum)
+	case *avro.RecordSchema:
+		record, ok := datum.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unexpected value of type %T for record %q", datum, s.FullName())
+		}
+		value := make(map[string]any, len(record))
+		for _, field := range s.Fields() {
+			fieldValue, err := fromAvro(field.Type(), record[field.Name()])
+			if err != nil {
+				return nil, fmt.Errorf("field %q: %w", field.Name(), err)
+			}
+			value[field.Name()] = fieldValue
+		}
+		return value, nil
+	case *avro.UnionSchema:
+		union, ok := datum.(map[string]any)
+		if !ok || len(union) != 1 {
+			return nil, fmt.Errorf("unexpected value of type %T for union", datum)
+		}
+		for name, unionValue := range union {
+			member, _ := s.Types().Get(name)
+			if member == nil {
+				return nil, fmt.Errorf("unknown union type %q", name)
+			}
+			return fromAvro(member, unionValue)
+		}
+	case *avro.ArraySchema:
+		items, ok := datum.([]any)
+		if !ok {
+			return nil, fmt.Errorf("unexpected value of type %T for array", datum)
+		}
+		value := make([]any, len(items))
+		for i, item := range items {
+			var err error
+			if value[i], err = fromAvro(s.Items(), item); err != nil {
+				return nil, err
+			}
+		}
+		return value, nil
+	case *avro.MapSchema:
+		entries, ok := datum.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unexpected value of type %T for map", datum)
+		}
+		value := make(map[string]any, len(entries))
+		for key, entry := range entries {
+			var err error
+			if value[key], err = fromAvro(s.Values(), entry); err != nil {
+				return nil, err
+			}
+		}
+		return value, nil
+	case *avro.PrimitiveSchema:
+		return fromAvroLogical(s.Logical(), datum)
+	case *avro.FixedSchema:
+		return fromAvroLogical(s.Logical(), datum)
+	}
+	return datum, nil
+}
+
+func fromAvroLogical(logical avro.LogicalSchema, datum any) (any, error) {
+	switch v := datum.(type) {
+	case time.Time:
+		if logical != nil && logical.Type() == avro.Date {
+			return v.Format(dateLayout), nil
+		}
+		return v.Format(time.RFC3339Nano), nil
+	case time.Duration:
+		if logical != nil && logical.Type() == avro.TimeMillis {
+			return v.Milliseconds(), nil
+		}
+		return v.Microseconds(), nil
+	case *big.Rat:
+		scale := 0
+		if decimal, ok := logical.(*avro.DecimalLogicalSchema); ok {
+			scale = decimal.Scale()
+		}
+		return v.FloatString(scale), nil
+	}
+	return datum, nil
+}
+
+// toAvro converts a log body to a datum that can be encoded with the schema. It is the
+// inverse of fromAvro: the member of a union is the first one which the value can be
+// converted to.
+func toAvro(schema avro.Schema, value any) (any, error) {
+	switch s := schema.(type) {
+	case *avro.RefSchema:
+		return toAvro(s.Schema(), value)
+	case *avro.NullSchema:
+		if value != nil {
+			return nil, fmt.Errorf("unexpected value of type %T for null", value)
+		}
+		return nil, nil
+	case *avro.RecordSchema:
+		fields, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unexpected value of type %T for record %q", value, s.FullName())
+		}
+		datum := make(map[string]any, len(s.Fields()))
+		for _, field := range s.Fields() {
+			fieldValue, ok := fields[field.Name()]
+			if !ok {
+				if field.HasDefault() {
+					continue
+				}
+				if !isNullable(field.Type()) {
+					return nil, fmt.Errorf("missing field %q of record %q", field.Name(), s.FullName())
+				}
+			}
+			var err error
+			if datum[field.Name()], err = toAvro(field.Type(), fieldValue); err != nil {
+				return nil, fmt.Errorf("field %q: %w", field.Name(), err)
+			}
+		}
+		return datum, nil
+	case *avro.UnionSchema:
+		var errs error
+		for _, member := range s.Types() {
+			datum, err := toAvro(member, value)
+			if err != nil {
+				errs = errors.Join(errs, err)
+				continue
+			}
+			if datum == nil {
+				return nil, nil
+			}
+			return map[string]any{typeName(member): datum}, nil
+		}
+		return nil, fmt.Errorf("value does not match any type of the union: %w", errs)
+	case *avro.ArraySchema:
+		items, ok := value.([]any)
+		if !ok {
+			return nil, fmt.Errorf("unexpected value of type %T for array", value)
+		}
+		datum := make([]any, len(items))
+		for i, item := range items {
+			var err error
+			if datum[i], err = toAvro(s.Items(), item); err != nil {
+				return nil, err
+			}
+		}
+		return datum, nil
+	case *avro.MapSchema:
+		entries, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unexpected value of type %T for map", value)
+		}
+		datum := make(map[string]any, len(entries))
+		for key, entry := range entries {
+			var err error
+			if datum[key], err = toAvro(s.Values(), entry); err != nil {
+				return nil, err
+			}
+		}
+		return datum, nil
+	case *avro.EnumSchema:
+		symbol, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected value of type %T for enum %q", value, s.FullName())
+		}
+		for _, known := range s.Symbols() {
+			if symbol == known {
+				return symbol, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown symbol %q for enum %q", symbol, s.FullName())
+	case *avro.FixedSchema:
+		if decimal, ok := s.Logical().(*avro.DecimalLogicalSchema); ok {
+			return toDecimal(decimal, value)
+		}
+		b, ok := value.([]byte)
+		if !ok || len(b) != s.Size() {
+			return nil, fmt.Errorf("unexpected value of type %T for fixed %q of size %d", value, s.FullName(), s.Size())
+		}
+		datum := reflect.New(reflect.ArrayOf(s.Size(), reflect.TypeOf(byte(0)))).Elem()
+		reflect.Copy(datum, reflect.ValueOf(b))
+		return datum.Interface(), nil
+	case *avro.PrimitiveSchema:
+		return toAvroPrimitive(s, value)
+	}
+	return nil, fmt.Errorf("unsupported schema type %q", schema.Type())
+}
+
+func toAvroPrimitive(schema *avro.PrimitiveSchema, value any) (any, error) {
+	var logical avro.LogicalType
+	if schema.Logical() != nil {
+		logical = schema.Logical().Type()
+	}
+
+	switch logical {
+	case avro.Date:
+		if s, ok := value.(string); ok {
+			return time.Parse(dateLayout, s)
+		}
+	case avro.TimestampMillis, avro.TimestampMicros:
+		if s, ok := value.(string); ok {
+			return time.Parse(time.RFC3339Nano, s)
+		}
+	case avro.TimeMillis:
+		if n, ok := value.(int64); ok {
+			return time.Duration(n) * time.Millisecond, nil
+		}
+	case avro.TimeMicros:
+		if n, ok := value.(int64); ok {
+			return time.Duration(n) * time.Microsecond, nil
+		}
+	case avro.Decimal:
+		return toDecimal(schema.Logical().(*avro.DecimalLogicalSchema), value)
+	default:
+		switch schema.Type() {
+		case avro.Boolean:
+			if b, ok := value.(bool); ok {
+				return b, nil
+			}
+		case avro.Int:
+			if n, ok := value.(int64); ok {
+				return int(n), nil
+			}
+		case avro.Long:
+			if n, ok := value.(int64); ok {
+				return n, nil
+			}
+		case avro.Float:
+			switch n := value.(type) {
+			case float64:
+				return float32(n), nil
+			case int64:
+				return float32(n), nil
+			}
+		case avro.Double:
+			switch n := value.(type) {
+			case float64:
+				return n, nil
+			case int64:
+				return float64(n), nil
+			}
+		case avro.String:
+			if s, ok := value.(string); ok {
+				return s, nil
+			}
+		case avro.Bytes:
+			if b, ok := value.([]byte); ok {
+				return b, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("unexpected value of type %T for %s", value, typeName(schema))
+}
+
+func toDecimal(decimal *avro.DecimalLogicalSchema, value any) (any, error) {
+	s, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value of type %T for decimal", value)
+	}
+	rat, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("invalid decimal %q", s)
+	}
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimal.Scale())), nil)
+	if !new(big.Rat).Mul(rat, new(big.Rat).SetInt(scale)).IsInt() {
+		return nil, fmt.Errorf("decimal %q has more than %d digits after the decimal point", s, decimal.Scale())
+	}
+	return rat, nil
+}
+
+func isNullable(schema avro.Schema) bool {
+	switch s := schema.(type) {
+	case *avro.NullSchema:
+		return true
+	case *avro.UnionSchema:
+		return s.Nullable()
+	}
+	return false
+}
+
+// typeName is the name identifying the type of a union member.
+func typeName(schema avro.Schema) string {
+	if ref, ok := schema.(*avro.RefSchema); ok {
+		schema = ref.Schema()
+	}
+	if named, ok := schema.(avro.NamedSchema); ok {
+		return named.FullName()
+	}
+
+	name := string(schema.Type())
+	if logicalSchema, ok := schema.(avro.LogicalTypeSchema); ok && logicalSchema.Logical() != nil {
+		name += "." + string(logicalSchema.Logical().Type())
+	}
+	return name
+}
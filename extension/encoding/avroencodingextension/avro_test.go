@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package avroencodingextension
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/hamba/avro/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func newTestCodec(t *testing.T) *avroLogCodec {
+	schema, err := avro.ParseFiles(filepath.Join("testdata", "schema.avsc"))
+	require.NoError(t, err)
+	return &avroLogCodec{schema: schema}
+}
+
+func newLogs(t *testing.T, bodies ...map[string]any) plog.Logs {
+	ld := plog.NewLogs()
+	logRecords := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for _, body := range bodies {
+		require.NoError(t, logRecords.AppendEmpty().Body().SetEmptyMap().FromRaw(body))
+	}
+	return ld
+}
+
+func TestAvroRoundTrip(t *testing.T) {
+	t.Parallel()
+	codec := newTestCodec(t)
+	first := map[string]any{
+		"timestamp": "2024-03-15T17:29:37.123Z",
+		"level":     "ERROR",
+		"message":   "payment failed",
+		"retries":   int64(3),
+		"duration":  1.5,
+		"user":      "alice",
+		"amount":    "12.50",
+		"tags":      map[string]any{"region": "eu"},
+		"spans":     []any{[]byte("01234567")},
+		"source":    map[string]any{"file": "payment.go", "line": int64(42)},
+	}
+	second := map[string]any{
+		"timestamp": "2024-03-15T17:29:38Z",
+		"level":     "INFO",
+		"message":   "payment succeeded",
+		"duration":  int64(2),
+		"user":      nil,
+		"tags":      map[string]any{},
+		"spans":     []any{},
+	}
+
+	buf, err := codec.MarshalLogs(newLogs(t, first, second))
+	require.NoError(t, err)
+
+	ld, err := codec.UnmarshalLogs(buf)
+	require.NoError(t, err)
+	require.Equal(t, 2, ld.LogRecordCount())
+	logRecords := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	assert.NotZero(t, logRecords.At(0).ObservedTimestamp())
+	assert.Equal(t, first, logRecords.At(0).Body().Map().AsRaw())
+	assert.Equal(t, map[string]any{
+		"timestamp": "2024-03-15T17:29:38Z",
+		"level":     "INFO",
+		"message":   "payment succeeded",
+		"retries":   int64(0),
+		"duration":  2.0,
+		"user":      nil,
+		"amount":    nil,
+		"tags":      map[string]any{},
+		"spans":     []any{},
+		"source":    nil,
+	}, logRecords.At(1).Body().Map().AsRaw())
+}
+
+func TestAvroUnmarshalEmpty(t *testing.T) {
+	t.Parallel()
+	ld, err := newTestCodec(t).UnmarshalLogs(nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, ld.LogRecordCount())
+}
+
+func TestInvalidUnmarshal(t *testing.T) {
+	t.Parallel()
+	_, err := newTestCodec(t).UnmarshalLogs([]byte{0x02, 0xff})
+	assert.Error(t, err)
+}
+
+func TestInvalidMarshal(t *testing.T) {
+	t.Parallel()
+	valid := map[string]any{
+		"timestamp": "2024-03-15T17:29:37Z",
+		"level":     "INFO",
+		"message":   "started",
+		"duration":  1.0,
+		"user":      nil,
+		"tags":      map[string]any{},
+		"spans":     []any{},
+	}
+	with := func(key string, value any) map[string]any {
+		body := map[string]any{}
+		for k, v := range valid {
+			body[k] = v
+		}
+		if value == nil {
+			delete(body, key)
+		} else {
+			body[key] = value
+		}
+		return body
+	}
+
+	tests := []struct {
+		name        string
+		body        map[string]any
+		expectedErr string
+	}{
+		{
+			name:        "missing field",
+			body:        with("message", nil),
+			expectedErr: `missing field "message" of record "com.example.Log"`,
+		},
+		{
+			name:        "unknown symbol",
+			body:        with("level", "FATAL"),
+			expectedErr: `unknown symbol "FATAL" for enum "com.example.Level"`,
+		},
+		{
+			name:        "invalid timestamp",
+			body:        with("timestamp", "yesterday"),
+			expectedErr: `field "timestamp"`,
+		},
+		{
+			name:        "invalid union",
+			body:        with("user", int64(1)),
+			expectedErr: "value does not match any type of the union",
+		},
+		{
+			name:        "invalid decimal",
+			body:        with("amount", "1.234"),
+			expectedErr: `decimal "1.234" has more than 2 digits after the decimal point`,
+		},
+		{
+			name:        "invalid fixed",
+			body:        with("spans", []any{[]byte("0123")}),
+			expectedErr: `unexpected value of type []uint8 for fixed "com.example.SpanID" of size 8`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newTestCodec(t).MarshalLogs(newLogs(t, tt.body))
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("NOT A MAP")
+	_, err := newTestCodec(t).MarshalLogs(ld)
+	assert.ErrorContains(t, err, `unexpected value of type string for record "com.example.Log"`)
+}
@@ -0,0 +1,18 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package avroencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/avroencodingextension"
+
+import "errors"
+
+type Config struct {
+	// SchemaFile is the path of the Avro schema of the encoded data.
+	SchemaFile string `mapstructure:"schema_file"`
+}
+
+func (c *Config) Validate() error {
+	if c.SchemaFile == "" {
+		return errors.New("schema_file must be set")
+	}
+	return nil
+}
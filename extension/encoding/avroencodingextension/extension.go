@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package avroencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/avroencodingextension"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hamba/avro/v2"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding"
+)
+
+var (
+	_ encoding.LogsMarshalerExtension   = (*avroExtension)(nil)
+	_ encoding.LogsUnmarshalerExtension = (*avroExtension)(nil)
+)
+
+var errNotStarted = errors.New("avro encoding extension is not started")
+
+type avroExtension struct {
+	config    *Config
+	avroCodec *avroLogCodec
+}
+
+// UnmarshalLogs fails with errNotStarted until the schema was parsed by Start.
+func (e *avroExtension) UnmarshalLogs(buf []byte) (plog.Logs, error) {
+	if e.avroCodec == nil {
+		return plog.Logs{}, errNotStarted
+	}
+	return e.avroCodec.UnmarshalLogs(buf)
+}
+
+// MarshalLogs fails with errNotStarted until the schema was parsed by Start.
+func (e *avroExtension) MarshalLogs(ld plog.Logs) ([]byte, error) {
+	if e.avroCodec == nil {
+		return nil, errNotStarted
+	}
+	return e.avroCodec.MarshalLogs(ld)
+}
+
+func (e *avroExtension) Start(_ context.Context, _ component.Host) error {
+	schema, err := avro.ParseFiles(e.config.SchemaFile)
+	if err != nil {
+		return fmt.Errorf("failed to parse schema file %q: %w", e.config.SchemaFile, err)
+	}
+	e.avroCodec = &avroLogCodec{schema: schema}
+	return nil
+}
+
+func (e *avroExtension) Shutdown(_ context.Context) error {
+	return nil
+}
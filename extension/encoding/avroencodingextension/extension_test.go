@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package avroencodingextension
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestExtension_Start(t *testing.T) {
+	tests := []struct {
+		name        string
+		schemaFile  string
+		expectedErr string
+	}{
+		{
+			name:       "valid",
+			schemaFile: filepath.Join("testdata", "schema.avsc"),
+		},
+		{
+			name:        "missing",
+			schemaFile:  filepath.Join("testdata", "missing.avsc"),
+			expectedErr: `failed to parse schema file "testdata/missing.avsc"`,
+		},
+		{
+			name:        "invalid",
+			schemaFile:  filepath.Join("testdata", "config.yaml"),
+			expectedErr: `failed to parse schema file "testdata/config.yaml"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig().(*Config)
+			cfg.SchemaFile = test.schemaFile
+			ext, err := factory.CreateExtension(context.Background(), extensiontest.NewNopCreateSettings(), cfg)
+			require.NoError(t, err)
+			err = ext.Start(context.Background(), componenttest.NewNopHost())
+			if test.expectedErr != "" {
+				require.ErrorContains(t, err, test.expectedErr)
+
+				// The extension cannot be used without its schema.
+				_, err = ext.(*avroExtension).UnmarshalLogs([]byte{0})
+				require.ErrorIs(t, err, errNotStarted)
+				_, err = ext.(*avroExtension).MarshalLogs(plog.NewLogs())
+				require.ErrorIs(t, err, errNotStarted)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, ext.Shutdown(context.Background()))
+		})
+	}
+}
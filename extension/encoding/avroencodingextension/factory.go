@@ -0,0 +1,32 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package avroencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/avroencodingextension"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/avroencodingextension/internal/metadata"
+)
+
+func NewFactory() extension.Factory {
+	return extension.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		createExtension,
+		metadata.ExtensionStability,
+	)
+}
+
+func createExtension(_ context.Context, _ extension.CreateSettings, config component.Config) (extension.Extension, error) {
+	return &avroExtension{
+		config: config.(*Config),
+	}, nil
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{}
+}
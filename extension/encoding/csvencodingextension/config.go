@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package csvencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/csvencodingextension"
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+type Config struct {
+	// Columns are the names of the columns, in order. When empty, the columns are read from
+	// the header when unmarshaling, and are the sorted keys of the first log body when marshaling.
+	Columns []string `mapstructure:"columns"`
+	// Header determines whether the data starts with a row of column names.
+	Header bool `mapstructure:"header"`
+	// Delimiter is the character separating the fields of a row.
+	Delimiter string `mapstructure:"delimiter"`
+}
+
+func (c *Config) Validate() error {
+	if !c.Header && len(c.Columns) == 0 {
+		return errors.New("columns must be set when header is false")
+	}
+	columns := make(map[string]struct{}, len(c.Columns))
+	for _, column := range c.Columns {
+		if _, ok := columns[column]; ok {
+			return fmt.Errorf("duplicate column %q", column)
+		}
+		columns[column] = struct{}{}
+	}
+	if _, err := c.delimiter(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *Config) delimiter() (rune, error) {
+	r, size := utf8.DecodeRuneInString(c.Delimiter)
+	if size == 0 || size != len(c.Delimiter) || r == utf8.RuneError {
+		return 0, fmt.Errorf("delimiter must be a single character, got %q", c.Delimiter)
+	}
+	switch r {
+	case '"', '\r', '\n':
+		return 0, fmt.Errorf("invalid delimiter %q", c.Delimiter)
+	}
+	return r, nil
+}
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package csvencodingextension
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/csvencodingextension/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id          component.ID
+		expected    component.Config
+		expectedErr string
+	}{
+		{
+			id:       component.NewID(metadata.Type),
+			expected: NewFactory().CreateDefaultConfig(),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "columns"),
+			expected: &Config{
+				Columns:   []string{"time", "level", "message"},
+				Header:    false,
+				Delimiter: ";",
+			},
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "missing_columns"),
+			expectedErr: "columns must be set when header is false",
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "duplicate_column"),
+			expectedErr: `duplicate column "level"`,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_delimiter"),
+			expectedErr: `delimiter must be a single character, got "::"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+			if tt.expectedErr != "" {
+				assert.EqualError(t, component.ValidateConfig(cfg), tt.expectedErr)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package csvencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/csvencodingextension"
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// csvLogCodec converts each row to a log record whose body maps the column names to the
+// fields of the row.
+type csvLogCodec struct {
+	columns   []string
+	header    bool
+	delimiter rune
+}
+
+func (c *csvLogCodec) UnmarshalLogs(buf []byte) (plog.Logs, error) {
+	p := plog.NewLogs()
+	reader := csv.NewReader(bytes.NewReader(buf))
+	reader.Comma = c.delimiter
+	reader.ReuseRecord = true
+
+	columns := c.columns
+	if c.header {
+		header, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return p, nil
+		}
+		if err != nil {
+			return p, err
+		}
+		if len(columns) == 0 {
+			columns = append([]string(nil), header...)
+		}
+	}
+	reader.FieldsPerRecord = len(columns)
+
+	logRecords := p.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	observedTime := pcommon.NewTimestampFromTime(time.Now())
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return p, nil
+		}
+		if err != nil {
+			return p, err
+		}
+
+		logRecord := logRecords.AppendEmpty()
+		logRecord.SetObservedTimestamp(observedTime)
+		body := logRecord.Body().SetEmptyMap()
+		body.EnsureCapacity(len(columns))
+		for i, column := range columns {
+			body.PutStr(column, row[i])
+		}
+	}
+}
+
+func (c *csvLogCodec) MarshalLogs(ld plog.Logs) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	writer := csv.NewWriter(buf)
+	writer.Comma = c.delimiter
+
+	columns := c.columns
+	headerWritten := false
+	row := make([]string, len(columns))
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		scopeLogs := ld.ResourceLogs().At(i).ScopeLogs()
+		for j := 0; j < scopeLogs.Len(); j++ {
+			logRecords := scopeLogs.At(j).LogRecords()
+			for k := 0; k < logRecords.Len(); k++ {
+				body := logRecords.At(k).Body()
+				if body.Type() != pcommon.ValueTypeMap {
+					return nil, fmt.Errorf("Marshal: Expected 'Map' found '%v'", body.Type().String())
+				}
+				if len(columns) == 0 {
+					columns = sortedKeys(body.Map())
+					row = make([]string, len(columns))
+				}
+				if c.header && !headerWritten {
+					if err := writer.Write(columns); err != nil {
+						return nil, err
+					}
+					headerWritten = true
+				}
+
+				for n, column := range columns {
+					row[n] = ""
+					if value, ok := body.Map().Get(column); ok {
+						row[n] = value.AsString()
+					}
+				}
+				if err := writer.Write(row); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func sortedKeys(m pcommon.Map) []string {
+	keys := make([]string, 0, m.Len())
+	m.Range(func(k string, _ pcommon.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package csvencodingextension
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestCSVUnmarshaler(t *testing.T) {
+	t.Parallel()
+	codec := &csvLogCodec{header: true, delimiter: ','}
+	ld, err := codec.UnmarshalLogs([]byte("level,message\ninfo,started\nerror,\"failed, retrying\"\n"))
+	require.NoError(t, err)
+	require.Equal(t, 2, ld.LogRecordCount())
+
+	logRecords := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	assert.Equal(t, map[string]any{"level": "info", "message": "started"}, logRecords.At(0).Body().Map().AsRaw())
+	assert.Equal(t, map[string]any{"level": "error", "message": "failed, retrying"}, logRecords.At(1).Body().Map().AsRaw())
+	assert.NotZero(t, logRecords.At(0).ObservedTimestamp())
+}
+
+func TestCSVUnmarshalerColumns(t *testing.T) {
+	t.Parallel()
+	codec := &csvLogCodec{columns: []string{"level", "message"}, header: true, delimiter: ';'}
+	ld, err := codec.UnmarshalLogs([]byte("lvl;msg\ninfo;started\n"))
+	require.NoError(t, err)
+	require.Equal(t, 1, ld.LogRecordCount())
+	assert.Equal(t, map[string]any{"level": "info", "message": "started"},
+		ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Map().AsRaw())
+
+	codec = &csvLogCodec{columns: []string{"level", "message"}, delimiter: ';'}
+	ld, err = codec.UnmarshalLogs([]byte("info;started\nwarn;slow\n"))
+	require.NoError(t, err)
+	require.Equal(t, 2, ld.LogRecordCount())
+	assert.Equal(t, map[string]any{"level": "warn", "message": "slow"},
+		ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1).Body().Map().AsRaw())
+}
+
+func TestCSVUnmarshalerEmpty(t *testing.T) {
+	t.Parallel()
+	codec := &csvLogCodec{header: true, delimiter: ','}
+	ld, err := codec.UnmarshalLogs(nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, ld.LogRecordCount())
+}
+
+func TestInvalidUnmarshal(t *testing.T) {
+	t.Parallel()
+	codec := &csvLogCodec{columns: []string{"level", "message"}, delimiter: ','}
+	_, err := codec.UnmarshalLogs([]byte("info,started,extra\n"))
+	assert.ErrorContains(t, err, "wrong number of fields")
+}
+
+func TestCSVMarshaler(t *testing.T) {
+	t.Parallel()
+	ld := plog.NewLogs()
+	logRecords := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	require.NoError(t, logRecords.AppendEmpty().Body().SetEmptyMap().FromRaw(map[string]any{"message": "started", "level": "info", "attempt": 1}))
+	require.NoError(t, logRecords.AppendEmpty().Body().SetEmptyMap().FromRaw(map[string]any{"message": "failed, retrying", "level": "error"}))
+
+	codec := &csvLogCodec{header: true, delimiter: ','}
+	buf, err := codec.MarshalLogs(ld)
+	require.NoError(t, err)
+	assert.Equal(t, "attempt,level,message\n1,info,started\n,error,\"failed, retrying\"\n", string(buf))
+
+	codec = &csvLogCodec{columns: []string{"level", "message"}, delimiter: ';'}
+	buf, err = codec.MarshalLogs(ld)
+	require.NoError(t, err)
+	assert.Equal(t, "info;started\nerror;failed, retrying\n", string(buf))
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	t.Parallel()
+	codec := &csvLogCodec{columns: []string{"level", "message"}, header: true, delimiter: '\t'}
+	data := "level\tmessage\ninfo\t\"quoted \"\"value\"\"\"\n"
+	ld, err := codec.UnmarshalLogs([]byte(data))
+	require.NoError(t, err)
+	buf, err := codec.MarshalLogs(ld)
+	require.NoError(t, err)
+	assert.Equal(t, data, string(buf))
+}
+
+func TestInvalidMarshal(t *testing.T) {
+	t.Parallel()
+	codec := &csvLogCodec{header: true, delimiter: ','}
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("NOT A MAP")
+	_, err := codec.MarshalLogs(ld)
+	assert.ErrorContains(t, err, "Marshal: Expected 'Map' found 'Str'")
+}
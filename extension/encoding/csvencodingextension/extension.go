@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package csvencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/csvencodingextension"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding"
+)
+
+var (
+	_ encoding.LogsMarshalerExtension   = (*csvExtension)(nil)
+	_ encoding.LogsUnmarshalerExtension = (*csvExtension)(nil)
+)
+
+type csvExtension struct {
+	config   *Config
+	csvCodec *csvLogCodec
+}
+
+func (e *csvExtension) UnmarshalLogs(buf []byte) (plog.Logs, error) {
+	return e.csvCodec.UnmarshalLogs(buf)
+}
+
+func (e *csvExtension) MarshalLogs(ld plog.Logs) ([]byte, error) {
+	return e.csvCodec.MarshalLogs(ld)
+}
+
+func (e *csvExtension) Start(_ context.Context, _ component.Host) error {
+	delimiter, err := e.config.delimiter()
+	if err != nil {
+		return err
+	}
+	e.csvCodec = &csvLogCodec{
+		columns:   e.config.Columns,
+		header:    e.config.Header,
+		delimiter: delimiter,
+	}
+	return nil
+}
+
+func (e *csvExtension) Shutdown(_ context.Context) error {
+	return nil
+}
@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package csvencodingextension
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+)
+
+func TestExtension(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Columns = []string{"level", "message"}
+	cfg.Delimiter = "|"
+	ext, err := factory.CreateExtension(context.Background(), extensiontest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, ext.Shutdown(context.Background()))
+	}()
+
+	data := "level|message\ninfo|started\n"
+	ld, err := ext.(*csvExtension).UnmarshalLogs([]byte(data))
+	require.NoError(t, err)
+	buf, err := ext.(*csvExtension).MarshalLogs(ld)
+	require.NoError(t, err)
+	assert.Equal(t, data, string(buf))
+}
@@ -0,0 +1,15 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package syslogencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/syslogencodingextension"
+
+type Config struct {
+	// EnableOctetCounting frames the messages with their length, as in RFC 6587, instead of
+	// separating them with newlines. Messages containing newlines can only be marshaled
+	// when it is enabled.
+	EnableOctetCounting bool `mapstructure:"enable_octet_counting"`
+}
+
+func (c *Config) Validate() error {
+	return nil
+}
@@ -0,0 +1,38 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package syslogencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/syslogencodingextension"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding"
+)
+
+var (
+	_ encoding.LogsMarshalerExtension   = (*syslogExtension)(nil)
+	_ encoding.LogsUnmarshalerExtension = (*syslogExtension)(nil)
+)
+
+type syslogExtension struct {
+	syslogCodec syslogLogCodec
+}
+
+func (e *syslogExtension) UnmarshalLogs(buf []byte) (plog.Logs, error) {
+	return e.syslogCodec.UnmarshalLogs(buf)
+}
+
+func (e *syslogExtension) MarshalLogs(ld plog.Logs) ([]byte, error) {
+	return e.syslogCodec.MarshalLogs(ld)
+}
+
+func (e *syslogExtension) Start(_ context.Context, _ component.Host) error {
+	return nil
+}
+
+func (e *syslogExtension) Shutdown(_ context.Context) error {
+	return nil
+}
@@ -0,0 +1,31 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package syslogencodingextension
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+)
+
+func TestExtension(t *testing.T) {
+	factory := NewFactory()
+	ext, err := factory.CreateExtension(context.Background(), extensiontest.NewNopCreateSettings(), factory.CreateDefaultConfig())
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, ext.Shutdown(context.Background()))
+	}()
+
+	data := "<13>1 2024-03-15T17:29:37Z host app - - - started\n"
+	ld, err := ext.(*syslogExtension).UnmarshalLogs([]byte(data))
+	require.NoError(t, err)
+	buf, err := ext.(*syslogExtension).MarshalLogs(ld)
+	require.NoError(t, err)
+	assert.Equal(t, data, string(buf))
+}
@@ -0,0 +1,32 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package syslogencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/syslogencodingextension"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/syslogencodingextension/internal/metadata"
+)
+
+func NewFactory() extension.Factory {
+	return extension.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		createExtension,
+		metadata.ExtensionStability,
+	)
+}
+
+func createExtension(_ context.Context, _ extension.CreateSettings, config component.Config) (extension.Extension, error) {
+	return &syslogExtension{
+		syslogCodec: syslogLogCodec{octetCounting: config.(*Config).EnableOctetCounting},
+	}, nil
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{}
+}
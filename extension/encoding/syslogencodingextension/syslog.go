@@ -0,0 +1,360 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package syslogencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/syslogencodingextension"
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/go-syslog/v3/rfc5424"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+const (
+	attributeHostname       = "hostname"
+	attributeAppname        = "appname"
+	attributeProcID         = "proc_id"
+	attributeMsgID          = "msg_id"
+	attributePriority       = "priority"
+	attributeFacility       = "facility"
+	attributeVersion        = "version"
+	attributeStructuredData = "structured_data"
+
+	// defaultFacility is the user-level messages facility.
+	defaultFacility = 1
+)
+
+var severityNumbers = [...]plog.SeverityNumber{
+	0: plog.SeverityNumberFatal,
+	1: plog.SeverityNumberError3,
+	2: plog.SeverityNumberError2,
+	3: plog.SeverityNumberError,
+	4: plog.SeverityNumberWarn,
+	5: plog.SeverityNumberInfo2,
+	6: plog.SeverityNumberInfo,
+	7: plog.SeverityNumberDebug,
+}
+
+var severityTexts = [...]string{
+	0: "emerg",
+	1: "alert",
+	2: "crit",
+	3: "err",
+	4: "warning",
+	5: "notice",
+	6: "info",
+	7: "debug",
+}
+
+// syslogLogCodec converts RFC 5424 syslog messages, separated by newlines or framed with their
+// length, to log records. The message is the body of the log record, and the header fields and
+// structured data are its attributes, named as in the syslog parser operator.
+type syslogLogCodec struct {
+	// octetCounting frames the messages with their length, as in RFC 6587.
+	octetCounting bool
+}
+
+func (c *syslogLogCodec) UnmarshalLogs(buf []byte) (plog.Logs, error) {
+	p := plog.NewLogs()
+	logRecords := p.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	observedTime := pcommon.NewTimestampFromTime(time.Now())
+
+	parser := rfc5424.NewParser()
+	appendLogRecord := func(data []byte) error {
+		message, err := parser.Parse(data)
+		if err != nil {
+			return err
+		}
+		logRecord := logRecords.AppendEmpty()
+		logRecord.SetObservedTimestamp(observedTime)
+		setLogRecord(logRecord, message.(*rfc5424.SyslogMessage))
+		return nil
+	}
+
+	if c.octetCounting {
+		messages, err := splitOctetCounted(buf)
+		if err != nil {
+			return p, err
+		}
+		for i, message := range messages {
+			if err = appendLogRecord(message); err != nil {
+				return p, fmt.Errorf("failed to parse syslog message %d: %w", i+1, err)
+			}
+		}
+		return p, nil
+	}
+
+	for i, line := range bytes.Split(buf, []byte("\n")) {
+		line = bytes.TrimSuffix(line, []byte("\r"))
+		if len(line) == 0 {
+			continue
+		}
+		if err := appendLogRecord(line); err != nil {
+			return p, fmt.Errorf("failed to parse syslog message on line %d: %w", i+1, err)
+		}
+	}
+	return p, nil
+}
+
+// splitOctetCounted splits messages framed with their length, as in RFC 6587.
+func splitOctetCounted(buf []byte) ([][]byte, error) {
+	var messages [][]byte
+	for len(buf) > 0 {
+		n := bytes.IndexByte(buf, ' ')
+		if n <= 0 || buf[0] == '0' || !isDigits(buf[:n]) {
+			return nil, fmt.Errorf("missing length of syslog message %d", len(messages)+1)
+		}
+		length, err := strconv.Atoi(string(buf[:n]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid length of syslog message %d: %w", len(messages)+1, err)
+		}
+		buf = buf[n+1:]
+		if length > len(buf) {
+			return nil, fmt.Errorf("syslog message %d is truncated, expected %d bytes, got %d", len(messages)+1, length, len(buf))
+		}
+		messages = append(messages, buf[:length])
+		buf = buf[length:]
+	}
+	return messages, nil
+}
+
+func isDigits(b []byte) bool {
+	for _, c := range b {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func setLogRecord(logRecord plog.LogRecord, message *rfc5424.SyslogMessage) {
+	if message.Timestamp != nil {
+		logRecord.SetTimestamp(pcommon.NewTimestampFromTime(*message.Timestamp))
+	}
+	if message.Severity != nil && int(*message.Severity) < len(severityNumbers) {
+		logRecord.SetSeverityNumber(severityNumbers[*message.Severity])
+		logRecord.SetSeverityText(severityTexts[*message.Severity])
+	}
+	if message.Message != nil {
+		logRecord.Body().SetStr(*message.Message)
+	}
+
+	attributes := logRecord.Attributes()
+	putStr := func(key string, value *string) {
+		if value != nil {
+			attributes.PutStr(key, *value)
+		}
+	}
+	putStr(attributeHostname, message.Hostname)
+	putStr(attributeAppname, message.Appname)
+	putStr(attributeProcID, message.ProcID)
+	putStr(attributeMsgID, message.MsgID)
+	if message.Priority != nil {
+		attributes.PutInt(attributePriority, int64(*message.Priority))
+	}
+	if message.Facility != nil {
+		attributes.PutInt(attributeFacility, int64(*message.Facility))
+	}
+	attributes.PutInt(attributeVersion, int64(message.Version))
+	if message.StructuredData != nil {
+		structuredData := attributes.PutEmptyMap(attributeStructuredData)
+		for id, params := range *message.StructuredData {
+			element := structuredData.PutEmptyMap(id)
+			for name, value := range params {
+				element.PutStr(name, value)
+			}
+		}
+	}
+}
+
+func (c *syslogLogCodec) MarshalLogs(ld plog.Logs) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		scopeLogs := ld.ResourceLogs().At(i).ScopeLogs()
+		for j := 0; j < scopeLogs.Len(); j++ {
+			logRecords := scopeLogs.At(j).LogRecords()
+			for k := 0; k < logRecords.Len(); k++ {
+				message, err := toSyslogMessage(logRecords.At(k))
+				if err != nil {
+					return nil, err
+				}
+				line, err := message.String()
+				if err != nil {
+					return nil, err
+				}
+				if c.octetCounting {
+					buf.WriteString(strconv.Itoa(len(line)))
+					buf.WriteByte(' ')
+					buf.WriteString(line)
+					continue
+				}
+				if strings.Contains(line, "\n") {
+					return nil, errors.New("syslog message contains a newline, enable_octet_counting must be set to marshal it")
+				}
+				buf.WriteString(line)
+				buf.WriteByte('\n')
+			}
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func toSyslogMessage(logRecord plog.LogRecord) (*rfc5424.SyslogMessage, error) {
+	attributes := logRecord.Attributes()
+	message := &rfc5424.SyslogMessage{}
+
+	facility := int64(defaultFacility)
+	if value, ok := attributes.Get(attributeFacility); ok {
+		if value.Type() != pcommon.ValueTypeInt || value.Int() < 0 || value.Int() > 23 {
+			return nil, fmt.Errorf("invalid facility %q", value.AsString())
+		}
+		facility = value.Int()
+	}
+	message.SetPriority(uint8(facility*8 + int64(toSeverity(logRecord.SeverityNumber()))))
+	message.SetVersion(1)
+
+	timestamp := logRecord.Timestamp()
+	if timestamp == 0 {
+		timestamp = logRecord.ObservedTimestamp()
+	}
+	if timestamp != 0 {
+		t := timestamp.AsTime()
+		message.Timestamp = &t
+	}
+
+	var err error
+	if message.Hostname, err = headerField(attributes, attributeHostname, 255); err != nil {
+		return nil, err
+	}
+	if message.Appname, err = headerField(attributes, attributeAppname, 48); err != nil {
+		return nil, err
+	}
+	if message.ProcID, err = headerField(attributes, attributeProcID, 128); err != nil {
+		return nil, err
+	}
+	if message.MsgID, err = headerField(attributes, attributeMsgID, 32); err != nil {
+		return nil, err
+	}
+	if message.StructuredData, err = structuredData(attributes); err != nil {
+		return nil, err
+	}
+
+	if logRecord.Body().Type() != pcommon.ValueTypeEmpty {
+		body := logRecord.Body().AsString()
+		message.Message = &body
+	}
+	return message, nil
+}
+
+// toSeverity is the inverse of severityNumbers. Unspecified severities are informational.
+func toSeverity(severityNumber plog.SeverityNumber) uint8 {
+	switch {
+	case severityNumber >= plog.SeverityNumberFatal:
+		return 0
+	case severityNumber >= plog.SeverityNumberError3:
+		return 1
+	case severityNumber == plog.SeverityNumberError2:
+		return 2
+	case severityNumber == plog.SeverityNumberError:
+		return 3
+	case severityNumber >= plog.SeverityNumberWarn:
+		return 4
+	case severityNumber >= plog.SeverityNumberInfo2:
+		return 5
+	case severityNumber == plog.SeverityNumberInfo, severityNumber == plog.SeverityNumberUnspecified:
+		return 6
+	default:
+		return 7
+	}
+}
+
+// headerField returns the value of a header field attribute, which must be made of at most
+// maxLen printable US-ASCII characters.
+func headerField(attributes pcommon.Map, key string, maxLen int) (*string, error) {
+	value, ok := attributes.Get(key)
+	if !ok {
+		return nil, nil
+	}
+	s := value.AsString()
+	if s == "" || s == "-" {
+		return nil, nil
+	}
+	if !isName(s, maxLen) {
+		return nil, fmt.Errorf("invalid %s %q: must be at most %d printable US-ASCII characters", key, s, maxLen)
+	}
+	return &s, nil
+}
+
+func structuredData(attributes pcommon.Map) (*map[string]map[string]string, error) {
+	value, ok := attributes.Get(attributeStructuredData)
+	if !ok {
+		return nil, nil
+	}
+	if value.Type() != pcommon.ValueTypeMap {
+		return nil, fmt.Errorf("invalid %s: expected 'Map' found '%v'", attributeStructuredData, value.Type().String())
+	}
+
+	elements := make(map[string]map[string]string, value.Map().Len())
+	var err error
+	value.Map().Range(func(id string, paramsValue pcommon.Value) bool {
+		if !isSDName(id) {
+			err = fmt.Errorf("invalid structured data ID %q", id)
+			return false
+		}
+		if paramsValue.Type() != pcommon.ValueTypeMap {
+			err = fmt.Errorf("invalid structured data element %q: expected 'Map' found '%v'", id, paramsValue.Type().String())
+			return false
+		}
+		params := make(map[string]string, paramsValue.Map().Len())
+		paramsValue.Map().Range(func(name string, paramValue pcommon.Value) bool {
+			if !isSDName(name) {
+				err = fmt.Errorf("invalid structured data parameter name %q", name)
+				return false
+			}
+			params[name] = paramValue.AsString()
+			return true
+		})
+		elements[id] = params
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(elements) == 0 {
+		return nil, nil
+	}
+	return &elements, nil
+}
+
+func isName(s string, maxLen int) bool {
+	if len(s) > maxLen {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < 33 || s[i] > 126 {
+			return false
+		}
+	}
+	return true
+}
+
+// isSDName checks the structured data IDs and parameter names, which cannot contain '=', ' ',
+// ']' and '"'.
+func isSDName(s string) bool {
+	if s == "" || !isName(s, 32) {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '=', ']', '"':
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package syslogencodingextension
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestSyslogUnmarshaler(t *testing.T) {
+	t.Parallel()
+	codec := &syslogLogCodec{}
+	ld, err := codec.UnmarshalLogs([]byte("<34>1 2003-10-11T22:14:15.003Z mymachine.example.com su - ID47 [exampleSDID@32473 iut=\"3\" eventSource=\"Application\"] 'su root' failed\r\n" +
+		"\n" +
+		"<165>1 2003-08-24T05:14:15.000003-07:00 192.0.2.1 myproc 8710 - -\n"))
+	require.NoError(t, err)
+	require.Equal(t, 2, ld.LogRecordCount())
+
+	logRecords := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	first := logRecords.At(0)
+	assert.Equal(t, time.Date(2003, 10, 11, 22, 14, 15, 3000000, time.UTC), first.Timestamp().AsTime())
+	assert.NotZero(t, first.ObservedTimestamp())
+	assert.Equal(t, plog.SeverityNumberError2, first.SeverityNumber())
+	assert.Equal(t, "crit", first.SeverityText())
+	assert.Equal(t, "'su root' failed", first.Body().Str())
+	assert.Equal(t, map[string]any{
+		"hostname": "mymachine.example.com",
+		"appname":  "su",
+		"msg_id":   "ID47",
+		"priority": int64(34),
+		"facility": int64(4),
+		"version":  int64(1),
+		"structured_data": map[string]any{
+			"exampleSDID@32473": map[string]any{
+				"iut":         "3",
+				"eventSource": "Application",
+			},
+		},
+	}, first.Attributes().AsRaw())
+
+	second := logRecords.At(1)
+	assert.Equal(t, time.Date(2003, 8, 24, 12, 14, 15, 3000, time.UTC), second.Timestamp().AsTime().UTC())
+	assert.Equal(t, plog.SeverityNumberInfo2, second.SeverityNumber())
+	assert.Equal(t, pcommon.ValueTypeEmpty, second.Body().Type())
+	assert.Equal(t, map[string]any{
+		"hostname": "192.0.2.1",
+		"appname":  "myproc",
+		"proc_id":  "8710",
+		"priority": int64(165),
+		"facility": int64(20),
+		"version":  int64(1),
+	}, second.Attributes().AsRaw())
+}
+
+func TestInvalidUnmarshal(t *testing.T) {
+	t.Parallel()
+	codec := &syslogLogCodec{}
+	_, err := codec.UnmarshalLogs([]byte("<34>1 2003-10-11T22:14:15.003Z host app - - - valid\nnot syslog\n"))
+	assert.ErrorContains(t, err, "failed to parse syslog message on line 2")
+
+	codec = &syslogLogCodec{octetCounting: true}
+	_, err = codec.UnmarshalLogs([]byte("51 <34>1 2003-10-11T22:14:15.003Z host app - - - valid\n"))
+	assert.EqualError(t, err, "missing length of syslog message 2")
+	_, err = codec.UnmarshalLogs([]byte("60 <34>1 2003-10-11T22:14:15.003Z host app - - - truncated"))
+	assert.EqualError(t, err, "syslog message 1 is truncated, expected 60 bytes, got 55")
+	_, err = codec.UnmarshalLogs([]byte("10 not syslog"))
+	assert.ErrorContains(t, err, "failed to parse syslog message 1")
+}
+
+func TestSyslogMarshaler(t *testing.T) {
+	t.Parallel()
+	ld := plog.NewLogs()
+	logRecords := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+
+	logRecord := logRecords.AppendEmpty()
+	logRecord.SetTimestamp(pcommon.NewTimestampFromTime(time.Date(2024, 3, 15, 17, 29, 37, 123456789, time.UTC)))
+	logRecord.SetSeverityNumber(plog.SeverityNumberWarn2)
+	logRecord.Body().SetStr("disk almost full")
+	logRecord.Attributes().PutStr("hostname", "node-1")
+	logRecord.Attributes().PutStr("appname", "agent")
+	logRecord.Attributes().PutInt("proc_id", 42)
+	logRecord.Attributes().PutInt("facility", 3)
+	require.NoError(t, logRecord.Attributes().PutEmptyMap("structured_data").FromRaw(map[string]any{
+		"disk@32473": map[string]any{"path": "/var", "usage": 0.95, "note": `quoted "]`},
+	}))
+
+	logRecord = logRecords.AppendEmpty()
+	logRecord.SetObservedTimestamp(pcommon.NewTimestampFromTime(time.Date(2024, 3, 15, 17, 29, 38, 0, time.UTC)))
+	logRecord.Body().SetEmptyMap().PutStr("key", "value")
+
+	codec := &syslogLogCodec{}
+	buf, err := codec.MarshalLogs(ld)
+	require.NoError(t, err)
+	assert.Equal(t, `<28>1 2024-03-15T17:29:37.123456Z node-1 agent 42 - [disk@32473 note="quoted \"\]" path="/var" usage="0.95"] disk almost full`+"\n"+
+		`<14>1 2024-03-15T17:29:38Z - - - - - {"key":"value"}`+"\n", string(buf))
+}
+
+func TestSyslogRoundTrip(t *testing.T) {
+	t.Parallel()
+	codec := &syslogLogCodec{}
+	data := "<34>1 2003-10-11T22:14:15.003Z mymachine.example.com su - ID47 [exampleSDID@32473 eventSource=\"Application\" iut=\"3\"] 'su root' failed\n" +
+		"<191>1 2003-08-24T12:14:15.000003Z 192.0.2.1 myproc 8710 - -\n"
+	ld, err := codec.UnmarshalLogs([]byte(data))
+	require.NoError(t, err)
+	buf, err := codec.MarshalLogs(ld)
+	require.NoError(t, err)
+	assert.Equal(t, data, string(buf))
+}
+
+func TestSyslogOctetCountingRoundTrip(t *testing.T) {
+	t.Parallel()
+	codec := &syslogLogCodec{octetCounting: true}
+	ld := plog.NewLogs()
+	logRecord := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	logRecord.SetTimestamp(pcommon.NewTimestampFromTime(time.Date(2024, 3, 15, 17, 29, 37, 0, time.UTC)))
+	logRecord.Body().SetStr("panic: runtime error\n\ngoroutine 1 [running]:\nmain.main()\n")
+	logRecord.Attributes().PutStr("appname", "app")
+
+	buf, err := codec.MarshalLogs(ld)
+	require.NoError(t, err)
+	assert.Equal(t, "96 <14>1 2024-03-15T17:29:37Z - app - - - panic: runtime error\n\ngoroutine 1 [running]:\nmain.main()\n", string(buf))
+
+	data := string(buf) + "60 <191>1 2003-08-24T12:14:15.000003Z 192.0.2.1 myproc 8710 - -"
+	unmarshaled, err := codec.UnmarshalLogs([]byte(data))
+	require.NoError(t, err)
+	require.Equal(t, 2, unmarshaled.LogRecordCount())
+	logRecords := unmarshaled.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	assert.Equal(t, "panic: runtime error\n\ngoroutine 1 [running]:\nmain.main()\n", logRecords.At(0).Body().Str())
+	assert.Equal(t, "myproc", logRecords.At(1).Attributes().AsRaw()["appname"])
+
+	buf, err = codec.MarshalLogs(unmarshaled)
+	require.NoError(t, err)
+	assert.Equal(t, data, string(buf))
+}
+
+func TestInvalidMarshal(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		attributes  map[string]any
+		expectedErr string
+	}{
+		{
+			name:        "invalid facility",
+			attributes:  map[string]any{"facility": 24},
+			expectedErr: `invalid facility "24"`,
+		},
+		{
+			name:        "invalid hostname",
+			attributes:  map[string]any{"hostname": "my host"},
+			expectedErr: `invalid hostname "my host": must be at most 255 printable US-ASCII characters`,
+		},
+		{
+			name:        "invalid structured data",
+			attributes:  map[string]any{"structured_data": "key=value"},
+			expectedErr: "invalid structured_data: expected 'Map' found 'Str'",
+		},
+		{
+			name:        "invalid structured data ID",
+			attributes:  map[string]any{"structured_data": map[string]any{"my id": map[string]any{}}},
+			expectedErr: `invalid structured data ID "my id"`,
+		},
+		{
+			name:        "invalid structured data parameter",
+			attributes:  map[string]any{"structured_data": map[string]any{"id": map[string]any{"a=b": "c"}}},
+			expectedErr: `invalid structured data parameter name "a=b"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ld := plog.NewLogs()
+			logRecord := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+			require.NoError(t, logRecord.Attributes().FromRaw(tt.attributes))
+			_, err := (&syslogLogCodec{}).MarshalLogs(ld)
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestMarshalNewlineWithoutOctetCounting(t *testing.T) {
+	t.Parallel()
+	ld := plog.NewLogs()
+	logRecord := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	logRecord.Body().SetStr("first line\nsecond line")
+	_, err := (&syslogLogCodec{}).MarshalLogs(ld)
+	assert.EqualError(t, err, "syslog message contains a newline, enable_octet_counting must be set to marshal it")
+}
@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthcheckextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension"
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension/internal/status"
+)
+
+// componentHealth decides whether the aggregated statuses of the components are healthy.
+type componentHealth struct {
+	config     ComponentHealthSettings
+	aggregator *status.Aggregator
+	now        func() time.Time
+}
+
+func newComponentHealth(config ComponentHealthSettings) *componentHealth {
+	return &componentHealth{
+		config:     config,
+		aggregator: status.NewAggregator(),
+		now:        time.Now,
+	}
+}
+
+// healthy returns whether a status is healthy. Errors are healthy unless they are included,
+// and recoverable errors are given RecoveryDuration to recover.
+func (ch *componentHealth) healthy(event *component.StatusEvent) bool {
+	switch event.Status() {
+	case component.StatusOK:
+		return true
+	case component.StatusRecoverableError:
+		return !ch.config.IncludeRecoverableErrors || ch.now().Before(ch.recoveryDeadline(event))
+	case component.StatusPermanentError:
+		return !ch.config.IncludePermanentErrors
+	default:
+		return false
+	}
+}
+
+// recoveryDeadline returns when a recoverable error becomes unhealthy.
+func (ch *componentHealth) recoveryDeadline(event *component.StatusEvent) time.Time {
+	return event.Timestamp().Add(ch.config.RecoveryDuration)
+}
+
+// statusCode returns the HTTP status code of a status: 200 when it is healthy, 503 when
+// the collector is starting or stopping, and 500 on errors.
+func (ch *componentHealth) statusCode(event *component.StatusEvent) int {
+	switch {
+	case ch.healthy(event):
+		return http.StatusOK
+	case component.StatusIsError(event.Status()):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusServiceUnavailable
+	}
+}
+
+type componentHealthResponse struct {
+	Healthy    bool                                `json:"healthy"`
+	Status     string                              `json:"status"`
+	Error      string                              `json:"error,omitempty"`
+	StatusTime time.Time                           `json:"status_time"`
+	Components map[string]*componentHealthResponse `json:"components,omitempty"`
+}
+
+func (ch *componentHealth) toResponse(st *status.AggregateStatus) *componentHealthResponse {
+	resp := &componentHealthResponse{
+		Healthy:    ch.healthy(st.StatusEvent),
+		Status:     st.Status().String(),
+		StatusTime: st.Timestamp(),
+	}
+	if err := st.Err(); err != nil {
+		resp.Error = err.Error()
+	}
+	if len(st.ComponentStatusMap) > 0 {
+		resp.Components = make(map[string]*componentHealthResponse, len(st.ComponentStatusMap))
+		for key, componentStatus := range st.ComponentStatusMap {
+			resp.Components[key] = ch.toResponse(componentStatus)
+		}
+	}
+	return resp
+}
+
+// handler serves the status of the collector, or of the pipeline set with the pipeline query
+// parameter. The statuses of the pipelines and components are included with the verbose
+// query parameter.
+func (ch *componentHealth) handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		group := ""
+		if pipeline := query.Get("pipeline"); pipeline != "" {
+			group = status.PipelineKey(pipeline)
+		}
+		st, ok := ch.aggregator.AggregateStatus(group, query.Has("verbose"))
+		if !ok {
+			http.Error(w, "unknown pipeline", http.StatusNotFound)
+			return
+		}
+
+		body, err := json.Marshal(ch.toResponse(st))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(ch.statusCode(st.StatusEvent))
+		_, _ = w.Write(body)
+	})
+}
@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthcheckextension
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+)
+
+var (
+	receiverID = &component.InstanceID{
+		ID:          component.MustNewID("otlp"),
+		Kind:        component.KindReceiver,
+		PipelineIDs: map[component.ID]struct{}{component.MustNewID("traces"): {}, component.MustNewID("metrics"): {}},
+	}
+	exporterID = &component.InstanceID{
+		ID:          component.MustNewID("otlp"),
+		Kind:        component.KindExporter,
+		PipelineIDs: map[component.ID]struct{}{component.MustNewID("traces"): {}},
+	}
+)
+
+func TestComponentHealthHealthy(t *testing.T) {
+	now := time.Now()
+	recoverable := component.NewRecoverableErrorEvent(errors.New("connection refused"))
+	permanent := component.NewPermanentErrorEvent(errors.New("invalid credentials"))
+
+	tests := []struct {
+		name     string
+		config   ComponentHealthSettings
+		event    *component.StatusEvent
+		now      time.Time
+		expected bool
+	}{
+		{
+			name:     "ok",
+			event:    component.NewStatusEvent(component.StatusOK),
+			now:      now,
+			expected: true,
+		},
+		{
+			name:     "starting",
+			event:    component.NewStatusEvent(component.StatusStarting),
+			now:      now,
+			expected: false,
+		},
+		{
+			name:     "stopped",
+			event:    component.NewStatusEvent(component.StatusStopped),
+			now:      now,
+			expected: false,
+		},
+		{
+			name:     "fatal error",
+			event:    component.NewFatalErrorEvent(errors.New("port in use")),
+			now:      now,
+			expected: false,
+		},
+		{
+			name:     "permanent error not included",
+			event:    permanent,
+			now:      now,
+			expected: true,
+		},
+		{
+			name:     "permanent error included",
+			config:   ComponentHealthSettings{IncludePermanentErrors: true},
+			event:    permanent,
+			now:      now,
+			expected: false,
+		},
+		{
+			name:     "recoverable error not included",
+			event:    recoverable,
+			now:      recoverable.Timestamp().Add(time.Hour),
+			expected: true,
+		},
+		{
+			name:     "recoverable error within recovery duration",
+			config:   ComponentHealthSettings{IncludeRecoverableErrors: true, RecoveryDuration: time.Minute},
+			event:    recoverable,
+			now:      recoverable.Timestamp().Add(30 * time.Second),
+			expected: true,
+		},
+		{
+			name:     "recoverable error after recovery duration",
+			config:   ComponentHealthSettings{IncludeRecoverableErrors: true, RecoveryDuration: time.Minute},
+			event:    recoverable,
+			now:      recoverable.Timestamp().Add(time.Minute),
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch := newComponentHealth(tt.config)
+			ch.now = func() time.Time { return tt.now }
+			assert.Equal(t, tt.expected, ch.healthy(tt.event))
+		})
+	}
+}
+
+func TestComponentHealthHandler(t *testing.T) {
+	ch := newComponentHealth(ComponentHealthSettings{IncludePermanentErrors: true})
+	handler := ch.handler()
+	get := func(target string) (int, *componentHealthResponse) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		if rec.Code == http.StatusNotFound {
+			return rec.Code, nil
+		}
+		resp := &componentHealthResponse{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), resp))
+		return rec.Code, resp
+	}
+
+	code, resp := get("/")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "StatusStarting", resp.Status)
+
+	code, _ = get("/?pipeline=traces")
+	assert.Equal(t, http.StatusNotFound, code)
+
+	ch.aggregator.RecordStatus(receiverID, component.NewStatusEvent(component.StatusOK))
+	ch.aggregator.RecordStatus(exporterID, component.NewPermanentErrorEvent(errors.New("invalid credentials")))
+
+	code, resp = get("/")
+	assert.Equal(t, http.StatusInternalServerError, code)
+	assert.False(t, resp.Healthy)
+	assert.Equal(t, "StatusPermanentError", resp.Status)
+	assert.Equal(t, "invalid credentials", resp.Error)
+	assert.Empty(t, resp.Components)
+
+	code, resp = get("/?pipeline=metrics")
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, resp.Healthy)
+
+	code, resp = get("/?pipeline=traces&verbose")
+	assert.Equal(t, http.StatusInternalServerError, code)
+	require.Len(t, resp.Components, 2)
+	assert.True(t, resp.Components["receiver:otlp"].Healthy)
+	assert.False(t, resp.Components["exporter:otlp"].Healthy)
+	assert.Equal(t, "invalid credentials", resp.Components["exporter:otlp"].Error)
+
+	code, resp = get("/?verbose")
+	assert.Equal(t, http.StatusInternalServerError, code)
+	require.Len(t, resp.Components, 2)
+	assert.False(t, resp.Components["pipeline:traces"].Healthy)
+	assert.True(t, resp.Components["pipeline:metrics"].Healthy)
+	assert.Len(t, resp.Components["pipeline:traces"].Components, 2)
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
 )
 
@@ -37,6 +38,32 @@ type Config struct {
 
 	// CheckCollectorPipeline contains the list of settings of collector pipeline health check
 	CheckCollectorPipeline checkCollectorPipelineSettings `mapstructure:"check_collector_pipeline"`
+
+	// ComponentHealth contains the settings of the component health check, which reports the
+	// status of the pipelines and components of the collector.
+	ComponentHealth ComponentHealthSettings `mapstructure:"component_health"`
+}
+
+// ComponentHealthSettings configures the component health check. When enabled, the health
+// of the collector is aggregated from the status events reported by its components.
+type ComponentHealthSettings struct {
+	// Enabled indicates whether to report the health of the pipelines and components.
+	Enabled bool `mapstructure:"enabled"`
+
+	// IncludePermanentErrors makes a component reporting a permanent error unhealthy.
+	IncludePermanentErrors bool `mapstructure:"include_permanent_errors"`
+
+	// IncludeRecoverableErrors makes a component reporting a recoverable error unhealthy
+	// once it has not recovered for RecoveryDuration.
+	IncludeRecoverableErrors bool `mapstructure:"include_recoverable_errors"`
+
+	// RecoveryDuration is the grace period given to a component to recover from a
+	// recoverable error. The default value is 5m.
+	RecoveryDuration time.Duration `mapstructure:"recovery_duration"`
+
+	// GRPC configures the grpc.health.v1 Health service. The service is not started when
+	// it is not set.
+	GRPC *configgrpc.ServerConfig `mapstructure:"grpc"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -44,6 +71,9 @@ var (
 	errNoEndpointProvided                      = errors.New("bad config: endpoint must be specified")
 	errInvalidExporterFailureThresholdProvided = errors.New("bad config: exporter_failure_threshold expects a positive number")
 	errInvalidPath                             = errors.New("bad config: path must start with /")
+	errComponentHealthWithCollectorPipeline    = errors.New("bad config: component_health and check_collector_pipeline cannot be enabled together")
+	errInvalidRecoveryDuration                 = errors.New("bad config: recovery_duration must not be negative")
+	errNoGRPCEndpointProvided                  = errors.New("bad config: grpc endpoint must be specified")
 )
 
 // Validate checks if the extension configuration is valid
@@ -61,6 +91,17 @@ func (cfg *Config) Validate() error {
 	if !strings.HasPrefix(cfg.Path, "/") {
 		return errInvalidPath
 	}
+	if cfg.ComponentHealth.Enabled {
+		if cfg.CheckCollectorPipeline.Enabled {
+			return errComponentHealthWithCollectorPipeline
+		}
+		if cfg.ComponentHealth.RecoveryDuration < 0 {
+			return errInvalidRecoveryDuration
+		}
+		if cfg.ComponentHealth.GRPC != nil && cfg.ComponentHealth.GRPC.NetAddr.Endpoint == "" {
+			return errNoGRPCEndpointProvided
+		}
+	}
 	return nil
 }
 
@@ -6,11 +6,14 @@ package healthcheckextension
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 
@@ -43,6 +46,7 @@ func TestLoadConfig(t *testing.T) {
 					},
 				},
 				CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+				ComponentHealth:        defaultComponentHealthSettings(),
 				Path:                   "/",
 				ResponseBody:           nil,
 			},
@@ -59,6 +63,39 @@ func TestLoadConfig(t *testing.T) {
 			id:          component.NewIDWithName(metadata.Type, "invalidpath"),
 			expectedErr: errInvalidPath,
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "componenthealth"),
+			expected: &Config{
+				ServerConfig: confighttp.ServerConfig{
+					Endpoint: "localhost:13",
+				},
+				CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+				ComponentHealth: ComponentHealthSettings{
+					Enabled:                  true,
+					IncludePermanentErrors:   true,
+					IncludeRecoverableErrors: true,
+					RecoveryDuration:         time.Minute,
+					GRPC: &configgrpc.ServerConfig{
+						NetAddr: confignet.AddrConfig{
+							Endpoint: "localhost:14",
+						},
+					},
+				},
+				Path: "/",
+			},
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "componenthealthwithcollectorpipeline"),
+			expectedErr: errComponentHealthWithCollectorPipeline,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalidrecoveryduration"),
+			expectedErr: errInvalidRecoveryDuration,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "missinggrpcendpoint"),
+			expectedErr: errNoGRPCEndpointProvided,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
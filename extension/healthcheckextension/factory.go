@@ -5,6 +5,7 @@ package healthcheckextension // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
@@ -14,7 +15,10 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/localhostgate"
 )
 
-const defaultPort = 13133
+const (
+	defaultPort             = 13133
+	defaultRecoveryDuration = 5 * time.Minute
+)
 
 // NewFactory creates a factory for HealthCheck extension.
 func NewFactory() extension.Factory {
@@ -32,6 +36,7 @@ func createDefaultConfig() component.Config {
 			Endpoint: localhostgate.EndpointForPort(defaultPort),
 		},
 		CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+		ComponentHealth:        defaultComponentHealthSettings(),
 		Path:                   "/",
 	}
 }
@@ -50,3 +55,11 @@ func defaultCheckCollectorPipelineSettings() checkCollectorPipelineSettings {
 		ExporterFailureThreshold: 5,
 	}
 }
+
+// defaultComponentHealthSettings returns the default settings for ComponentHealth.
+func defaultComponentHealthSettings() ComponentHealthSettings {
+	return ComponentHealthSettings{
+		Enabled:          false,
+		RecoveryDuration: defaultRecoveryDuration,
+	}
+}
@@ -23,6 +23,7 @@ func TestFactory_CreateDefaultConfig(t *testing.T) {
 			Endpoint: "0.0.0.0:13133",
 		},
 		CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+		ComponentHealth:        defaultComponentHealthSettings(),
 		Path:                   "/",
 	}, cfg)
 
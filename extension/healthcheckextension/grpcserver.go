@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthcheckextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	grpcstatus "google.golang.org/grpc/status"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension/internal/status"
+)
+
+// healthServer implements the grpc.health.v1 Health service. The empty service is the
+// collector, and the other services are its pipelines, e.g. "traces/2".
+type healthServer struct {
+	healthpb.UnimplementedHealthServer
+	health *componentHealth
+}
+
+var _ healthpb.HealthServer = (*healthServer)(nil)
+
+func (s *healthServer) Check(_ context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	st, ok := s.health.aggregator.AggregateStatus(serviceGroup(req.Service), false)
+	if !ok {
+		return nil, grpcstatus.Error(codes.NotFound, "unknown service")
+	}
+	return &healthpb.HealthCheckResponse{Status: s.servingStatus(st.StatusEvent)}, nil
+}
+
+// Watch sends the serving status of the service whenever it changes, including when a
+// recoverable error has not recovered within the recovery duration.
+func (s *healthServer) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	notifications, unsubscribe := s.health.aggregator.Subscribe()
+	defer unsubscribe()
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	<-timer.C
+
+	lastStatus := healthpb.HealthCheckResponse_UNKNOWN
+	for {
+		servingStatus := healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+		if st, ok := s.health.aggregator.AggregateStatus(serviceGroup(req.Service), false); ok {
+			servingStatus = s.servingStatus(st.StatusEvent)
+			if servingStatus == healthpb.HealthCheckResponse_SERVING &&
+				st.Status() == component.StatusRecoverableError && s.health.config.IncludeRecoverableErrors {
+				if !timer.Stop() {
+					select {
+					case <-timer.C:
+					default:
+					}
+				}
+				timer.Reset(time.Until(s.health.recoveryDeadline(st.StatusEvent)))
+			}
+		}
+
+		if servingStatus != lastStatus {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: servingStatus}); err != nil {
+				return grpcstatus.Error(codes.Canceled, "stream has ended")
+			}
+			lastStatus = servingStatus
+		}
+
+		select {
+		case <-notifications:
+		case <-timer.C:
+		case <-stream.Context().Done():
+			return grpcstatus.Error(codes.Canceled, "stream has ended")
+		}
+	}
+}
+
+func (s *healthServer) servingStatus(event *component.StatusEvent) healthpb.HealthCheckResponse_ServingStatus {
+	if s.health.healthy(event) {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
+
+func serviceGroup(service string) string {
+	if service == "" {
+		return ""
+	}
+	return status.PipelineKey(service)
+}
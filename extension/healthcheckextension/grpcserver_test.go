@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthcheckextension
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/confignet"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	grpcstatus "google.golang.org/grpc/status"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+)
+
+func startGRPCHealthCheck(t *testing.T, settings ComponentHealthSettings) (*healthCheckExtension, healthpb.HealthClient) {
+	grpcEndpoint := testutil.GetAvailableLocalAddress(t)
+	settings.Enabled = true
+	settings.GRPC = &configgrpc.ServerConfig{
+		NetAddr: confignet.AddrConfig{
+			Endpoint: grpcEndpoint,
+		},
+	}
+	config := Config{
+		ServerConfig: confighttp.ServerConfig{
+			Endpoint: testutil.GetAvailableLocalAddress(t),
+		},
+		CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+		ComponentHealth:        settings,
+		Path:                   "/",
+	}
+
+	hcExt := newServer(config, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, hcExt.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, hcExt.Shutdown(context.Background())) })
+
+	conn, err := grpc.Dial(grpcEndpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, conn.Close()) })
+	return hcExt, healthpb.NewHealthClient(conn)
+}
+
+func TestGRPCHealthCheck(t *testing.T) {
+	hcExt, client := startGRPCHealthCheck(t, ComponentHealthSettings{IncludePermanentErrors: true})
+	check := func(service string) (healthpb.HealthCheckResponse_ServingStatus, error) {
+		resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			return healthpb.HealthCheckResponse_UNKNOWN, err
+		}
+		return resp.Status, nil
+	}
+
+	servingStatus, err := check("")
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatus)
+
+	_, err = check("traces")
+	assert.Equal(t, codes.NotFound, grpcstatus.Code(err))
+
+	hcExt.ComponentStatusChanged(receiverID, component.NewStatusEvent(component.StatusOK))
+	hcExt.ComponentStatusChanged(exporterID, component.NewStatusEvent(component.StatusOK))
+	servingStatus, err = check("")
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, servingStatus)
+
+	hcExt.ComponentStatusChanged(exporterID, component.NewPermanentErrorEvent(errors.New("invalid credentials")))
+	servingStatus, err = check("traces")
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatus)
+	servingStatus, err = check("metrics")
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, servingStatus)
+}
+
+func TestGRPCHealthWatch(t *testing.T) {
+	hcExt, client := startGRPCHealthCheck(t, ComponentHealthSettings{
+		IncludeRecoverableErrors: true,
+		RecoveryDuration:         100 * time.Millisecond,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "traces"})
+	require.NoError(t, err)
+	recv := func() healthpb.HealthCheckResponse_ServingStatus {
+		resp, errRecv := stream.Recv()
+		require.NoError(t, errRecv)
+		return resp.Status
+	}
+
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVICE_UNKNOWN, recv())
+
+	hcExt.ComponentStatusChanged(receiverID, component.NewStatusEvent(component.StatusOK))
+	hcExt.ComponentStatusChanged(exporterID, component.NewStatusEvent(component.StatusOK))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, recv())
+
+	// The recoverable error is healthy until the recovery duration has elapsed.
+	start := time.Now()
+	hcExt.ComponentStatusChanged(exporterID, component.NewRecoverableErrorEvent(errors.New("connection refused")))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, recv())
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+
+	hcExt.ComponentStatusChanged(exporterID, component.NewStatusEvent(component.StatusOK))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, recv())
+}
@@ -14,23 +14,29 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension/internal/healthcheck"
 )
 
 type healthCheckExtension struct {
-	config   Config
-	logger   *zap.Logger
-	state    *healthcheck.HealthCheck
-	server   *http.Server
-	stopCh   chan struct{}
-	exporter *healthCheckExporter
-	settings component.TelemetrySettings
+	config          Config
+	logger          *zap.Logger
+	state           *healthcheck.HealthCheck
+	server          *http.Server
+	stopCh          chan struct{}
+	exporter        *healthCheckExporter
+	componentHealth *componentHealth
+	grpcServer      *grpc.Server
+	grpcStopCh      chan struct{}
+	settings        component.TelemetrySettings
 }
 
 var _ extension.PipelineWatcher = (*healthCheckExtension)(nil)
+var _ extension.StatusWatcher = (*healthCheckExtension)(nil)
 
-func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) error {
+func (hc *healthCheckExtension) Start(ctx context.Context, host component.Host) error {
 
 	hc.logger.Info("Starting health_check extension", zap.Any("config", hc.config))
 	ln, err := hc.config.ToListener()
@@ -43,7 +49,24 @@ func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) er
 		return err
 	}
 
-	if !hc.config.CheckCollectorPipeline.Enabled {
+	if hc.config.ComponentHealth.Enabled {
+		if err = hc.startGRPCServer(ctx, host); err != nil {
+			_ = ln.Close()
+			return err
+		}
+
+		mux := http.NewServeMux()
+		mux.Handle(hc.config.Path, hc.componentHealth.handler())
+		hc.server.Handler = mux
+		hc.stopCh = make(chan struct{})
+		go func() {
+			defer close(hc.stopCh)
+
+			if errHTTP := hc.server.Serve(ln); !errors.Is(errHTTP, http.ErrServerClosed) && errHTTP != nil {
+				hc.settings.ReportStatus(component.NewFatalErrorEvent(errHTTP))
+			}
+		}()
+	} else if !hc.config.CheckCollectorPipeline.Enabled {
 		// Mount HC handler
 		mux := http.NewServeMux()
 		mux.Handle(hc.config.Path, hc.baseHandler())
@@ -99,6 +122,39 @@ func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) er
 	return nil
 }
 
+// startGRPCServer starts the grpc.health.v1 Health service, if it is configured.
+func (hc *healthCheckExtension) startGRPCServer(ctx context.Context, host component.Host) error {
+	grpcConfig := hc.config.ComponentHealth.GRPC
+	if grpcConfig == nil {
+		return nil
+	}
+
+	netAddr := grpcConfig.NetAddr
+	if netAddr.Transport == "" {
+		netAddr.Transport = "tcp"
+	}
+	ln, err := netAddr.Listen(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", netAddr.Endpoint, err)
+	}
+	hc.grpcServer, err = grpcConfig.ToServerContext(ctx, host, hc.settings)
+	if err != nil {
+		_ = ln.Close()
+		return err
+	}
+	healthpb.RegisterHealthServer(hc.grpcServer, &healthServer{health: hc.componentHealth})
+
+	hc.grpcStopCh = make(chan struct{})
+	go func() {
+		defer close(hc.grpcStopCh)
+
+		if errGRPC := hc.grpcServer.Serve(ln); !errors.Is(errGRPC, grpc.ErrServerStopped) && errGRPC != nil {
+			hc.settings.ReportStatus(component.NewFatalErrorEvent(errGRPC))
+		}
+	}()
+	return nil
+}
+
 // base handler function
 func (hc *healthCheckExtension) baseHandler() http.Handler {
 	if hc.config.ResponseBody != nil {
@@ -137,6 +193,11 @@ func (hc *healthCheckExtension) check() bool {
 }
 
 func (hc *healthCheckExtension) Shutdown(context.Context) error {
+	if hc.grpcServer != nil {
+		// Stop rather than GracefulStop, Watch streams only end with their connection.
+		hc.grpcServer.Stop()
+		<-hc.grpcStopCh
+	}
 	if hc.server == nil {
 		return nil
 	}
@@ -157,6 +218,14 @@ func (hc *healthCheckExtension) NotReady() error {
 	return nil
 }
 
+// ComponentStatusChanged records the status of the components for the component health check.
+func (hc *healthCheckExtension) ComponentStatusChanged(source *component.InstanceID, event *component.StatusEvent) {
+	if hc.componentHealth == nil {
+		return
+	}
+	hc.componentHealth.aggregator.RecordStatus(source, event)
+}
+
 func newServer(config Config, settings component.TelemetrySettings) *healthCheckExtension {
 	hc := &healthCheckExtension{
 		config:   config,
@@ -166,6 +235,9 @@ func newServer(config Config, settings component.TelemetrySettings) *healthCheck
 	}
 
 	hc.state.SetLogger(settings.Logger)
+	if config.ComponentHealth.Enabled {
+		hc.componentHealth = newComponentHealth(config.ComponentHealth)
+	}
 
 	return hc
 }
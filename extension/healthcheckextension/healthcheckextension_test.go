@@ -5,6 +5,7 @@ package healthcheckextension
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net"
 	"net/http"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/confighttp"
 
@@ -303,6 +305,61 @@ func TestHealthCheckExtensionUsage(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "WithComponentHealth",
+			config: Config{
+				ServerConfig: confighttp.ServerConfig{
+					Endpoint: testutil.GetAvailableLocalAddress(t),
+				},
+				CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+				ComponentHealth: ComponentHealthSettings{
+					Enabled:                true,
+					IncludePermanentErrors: true,
+					RecoveryDuration:       defaultRecoveryDuration,
+				},
+				Path: "/",
+			},
+			teststeps: []teststep{
+				{
+					expectedStatusCode: http.StatusServiceUnavailable,
+					expectedBody:       `"status":"StatusStarting"`,
+				},
+				{
+					step: func(hcExt *healthCheckExtension) error {
+						hcExt.ComponentStatusChanged(receiverID, component.NewStatusEvent(component.StatusOK))
+						hcExt.ComponentStatusChanged(exporterID, component.NewStatusEvent(component.StatusOK))
+						return nil
+					},
+					expectedStatusCode: http.StatusOK,
+					expectedBody:       `"status":"StatusOK"`,
+				},
+				{
+					step: func(hcExt *healthCheckExtension) error {
+						hcExt.ComponentStatusChanged(exporterID, component.NewRecoverableErrorEvent(errors.New("connection refused")))
+						return nil
+					},
+					expectedStatusCode: http.StatusOK,
+					expectedBody:       `"status":"StatusRecoverableError","error":"connection refused"`,
+				},
+				{
+					step: func(hcExt *healthCheckExtension) error {
+						hcExt.ComponentStatusChanged(exporterID, component.NewPermanentErrorEvent(errors.New("invalid credentials")))
+						return nil
+					},
+					expectedStatusCode: http.StatusInternalServerError,
+					expectedBody:       `"healthy":false,"status":"StatusPermanentError","error":"invalid credentials"`,
+				},
+				{
+					step: func(hcExt *healthCheckExtension) error {
+						hcExt.ComponentStatusChanged(receiverID, component.NewStatusEvent(component.StatusStopping))
+						hcExt.ComponentStatusChanged(exporterID, component.NewStatusEvent(component.StatusStopping))
+						return nil
+					},
+					expectedStatusCode: http.StatusServiceUnavailable,
+					expectedBody:       `"status":"StatusStopping"`,
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
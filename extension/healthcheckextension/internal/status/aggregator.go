@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package status // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension/internal/status"
+
+import (
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// ExtensionsGroup holds the status of the components which are not part of any pipeline,
+// i.e. the extensions.
+const ExtensionsGroup = "extensions"
+
+// AggregateStatus is the aggregated status of the collector, a pipeline or a component.
+// ComponentStatusMap holds the statuses it was aggregated from, when they were requested.
+type AggregateStatus struct {
+	*component.StatusEvent
+	ComponentStatusMap map[string]*AggregateStatus
+}
+
+// Aggregator records the status events of the components per pipeline, and aggregates them
+// into the status of the pipelines and of the collector.
+type Aggregator struct {
+	mu sync.RWMutex
+	// groups maps a pipeline (or the extensions group) to the last status event of its
+	// components.
+	groups map[string]map[string]*component.StatusEvent
+	// subscriptions are notified whenever a status event is recorded.
+	subscriptions map[chan struct{}]struct{}
+}
+
+// NewAggregator creates an Aggregator without any recorded status.
+func NewAggregator() *Aggregator {
+	return &Aggregator{
+		groups:        map[string]map[string]*component.StatusEvent{},
+		subscriptions: map[chan struct{}]struct{}{},
+	}
+}
+
+// RecordStatus records the status event of a component in every pipeline it is part of.
+func (a *Aggregator) RecordStatus(source *component.InstanceID, event *component.StatusEvent) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	key := ComponentKey(source.Kind.String(), source.ID)
+	groups := make([]string, 0, len(source.PipelineIDs))
+	for pipelineID := range source.PipelineIDs {
+		groups = append(groups, PipelineKey(pipelineID.String()))
+	}
+	if len(groups) == 0 {
+		groups = append(groups, ExtensionsGroup)
+	}
+
+	for _, group := range groups {
+		components, ok := a.groups[group]
+		if !ok {
+			components = map[string]*component.StatusEvent{}
+			a.groups[group] = components
+		}
+		components[key] = event
+	}
+
+	for ch := range a.subscriptions {
+		select {
+		case ch <- struct{}{}:
+		default:
+			// A notification is already pending.
+		}
+	}
+}
+
+// AggregateStatus returns the status of a group, or of the collector when group is empty.
+// When verbose is set, the statuses of the pipelines and components are included. It returns
+// false when no component of the group has reported its status.
+func (a *Aggregator) AggregateStatus(group string, verbose bool) (*AggregateStatus, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if group != "" {
+		components, ok := a.groups[group]
+		if !ok {
+			return nil, false
+		}
+		return aggregateGroup(components, verbose), true
+	}
+
+	// No component has reported its status yet, the collector is starting.
+	if len(a.groups) == 0 {
+		return &AggregateStatus{StatusEvent: component.NewStatusEvent(component.StatusStarting)}, true
+	}
+
+	events := make(map[string]*component.StatusEvent, len(a.groups))
+	status := &AggregateStatus{}
+	if verbose {
+		status.ComponentStatusMap = make(map[string]*AggregateStatus, len(a.groups))
+	}
+	for group, components := range a.groups {
+		groupStatus := aggregateGroup(components, verbose)
+		events[group] = groupStatus.StatusEvent
+		if verbose {
+			status.ComponentStatusMap[group] = groupStatus
+		}
+	}
+	status.StatusEvent = component.AggregateStatusEvent(events)
+	return status, true
+}
+
+// Subscribe returns a channel which receives a notification when a status event is recorded,
+// and a function to stop the notifications. Notifications are coalesced, so the current
+// status must be requested after each notification.
+func (a *Aggregator) Subscribe() (<-chan struct{}, func()) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	ch := make(chan struct{}, 1)
+	a.subscriptions[ch] = struct{}{}
+	return ch, func() {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		delete(a.subscriptions, ch)
+	}
+}
+
+func aggregateGroup(components map[string]*component.StatusEvent, verbose bool) *AggregateStatus {
+	status := &AggregateStatus{StatusEvent: component.AggregateStatusEvent(components)}
+	if verbose {
+		status.ComponentStatusMap = make(map[string]*AggregateStatus, len(components))
+		for key, event := range components {
+			status.ComponentStatusMap[key] = &AggregateStatus{StatusEvent: event}
+		}
+	}
+	return status
+}
+
+// PipelineKey returns the group of a pipeline, e.g. "pipeline:traces/2".
+func PipelineKey(pipeline string) string {
+	return "pipeline:" + pipeline
+}
+
+// ComponentKey returns the key of a component, e.g. "receiver:otlp/2".
+func ComponentKey(kind string, id component.ID) string {
+	return strings.ToLower(kind) + ":" + id.String()
+}
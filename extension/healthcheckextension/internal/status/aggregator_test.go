@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package status
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+)
+
+func newInstanceID(kind component.Kind, id string, pipelines ...string) *component.InstanceID {
+	instanceID := &component.InstanceID{
+		ID:          component.MustNewID(id),
+		Kind:        kind,
+		PipelineIDs: map[component.ID]struct{}{},
+	}
+	for _, pipeline := range pipelines {
+		instanceID.PipelineIDs[component.MustNewID(pipeline)] = struct{}{}
+	}
+	return instanceID
+}
+
+func TestAggregateStatusStarting(t *testing.T) {
+	a := NewAggregator()
+	st, ok := a.AggregateStatus("", true)
+	require.True(t, ok)
+	assert.Equal(t, component.StatusStarting, st.Status())
+	assert.Empty(t, st.ComponentStatusMap)
+
+	_, ok = a.AggregateStatus(PipelineKey("traces"), false)
+	assert.False(t, ok)
+}
+
+func TestAggregateStatus(t *testing.T) {
+	a := NewAggregator()
+	receiver := newInstanceID(component.KindReceiver, "otlp", "traces", "metrics")
+	exporter := newInstanceID(component.KindExporter, "otlp", "traces")
+	ext := newInstanceID(component.KindExtension, "health_check")
+
+	for _, source := range []*component.InstanceID{receiver, exporter, ext} {
+		a.RecordStatus(source, component.NewStatusEvent(component.StatusStarting))
+		a.RecordStatus(source, component.NewStatusEvent(component.StatusOK))
+	}
+
+	st, ok := a.AggregateStatus("", false)
+	require.True(t, ok)
+	assert.Equal(t, component.StatusOK, st.Status())
+	assert.Empty(t, st.ComponentStatusMap)
+
+	st, ok = a.AggregateStatus("", true)
+	require.True(t, ok)
+	require.Len(t, st.ComponentStatusMap, 3)
+	assert.Len(t, st.ComponentStatusMap["pipeline:traces"].ComponentStatusMap, 2)
+	assert.Len(t, st.ComponentStatusMap["pipeline:metrics"].ComponentStatusMap, 1)
+	assert.Contains(t, st.ComponentStatusMap[ExtensionsGroup].ComponentStatusMap, "extension:health_check")
+
+	a.RecordStatus(exporter, component.NewPermanentErrorEvent(errors.New("invalid credentials")))
+	st, ok = a.AggregateStatus("", false)
+	require.True(t, ok)
+	assert.Equal(t, component.StatusPermanentError, st.Status())
+	assert.EqualError(t, st.Err(), "invalid credentials")
+
+	st, ok = a.AggregateStatus(PipelineKey("traces"), true)
+	require.True(t, ok)
+	assert.Equal(t, component.StatusPermanentError, st.Status())
+	assert.Equal(t, component.StatusOK, st.ComponentStatusMap["receiver:otlp"].Status())
+	assert.Equal(t, component.StatusPermanentError, st.ComponentStatusMap["exporter:otlp"].Status())
+
+	st, ok = a.AggregateStatus(PipelineKey("metrics"), false)
+	require.True(t, ok)
+	assert.Equal(t, component.StatusOK, st.Status())
+}
+
+func TestSubscribe(t *testing.T) {
+	a := NewAggregator()
+	notifications, unsubscribe := a.Subscribe()
+
+	receiver := newInstanceID(component.KindReceiver, "otlp", "traces")
+	a.RecordStatus(receiver, component.NewStatusEvent(component.StatusStarting))
+	a.RecordStatus(receiver, component.NewStatusEvent(component.StatusOK))
+	<-notifications
+	select {
+	case <-notifications:
+		t.Fatal("notifications are not coalesced")
+	default:
+	}
+
+	unsubscribe()
+	a.RecordStatus(receiver, component.NewStatusEvent(component.StatusStopping))
+	select {
+	case <-notifications:
+		t.Fatal("notified after unsubscribing")
+	default:
+	}
+}
@@ -25,21 +25,27 @@ const (
 	PodType EndpointType = "pod"
 	// K8sServiceType is a service endpoint.
 	K8sServiceType EndpointType = "k8s.service"
+	// K8sIngressType is a Kubernetes Ingress endpoint.
+	K8sIngressType EndpointType = "k8s.ingress"
 	// K8sNodeType is a Kubernetes Node endpoint.
 	K8sNodeType EndpointType = "k8s.node"
 	// HostPortType is a hostport endpoint.
 	HostPortType EndpointType = "hostport"
 	// ContainerType is a container endpoint.
 	ContainerType EndpointType = "container"
+	// StaticType is a statically defined endpoint.
+	StaticType EndpointType = "static"
 )
 
 var (
 	_ EndpointDetails = (*Pod)(nil)
 	_ EndpointDetails = (*Port)(nil)
 	_ EndpointDetails = (*K8sService)(nil)
+	_ EndpointDetails = (*K8sIngress)(nil)
 	_ EndpointDetails = (*K8sNode)(nil)
 	_ EndpointDetails = (*HostPort)(nil)
 	_ EndpointDetails = (*Container)(nil)
+	_ EndpointDetails = (*Static)(nil)
 )
 
 // EndpointDetails provides additional context about an endpoint such as a Pod or Port.
@@ -111,9 +117,32 @@ type K8sService struct {
 	ClusterIP string
 	// ServiceType is the type of the service: ClusterIP, NodePort, LoadBalancer, ExternalName
 	ServiceType string
+	// Ports are the ports exposed by the service.
+	Ports []K8sServicePort
+}
+
+// K8sServicePort is a port exposed by a k8s service.
+type K8sServicePort struct {
+	// Name is the name of the service port.
+	Name string
+	// Port number exposed by the service.
+	Port uint16
+	// NodePort is the port exposed on each node for NodePort and LoadBalancer services.
+	NodePort uint16
+	// Transport is the transport protocol used by the port. (TCP or UDP).
+	Transport Transport
 }
 
 func (s *K8sService) Env() EndpointEnv {
+	ports := make([]map[string]any, 0, len(s.Ports))
+	for _, port := range s.Ports {
+		ports = append(ports, map[string]any{
+			"name":      port.Name,
+			"port":      port.Port,
+			"node_port": port.NodePort,
+			"transport": port.Transport,
+		})
+	}
 	return map[string]any{
 		"uid":          s.UID,
 		"name":         s.Name,
@@ -122,6 +151,7 @@ func (s *K8sService) Env() EndpointEnv {
 		"namespace":    s.Namespace,
 		"cluster_ip":   s.ClusterIP,
 		"service_type": s.ServiceType,
+		"ports":        ports,
 	}
 }
 
@@ -129,6 +159,51 @@ func (s *K8sService) Type() EndpointType {
 	return K8sServiceType
 }
 
+// K8sIngress is a discovered k8s ingress rule. An ingress has an endpoint for each
+// path of its rules.
+type K8sIngress struct {
+	// Name of the ingress.
+	Name string
+	// UID is the unique ID in the cluster for the ingress.
+	UID string
+	// Labels is a map of user-specified metadata.
+	Labels map[string]string
+	// Annotations is a map of user-specified metadata.
+	Annotations map[string]string
+	// Namespace must be unique for ingresses with same name.
+	Namespace string
+	// Scheme is the scheme of the rule: https if its host has a TLS configuration, http otherwise.
+	Scheme string
+	// Host is the host of the rule, or the address of the ingress load balancer if the
+	// rule has no host.
+	Host string
+	// Path is the path of the rule.
+	Path string
+	// ServiceName is the name of the service the path is routed to.
+	ServiceName string
+	// ServicePort is the name or number of the service port the path is routed to.
+	ServicePort string
+}
+
+func (i *K8sIngress) Env() EndpointEnv {
+	return map[string]any{
+		"uid":          i.UID,
+		"name":         i.Name,
+		"labels":       i.Labels,
+		"annotations":  i.Annotations,
+		"namespace":    i.Namespace,
+		"scheme":       i.Scheme,
+		"host":         i.Host,
+		"path":         i.Path,
+		"service_name": i.ServiceName,
+		"service_port": i.ServicePort,
+	}
+}
+
+func (i *K8sIngress) Type() EndpointType {
+	return K8sIngressType
+}
+
 // Pod is a discovered k8s pod.
 type Pod struct {
 	// Name of the pod.
@@ -299,3 +374,25 @@ func (n *K8sNode) Env() EndpointEnv {
 func (n *K8sNode) Type() EndpointType {
 	return K8sNodeType
 }
+
+// Static is an endpoint defined statically, e.g. in an inventory file.
+type Static struct {
+	// Name of the endpoint.
+	Name string
+	// Labels is a map of user-specified metadata.
+	Labels map[string]string
+	// Attributes is a map of arbitrary user-specified details.
+	Attributes map[string]any
+}
+
+func (s *Static) Env() EndpointEnv {
+	return map[string]any{
+		"name":       s.Name,
+		"labels":     s.Labels,
+		"attributes": s.Attributes,
+	}
+}
+
+func (s *Static) Type() EndpointType {
+	return StaticType
+}
@@ -107,6 +107,9 @@ func TestEndpointEnv(t *testing.T) {
 					Namespace:   "service-namespace",
 					ServiceType: "LoadBalancer",
 					ClusterIP:   "192.68.73.2",
+					Ports: []K8sServicePort{
+						{Name: "http", Port: 80, NodePort: 30080, Transport: ProtocolTCP},
+					},
 				},
 			},
 			want: EndpointEnv{
@@ -124,6 +127,51 @@ func TestEndpointEnv(t *testing.T) {
 				"namespace":    "service-namespace",
 				"cluster_ip":   "192.68.73.2",
 				"service_type": "LoadBalancer",
+				"ports": []map[string]any{
+					{"name": "http", "port": uint16(80), "node_port": uint16(30080), "transport": ProtocolTCP},
+				},
+			},
+		},
+		{
+			name: "K8s ingress",
+			endpoint: Endpoint{
+				ID:     EndpointID("ingress_id"),
+				Target: "https://example.com/api",
+				Details: &K8sIngress{
+					Name: "ingress_name",
+					UID:  "ingress-uid",
+					Labels: map[string]string{
+						"label_key": "label_val",
+					},
+					Annotations: map[string]string{
+						"annotation_1": "value_1",
+					},
+					Namespace:   "ingress-namespace",
+					Scheme:      "https",
+					Host:        "example.com",
+					Path:        "/api",
+					ServiceName: "api",
+					ServicePort: "http",
+				},
+			},
+			want: EndpointEnv{
+				"type":     "k8s.ingress",
+				"endpoint": "https://example.com/api",
+				"id":       "ingress_id",
+				"name":     "ingress_name",
+				"labels": map[string]string{
+					"label_key": "label_val",
+				},
+				"annotations": map[string]string{
+					"annotation_1": "value_1",
+				},
+				"uid":          "ingress-uid",
+				"namespace":    "ingress-namespace",
+				"scheme":       "https",
+				"host":         "example.com",
+				"path":         "/api",
+				"service_name": "api",
+				"service_port": "http",
 			},
 		},
 		{
@@ -230,6 +278,36 @@ func TestEndpointEnv(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Static",
+			endpoint: Endpoint{
+				ID:     EndpointID("static_endpoint_id"),
+				Target: "10.0.0.5:5432",
+				Details: &Static{
+					Name: "orders-db",
+					Labels: map[string]string{
+						"label_key": "label_val",
+					},
+					Attributes: map[string]any{
+						"database": "orders",
+						"replicas": 2,
+					},
+				},
+			},
+			want: EndpointEnv{
+				"type":     "static",
+				"id":       "static_endpoint_id",
+				"name":     "orders-db",
+				"endpoint": "10.0.0.5:5432",
+				"labels": map[string]string{
+					"label_key": "label_val",
+				},
+				"attributes": map[string]any{
+					"database": "orders",
+					"replicas": 2,
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileobserver // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/fileobserver"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Config defines configuration for file observer.
+type Config struct {
+	// Include is a list of files, directories and glob patterns of the files defining
+	// endpoints. The files of a directory with a .yaml, .yml or .json extension are read.
+	Include []string `mapstructure:"include"`
+
+	// Endpoints is a list of endpoints defined in the configuration.
+	Endpoints []EndpointConfig `mapstructure:"endpoints"`
+
+	// RefreshInterval determines how frequently the files are checked for changes.
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+}
+
+// EndpointConfig defines an endpoint, in the configuration or in a file.
+type EndpointConfig struct {
+	// ID uniquely identifies the endpoint. The default value is the target.
+	ID string `mapstructure:"id" yaml:"id"`
+
+	// Target is an IP address or hostname of the endpoint, optionally with a port.
+	Target string `mapstructure:"target" yaml:"target"`
+
+	// Name of the endpoint. The default value is the ID.
+	Name string `mapstructure:"name" yaml:"name"`
+
+	// Labels is a map of user-specified metadata.
+	Labels map[string]string `mapstructure:"labels" yaml:"labels"`
+
+	// Attributes is a map of arbitrary details of the endpoint.
+	Attributes map[string]any `mapstructure:"attributes" yaml:"attributes"`
+}
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if len(cfg.Include) == 0 && len(cfg.Endpoints) == 0 {
+		return errors.New("include or endpoints must be set")
+	}
+	if cfg.RefreshInterval <= 0 {
+		return errors.New("refresh_interval must be greater than 0")
+	}
+
+	ids := make(map[string]struct{}, len(cfg.Endpoints))
+	for i, endpoint := range cfg.Endpoints {
+		if err := endpoint.validate(); err != nil {
+			return fmt.Errorf("invalid endpoint %d: %w", i, err)
+		}
+		id := endpoint.id()
+		if _, ok := ids[id]; ok {
+			return fmt.Errorf("duplicate endpoint ID %q", id)
+		}
+		ids[id] = struct{}{}
+	}
+	return nil
+}
+
+func (e *EndpointConfig) validate() error {
+	if e.Target == "" {
+		return errors.New("target must be set")
+	}
+	return nil
+}
+
+func (e *EndpointConfig) id() string {
+	if e.ID != "" {
+		return e.ID
+	}
+	return e.Target
+}
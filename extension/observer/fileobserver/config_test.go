@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileobserver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/fileobserver/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id          component.ID
+		expected    component.Config
+		expectedErr string
+	}{
+		{
+			id:          component.NewID(metadata.Type),
+			expectedErr: "include or endpoints must be set",
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "all_settings"),
+			expected: &Config{
+				Include: []string{"/etc/otelcol/inventory/*.yaml"},
+				Endpoints: []EndpointConfig{
+					{
+						ID:     "orders-db",
+						Target: "10.0.0.5:5432",
+						Name:   "orders",
+						Labels: map[string]string{"role": "postgres"},
+						Attributes: map[string]any{
+							"database": "orders",
+							"replicas": 2,
+						},
+					},
+				},
+				RefreshInterval: 20 * time.Second,
+			},
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "missing_target"),
+			expectedErr: "invalid endpoint 0: target must be set",
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "duplicate_id"),
+			expectedErr: `duplicate endpoint ID "10.0.0.5:5432"`,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_refresh_interval"),
+			expectedErr: "refresh_interval must be greater than 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, component.ValidateConfig(cfg), tt.expectedErr)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileobserver // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/fileobserver"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+type fileObserver struct {
+	*observer.EndpointsWatcher
+}
+
+var _ extension.Extension = (*fileObserver)(nil)
+
+// endpointsFile is the format of the files defining endpoints. JSON files are read as YAML.
+type endpointsFile struct {
+	Endpoints []EndpointConfig `yaml:"endpoints"`
+}
+
+// fileState is the last read state of a file.
+type fileState struct {
+	modTime   time.Time
+	size      int64
+	endpoints []EndpointConfig
+}
+
+type endpointsLister struct {
+	logger    *zap.Logger
+	include   []string
+	endpoints []EndpointConfig
+
+	mu sync.Mutex
+	// files caches the endpoints of the included files, which are only read again when they
+	// change. The endpoints of a file which cannot be read are kept until it is fixed, so that
+	// a file being written does not remove its endpoints.
+	files map[string]*fileState
+}
+
+func newObserver(params extension.CreateSettings, config *Config) *fileObserver {
+	return &fileObserver{
+		EndpointsWatcher: observer.NewEndpointsWatcher(
+			newEndpointsLister(params.Logger, config),
+			config.RefreshInterval,
+			params.Logger,
+		),
+	}
+}
+
+func (f *fileObserver) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (f *fileObserver) Shutdown(context.Context) error {
+	f.StopListAndWatch()
+	return nil
+}
+
+func newEndpointsLister(logger *zap.Logger, config *Config) *endpointsLister {
+	return &endpointsLister{
+		logger:    logger,
+		include:   config.Include,
+		endpoints: config.Endpoints,
+		files:     map[string]*fileState{},
+	}
+}
+
+// ListEndpoints returns the endpoints of the configuration followed by the endpoints of the
+// included files, in lexical order of their path. The first endpoint with a given ID is
+// kept.
+func (e *endpointsLister) ListEndpoints() []observer.Endpoint {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	paths := e.paths()
+	files := make(map[string]*fileState, len(paths))
+	for _, path := range paths {
+		if state := e.readFile(path); state != nil {
+			files[path] = state
+		}
+	}
+	e.files = files
+
+	var endpoints []observer.Endpoint
+	ids := map[observer.EndpointID]string{}
+	add := func(source string, endpointConfigs []EndpointConfig) {
+		for _, endpointConfig := range endpointConfigs {
+			endpoint := toEndpoint(endpointConfig)
+			if previousSource, ok := ids[endpoint.ID]; ok {
+				e.logger.Warn("Ignoring duplicate endpoint",
+					zap.String("id", string(endpoint.ID)), zap.String("source", source), zap.String("defined_in", previousSource))
+				continue
+			}
+			ids[endpoint.ID] = source
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	add("config", e.endpoints)
+	for _, path := range paths {
+		if state, ok := files[path]; ok {
+			add(path, state.endpoints)
+		}
+	}
+	return endpoints
+}
+
+// paths returns the sorted paths of the included files.
+func (e *endpointsLister) paths() []string {
+	seen := map[string]struct{}{}
+	for _, include := range e.include {
+		matches, err := filepath.Glob(include)
+		if err != nil {
+			e.logger.Warn("Invalid include pattern", zap.String("include", include), zap.Error(err))
+			continue
+		}
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			if err != nil {
+				continue
+			}
+			if !info.IsDir() {
+				seen[match] = struct{}{}
+				continue
+			}
+			entries, err := os.ReadDir(match)
+			if err != nil {
+				e.logger.Warn("Failed to read directory", zap.String("path", match), zap.Error(err))
+				continue
+			}
+			for _, entry := range entries {
+				if !entry.IsDir() && isEndpointsFile(entry.Name()) {
+					seen[filepath.Join(match, entry.Name())] = struct{}{}
+				}
+			}
+		}
+	}
+
+	paths := make([]string, 0, len(seen))
+	for path := range seen {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// readFile returns the state of a file, reading it if it changed since it was last read.
+// It returns nil if the file does not exist anymore.
+func (e *endpointsLister) readFile(path string) *fileState {
+	info, err := os.Stat(path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			e.logger.Warn("Failed to read endpoints file", zap.String("path", path), zap.Error(err))
+		}
+		return nil
+	}
+
+	state, ok := e.files[path]
+	if ok && state.modTime.Equal(info.ModTime()) && state.size == info.Size() {
+		return state
+	}
+	if !ok {
+		state = &fileState{}
+	}
+
+	// The file is read again until it is parsed, e.g. once it is completely written.
+	endpoints, err := parseEndpointsFile(path)
+	if err != nil {
+		e.logger.Warn("Failed to read endpoints file, keeping its previous endpoints", zap.String("path", path), zap.Error(err))
+		return state
+	}
+	state.modTime = info.ModTime()
+	state.size = info.Size()
+	state.endpoints = endpoints
+	return state
+}
+
+func parseEndpointsFile(path string) ([]EndpointConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file endpointsFile
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err = decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	for i, endpoint := range file.Endpoints {
+		if err = endpoint.validate(); err != nil {
+			return nil, fmt.Errorf("invalid endpoint %d: %w", i, err)
+		}
+	}
+	return file.Endpoints, nil
+}
+
+func isEndpointsFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	default:
+		return false
+	}
+}
+
+func toEndpoint(endpointConfig EndpointConfig) observer.Endpoint {
+	id := endpointConfig.id()
+	name := endpointConfig.Name
+	if name == "" {
+		name = id
+	}
+	return observer.Endpoint{
+		ID:     observer.EndpointID(id),
+		Target: endpointConfig.Target,
+		Details: &observer.Static{
+			Name:       name,
+			Labels:     endpointConfig.Labels,
+			Attributes: endpointConfig.Attributes,
+		},
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileobserver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+func TestListEndpoints(t *testing.T) {
+	lister := newEndpointsLister(zap.NewNop(), &Config{
+		Include: []string{filepath.Join("testdata", "inventory"), filepath.Join("testdata", "inventory", "*.json")},
+		Endpoints: []EndpointConfig{
+			{ID: "orders-db", Target: "10.0.0.15:5432"},
+			{Target: "localhost:9100"},
+		},
+	})
+
+	assert.Equal(t, []observer.Endpoint{
+		{
+			ID:      "orders-db",
+			Target:  "10.0.0.15:5432",
+			Details: &observer.Static{Name: "orders-db"},
+		},
+		{
+			ID:      "localhost:9100",
+			Target:  "localhost:9100",
+			Details: &observer.Static{Name: "localhost:9100"},
+		},
+		{
+			ID:     "10.0.0.6:3306",
+			Target: "10.0.0.6:3306",
+			Details: &observer.Static{
+				Name:   "users",
+				Labels: map[string]string{"role": "mysql"},
+			},
+		},
+		{
+			ID:     "node-1",
+			Target: "10.0.1.1:9100",
+			Details: &observer.Static{
+				Name:       "node-1",
+				Labels:     map[string]string{"role": "node"},
+				Attributes: map[string]any{"rack": "r1", "cores": 32},
+			},
+		},
+	}, lister.ListEndpoints())
+}
+
+func TestListEndpointsFileChanges(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "inventory.yaml")
+	lister := newEndpointsLister(zap.NewNop(), &Config{Include: []string{dir}})
+	assert.Empty(t, lister.ListEndpoints())
+
+	writeFile(t, path, "endpoints:\n  - target: 10.0.0.5:5432\n")
+	endpoints := lister.ListEndpoints()
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, observer.EndpointID("10.0.0.5:5432"), endpoints[0].ID)
+
+	writeFile(t, path, "endpoints:\n  - target: 10.0.0.5:5432\n    labels:\n      role: postgres\n")
+	endpoints = lister.ListEndpoints()
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, map[string]string{"role": "postgres"}, endpoints[0].Details.(*observer.Static).Labels)
+
+	// The endpoints of an invalid file are kept until it is fixed.
+	writeFile(t, path, "endpoints:\n  - target: 10.0.0.5:5432\n    unknown: field\n")
+	assert.Equal(t, endpoints, lister.ListEndpoints())
+	writeFile(t, path, "endpoints:\n  - name: missing target\n")
+	assert.Equal(t, endpoints, lister.ListEndpoints())
+
+	require.NoError(t, os.Remove(path))
+	assert.Empty(t, lister.ListEndpoints())
+}
+
+func TestListEndpointsRetriesInvalidFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "inventory.yaml")
+	lister := newEndpointsLister(zap.NewNop(), &Config{Include: []string{dir}})
+
+	writeFile(t, path, "endpoints:\n  - target: 10.0.0.7:5432\n    unknown: x\n")
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Empty(t, lister.ListEndpoints())
+
+	// The file is read again although its size and modification time did not change.
+	writeFile(t, path, "endpoints:\n  - target: 10.0.0.7:5432\n    name: abcd\n")
+	require.NoError(t, os.Chtimes(path, info.ModTime(), info.ModTime()))
+	endpoints := lister.ListEndpoints()
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, "abcd", endpoints[0].Details.(*observer.Static).Name)
+}
+
+func TestFileObserver(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "inventory.yaml")
+	writeFile(t, path, "endpoints:\n  - target: 10.0.0.5:5432\n")
+
+	config := createDefaultConfig().(*Config)
+	config.Include = []string{filepath.Join(dir, "*.yaml")}
+	config.RefreshInterval = 10 * time.Millisecond
+	ext, err := createExtension(context.Background(), extensiontest.NewNopCreateSettings(), config)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, ext.Shutdown(context.Background()))
+	}()
+
+	notify := &mockNotifier{endpoints: map[observer.EndpointID]observer.Endpoint{}}
+	ext.(observer.Observable).ListAndWatch(notify)
+	defer ext.(observer.Observable).Unsubscribe(notify)
+	require.Len(t, notify.get(), 1)
+
+	writeFile(t, path, "endpoints:\n  - target: 10.0.0.5:5432\n    attributes:\n      database: orders\n  - target: 10.0.0.6:3306\n")
+	require.Eventually(t, func() bool {
+		endpoints := notify.get()
+		return len(endpoints) == 2 &&
+			endpoints["10.0.0.5:5432"].Details.(*observer.Static).Attributes["database"] == "orders"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, os.Remove(path))
+	require.Eventually(t, func() bool {
+		return len(notify.get()) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+// writeFile writes a file with a modification time different from the previous write.
+func writeFile(t *testing.T, path string, content string) {
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	modTime := time.Now().Add(time.Duration(len(content)) * time.Second)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+type mockNotifier struct {
+	mu        sync.Mutex
+	endpoints map[observer.EndpointID]observer.Endpoint
+}
+
+var _ observer.Notify = (*mockNotifier)(nil)
+
+func (m *mockNotifier) ID() observer.NotifyID {
+	return "mockNotifier"
+}
+
+func (m *mockNotifier) OnAdd(added []observer.Endpoint) {
+	m.OnChange(added)
+}
+
+func (m *mockNotifier) OnRemove(removed []observer.Endpoint) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, e := range removed {
+		delete(m.endpoints, e.ID)
+	}
+}
+
+func (m *mockNotifier) OnChange(changed []observer.Endpoint) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, e := range changed {
+		m.endpoints[e.ID] = e
+	}
+}
+
+func (m *mockNotifier) get() map[observer.EndpointID]observer.Endpoint {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	endpoints := make(map[observer.EndpointID]observer.Endpoint, len(m.endpoints))
+	for id, e := range m.endpoints {
+		endpoints[id] = e
+	}
+	return endpoints
+}
@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileobserver // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/fileobserver"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/fileobserver/internal/metadata"
+)
+
+const (
+	defaultRefreshInterval = 10 * time.Second
+)
+
+// NewFactory creates a factory for FileObserver extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		createExtension,
+		metadata.ExtensionStability,
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		RefreshInterval: defaultRefreshInterval,
+	}
+}
+
+func createExtension(
+	_ context.Context,
+	params extension.CreateSettings,
+	cfg component.Config,
+) (extension.Extension, error) {
+	return newObserver(params, cfg.(*Config)), nil
+}
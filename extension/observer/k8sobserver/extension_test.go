@@ -129,6 +129,81 @@ func TestExtensionObserveServices(t *testing.T) {
 	require.NoError(t, ext.Shutdown(context.Background()))
 }
 
+func TestExtensionObserveIngresses(t *testing.T) {
+	factory := NewFactory()
+	config := factory.CreateDefaultConfig().(*Config)
+	config.ObservePods = false // avoid causing data race when multiple test cases running in the same process using podListerWatcher
+	config.ObserveIngresses = true
+	mockServiceHost(t, config)
+
+	set := extensiontest.NewNopCreateSettings()
+	set.ID = component.NewID(metadata.Type)
+	ext, err := newObserver(config, set)
+	require.NoError(t, err)
+	require.NotNil(t, ext)
+
+	obs := ext.(*k8sObserver)
+	require.NotNil(t, obs.ingressListerWatcher)
+	ingressListerWatcher := framework.NewFakeControllerSource()
+	obs.ingressListerWatcher = ingressListerWatcher
+
+	ingress := ingressWithRules.DeepCopy()
+	ingress.Spec.Rules = ingress.Spec.Rules[:1]
+	ingressListerWatcher.Add(ingress)
+
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+
+	sink := &endpointSink{}
+	obs.ListAndWatch(sink)
+
+	requireSink(t, sink, func() bool {
+		return len(sink.added) == 1
+	})
+
+	expected := observer.Endpoint{
+		ID:     "k8s_observer/ingress-1-UID/secure.example.com/api",
+		Target: "https://secure.example.com/api",
+		Details: &observer.K8sIngress{
+			Name:      "ingress-1",
+			Namespace: "default",
+			UID:       "ingress-1-UID",
+			Labels: map[string]string{
+				"env": "prod",
+			},
+			Scheme:      "https",
+			Host:        "secure.example.com",
+			Path:        "/api",
+			ServiceName: "api",
+			ServicePort: "http",
+		},
+	}
+	assert.Equal(t, expected, sink.added[0])
+
+	ingressV2 := ingress.DeepCopy()
+	ingressV2.Labels["ingress-version"] = "2"
+	ingressListerWatcher.Modify(ingressV2)
+
+	requireSink(t, sink, func() bool {
+		return len(sink.changed) == 1
+	})
+
+	expected.Details.(*observer.K8sIngress).Labels = map[string]string{
+		"env":             "prod",
+		"ingress-version": "2",
+	}
+	assert.Equal(t, expected, sink.changed[0])
+
+	ingressListerWatcher.Delete(ingressV2)
+
+	requireSink(t, sink, func() bool {
+		return len(sink.removed) == 1
+	})
+
+	assert.Equal(t, expected, sink.removed[0])
+
+	require.NoError(t, ext.Shutdown(context.Background()))
+}
+
 func TestExtensionObservePods(t *testing.T) {
 	factory := NewFactory()
 	config := factory.CreateDefaultConfig().(*Config)
@@ -9,6 +9,7 @@ import (
 
 	"go.uber.org/zap"
 	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/client-go/tools/cache"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
@@ -40,7 +41,7 @@ func (h *handler) ListEndpoints() []observer.Endpoint {
 	return endpoints
 }
 
-// OnAdd is called in response to a new pod or node being detected.
+// OnAdd is called in response to a new pod, service, ingress or node being detected.
 func (h *handler) OnAdd(objectInterface any, _ bool) {
 	var endpoints []observer.Endpoint
 
@@ -49,6 +50,8 @@ func (h *handler) OnAdd(objectInterface any, _ bool) {
 		endpoints = convertPodToEndpoints(h.idNamespace, object)
 	case *v1.Service:
 		endpoints = convertServiceToEndpoints(h.idNamespace, object)
+	case *networkingv1.Ingress:
+		endpoints = convertIngressToEndpoints(h.idNamespace, object)
 	case *v1.Node:
 		endpoints = append(endpoints, convertNodeToEndpoint(h.idNamespace, object))
 	default: // unsupported
@@ -60,7 +63,7 @@ func (h *handler) OnAdd(objectInterface any, _ bool) {
 	}
 }
 
-// OnUpdate is called in response to an existing pod or node changing.
+// OnUpdate is called in response to an existing pod, service, ingress or node changing.
 func (h *handler) OnUpdate(oldObjectInterface, newObjectInterface any) {
 	oldEndpoints := map[observer.EndpointID]observer.Endpoint{}
 	newEndpoints := map[observer.EndpointID]observer.Endpoint{}
@@ -92,6 +95,19 @@ func (h *handler) OnUpdate(oldObjectInterface, newObjectInterface any) {
 			newEndpoints[e.ID] = e
 		}
 
+	case *networkingv1.Ingress:
+		newIngress, ok := newObjectInterface.(*networkingv1.Ingress)
+		if !ok {
+			h.logger.Warn("skip updating endpoint for ingress as the update is of different type", zap.Any("oldIngress", oldObjectInterface), zap.Any("newObject", newObjectInterface))
+			return
+		}
+		for _, e := range convertIngressToEndpoints(h.idNamespace, oldObject) {
+			oldEndpoints[e.ID] = e
+		}
+		for _, e := range convertIngressToEndpoints(h.idNamespace, newIngress) {
+			newEndpoints[e.ID] = e
+		}
+
 	case *v1.Node:
 		newNode, ok := newObjectInterface.(*v1.Node)
 		if !ok {
@@ -147,7 +163,7 @@ func (h *handler) OnUpdate(oldObjectInterface, newObjectInterface any) {
 	}
 }
 
-// OnDelete is called in response to a pod or node being deleted.
+// OnDelete is called in response to a pod, service, ingress or node being deleted.
 func (h *handler) OnDelete(objectInterface any) {
 	var endpoints []observer.Endpoint
 
@@ -165,6 +181,10 @@ func (h *handler) OnDelete(objectInterface any) {
 		if object != nil {
 			endpoints = convertServiceToEndpoints(h.idNamespace, object)
 		}
+	case *networkingv1.Ingress:
+		if object != nil {
+			endpoints = convertIngressToEndpoints(h.idNamespace, object)
+		}
 	case *v1.Node:
 		if object != nil {
 			endpoints = append(endpoints, convertNodeToEndpoint(h.idNamespace, object))
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	networkingv1 "k8s.io/api/networking/v1"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
 )
@@ -165,6 +166,76 @@ func TestServiceEndpointsChanged(t *testing.T) {
 	}, th.ListEndpoints())
 }
 
+func TestIngressEndpointsAdded(t *testing.T) {
+	th := newTestHandler()
+	th.OnAdd(ingressWithRules, true)
+	assert.ElementsMatch(t, []observer.Endpoint{
+		{
+			ID:     "test-1/ingress-1-UID/secure.example.com/api",
+			Target: "https://secure.example.com/api",
+			Details: &observer.K8sIngress{
+				Name:        "ingress-1",
+				Namespace:   "default",
+				UID:         "ingress-1-UID",
+				Labels:      map[string]string{"env": "prod"},
+				Scheme:      "https",
+				Host:        "secure.example.com",
+				Path:        "/api",
+				ServiceName: "api",
+				ServicePort: "http",
+			},
+		},
+		{
+			ID:     "test-1/ingress-1-UID//",
+			Target: "http://10.0.0.1/",
+			Details: &observer.K8sIngress{
+				Name:        "ingress-1",
+				Namespace:   "default",
+				UID:         "ingress-1-UID",
+				Labels:      map[string]string{"env": "prod"},
+				Scheme:      "http",
+				Host:        "10.0.0.1",
+				Path:        "/",
+				ServiceName: "web",
+				ServicePort: "8080",
+			},
+		}}, th.ListEndpoints())
+}
+
+func TestIngressEndpointsRemoved(t *testing.T) {
+	th := newTestHandler()
+	th.OnAdd(ingressWithRules, true)
+	th.OnDelete(ingressWithRules)
+	assert.Empty(t, th.ListEndpoints())
+}
+
+func TestIngressEndpointsChanged(t *testing.T) {
+	th := newTestHandler()
+	th.OnAdd(ingressWithRules, true)
+
+	// The secure path changed and the rule without a host lost its load balancer address.
+	updatedIngress := ingressWithRules.DeepCopy()
+	updatedIngress.Spec.Rules[0].HTTP.Paths[0].Path = "/v2"
+	updatedIngress.Status = networkingv1.IngressStatus{}
+	th.OnUpdate(ingressWithRules, updatedIngress)
+	require.ElementsMatch(t, []observer.Endpoint{
+		{
+			ID:     "test-1/ingress-1-UID/secure.example.com/v2",
+			Target: "https://secure.example.com/v2",
+			Details: &observer.K8sIngress{
+				Name:        "ingress-1",
+				Namespace:   "default",
+				UID:         "ingress-1-UID",
+				Labels:      map[string]string{"env": "prod"},
+				Scheme:      "https",
+				Host:        "secure.example.com",
+				Path:        "/v2",
+				ServiceName: "api",
+				ServicePort: "http",
+			}},
+	}, th.ListEndpoints())
+}
+
 func TestNodeEndpointsAdded(t *testing.T) {
 	th := newTestHandler()
 	th.OnAdd(node1V1, true)
@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sobserver // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/k8sobserver"
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	v1 "k8s.io/api/networking/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+// convertIngressToEndpoints converts an ingress instance into a slice of endpoints. The endpoints
+// include one endpoint for each path of the ingress rules. Rules with a wildcard host are skipped
+// as they cannot be reached with a single target, as are rules without a host when the ingress
+// has no load balancer address yet.
+func convertIngressToEndpoints(idNamespace string, ingress *v1.Ingress) []observer.Endpoint {
+	var endpoints []observer.Endpoint
+	tlsHosts := getTLSHosts(ingress)
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil || strings.HasPrefix(rule.Host, "*") {
+			continue
+		}
+		host := rule.Host
+		if host == "" {
+			host = getLoadBalancerAddress(ingress)
+			if host == "" {
+				continue
+			}
+		}
+		scheme := "http"
+		if _, ok := tlsHosts[rule.Host]; ok {
+			scheme = "https"
+		}
+
+		for _, path := range rule.HTTP.Paths {
+			details := observer.K8sIngress{
+				UID:         string(ingress.UID),
+				Annotations: ingress.Annotations,
+				Labels:      ingress.Labels,
+				Name:        ingress.Name,
+				Namespace:   ingress.Namespace,
+				Scheme:      scheme,
+				Host:        host,
+				Path:        path.Path,
+			}
+			if service := path.Backend.Service; service != nil {
+				details.ServiceName = service.Name
+				details.ServicePort = getServiceBackendPort(service.Port)
+			}
+
+			endpoints = append(endpoints, observer.Endpoint{
+				ID:      observer.EndpointID(fmt.Sprintf("%s/%s/%s%s", idNamespace, ingress.UID, rule.Host, path.Path)),
+				Target:  (&url.URL{Scheme: scheme, Host: host, Path: path.Path}).String(),
+				Details: &details,
+			})
+		}
+	}
+
+	return endpoints
+}
+
+func getTLSHosts(ingress *v1.Ingress) map[string]struct{} {
+	hosts := map[string]struct{}{}
+	for _, tls := range ingress.Spec.TLS {
+		for _, host := range tls.Hosts {
+			hosts[host] = struct{}{}
+		}
+	}
+	return hosts
+}
+
+// getLoadBalancerAddress returns the first address assigned to the ingress load balancer.
+func getLoadBalancerAddress(ingress *v1.Ingress) string {
+	for _, lb := range ingress.Status.LoadBalancer.Ingress {
+		if lb.Hostname != "" {
+			return lb.Hostname
+		}
+		if lb.IP != "" {
+			return lb.IP
+		}
+	}
+	return ""
+}
+
+func getServiceBackendPort(port v1.ServiceBackendPort) string {
+	if port.Name != "" {
+		return port.Name
+	}
+	if port.Number != 0 {
+		return fmt.Sprint(port.Number)
+	}
+	return ""
+}
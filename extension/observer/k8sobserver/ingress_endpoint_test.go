@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sobserver // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/k8sobserver"
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+func TestIngressObjectToEndpoints(t *testing.T) {
+	expectedEndpoints := []observer.Endpoint{
+		{
+			ID:     "namespace/ingress-1-UID/secure.example.com/api",
+			Target: "https://secure.example.com/api",
+			Details: &observer.K8sIngress{
+				Name:        "ingress-1",
+				Namespace:   "default",
+				UID:         "ingress-1-UID",
+				Labels:      map[string]string{"env": "prod"},
+				Scheme:      "https",
+				Host:        "secure.example.com",
+				Path:        "/api",
+				ServiceName: "api",
+				ServicePort: "http",
+			}},
+		{
+			ID:     "namespace/ingress-1-UID//",
+			Target: "http://10.0.0.1/",
+			Details: &observer.K8sIngress{
+				Name:        "ingress-1",
+				Namespace:   "default",
+				UID:         "ingress-1-UID",
+				Labels:      map[string]string{"env": "prod"},
+				Scheme:      "http",
+				Host:        "10.0.0.1",
+				Path:        "/",
+				ServiceName: "web",
+				ServicePort: "8080",
+			}},
+	}
+
+	endpoints := convertIngressToEndpoints("namespace", ingressWithRules)
+	require.Equal(t, expectedEndpoints, endpoints)
+}
+
+func TestIngressWithoutLoadBalancerObjectToEndpoints(t *testing.T) {
+	ingress := ingressWithRules.DeepCopy()
+	ingress.Status = networkingv1.IngressStatus{}
+
+	endpoints := convertIngressToEndpoints("namespace", ingress)
+	require.Len(t, endpoints, 1)
+	require.Equal(t, observer.EndpointID("namespace/ingress-1-UID/secure.example.com/api"), endpoints[0].ID)
+}
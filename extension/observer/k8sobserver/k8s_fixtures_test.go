@@ -5,6 +5,7 @@ package k8sobserver
 
 import (
 	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -134,6 +135,82 @@ var serviceWithClusterIPV2 = func() *v1.Service {
 	return service
 }()
 
+var serviceWithPorts = func() *v1.Service {
+	service := newService("service-2")
+	service.Spec.Type = v1.ServiceTypeNodePort
+	service.Spec.Ports = []v1.ServicePort{
+		{Name: "http", Port: 80, NodePort: 30080, Protocol: v1.ProtocolTCP},
+		{Name: "dns", Port: 53, NodePort: 30053, Protocol: v1.ProtocolUDP},
+	}
+	return service
+}()
+
+// newIngress is a helper function for creating Ingresses for testing.
+func newIngress(name string) *networkingv1.Ingress {
+	pathType := networkingv1.PathTypePrefix
+	return &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			UID:       types.UID(name + "-UID"),
+			Labels: map[string]string{
+				"env": "prod",
+			},
+		},
+		Spec: networkingv1.IngressSpec{
+			TLS: []networkingv1.IngressTLS{{Hosts: []string{"secure.example.com"}}},
+			Rules: []networkingv1.IngressRule{
+				{
+					Host: "secure.example.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{
+							{
+								Path:     "/api",
+								PathType: &pathType,
+								Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{
+									Name: "api",
+									Port: networkingv1.ServiceBackendPort{Name: "http"},
+								}},
+							},
+						},
+					}},
+				},
+				{
+					Host: "*.example.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{
+							{Path: "/", PathType: &pathType},
+						},
+					}},
+				},
+				{
+					IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{
+							{
+								Path:     "/",
+								PathType: &pathType,
+								Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{
+									Name: "web",
+									Port: networkingv1.ServiceBackendPort{Number: 8080},
+								}},
+							},
+						},
+					}},
+				},
+			},
+		},
+		Status: networkingv1.IngressStatus{
+			LoadBalancer: networkingv1.IngressLoadBalancerStatus{
+				Ingress: []networkingv1.IngressLoadBalancerIngress{{IP: "10.0.0.1"}},
+			},
+		},
+	}
+}
+
+var ingressWithRules = func() *networkingv1.Ingress {
+	return newIngress("ingress-1")
+}()
+
 // newNode is a helper function for creating Nodes for testing.
 func newNode(name, hostname string) *v1.Node {
 	return &v1.Node{
@@ -24,6 +24,7 @@ func convertServiceToEndpoints(idNamespace string, service *v1.Service) []observ
 		Namespace:   service.Namespace,
 		ClusterIP:   service.Spec.ClusterIP,
 		ServiceType: string(service.Spec.Type),
+		Ports:       convertServicePorts(service.Spec.Ports),
 	}
 
 	endpoints := []observer.Endpoint{{
@@ -35,6 +36,22 @@ func convertServiceToEndpoints(idNamespace string, service *v1.Service) []observ
 	return endpoints
 }
 
+func convertServicePorts(servicePorts []v1.ServicePort) []observer.K8sServicePort {
+	if len(servicePorts) == 0 {
+		return nil
+	}
+	ports := make([]observer.K8sServicePort, 0, len(servicePorts))
+	for _, port := range servicePorts {
+		ports = append(ports, observer.K8sServicePort{
+			Name:      port.Name,
+			Port:      uint16(port.Port),
+			NodePort:  uint16(port.NodePort),
+			Transport: getTransport(port.Protocol),
+		})
+	}
+	return ports
+}
+
 func generateServiceTarget(service *observer.K8sService) string {
 	return fmt.Sprintf("%s.%s.svc.cluster.local", service.Name, service.Namespace)
 }
@@ -29,3 +29,26 @@ func TestServiceObjectToEndpoint(t *testing.T) {
 	endpoints := convertServiceToEndpoints("namespace", serviceWithClusterIP)
 	require.Equal(t, expectedEndpoints, endpoints)
 }
+
+func TestServiceWithPortsObjectToEndpoint(t *testing.T) {
+	expectedEndpoints := []observer.Endpoint{
+		{
+			ID:     "namespace/service-2-UID",
+			Target: "service-2.default.svc.cluster.local",
+			Details: &observer.K8sService{
+				Name:        "service-2",
+				Namespace:   "default",
+				UID:         "service-2-UID",
+				Labels:      map[string]string{"env": "prod"},
+				ServiceType: "NodePort",
+				ClusterIP:   "1.2.3.4",
+				Ports: []observer.K8sServicePort{
+					{Name: "http", Port: 80, NodePort: 30080, Transport: observer.ProtocolTCP},
+					{Name: "dns", Port: 53, NodePort: 30053, Transport: observer.ProtocolUDP},
+				},
+			}},
+	}
+
+	endpoints := convertServiceToEndpoints("namespace", serviceWithPorts)
+	require.Equal(t, expectedEndpoints, endpoints)
+}
@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opampextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/opampextension"
+
+import (
+	"path"
+	"runtime/debug"
+	"sort"
+	"strings"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"go.opentelemetry.io/collector/component"
+)
+
+// availableComponentsAttribute is the non-identifying attribute of the agent description
+// listing the components the collector has a factory for.
+const availableComponentsAttribute = "available_components"
+
+// componentKinds are the kinds of components, as used in the paths of their modules.
+var componentKinds = map[string]component.Kind{
+	"receiver":  component.KindReceiver,
+	"processor": component.KindProcessor,
+	"exporter":  component.KindExporter,
+	"extension": component.KindExtension,
+	"connector": component.KindConnector,
+}
+
+// componentKindsOrder is the order in which the kinds of components are reported.
+var componentKindsOrder = []string{"receiver", "processor", "exporter", "extension", "connector"}
+
+// availableComponents returns the components the host has a factory for, grouped by kind,
+// with the version of the module providing them. The host only looks factories up by
+// type, so the candidates are the configured extensions and exporters, and the
+// components of the modules following the collector naming convention, e.g.
+// `receiver/otlpreceiver` provides the `otlp` receiver. The version of a component
+// whose module is not known is empty.
+func availableComponents(host component.Host, modules []*debug.Module) *protobufs.KeyValue {
+	versions := map[string]map[string]string{}
+	addCandidate := func(kind, componentType, version string) {
+		if versions[kind] == nil {
+			versions[kind] = map[string]string{}
+		}
+		if _, ok := versions[kind][componentType]; !ok || version != "" {
+			versions[kind][componentType] = version
+		}
+	}
+	for _, module := range modules {
+		kind, componentType, ok := parseComponentModule(module.Path)
+		if !ok {
+			continue
+		}
+		version := module.Version
+		if module.Replace != nil && module.Replace.Version != "" {
+			version = module.Replace.Version
+		}
+		addCandidate(kind, componentType, version)
+	}
+	for id := range host.GetExtensions() {
+		addCandidate("extension", id.Type().String(), "")
+	}
+	for _, exporters := range host.GetExporters() {
+		for id := range exporters {
+			addCandidate("exporter", id.Type().String(), "")
+		}
+	}
+
+	kinds := make([]*protobufs.KeyValue, 0, len(versions))
+	for _, kind := range componentKindsOrder {
+		types := make([]string, 0, len(versions[kind]))
+		for componentType := range versions[kind] {
+			if hasFactory(host, componentKinds[kind], componentType) {
+				types = append(types, componentType)
+			}
+		}
+		if len(types) == 0 {
+			continue
+		}
+		sort.Strings(types)
+
+		components := make([]*protobufs.KeyValue, 0, len(types))
+		for _, componentType := range types {
+			components = append(components, stringKeyValue(componentType, versions[kind][componentType]))
+		}
+		kinds = append(kinds, kvListKeyValue(kind+"s", components))
+	}
+	return kvListKeyValue(availableComponentsAttribute, kinds)
+}
+
+// hasFactory reports whether the host has a factory for the component type of a kind.
+func hasFactory(host component.Host, kind component.Kind, componentType string) bool {
+	t, err := component.NewType(componentType)
+	if err != nil {
+		return false
+	}
+	return host.GetFactory(kind, t) != nil
+}
+
+// parseComponentModule returns the kind and type of the component provided by a module.
+func parseComponentModule(modulePath string) (string, string, bool) {
+	dir, name := path.Split(modulePath)
+	for _, kind := range componentKindsOrder {
+		if !strings.HasSuffix(dir, "/"+kind+"/") || !strings.HasSuffix(name, kind) || name == kind {
+			continue
+		}
+		return kind, strings.TrimSuffix(name, kind), true
+	}
+	return "", "", false
+}
+
+// buildModules returns the main module and the dependencies of the running binary.
+func buildModules() []*debug.Module {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+	return append([]*debug.Module{&info.Main}, info.Deps...)
+}
+
+func kvListKeyValue(key string, values []*protobufs.KeyValue) *protobufs.KeyValue {
+	return &protobufs.KeyValue{
+		Key: key,
+		Value: &protobufs.AnyValue{
+			Value: &protobufs.AnyValue_KvlistValue{KvlistValue: &protobufs.KeyValueList{Values: values}},
+		},
+	}
+}
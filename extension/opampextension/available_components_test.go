@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opampextension
+
+import (
+	"runtime/debug"
+	"testing"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension"
+	"google.golang.org/protobuf/proto"
+)
+
+// factoriesHost is a host with factories for a set of component types.
+type factoriesHost struct {
+	component.Host
+	factories  map[component.Kind][]string
+	extensions map[component.ID]component.Component
+	exporters  map[component.DataType]map[component.ID]component.Component
+}
+
+func (h *factoriesHost) GetFactory(kind component.Kind, componentType component.Type) component.Factory {
+	for _, t := range h.factories[kind] {
+		if t == componentType.String() {
+			return extension.NewFactory(componentType, nil, nil, component.StabilityLevelDevelopment)
+		}
+	}
+	return nil
+}
+
+func (h *factoriesHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+func (h *factoriesHost) GetExporters() map[component.DataType]map[component.ID]component.Component {
+	return h.exporters
+}
+
+func TestAvailableComponents(t *testing.T) {
+	modules := []*debug.Module{
+		{Path: "go.opentelemetry.io/collector/receiver/otlpreceiver", Version: "v0.96.0"},
+		{Path: "go.opentelemetry.io/collector/receiver", Version: "v0.96.0"},
+		{Path: "go.opentelemetry.io/collector/exporter/otlpexporter", Version: "v0.96.0"},
+		{Path: "go.opentelemetry.io/collector/processor/batchprocessor", Version: "v0.96.0"},
+		{
+			Path:    "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/filelogreceiver",
+			Version: "v0.96.0",
+			Replace: &debug.Module{Path: "../receiver/filelogreceiver"},
+		},
+		{
+			Path:    "github.com/open-telemetry/opentelemetry-collector-contrib/extension/opampextension",
+			Version: "v0.96.0",
+			Replace: &debug.Module{Path: "github.com/fork/opampextension", Version: "v0.96.1"},
+		},
+		// The host has no factory for the components of this module
+		{Path: "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/nopreceiver", Version: "v0.96.0"},
+		{Path: "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza", Version: "v0.96.0"},
+		{Path: "github.com/open-telemetry/opamp-go", Version: "v0.12.0"},
+	}
+	host := &factoriesHost{
+		Host: componenttest.NewNopHost(),
+		factories: map[component.Kind][]string{
+			component.KindReceiver:  {"filelog", "otlp"},
+			component.KindProcessor: {"batch"},
+			component.KindExporter:  {"debug", "otlp"},
+			component.KindExtension: {"health_check", "opamp"},
+		},
+		extensions: map[component.ID]component.Component{
+			component.MustNewID("opamp"):                           nil,
+			component.MustNewIDWithName("health_check", "primary"): nil,
+		},
+		exporters: map[component.DataType]map[component.ID]component.Component{
+			component.DataTypeLogs: {component.MustNewID("debug"): nil},
+		},
+	}
+
+	expected := kvListKeyValue("available_components", []*protobufs.KeyValue{
+		kvListKeyValue("receivers", []*protobufs.KeyValue{
+			stringKeyValue("filelog", "v0.96.0"),
+			stringKeyValue("otlp", "v0.96.0"),
+		}),
+		kvListKeyValue("processors", []*protobufs.KeyValue{
+			stringKeyValue("batch", "v0.96.0"),
+		}),
+		kvListKeyValue("exporters", []*protobufs.KeyValue{
+			stringKeyValue("debug", ""),
+			stringKeyValue("otlp", "v0.96.0"),
+		}),
+		kvListKeyValue("extensions", []*protobufs.KeyValue{
+			stringKeyValue("health_check", ""),
+			stringKeyValue("opamp", "v0.96.1"),
+		}),
+	})
+	actual := availableComponents(host, modules)
+	assert.True(t, proto.Equal(expected, actual), "expected %v, got %v", expected, actual)
+}
@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opampextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/opampextension"
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"go.opentelemetry.io/collector/component"
+)
+
+// extensionsGroup holds the status of the components which are not part of any
+// pipeline, i.e. the extensions.
+const extensionsGroup = "extensions"
+
+// componentStatus is the last status event reported by a component instance.
+type componentStatus struct {
+	event     *component.StatusEvent
+	startTime time.Time
+}
+
+// componentHealth aggregates the status events of the collector components per
+// pipeline into the health reported to the OpAMP server.
+type componentHealth struct {
+	mu        sync.Mutex
+	startTime time.Time
+	// groups maps a pipeline (or the extensions group) to the status of its components.
+	groups map[string]map[string]*componentStatus
+}
+
+func newComponentHealth(startTime time.Time) *componentHealth {
+	return &componentHealth{
+		startTime: startTime,
+		groups:    map[string]map[string]*componentStatus{},
+	}
+}
+
+// setStatus records the status event of a component in every pipeline it is part of.
+func (h *componentHealth) setStatus(source *component.InstanceID, event *component.StatusEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	key := componentKey(source.Kind.String(), source.ID)
+	groups := make([]string, 0, len(source.PipelineIDs))
+	for pipelineID := range source.PipelineIDs {
+		groups = append(groups, componentKey("pipeline", pipelineID))
+	}
+	if len(groups) == 0 {
+		groups = append(groups, extensionsGroup)
+	}
+
+	for _, group := range groups {
+		components, ok := h.groups[group]
+		if !ok {
+			components = map[string]*componentStatus{}
+			h.groups[group] = components
+		}
+		status, ok := components[key]
+		if !ok {
+			status = &componentStatus{}
+			components[key] = status
+		}
+		status.event = event
+		switch event.Status() {
+		case component.StatusStarting:
+			if status.startTime.IsZero() {
+				status.startTime = event.Timestamp()
+			}
+		case component.StatusStopped:
+			status.startTime = time.Time{}
+		}
+	}
+}
+
+// toProto returns the health of the collector including the health of every pipeline
+// and of their components.
+func (h *componentHealth) toProto() *protobufs.ComponentHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	groupEvents := make(map[string]*component.StatusEvent, len(h.groups))
+	groupsHealth := make(map[string]*protobufs.ComponentHealth, len(h.groups))
+	for group, components := range h.groups {
+		var startTime time.Time
+		events := make(map[string]*component.StatusEvent, len(components))
+		componentsHealth := make(map[string]*protobufs.ComponentHealth, len(components))
+		for key, status := range components {
+			events[key] = status.event
+			componentsHealth[key] = statusToHealth(status.event, status.startTime)
+			if !status.startTime.IsZero() && (startTime.IsZero() || status.startTime.Before(startTime)) {
+				startTime = status.startTime
+			}
+		}
+		groupEvents[group] = component.AggregateStatusEvent(events)
+		groupHealth := statusToHealth(groupEvents[group], startTime)
+		groupHealth.ComponentHealthMap = componentsHealth
+		groupsHealth[group] = groupHealth
+	}
+
+	// No component has reported its status yet, the collector is starting.
+	if len(groupEvents) == 0 {
+		return statusToHealth(component.NewStatusEvent(component.StatusStarting), h.startTime)
+	}
+	health := statusToHealth(component.AggregateStatusEvent(groupEvents), h.startTime)
+	health.ComponentHealthMap = groupsHealth
+	return health
+}
+
+func statusToHealth(event *component.StatusEvent, startTime time.Time) *protobufs.ComponentHealth {
+	health := &protobufs.ComponentHealth{
+		Healthy:            event.Status() == component.StatusOK,
+		Status:             event.Status().String(),
+		StatusTimeUnixNano: uint64(event.Timestamp().UnixNano()),
+	}
+	if !startTime.IsZero() {
+		health.StartTimeUnixNano = uint64(startTime.UnixNano())
+	}
+	if err := event.Err(); err != nil {
+		health.LastError = err.Error()
+	}
+	return health
+}
+
+func componentKey(kind string, id component.ID) string {
+	return strings.ToLower(kind) + ":" + id.String()
+}
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opampextension
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+)
+
+func newInstanceID(kind component.Kind, id string, pipelines ...string) *component.InstanceID {
+	instanceID := &component.InstanceID{
+		ID:          component.MustNewID(id),
+		Kind:        kind,
+		PipelineIDs: map[component.ID]struct{}{},
+	}
+	for _, pipeline := range pipelines {
+		instanceID.PipelineIDs[component.MustNewID(pipeline)] = struct{}{}
+	}
+	return instanceID
+}
+
+func TestComponentHealthStarting(t *testing.T) {
+	startTime := time.Now()
+	health := newComponentHealth(startTime).toProto()
+	assert.False(t, health.Healthy)
+	assert.Equal(t, "StatusStarting", health.Status)
+	assert.Equal(t, uint64(startTime.UnixNano()), health.StartTimeUnixNano)
+	assert.Empty(t, health.ComponentHealthMap)
+}
+
+func TestComponentHealth(t *testing.T) {
+	h := newComponentHealth(time.Now())
+	receiver := newInstanceID(component.KindReceiver, "otlp", "traces", "metrics")
+	exporter := newInstanceID(component.KindExporter, "otlp", "traces")
+	ext := newInstanceID(component.KindExtension, "opamp")
+
+	for _, source := range []*component.InstanceID{receiver, exporter, ext} {
+		h.setStatus(source, component.NewStatusEvent(component.StatusStarting))
+		h.setStatus(source, component.NewStatusEvent(component.StatusOK))
+	}
+
+	health := h.toProto()
+	assert.True(t, health.Healthy)
+	assert.Equal(t, "StatusOK", health.Status)
+	require.Len(t, health.ComponentHealthMap, 3)
+	for _, group := range []string{"pipeline:traces", "pipeline:metrics", "extensions"} {
+		assert.True(t, health.ComponentHealthMap[group].Healthy)
+		assert.NotZero(t, health.ComponentHealthMap[group].StartTimeUnixNano)
+	}
+	assert.Len(t, health.ComponentHealthMap["pipeline:traces"].ComponentHealthMap, 2)
+	assert.Len(t, health.ComponentHealthMap["pipeline:metrics"].ComponentHealthMap, 1)
+	assert.Contains(t, health.ComponentHealthMap["extensions"].ComponentHealthMap, "extension:opamp")
+
+	h.setStatus(exporter, component.NewRecoverableErrorEvent(errors.New("connection refused")))
+
+	health = h.toProto()
+	assert.False(t, health.Healthy)
+	assert.Equal(t, "StatusRecoverableError", health.Status)
+	assert.Equal(t, "connection refused", health.LastError)
+
+	traces := health.ComponentHealthMap["pipeline:traces"]
+	assert.False(t, traces.Healthy)
+	assert.Equal(t, "connection refused", traces.LastError)
+	assert.False(t, traces.ComponentHealthMap["exporter:otlp"].Healthy)
+	assert.Equal(t, "connection refused", traces.ComponentHealthMap["exporter:otlp"].LastError)
+	assert.True(t, traces.ComponentHealthMap["receiver:otlp"].Healthy)
+	assert.Empty(t, traces.ComponentHealthMap["receiver:otlp"].LastError)
+	assert.True(t, health.ComponentHealthMap["pipeline:metrics"].Healthy)
+
+	h.setStatus(exporter, component.NewStatusEvent(component.StatusStopping))
+	h.setStatus(exporter, component.NewStatusEvent(component.StatusStopped))
+
+	health = h.toProto()
+	exporterHealth := health.ComponentHealthMap["pipeline:traces"].ComponentHealthMap["exporter:otlp"]
+	assert.Equal(t, "StatusStopped", exporterHealth.Status)
+	assert.Zero(t, exporterHealth.StartTimeUnixNano)
+}
@@ -31,6 +31,12 @@ type Config struct {
 type Capabilities struct {
 	// ReportsEffectiveConfig enables the OpAMP ReportsEffectiveConfig Capability. (default: true)
 	ReportsEffectiveConfig bool `mapstructure:"reports_effective_config"`
+	// ReportsHealth enables the OpAMP ReportsHealth Capability. The health of the
+	// pipelines and of their components is reported from the component status events. (default: true)
+	ReportsHealth bool `mapstructure:"reports_health"`
+	// ReportsAvailableComponents enables reporting the components included in the
+	// collector build in the agent description. (default: true)
+	ReportsAvailableComponents bool `mapstructure:"reports_available_components"`
 }
 
 func (caps Capabilities) toAgentCapabilities() protobufs.AgentCapabilities {
@@ -40,6 +46,9 @@ func (caps Capabilities) toAgentCapabilities() protobufs.AgentCapabilities {
 	if caps.ReportsEffectiveConfig {
 		agentCapabilities |= protobufs.AgentCapabilities_AgentCapabilities_ReportsEffectiveConfig
 	}
+	if caps.ReportsHealth {
+		agentCapabilities |= protobufs.AgentCapabilities_AgentCapabilities_ReportsHealth
+	}
 
 	return agentCapabilities
 }
@@ -38,7 +38,9 @@ func TestUnmarshalConfig(t *testing.T) {
 			},
 			InstanceUID: "01BX5ZZKBKACTAV9WEVGEMMVRZ",
 			Capabilities: Capabilities{
-				ReportsEffectiveConfig: true,
+				ReportsEffectiveConfig:     true,
+				ReportsHealth:              true,
+				ReportsAvailableComponents: true,
 			},
 		}, cfg)
 }
@@ -58,7 +60,9 @@ func TestUnmarshalHttpConfig(t *testing.T) {
 			},
 			InstanceUID: "01BX5ZZKBKACTAV9WEVGEMMVRZ",
 			Capabilities: Capabilities{
-				ReportsEffectiveConfig: true,
+				ReportsEffectiveConfig:     true,
+				ReportsHealth:              true,
+				ReportsAvailableComponents: true,
 			},
 		}, cfg)
 }
@@ -25,7 +25,9 @@ func createDefaultConfig() component.Config {
 	return &Config{
 		Server: &OpAMPServer{},
 		Capabilities: Capabilities{
-			ReportsEffectiveConfig: true,
+			ReportsEffectiveConfig:     true,
+			ReportsHealth:              true,
+			ReportsAvailableComponents: true,
 		},
 	}
 }
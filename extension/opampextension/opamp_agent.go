@@ -10,6 +10,7 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/oklog/ulid/v2"
@@ -18,6 +19,7 @@ import (
 	"github.com/open-telemetry/opamp-go/protobufs"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/extension"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	semconv "go.opentelemetry.io/collector/semconv/v1.18.0"
 	"go.uber.org/zap"
@@ -40,10 +42,14 @@ type opampAgent struct {
 
 	agentDescription *protobufs.AgentDescription
 
+	health *componentHealth
+
 	opampClient client.OpAMPClient
 }
 
-func (o *opampAgent) Start(_ context.Context, _ component.Host) error {
+var _ extension.StatusWatcher = (*opampAgent)(nil)
+
+func (o *opampAgent) Start(_ context.Context, host component.Host) error {
 	header := http.Header{}
 	for k, v := range o.cfg.Server.GetHeaders() {
 		header.Set(k, string(v))
@@ -77,7 +83,7 @@ func (o *opampAgent) Start(_ context.Context, _ component.Host) error {
 		Capabilities: o.capabilities.toAgentCapabilities(),
 	}
 
-	if err := o.createAgentDescription(); err != nil {
+	if err := o.createAgentDescription(host); err != nil {
 		return err
 	}
 
@@ -85,6 +91,13 @@ func (o *opampAgent) Start(_ context.Context, _ component.Host) error {
 		return err
 	}
 
+	// The OpAMP client requires the health to be set before being started.
+	if o.capabilities.ReportsHealth {
+		if err := o.opampClient.SetHealth(o.health.toProto()); err != nil {
+			return err
+		}
+	}
+
 	o.logger.Debug("Starting OpAMP client...")
 
 	if err := o.opampClient.Start(context.Background(), settings); err != nil {
@@ -119,6 +132,18 @@ func (o *opampAgent) NotifyConfig(ctx context.Context, conf *confmap.Conf) error
 	return nil
 }
 
+// ComponentStatusChanged reports the health of the collector to the OpAMP server whenever
+// the status of one of its components changes.
+func (o *opampAgent) ComponentStatusChanged(source *component.InstanceID, event *component.StatusEvent) {
+	if !o.capabilities.ReportsHealth {
+		return
+	}
+	o.health.setStatus(source, event)
+	if err := o.opampClient.SetHealth(o.health.toProto()); err != nil {
+		o.logger.Error("Failed to report the collector health", zap.Error(err))
+	}
+}
+
 func (o *opampAgent) updateEffectiveConfig(conf *confmap.Conf) {
 	o.eclk.Lock()
 	defer o.eclk.Unlock()
@@ -167,6 +192,7 @@ func newOpampAgent(cfg *Config, logger *zap.Logger, build component.BuildInfo, r
 		agentVersion: agentVersion,
 		instanceID:   uid,
 		capabilities: cfg.Capabilities,
+		health:       newComponentHealth(time.Now()),
 		opampClient:  cfg.Server.GetClient(logger),
 	}
 
@@ -182,7 +208,7 @@ func stringKeyValue(key, value string) *protobufs.KeyValue {
 	}
 }
 
-func (o *opampAgent) createAgentDescription() error {
+func (o *opampAgent) createAgentDescription(host component.Host) error {
 	hostname, err := os.Hostname()
 	if err != nil {
 		return err
@@ -199,6 +225,9 @@ func (o *opampAgent) createAgentDescription() error {
 		stringKeyValue(semconv.AttributeHostArch, runtime.GOARCH),
 		stringKeyValue(semconv.AttributeHostName, hostname),
 	}
+	if o.capabilities.ReportsAvailableComponents {
+		nonIdent = append(nonIdent, availableComponents(host, buildModules()))
+	}
 
 	o.agentDescription = &protobufs.AgentDescription{
 		IdentifyingAttributes:    ident,
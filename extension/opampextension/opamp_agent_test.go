@@ -5,6 +5,7 @@ package opampextension
 
 import (
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -28,6 +29,8 @@ func TestNewOpampAgent(t *testing.T) {
 	assert.Equal(t, "test version", o.agentVersion)
 	assert.NotEmpty(t, o.instanceID.String())
 	assert.True(t, o.capabilities.ReportsEffectiveConfig)
+	assert.True(t, o.capabilities.ReportsHealth)
+	assert.True(t, o.capabilities.ReportsAvailableComponents)
 	assert.Empty(t, o.effectiveConfig)
 	assert.Nil(t, o.agentDescription)
 }
@@ -53,9 +56,38 @@ func TestCreateAgentDescription(t *testing.T) {
 	assert.NoError(t, err)
 
 	assert.Nil(t, o.agentDescription)
-	err = o.createAgentDescription()
+	err = o.createAgentDescription(componenttest.NewNopHost())
 	assert.NoError(t, err)
 	assert.NotNil(t, o.agentDescription)
+
+	nonIdent := o.agentDescription.NonIdentifyingAttributes
+	assert.Equal(t, "available_components", nonIdent[len(nonIdent)-1].Key)
+
+	o.capabilities.ReportsAvailableComponents = false
+	assert.NoError(t, o.createAgentDescription(componenttest.NewNopHost()))
+	for _, attr := range o.agentDescription.NonIdentifyingAttributes {
+		assert.NotEqual(t, "available_components", attr.Key)
+	}
+}
+
+func TestComponentStatusChanged(t *testing.T) {
+	cfg := createDefaultConfig()
+	set := extensiontest.NewNopCreateSettings()
+	o, err := newOpampAgent(cfg.(*Config), set.Logger, set.BuildInfo, set.Resource)
+	assert.NoError(t, err)
+
+	source := &component.InstanceID{ID: component.MustNewID("opamp"), Kind: component.KindExtension}
+	o.ComponentStatusChanged(source, component.NewStatusEvent(component.StatusStarting))
+	o.ComponentStatusChanged(source, component.NewPermanentErrorEvent(errors.New("failed")))
+
+	health := o.health.toProto()
+	assert.False(t, health.Healthy)
+	assert.Equal(t, "failed", health.LastError)
+	assert.Equal(t, "failed", health.ComponentHealthMap["extensions"].ComponentHealthMap["extension:opamp"].LastError)
+
+	o.capabilities.ReportsHealth = false
+	o.ComponentStatusChanged(source, component.NewStatusEvent(component.StatusOK))
+	assert.Equal(t, "StatusPermanentError", o.health.toProto().Status)
 }
 
 func TestUpdateAgentIdentity(t *testing.T) {
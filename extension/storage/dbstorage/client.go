@@ -8,6 +8,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	// Postgres driver
 	_ "github.com/jackc/pgx/v4/stdlib"
@@ -17,10 +18,10 @@ import (
 )
 
 const (
-	createTable     = "create table if not exists %s (key text primary key, value blob)"
-	getQueryText    = "select value from %s where key=?"
-	setQueryText    = "insert into %s(key, value) values(?,?) on conflict(key) do update set value=?"
-	deleteQueryText = "delete from %s where key=?"
+	getQueryText           = "select value from %s where key=? and (expires_at is null or expires_at>?)"
+	setQueryText           = "insert into %s(key, value, expires_at) values(?,?,?) on conflict(key) do update set value=excluded.value, expires_at=excluded.expires_at"
+	deleteQueryText        = "delete from %s where key=?"
+	deleteExpiredQueryText = "delete from %s where expires_at<=?"
 )
 
 type dbStorageClient struct {
@@ -28,52 +29,46 @@ type dbStorageClient struct {
 	getQuery    *sql.Stmt
 	setQuery    *sql.Stmt
 	deleteQuery *sql.Stmt
+	// ttl is how long keys are kept after they are set, or 0 to keep them forever.
+	ttl time.Duration
+	now func() time.Time
 }
 
-func newClient(ctx context.Context, db *sql.DB, tableName string) (*dbStorageClient, error) {
-	var err error
-	_, err = db.ExecContext(ctx, fmt.Sprintf(createTable, tableName))
-	if err != nil {
-		return nil, err
+func newClient(ctx context.Context, db *sql.DB, d dialect, tableName string, ttl time.Duration) (*dbStorageClient, error) {
+	if err := migrate(ctx, db, d, tableName); err != nil {
+		return nil, fmt.Errorf("failed to migrate table %s: %w", tableName, err)
 	}
 
-	selectQuery, err := db.PrepareContext(ctx, fmt.Sprintf(getQueryText, tableName))
+	selectQuery, err := db.PrepareContext(ctx, d.rebind(fmt.Sprintf(getQueryText, tableName)))
 	if err != nil {
 		return nil, err
 	}
-	setQuery, err := db.PrepareContext(ctx, fmt.Sprintf(setQueryText, tableName))
+	setQuery, err := db.PrepareContext(ctx, d.rebind(fmt.Sprintf(setQueryText, tableName)))
 	if err != nil {
 		return nil, err
 	}
-	deleteQuery, err := db.PrepareContext(ctx, fmt.Sprintf(deleteQueryText, tableName))
+	deleteQuery, err := db.PrepareContext(ctx, d.rebind(fmt.Sprintf(deleteQueryText, tableName)))
 	if err != nil {
 		return nil, err
 	}
-	return &dbStorageClient{db, selectQuery, setQuery, deleteQuery}, nil
+	return &dbStorageClient{
+		db:          db,
+		getQuery:    selectQuery,
+		setQuery:    setQuery,
+		deleteQuery: deleteQuery,
+		ttl:         ttl,
+		now:         time.Now,
+	}, nil
 }
 
 // Get will retrieve data from storage that corresponds to the specified key
 func (c *dbStorageClient) Get(ctx context.Context, key string) ([]byte, error) {
-	rows, err := c.getQuery.QueryContext(ctx, key)
-	if err != nil {
-		return nil, err
-	}
-	if !rows.Next() {
-		return nil, nil
-	}
-	var result []byte
-	err = rows.Scan(&result)
-	if err != nil {
-		return result, err
-	}
-	err = rows.Close()
-	return result, err
+	return c.get(ctx, c.getQuery, key)
 }
 
 // Set will store data. The data can be retrieved using the same key
 func (c *dbStorageClient) Set(ctx context.Context, key string, value []byte) error {
-	_, err := c.setQuery.ExecContext(ctx, key, value, value)
-	return err
+	return c.set(ctx, c.setQuery, key, value)
 }
 
 // Delete will delete data associated with the specified key
@@ -82,26 +77,36 @@ func (c *dbStorageClient) Delete(ctx context.Context, key string) error {
 	return err
 }
 
-// Batch executes the specified operations in order. Get operation results are updated in place
+// Batch executes the specified operations in order, in a single transaction. Get operation
+// results are updated in place. If any operation fails, none of them is applied.
 func (c *dbStorageClient) Batch(ctx context.Context, ops ...storage.Operation) error {
-	var err error
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	// Statements prepared for the transaction are closed when it ends.
+	getQuery := tx.StmtContext(ctx, c.getQuery)
+	setQuery := tx.StmtContext(ctx, c.setQuery)
+	deleteQuery := tx.StmtContext(ctx, c.deleteQuery)
+
 	for _, op := range ops {
 		switch op.Type {
 		case storage.Get:
-			op.Value, err = c.Get(ctx, op.Key)
+			op.Value, err = c.get(ctx, getQuery, op.Key)
 		case storage.Set:
-			err = c.Set(ctx, op.Key, op.Value)
+			err = c.set(ctx, setQuery, op.Key, op.Value)
 		case storage.Delete:
-			err = c.Delete(ctx, op.Key)
+			_, err = deleteQuery.ExecContext(ctx, op.Key)
 		default:
-			return errors.New("wrong operation type")
+			err = errors.New("wrong operation type")
 		}
 
 		if err != nil {
-			return err
+			return errors.Join(err, tx.Rollback())
 		}
 	}
-	return err
+	return tx.Commit()
 }
 
 // Close will close the database
@@ -114,3 +119,30 @@ func (c *dbStorageClient) Close(_ context.Context) error {
 	}
 	return c.getQuery.Close()
 }
+
+func (c *dbStorageClient) get(ctx context.Context, query *sql.Stmt, key string) ([]byte, error) {
+	var result []byte
+	err := query.QueryRowContext(ctx, key, c.now().UnixMilli()).Scan(&result)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return result, err
+}
+
+func (c *dbStorageClient) set(ctx context.Context, query *sql.Stmt, key string, value []byte) error {
+	var expiresAt sql.NullInt64
+	if c.ttl > 0 {
+		expiresAt = sql.NullInt64{Int64: c.now().Add(c.ttl).UnixMilli(), Valid: true}
+	}
+	_, err := query.ExecContext(ctx, key, value, expiresAt)
+	return err
+}
+
+// deleteExpired deletes the keys of a table which expired before now.
+func deleteExpired(ctx context.Context, db *sql.DB, d dialect, tableName string, now time.Time) (int64, error) {
+	result, err := db.ExecContext(ctx, d.rebind(fmt.Sprintf(deleteExpiredQueryText, tableName)), now.UnixMilli())
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -5,12 +5,23 @@ package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-c
 
 import (
 	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
 )
 
 // Config defines configuration for dbstorage extension.
 type Config struct {
 	DriverName string `mapstructure:"driver,omitempty"`
 	DataSource string `mapstructure:"datasource,omitempty"`
+	// TTL is how long the keys of the clients of a component are kept after they are last
+	// set, by component kind and ID, e.g. `receiver/filelog/app`. The keys of the clients
+	// of other components never expire.
+	TTL map[string]time.Duration `mapstructure:"ttl,omitempty"`
+	// CleanupInterval is how often expired keys are deleted when TTL is set.
+	CleanupInterval time.Duration `mapstructure:"cleanup_interval,omitempty"`
 }
 
 func (cfg *Config) Validate() error {
@@ -20,6 +31,45 @@ func (cfg *Config) Validate() error {
 	if cfg.DriverName == "" {
 		return errors.New("missing driver name")
 	}
+	for name, ttl := range cfg.TTL {
+		if _, _, err := parseComponent(name); err != nil {
+			return fmt.Errorf("invalid ttl component %q: %w", name, err)
+		}
+		if ttl <= 0 {
+			return fmt.Errorf("ttl of %q must be positive", name)
+		}
+	}
+	if len(cfg.TTL) > 0 && cfg.CleanupInterval <= 0 {
+		return errors.New("cleanup_interval must be positive when ttl is set")
+	}
 
 	return nil
 }
+
+// parseComponent parses a component written as its kind followed by its ID.
+func parseComponent(name string) (component.Kind, component.ID, error) {
+	var id component.ID
+	kindName, idText, ok := strings.Cut(name, "/")
+	if !ok {
+		return 0, id, errors.New("missing component ID")
+	}
+	var kind component.Kind
+	switch kindName {
+	case "receiver":
+		kind = component.KindReceiver
+	case "processor":
+		kind = component.KindProcessor
+	case "exporter":
+		kind = component.KindExporter
+	case "extension":
+		kind = component.KindExtension
+	case "connector":
+		kind = component.KindConnector
+	default:
+		return 0, id, fmt.Errorf("unknown component kind %q", kindName)
+	}
+	if err := id.UnmarshalText([]byte(idText)); err != nil {
+		return 0, id, err
+	}
+	return kind, id, nil
+}
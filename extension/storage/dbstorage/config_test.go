@@ -6,6 +6,7 @@ package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-c
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -26,11 +27,41 @@ func TestConfigValidate(t *testing.T) {
 			Config{DriverName: "foo"},
 			errors.New("missing datasource"),
 		},
+		{
+			"Negative ttl",
+			Config{DriverName: "foo", DataSource: "bar", TTL: map[string]time.Duration{"receiver/filelog": -time.Second}},
+			errors.New(`ttl of "receiver/filelog" must be positive`),
+		},
+		{
+			"Unknown ttl component kind",
+			Config{DriverName: "foo", DataSource: "bar", TTL: map[string]time.Duration{"filelog/app": time.Hour}},
+			errors.New(`invalid ttl component "filelog/app": unknown component kind "filelog"`),
+		},
+		{
+			"Missing ttl component ID",
+			Config{DriverName: "foo", DataSource: "bar", TTL: map[string]time.Duration{"receiver": time.Hour}},
+			errors.New(`invalid ttl component "receiver": missing component ID`),
+		},
+		{
+			"Missing cleanup interval",
+			Config{DriverName: "foo", DataSource: "bar", TTL: map[string]time.Duration{"receiver/filelog": time.Hour}},
+			errors.New("cleanup_interval must be positive when ttl is set"),
+		},
 		{
 			"valid",
 			Config{DriverName: "foo", DataSource: "bar"},
 			nil,
 		},
+		{
+			"valid with ttl",
+			Config{
+				DriverName:      "foo",
+				DataSource:      "bar",
+				TTL:             map[string]time.Duration{"receiver/filelog/app": time.Hour},
+				CleanupInterval: time.Minute,
+			},
+			nil,
+		},
 	}
 
 	for _, test := range tests {
@@ -38,7 +69,7 @@ func TestConfigValidate(t *testing.T) {
 		if test.errWanted == nil {
 			assert.NoError(t, err)
 		} else {
-			assert.Equal(t, test.errWanted, err)
+			assert.EqualError(t, err, test.errWanted.Error())
 		}
 	}
 }
@@ -8,6 +8,8 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
@@ -18,18 +20,42 @@ import (
 type databaseStorage struct {
 	driverName     string
 	datasourceName string
-	logger         *zap.Logger
-	db             *sql.DB
+	// ttls are the TTLs of the keys of the clients of components, by component.
+	ttls            map[string]time.Duration
+	cleanupInterval time.Duration
+	logger          *zap.Logger
+	db              *sql.DB
+	dialect         dialect
+
+	// Tables of the clients with a TTL, from which expired keys are deleted.
+	tablesMu sync.Mutex
+	tables   map[string]struct{}
+
+	stopCleanup chan struct{}
+	cleanupWG   sync.WaitGroup
 }
 
 // Ensure this storage extension implements the appropriate interface
 var _ storage.Extension = (*databaseStorage)(nil)
 
 func newDBStorage(logger *zap.Logger, config *Config) (extension.Extension, error) {
+	ttls := make(map[string]time.Duration, len(config.TTL))
+	for name, ttl := range config.TTL {
+		kind, id, err := parseComponent(name)
+		if err != nil {
+			return nil, err
+		}
+		ttls[componentName(kind, id)] = ttl
+	}
+
 	return &databaseStorage{
-		driverName:     config.DriverName,
-		datasourceName: config.DataSource,
-		logger:         logger,
+		driverName:      config.DriverName,
+		datasourceName:  config.DataSource,
+		ttls:            ttls,
+		cleanupInterval: config.CleanupInterval,
+		logger:          logger,
+		dialect:         dialectForDriver(config.DriverName),
+		tables:          map[string]struct{}{},
 	}, nil
 }
 
@@ -44,17 +70,67 @@ func (ds *databaseStorage) Start(context.Context, component.Host) error {
 		return err
 	}
 	ds.db = db
+
+	if len(ds.ttls) > 0 {
+		ds.stopCleanup = make(chan struct{})
+		ds.cleanupWG.Add(1)
+		go ds.cleanupLoop()
+	}
 	return nil
 }
 
 // Shutdown closes the connection to the database
 func (ds *databaseStorage) Shutdown(context.Context) error {
+	if ds.stopCleanup != nil {
+		close(ds.stopCleanup)
+		ds.cleanupWG.Wait()
+		ds.stopCleanup = nil
+	}
 	if ds.db == nil {
 		return nil
 	}
 	return ds.db.Close()
 }
 
+// cleanupLoop periodically deletes the expired keys of the tables of the clients.
+func (ds *databaseStorage) cleanupLoop() {
+	defer ds.cleanupWG.Done()
+
+	ticker := time.NewTicker(ds.cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ds.stopCleanup:
+			return
+		case <-ticker.C:
+			ds.cleanup()
+		}
+	}
+}
+
+func (ds *databaseStorage) cleanup() {
+	ds.tablesMu.Lock()
+	tables := make([]string, 0, len(ds.tables))
+	for table := range ds.tables {
+		tables = append(tables, table)
+	}
+	ds.tablesMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), ds.cleanupInterval)
+	defer cancel()
+
+	now := time.Now()
+	for _, table := range tables {
+		deleted, err := deleteExpired(ctx, ds.db, ds.dialect, table, now)
+		if err != nil {
+			ds.logger.Warn("Failed to delete expired keys", zap.String("table", table), zap.Error(err))
+			continue
+		}
+		ds.logger.Debug("Deleted expired keys", zap.String("table", table), zap.Int64("count", deleted))
+	}
+}
+
 // GetClient returns a storage client for an individual component
 func (ds *databaseStorage) GetClient(ctx context.Context, kind component.Kind, ent component.ID, name string) (storage.Client, error) {
 	var fullName string
@@ -64,7 +140,24 @@ func (ds *databaseStorage) GetClient(ctx context.Context, kind component.Kind, e
 		fullName = fmt.Sprintf("%s_%s_%s_%s", kindString(kind), ent.Type(), ent.Name(), name)
 	}
 	fullName = strings.ReplaceAll(fullName, " ", "")
-	return newClient(ctx, ds.db, fullName)
+
+	ttl := ds.ttls[componentName(kind, ent)]
+	client, err := newClient(ctx, ds.db, ds.dialect, fullName, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	if ttl > 0 {
+		ds.tablesMu.Lock()
+		ds.tables[fullName] = struct{}{}
+		ds.tablesMu.Unlock()
+	}
+	return client, nil
+}
+
+// componentName returns the name of a component in the ttl setting.
+func componentName(kind component.Kind, id component.ID) string {
+	return kindString(kind) + "/" + id.String()
 }
 
 func kindString(k component.Kind) string {
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -97,11 +98,159 @@ func TestExtensionIntegrity(t *testing.T) {
 	wg.Wait()
 }
 
-func newTestExtension(t *testing.T) storage.Extension {
+func TestBatch(t *testing.T) {
+	ctx := context.Background()
+	se := newTestExtension(t)
+	require.NoError(t, se.Start(ctx, componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, se.Shutdown(ctx))
+	}()
+
+	client, err := se.GetClient(ctx, component.KindExporter, newTestEntity("batch"), "")
+	require.NoError(t, err)
+	defer client.Close(ctx)
+
+	require.NoError(t, client.Set(ctx, "deleted", []byte("value")))
+
+	getOp := storage.GetOperation("key")
+	require.NoError(t, client.Batch(ctx,
+		storage.SetOperation("key", []byte("value")),
+		getOp,
+		storage.DeleteOperation("deleted"),
+	))
+	assert.Equal(t, []byte("value"), getOp.Value)
+	value, err := client.Get(ctx, "deleted")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	// A failing batch is rolled back.
+	invalidOp := storage.GetOperation("key")
+	invalidOp.Type = -1
+	err = client.Batch(ctx,
+		storage.SetOperation("key", []byte("new value")),
+		storage.DeleteOperation("other"),
+		invalidOp,
+	)
+	require.ErrorContains(t, err, "wrong operation type")
+	value, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+}
+
+func TestTTL(t *testing.T) {
+	ctx := context.Background()
+	se := newTestExtension(t, func(cfg *Config) {
+		cfg.TTL = map[string]time.Duration{"exporter/nop/ttl": time.Minute}
+	})
+	require.NoError(t, se.Start(ctx, componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, se.Shutdown(ctx))
+	}()
+
+	client, err := se.GetClient(ctx, component.KindExporter, newTestEntity("ttl"), "")
+	require.NoError(t, err)
+	defer client.Close(ctx)
+
+	now := time.Now()
+	client.(*dbStorageClient).now = func() time.Time { return now }
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+
+	now = now.Add(59 * time.Second)
+	value, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	// Setting the key again extends its expiry.
+	require.NoError(t, client.Set(ctx, "key", []byte("new value")))
+	now = now.Add(59 * time.Second)
+	value, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("new value"), value)
+
+	now = now.Add(time.Second)
+	value, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	// The keys of the clients of other components never expire.
+	other, err := se.GetClient(ctx, component.KindExporter, newTestEntity("queue"), "")
+	require.NoError(t, err)
+	defer other.Close(ctx)
+	other.(*dbStorageClient).now = func() time.Time { return now }
+	require.NoError(t, other.Set(ctx, "ri", []byte("0")))
+	now = now.Add(24 * time.Hour)
+	value, err = other.Get(ctx, "ri")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("0"), value)
+}
+
+func TestCleanupExpiredKeys(t *testing.T) {
+	ctx := context.Background()
+	se := newTestExtension(t, func(cfg *Config) {
+		cfg.TTL = map[string]time.Duration{"exporter/nop/cleanup": time.Millisecond}
+		cfg.CleanupInterval = 10 * time.Millisecond
+	})
+	require.NoError(t, se.Start(ctx, componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, se.Shutdown(ctx))
+	}()
+
+	client, err := se.GetClient(ctx, component.KindExporter, newTestEntity("cleanup"), "")
+	require.NoError(t, err)
+	defer client.Close(ctx)
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+
+	db := se.(*databaseStorage).db
+	assert.Eventually(t, func() bool {
+		var count int
+		require.NoError(t, db.QueryRowContext(ctx, "select count(*) from exporter_nop_cleanup").Scan(&count))
+		return count == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestMigrateExistingTable(t *testing.T) {
+	ctx := context.Background()
+	se := newTestExtension(t)
+	require.NoError(t, se.Start(ctx, componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, se.Shutdown(ctx))
+	}()
+
+	// Table created before the schema was versioned.
+	db := se.(*databaseStorage).db
+	_, err := db.ExecContext(ctx, "create table receiver_nop_legacy (key text primary key, value blob)")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "insert into receiver_nop_legacy(key, value) values('key', 'value')")
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		client, err := se.GetClient(ctx, component.KindReceiver, newTestEntity("legacy"), "")
+		require.NoError(t, err)
+		value, err := client.Get(ctx, "key")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("value"), value)
+		require.NoError(t, client.Close(ctx))
+	}
+
+	var version int
+	require.NoError(t, db.QueryRowContext(ctx, "select version from dbstorage_schema_versions where table_name='receiver_nop_legacy'").Scan(&version))
+	assert.Equal(t, len(migrations), version)
+
+	// Tables migrated by a newer version are not used.
+	_, err = db.ExecContext(ctx, "update dbstorage_schema_versions set version=? where table_name='receiver_nop_legacy'", len(migrations)+1)
+	require.NoError(t, err)
+	_, err = se.GetClient(ctx, component.KindReceiver, newTestEntity("legacy"), "")
+	require.ErrorContains(t, err, "is newer than the latest supported version")
+}
+
+func newTestExtension(t *testing.T, opts ...func(*Config)) storage.Extension {
 	f := NewFactory()
 	cfg := f.CreateDefaultConfig().(*Config)
 	cfg.DriverName = "sqlite3"
 	cfg.DataSource = fmt.Sprintf("file:%s/foo.db?_busy_timeout=10000&_journal=WAL&_sync=NORMAL", t.TempDir())
+	for _, opt := range opts {
+		opt(cfg)
+	}
 
 	extension, err := f.CreateExtension(context.Background(), extensiontest.NewNopCreateSettings(), cfg)
 	require.NoError(t, err)
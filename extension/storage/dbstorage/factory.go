@@ -7,6 +7,7 @@ package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-c
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
@@ -14,6 +15,8 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage/internal/metadata"
 )
 
+const defaultCleanupInterval = time.Minute
+
 // NewFactory creates a factory for DBStorage extension.
 func NewFactory() extension.Factory {
 	return extension.NewFactory(
@@ -25,7 +28,9 @@ func NewFactory() extension.Factory {
 }
 
 func createDefaultConfig() component.Config {
-	return &Config{}
+	return &Config{
+		CleanupInterval: defaultCleanupInterval,
+	}
 }
 
 func createExtension(
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage"
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// schemaVersionsTable records the version of the schema of each table of the clients.
+	schemaVersionsTable       = "dbstorage_schema_versions"
+	createSchemaVersionsTable = "create table if not exists " + schemaVersionsTable + " (table_name text primary key, version integer not null)"
+	getSchemaVersionQueryText = "select version from " + schemaVersionsTable + " where table_name=?"
+	setSchemaVersionQueryText = "insert into " + schemaVersionsTable + "(table_name, version) values(?,?) on conflict(table_name) do update set version=excluded.version"
+)
+
+// migrations are the statements upgrading the schema of the table of a client, in order.
+// The version of a table is the number of migrations applied to it. Migrations must never
+// be changed or removed once released, only appended.
+var migrations = []func(tableName string, d dialect) string{
+	// Tables created before the schema was versioned already exist.
+	func(tableName string, d dialect) string {
+		return fmt.Sprintf("create table if not exists %s (key text primary key, value %s)", tableName, d.binaryType)
+	},
+	func(tableName string, _ dialect) string {
+		return fmt.Sprintf("alter table %s add column expires_at bigint", tableName)
+	},
+	func(tableName string, _ dialect) string {
+		return fmt.Sprintf("create index if not exists %[1]s_expires_at on %[1]s (expires_at)", tableName)
+	},
+}
+
+// migrate applies the migrations missing from the table of a client in a transaction.
+// Collectors sharing the database migrate their tables one at a time.
+func migrate(ctx context.Context, db *sql.DB, d dialect, tableName string) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if d.lockQuery != "" {
+		if _, err = tx.ExecContext(ctx, d.lockQuery); err != nil {
+			return errors.Join(err, tx.Rollback())
+		}
+	}
+	if _, err = tx.ExecContext(ctx, createSchemaVersionsTable); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+
+	var version int
+	err = tx.QueryRowContext(ctx, d.rebind(getSchemaVersionQueryText), tableName).Scan(&version)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return errors.Join(err, tx.Rollback())
+	}
+	if version > len(migrations) {
+		return errors.Join(
+			fmt.Errorf("schema version %d is newer than the latest supported version %d", version, len(migrations)),
+			tx.Rollback(),
+		)
+	}
+	if version == len(migrations) {
+		return tx.Rollback()
+	}
+
+	for _, migration := range migrations[version:] {
+		if _, err = tx.ExecContext(ctx, migration(tableName, d)); err != nil {
+			return errors.Join(err, tx.Rollback())
+		}
+	}
+	if _, err = tx.ExecContext(ctx, d.rebind(setSchemaVersionQueryText), tableName, len(migrations)); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+	return tx.Commit()
+}
+
+// dialect holds the differences between the SQL dialects of the supported databases.
+type dialect struct {
+	// binaryType is the column type of binary values.
+	binaryType string
+	// numberedPlaceholders is true when query parameters are written $1, $2, ... instead of ?.
+	numberedPlaceholders bool
+	// lockQuery locks the schema versions until the end of the transaction migrating a
+	// table. SQLite needs none, as it fails a transaction writing to a database another
+	// one is writing to.
+	lockQuery string
+}
+
+func dialectForDriver(driverName string) dialect {
+	switch driverName {
+	case "pgx", "postgres":
+		return dialect{
+			binaryType:           "bytea",
+			numberedPlaceholders: true,
+			lockQuery:            "select pg_advisory_xact_lock(hashtext('" + schemaVersionsTable + "'))",
+		}
+	default:
+		return dialect{binaryType: "blob"}
+	}
+}
+
+// rebind rewrites the ? placeholders of a query for the dialect.
+func (d dialect) rebind(query string) string {
+	if !d.numberedPlaceholders {
+		return query
+	}
+
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r != '?' {
+			b.WriteRune(r)
+			continue
+		}
+		n++
+		b.WriteByte('$')
+		b.WriteString(strconv.Itoa(n))
+	}
+	return b.String()
+}
@@ -0,0 +1,19 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dbstorage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRebind(t *testing.T) {
+	query := "insert into t(key, value) values(?,?) on conflict(key) do update set value=?"
+	assert.Equal(t, query, dialectForDriver("sqlite3").rebind(query))
+	assert.Equal(t,
+		"insert into t(key, value) values($1,$2) on conflict(key) do update set value=$3",
+		dialectForDriver("pgx").rebind(query),
+	)
+}
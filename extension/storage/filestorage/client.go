@@ -33,6 +33,7 @@ type fileStorageClient struct {
 	db              *bbolt.DB
 	compactionCfg   *CompactionConfig
 	openTimeout     time.Duration
+	encryptor       *encryptor
 	cancel          context.CancelFunc
 	closed          bool
 }
@@ -46,7 +47,7 @@ func bboltOptions(timeout time.Duration, noSync bool) *bbolt.Options {
 	}
 }
 
-func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compactionCfg *CompactionConfig, noSync bool) (*fileStorageClient, error) {
+func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compactionCfg *CompactionConfig, noSync bool, encryptor *encryptor) (*fileStorageClient, error) {
 	options := bboltOptions(timeout, noSync)
 	db, err := bbolt.Open(filePath, 0600, options)
 	if err != nil {
@@ -54,15 +55,17 @@ func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compa
 	}
 
 	initBucket := func(tx *bbolt.Tx) error {
-		_, err := tx.CreateBucketIfNotExists(defaultBucket)
-		return err
+		if _, err := tx.CreateBucketIfNotExists(defaultBucket); err != nil {
+			return err
+		}
+		return initEncryption(tx, encryptor)
 	}
 	if err := db.Update(initBucket); err != nil {
 		_ = db.Close()
-		return nil, err
+		return nil, fmt.Errorf("failed to open %s: %w", filePath, err)
 	}
 
-	client := &fileStorageClient{logger: logger, db: db, compactionCfg: compactionCfg, openTimeout: timeout}
+	client := &fileStorageClient{logger: logger, db: db, compactionCfg: compactionCfg, openTimeout: timeout, encryptor: encryptor}
 	if compactionCfg.OnRebound {
 		client.startCompactionLoop(context.Background())
 	}
@@ -104,16 +107,26 @@ func (c *fileStorageClient) Batch(_ context.Context, ops ...storage.Operation) e
 			switch op.Type {
 			case storage.Get:
 				value := bucket.Get([]byte(op.Key))
-				if value != nil {
+				switch {
+				case value == nil:
+					op.Value = nil
+				case c.encryptor != nil:
+					// decrypting copies the value out of the transaction
+					op.Value, err = c.encryptor.decrypt(op.Key, value)
+				default:
 					// the output of Bucket.Get is only valid within a transaction, so we need to make a copy
 					// to be able to return the value
 					op.Value = make([]byte, len(value))
 					copy(op.Value, value)
-				} else {
-					op.Value = nil
 				}
 			case storage.Set:
-				err = bucket.Put([]byte(op.Key), op.Value)
+				value := op.Value
+				if c.encryptor != nil {
+					if value, err = c.encryptor.encrypt(op.Key, op.Value); err != nil {
+						return err
+					}
+				}
+				err = bucket.Put([]byte(op.Key), value)
 			case storage.Delete:
 				err = bucket.Delete([]byte(op.Key))
 			default:
@@ -197,6 +210,14 @@ func (c *fileStorageClient) Compact(compactionDirectory string, timeout time.Dur
 		return err
 	}
 
+	// re-encrypt the values encrypted with previous keys, so that they are no longer needed
+	if c.encryptor != nil {
+		if err = c.encryptor.rotateKeys(compactedDb, maxTransactionSize); err != nil {
+			compactedDb.Close()
+			return fmt.Errorf("failed to re-encrypt values: %w", err)
+		}
+	}
+
 	dbPath := c.db.Path()
 	compactedDbPath := compactedDb.Path()
 
@@ -20,6 +20,26 @@ type Config struct {
 
 	// FSync specifies that fsync should be called after each database write
 	FSync bool `mapstructure:"fsync,omitempty"`
+
+	// Encryption specifies that values are encrypted with AES-GCM. Values are stored in plaintext when not set.
+	Encryption *EncryptionConfig `mapstructure:"encryption,omitempty"`
+}
+
+// EncryptionConfig defines configuration for optional encryption of the stored values.
+type EncryptionConfig struct {
+	// Key is the key values are encrypted with
+	Key KeyConfig `mapstructure:"key"`
+	// PreviousKeys are keys values were encrypted with before the key was rotated. They are still used to
+	// decrypt values, until the values are re-encrypted with the current key during compaction
+	PreviousKeys []KeyConfig `mapstructure:"previous_keys,omitempty"`
+}
+
+// KeyConfig specifies where a base64 encoded AES-128, AES-192 or AES-256 key is loaded from.
+type KeyConfig struct {
+	// File is the path to a file holding the key
+	File string `mapstructure:"file,omitempty"`
+	// Env is the name of an environment variable holding the key
+	Env string `mapstructure:"env,omitempty"`
 }
 
 // CompactionConfig defines configuration for optional file storage compaction.
@@ -79,5 +99,23 @@ func (cfg *Config) Validate() error {
 		return errors.New("compaction check interval must be positive when rebound compaction is set")
 	}
 
+	if cfg.Encryption != nil {
+		if err := cfg.Encryption.Key.validate(); err != nil {
+			return fmt.Errorf("invalid encryption key: %w", err)
+		}
+		for i, key := range cfg.Encryption.PreviousKeys {
+			if err := key.validate(); err != nil {
+				return fmt.Errorf("invalid previous encryption key %d: %w", i, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (cfg KeyConfig) validate() error {
+	if (cfg.File == "") == (cfg.Env == "") {
+		return errors.New("exactly one of file or env must be set")
+	}
 	return nil
 }
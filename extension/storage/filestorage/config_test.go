@@ -48,6 +48,12 @@ func TestLoadConfig(t *testing.T) {
 				},
 				Timeout: 2 * time.Second,
 				FSync:   true,
+				Encryption: &EncryptionConfig{
+					Key: KeyConfig{File: "current.key"},
+					PreviousKeys: []KeyConfig{
+						{Env: "FILE_STORAGE_PREVIOUS_KEY"},
+					},
+				},
 			},
 		},
 	}
@@ -94,3 +100,18 @@ func TestHandleProvidingFilePathAsDirWithAnError(t *testing.T) {
 	require.Error(t, err)
 	require.EqualError(t, err, file.Name()+" is not a directory")
 }
+
+func TestInvalidEncryptionKeyConfig(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+
+	cfg.Encryption = &EncryptionConfig{}
+	require.EqualError(t, component.ValidateConfig(cfg), "invalid encryption key: exactly one of file or env must be set")
+
+	cfg.Encryption = &EncryptionConfig{
+		Key:          KeyConfig{File: "current.key"},
+		PreviousKeys: []KeyConfig{{File: "previous.key", Env: "FILE_STORAGE_PREVIOUS_KEY"}},
+	}
+	require.EqualError(t, component.ValidateConfig(cfg), "invalid previous encryption key 0: exactly one of file or env must be set")
+}
@@ -0,0 +1,256 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"go.etcd.io/bbolt"
+)
+
+// encryptionBucket holds the IDs of the keys the values of the default bucket are encrypted
+// with. It only exists in encrypted files.
+var encryptionBucket = []byte(`encryption`)
+
+// keyIDSize is the size of the ID of the key prepended to encrypted values.
+const keyIDSize = 4
+
+// encryptionKey is an AES key used with GCM.
+type encryptionKey struct {
+	id   [keyIDSize]byte
+	aead cipher.AEAD
+}
+
+// encryptor encrypts the values with the current key, and decrypts the values encrypted with
+// the current or a previous key. An encrypted value is the ID of the key, followed by the
+// nonce and the sealed value. The storage key is used as additional data, so that encrypted
+// values cannot be swapped between keys.
+type encryptor struct {
+	current *encryptionKey
+	keys    map[[keyIDSize]byte]*encryptionKey
+}
+
+func newEncryptor(cfg *EncryptionConfig) (*encryptor, error) {
+	current, err := loadEncryptionKey(cfg.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load encryption key: %w", err)
+	}
+
+	e := &encryptor{
+		current: current,
+		keys:    map[[keyIDSize]byte]*encryptionKey{current.id: current},
+	}
+	for i, keyCfg := range cfg.PreviousKeys {
+		key, err := loadEncryptionKey(keyCfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load previous encryption key %d: %w", i, err)
+		}
+		e.keys[key.id] = key
+	}
+	return e, nil
+}
+
+// loadEncryptionKey loads a base64 encoded AES-128, AES-192 or AES-256 key.
+func loadEncryptionKey(cfg KeyConfig) (*encryptionKey, error) {
+	var encoded string
+	switch {
+	case cfg.File != "":
+		data, err := os.ReadFile(cfg.File)
+		if err != nil {
+			return nil, err
+		}
+		encoded = string(data)
+	case cfg.Env != "":
+		encoded = os.Getenv(cfg.Env)
+		if encoded == "" {
+			return nil, fmt.Errorf("environment variable %s is not set", cfg.Env)
+		}
+	default:
+		return nil, errors.New("no key file or environment variable")
+	}
+
+	secret, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("key is not base64 encoded: %w", err)
+	}
+	block, err := aes.NewCipher(secret)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	key := &encryptionKey{aead: aead}
+	digest := sha256.Sum256(secret)
+	copy(key.id[:], digest[:keyIDSize])
+	return key, nil
+}
+
+func (e *encryptor) encrypt(key string, value []byte) ([]byte, error) {
+	aead := e.current.aead
+	out := make([]byte, keyIDSize+aead.NonceSize(), keyIDSize+aead.NonceSize()+len(value)+aead.Overhead())
+	copy(out, e.current.id[:])
+	nonce := out[keyIDSize:]
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(out, nonce, value, []byte(key)), nil
+}
+
+func (e *encryptor) decrypt(key string, value []byte) ([]byte, error) {
+	if len(value) < keyIDSize {
+		return nil, fmt.Errorf("value of key %s is not encrypted", key)
+	}
+	var id [keyIDSize]byte
+	copy(id[:], value)
+	encryptionKey, ok := e.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("value of key %s is encrypted with key %x, which is not configured", key, id)
+	}
+
+	aead := encryptionKey.aead
+	if len(value) < keyIDSize+aead.NonceSize() {
+		return nil, fmt.Errorf("value of key %s is truncated", key)
+	}
+	nonce := value[keyIDSize : keyIDSize+aead.NonceSize()]
+	plaintext, err := aead.Open(nil, nonce, value[keyIDSize+aead.NonceSize():], []byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt value of key %s: %w", key, err)
+	}
+	return plaintext, nil
+}
+
+// isCurrent returns whether an encrypted value is encrypted with the current key.
+func (e *encryptor) isCurrent(value []byte) bool {
+	return len(value) >= keyIDSize && [keyIDSize]byte(value[:keyIDSize]) == e.current.id
+}
+
+// initEncryption checks that the keys the file is encrypted with are configured, and
+// records the current key as used. Values of a plaintext file are encrypted, and opening
+// an encrypted file without encryption fails.
+func initEncryption(tx *bbolt.Tx, e *encryptor) error {
+	used := tx.Bucket(encryptionBucket)
+	if e == nil {
+		if used != nil {
+			return errors.New("file is encrypted, but encryption is not configured")
+		}
+		return nil
+	}
+
+	if used == nil {
+		var err error
+		if used, err = tx.CreateBucket(encryptionBucket); err != nil {
+			return err
+		}
+		if err = e.encryptValues(tx.Bucket(defaultBucket)); err != nil {
+			return fmt.Errorf("failed to encrypt the file: %w", err)
+		}
+	}
+
+	var missing []string
+	err := used.ForEach(func(id, _ []byte) error {
+		if len(id) != keyIDSize {
+			return fmt.Errorf("invalid key ID %x", id)
+		}
+		if _, ok := e.keys[[keyIDSize]byte(id)]; !ok {
+			missing = append(missing, hex.EncodeToString(id))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("file is encrypted with keys %s, which are not configured as the key or previous keys", strings.Join(missing, ", "))
+	}
+
+	return used.Put(e.current.id[:], nil)
+}
+
+// encryptValues encrypts the plaintext values of a bucket with the current key.
+func (e *encryptor) encryptValues(bucket *bbolt.Bucket) error {
+	updates := map[string][]byte{}
+	err := bucket.ForEach(func(k, v []byte) error {
+		value, err := e.encrypt(string(k), v)
+		if err != nil {
+			return err
+		}
+		updates[string(k)] = value
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return putAll(bucket, updates)
+}
+
+// rotateKeys re-encrypts the values encrypted with previous keys with the current key, in
+// transactions of at most maxTransactionSize values, and then records the current key as
+// the only one used by the file.
+func (e *encryptor) rotateKeys(db *bbolt.DB, maxTransactionSize int64) error {
+	for done := false; !done; {
+		err := db.Update(func(tx *bbolt.Tx) error {
+			bucket := tx.Bucket(defaultBucket)
+			updates := map[string][]byte{}
+			c := bucket.Cursor()
+			for k, v := c.First(); k != nil; k, v = c.Next() {
+				if e.isCurrent(v) {
+					continue
+				}
+				if maxTransactionSize > 0 && int64(len(updates)) == maxTransactionSize {
+					// Values cannot be updated while iterating, continue in another transaction.
+					return putAll(bucket, updates)
+				}
+				plaintext, err := e.decrypt(string(k), v)
+				if err != nil {
+					return err
+				}
+				value, err := e.encrypt(string(k), plaintext)
+				if err != nil {
+					return err
+				}
+				updates[string(k)] = value
+			}
+			if err := putAll(bucket, updates); err != nil {
+				return err
+			}
+			done = true
+
+			if err := tx.DeleteBucket(encryptionBucket); err != nil {
+				return err
+			}
+			used, err := tx.CreateBucket(encryptionBucket)
+			if err != nil {
+				return err
+			}
+			return used.Put(e.current.id[:], nil)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func putAll(bucket *bbolt.Bucket, values map[string][]byte) error {
+	for k, v := range values {
+		if err := bucket.Put([]byte(k), v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
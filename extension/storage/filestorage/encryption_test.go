@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorage
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+	"go.uber.org/zap"
+)
+
+func newTestKeyFile(t *testing.T) KeyConfig {
+	secret := make([]byte, 32)
+	_, err := rand.Read(secret)
+	require.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(keyFile, []byte(base64.StdEncoding.EncodeToString(secret)+"\n"), 0600))
+	return KeyConfig{File: keyFile}
+}
+
+func newTestEncryptor(t *testing.T, key KeyConfig, previousKeys ...KeyConfig) *encryptor {
+	e, err := newEncryptor(&EncryptionConfig{Key: key, PreviousKeys: previousKeys})
+	require.NoError(t, err)
+	return e
+}
+
+func rawValue(t *testing.T, dbFile string, key string) []byte {
+	db, err := bbolt.Open(dbFile, 0600, &bbolt.Options{Timeout: time.Second, ReadOnly: true})
+	require.NoError(t, err)
+	defer db.Close()
+
+	var value []byte
+	require.NoError(t, db.View(func(tx *bbolt.Tx) error {
+		value = append(value, tx.Bucket(defaultBucket).Get([]byte(key))...)
+		return nil
+	}))
+	return value
+}
+
+func TestEncryptedClientOperations(t *testing.T) {
+	ctx := context.Background()
+	dbFile := filepath.Join(t.TempDir(), "my_db")
+	e := newTestEncryptor(t, newTestKeyFile(t))
+
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, e)
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "testKey", []byte("testValue")))
+	value, err := client.Get(ctx, "testKey")
+	require.NoError(t, err)
+	require.Equal(t, []byte("testValue"), value)
+	require.NoError(t, client.Close(ctx))
+
+	raw := rawValue(t, dbFile, "testKey")
+	require.NotContains(t, string(raw), "testValue")
+	require.Equal(t, e.current.id[:], raw[:keyIDSize])
+
+	// Values are bound to their key.
+	_, err = e.decrypt("otherKey", raw)
+	require.ErrorContains(t, err, "failed to decrypt value of key otherKey")
+}
+
+func TestEncryptPlaintextFile(t *testing.T) {
+	ctx := context.Background()
+	dbFile := filepath.Join(t.TempDir(), "my_db")
+
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, nil)
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "testKey", []byte("testValue")))
+	require.NoError(t, client.Close(ctx))
+
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, newTestEncryptor(t, newTestKeyFile(t)))
+	require.NoError(t, err)
+	value, err := client.Get(ctx, "testKey")
+	require.NoError(t, err)
+	require.Equal(t, []byte("testValue"), value)
+	require.NoError(t, client.Close(ctx))
+	require.NotContains(t, string(rawValue(t, dbFile, "testKey")), "testValue")
+
+	_, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, nil)
+	require.ErrorContains(t, err, "file is encrypted, but encryption is not configured")
+}
+
+func TestEncryptionKeyMismatch(t *testing.T) {
+	ctx := context.Background()
+	dbFile := filepath.Join(t.TempDir(), "my_db")
+	e := newTestEncryptor(t, newTestKeyFile(t))
+
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, e)
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "testKey", []byte("testValue")))
+	require.NoError(t, client.Close(ctx))
+
+	_, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, newTestEncryptor(t, newTestKeyFile(t)))
+	require.ErrorContains(t, err, fmt.Sprintf("file is encrypted with keys %x, which are not configured", e.current.id))
+}
+
+func TestEncryptionKeyRotation(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	dbFile := filepath.Join(tempDir, "my_db")
+	previousKey := newTestKeyFile(t)
+	currentKey := newTestKeyFile(t)
+
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, newTestEncryptor(t, previousKey))
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		require.NoError(t, client.Set(ctx, fmt.Sprintf("key%d", i), []byte(fmt.Sprintf("value%d", i))))
+	}
+	require.NoError(t, client.Close(ctx))
+
+	e := newTestEncryptor(t, currentKey, previousKey)
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, e)
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "key0", []byte("new value0")))
+	value, err := client.Get(ctx, "key1")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), value)
+
+	// The previous key is needed until the values are re-encrypted during compaction.
+	require.NoError(t, client.Compact(tempDir, time.Second, 3))
+	require.NoError(t, client.Close(ctx))
+
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, newTestEncryptor(t, currentKey))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, client.Close(ctx))
+	}()
+	value, err = client.Get(ctx, "key0")
+	require.NoError(t, err)
+	require.Equal(t, []byte("new value0"), value)
+	for i := 1; i < 10; i++ {
+		value, err = client.Get(ctx, fmt.Sprintf("key%d", i))
+		require.NoError(t, err)
+		require.Equal(t, []byte(fmt.Sprintf("value%d", i)), value)
+	}
+}
+
+func TestLoadEncryptionKey(t *testing.T) {
+	secret := base64.StdEncoding.EncodeToString(make([]byte, 16))
+	t.Setenv("FILE_STORAGE_TEST_KEY", secret)
+	_, err := loadEncryptionKey(KeyConfig{Env: "FILE_STORAGE_TEST_KEY"})
+	require.NoError(t, err)
+
+	_, err = loadEncryptionKey(KeyConfig{Env: "FILE_STORAGE_MISSING_KEY"})
+	require.EqualError(t, err, "environment variable FILE_STORAGE_MISSING_KEY is not set")
+
+	t.Setenv("FILE_STORAGE_TEST_KEY", "not base64")
+	_, err = loadEncryptionKey(KeyConfig{Env: "FILE_STORAGE_TEST_KEY"})
+	require.ErrorContains(t, err, "key is not base64 encoded")
+
+	t.Setenv("FILE_STORAGE_TEST_KEY", base64.StdEncoding.EncodeToString(make([]byte, 10)))
+	_, err = loadEncryptionKey(KeyConfig{Env: "FILE_STORAGE_TEST_KEY"})
+	require.ErrorContains(t, err, "invalid key size 10")
+}
@@ -25,8 +25,9 @@ var replaceUnsafeCharactersFeatureGate = featuregate.GlobalRegistry().MustRegist
 )
 
 type localFileStorage struct {
-	cfg    *Config
-	logger *zap.Logger
+	cfg       *Config
+	logger    *zap.Logger
+	encryptor *encryptor
 }
 
 // Ensure this storage extension implements the appropriate interface
@@ -39,9 +40,14 @@ func newLocalFileStorage(logger *zap.Logger, config *Config) (extension.Extensio
 	}, nil
 }
 
-// Start does nothing
+// Start loads the encryption keys
 func (lfs *localFileStorage) Start(context.Context, component.Host) error {
-	return nil
+	if lfs.cfg.Encryption == nil {
+		return nil
+	}
+	var err error
+	lfs.encryptor, err = newEncryptor(lfs.cfg.Encryption)
+	return err
 }
 
 // Shutdown will close any open databases
@@ -64,7 +70,7 @@ func (lfs *localFileStorage) GetClient(_ context.Context, kind component.Kind, e
 		rawName = sanitize(rawName)
 	}
 	absoluteName := filepath.Join(lfs.cfg.Directory, rawName)
-	client, err := newClient(lfs.logger, absoluteName, lfs.cfg.Timeout, lfs.cfg.Compaction, !lfs.cfg.FSync)
+	client, err := newClient(lfs.logger, absoluteName, lfs.cfg.Timeout, lfs.cfg.Compaction, !lfs.cfg.FSync, lfs.encryptor)
 
 	if err != nil {
 		return nil, err
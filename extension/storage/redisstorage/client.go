@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redisstorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/redisstorage"
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+type redisStorageClient struct {
+	client    *redis.Client
+	namespace string
+	// ttl is how long keys are kept after they are set, or 0 to keep them forever.
+	ttl time.Duration
+}
+
+func newClient(client *redis.Client, namespace string, ttl time.Duration) *redisStorageClient {
+	return &redisStorageClient{
+		client:    client,
+		namespace: namespace,
+		ttl:       ttl,
+	}
+}
+
+// Get will retrieve data from storage that corresponds to the specified key
+func (c *redisStorageClient) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := c.client.Get(ctx, c.key(key)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	return value, err
+}
+
+// Set will store data. The data can be retrieved using the same key
+func (c *redisStorageClient) Set(ctx context.Context, key string, value []byte) error {
+	return c.client.Set(ctx, c.key(key), value, c.ttl).Err()
+}
+
+// Delete will delete data associated with the specified key
+func (c *redisStorageClient) Delete(ctx context.Context, key string) error {
+	return c.client.Del(ctx, c.key(key)).Err()
+}
+
+// Batch executes the specified operations in order, in a single round trip to the server.
+// The operations are wrapped in a MULTI/EXEC transaction so that they are applied
+// atomically. Get operation results are updated in place.
+func (c *redisStorageClient) Batch(ctx context.Context, ops ...storage.Operation) error {
+	pipeline := c.client.TxPipeline()
+	cmds := make([]redis.Cmder, len(ops))
+	for i, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			cmds[i] = pipeline.Get(ctx, c.key(op.Key))
+		case storage.Set:
+			cmds[i] = pipeline.Set(ctx, c.key(op.Key), op.Value, c.ttl)
+		case storage.Delete:
+			cmds[i] = pipeline.Del(ctx, c.key(op.Key))
+		default:
+			return errors.New("wrong operation type")
+		}
+	}
+
+	// Exec only returns the first error of the commands, redis.Nil when a key to get
+	// is not found, so the error of every command is checked.
+	if _, err := pipeline.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return err
+	}
+
+	for i, cmd := range cmds {
+		if ops[i].Type != storage.Get {
+			if err := cmd.Err(); err != nil {
+				return err
+			}
+			continue
+		}
+		value, err := cmd.(*redis.StringCmd).Bytes()
+		switch {
+		case errors.Is(err, redis.Nil):
+			ops[i].Value = nil
+		case err != nil:
+			return err
+		default:
+			ops[i].Value = value
+		}
+	}
+	return nil
+}
+
+// Close is a no-op, the connection to the Redis server is shared by the clients and closed
+// when the extension shuts down.
+func (c *redisStorageClient) Close(_ context.Context) error {
+	return nil
+}
+
+func (c *redisStorageClient) key(key string) string {
+	return c.namespace + ":" + key
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redisstorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/redisstorage"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+// Config defines configuration for the Redis storage extension.
+type Config struct {
+	// Endpoint is the address of the Redis server, in the host:port format.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Optional username, to authenticate with the Redis ACL system.
+	Username string `mapstructure:"username"`
+
+	// Optional password, matching the requirepass server option or the password of the user.
+	Password configopaque.String `mapstructure:"password"`
+
+	// DB is the index of the Redis database to use.
+	DB int `mapstructure:"db"`
+
+	TLS configtls.TLSClientSetting `mapstructure:"tls,omitempty"`
+
+	// Prefix is prepended to all the keys, to share a Redis database between Collectors.
+	Prefix string `mapstructure:"prefix"`
+
+	// TTL is how long keys are kept after they are last set. Keys never expire when 0.
+	TTL time.Duration `mapstructure:"ttl"`
+}
+
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("missing endpoint")
+	}
+	if cfg.DB < 0 {
+		return errors.New("db must not be negative")
+	}
+	if cfg.TTL < 0 {
+		return errors.New("ttl must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redisstorage
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/redisstorage/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id       component.ID
+		expected component.Config
+	}{
+		{
+			id:       component.NewID(metadata.Type),
+			expected: NewFactory().CreateDefaultConfig(),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "all_settings"),
+			expected: &Config{
+				Endpoint: "redis.example.com:6380",
+				Username: "otel",
+				Password: "secret",
+				DB:       2,
+				TLS: configtls.TLSClientSetting{
+					TLSSetting: configtls.TLSSetting{
+						CAFile: "ca.pem",
+					},
+				},
+				Prefix: "collector-1:",
+				TTL:    24 * time.Hour,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    Config
+		errWanted error
+	}{
+		{
+			"Missing endpoint",
+			Config{},
+			errors.New("missing endpoint"),
+		},
+		{
+			"Negative db",
+			Config{Endpoint: "localhost:6379", DB: -1},
+			errors.New("db must not be negative"),
+		},
+		{
+			"Negative ttl",
+			Config{Endpoint: "localhost:6379", TTL: -time.Second},
+			errors.New("ttl must not be negative"),
+		},
+		{
+			"valid",
+			Config{Endpoint: "localhost:6379", TTL: time.Hour},
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		err := test.config.Validate()
+		if test.errWanted == nil {
+			assert.NoError(t, err)
+		} else {
+			assert.Equal(t, test.errWanted, err)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redisstorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/redisstorage"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
+)
+
+type redisStorage struct {
+	cfg    *Config
+	logger *zap.Logger
+	client *redis.Client
+}
+
+// Ensure this storage extension implements the appropriate interface
+var _ storage.Extension = (*redisStorage)(nil)
+
+func newRedisStorage(logger *zap.Logger, config *Config) *redisStorage {
+	return &redisStorage{
+		cfg:    config,
+		logger: logger,
+	}
+}
+
+// Start connects to the Redis server
+func (rs *redisStorage) Start(ctx context.Context, _ component.Host) error {
+	tlsConfig, err := rs.cfg.TLS.LoadTLSConfig()
+	if err != nil {
+		return err
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:      rs.cfg.Endpoint,
+		Username:  rs.cfg.Username,
+		Password:  string(rs.cfg.Password),
+		DB:        rs.cfg.DB,
+		TLSConfig: tlsConfig,
+	})
+	if err = client.Ping(ctx).Err(); err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", rs.cfg.Endpoint, err)
+	}
+	rs.client = client
+	return nil
+}
+
+// Shutdown closes the connection to the Redis server
+func (rs *redisStorage) Shutdown(context.Context) error {
+	if rs.client == nil {
+		return nil
+	}
+	return rs.client.Close()
+}
+
+// GetClient returns a storage client for an individual component. The keys of the client
+// are namespaced with the kind, type and name of the component.
+func (rs *redisStorage) GetClient(_ context.Context, kind component.Kind, ent component.ID, name string) (storage.Client, error) {
+	var namespace string
+	if name == "" {
+		namespace = fmt.Sprintf("%s%s_%s_%s", rs.cfg.Prefix, kindString(kind), ent.Type(), ent.Name())
+	} else {
+		namespace = fmt.Sprintf("%s%s_%s_%s_%s", rs.cfg.Prefix, kindString(kind), ent.Type(), ent.Name(), name)
+	}
+	namespace = strings.ReplaceAll(namespace, " ", "")
+	return newClient(rs.client, namespace, rs.cfg.TTL), nil
+}
+
+func kindString(k component.Kind) string {
+	switch k {
+	case component.KindReceiver:
+		return "receiver"
+	case component.KindProcessor:
+		return "processor"
+	case component.KindExporter:
+		return "exporter"
+	case component.KindExtension:
+		return "extension"
+	case component.KindConnector:
+		return "connector"
+	default:
+		return "other" // not expected
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redisstorage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+)
+
+func TestExtensionNamespacing(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	se := newTestExtension(t, mr, func(cfg *Config) {
+		cfg.Prefix = "collector-1:"
+	})
+
+	receiver, err := se.GetClient(ctx, component.KindReceiver, component.MustNewIDWithName("filelog", "app"), "")
+	require.NoError(t, err)
+	exporter, err := se.GetClient(ctx, component.KindExporter, component.MustNewID("otlp"), "sending_queue")
+	require.NoError(t, err)
+
+	require.NoError(t, receiver.Set(ctx, "key", []byte("receiver")))
+	require.NoError(t, exporter.Set(ctx, "key", []byte("exporter")))
+
+	value, err := receiver.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("receiver"), value)
+	value, err = exporter.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("exporter"), value)
+	assert.ElementsMatch(t, []string{
+		"collector-1:receiver_filelog_app:key",
+		"collector-1:exporter_otlp__sending_queue:key",
+	}, mr.Keys())
+
+	require.NoError(t, receiver.Delete(ctx, "key"))
+	value, err = receiver.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+	value, err = exporter.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("exporter"), value)
+
+	require.NoError(t, receiver.Close(ctx))
+	require.NoError(t, exporter.Close(ctx))
+}
+
+func TestTTL(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	se := newTestExtension(t, mr, func(cfg *Config) {
+		cfg.TTL = time.Minute
+	})
+
+	client, err := se.GetClient(ctx, component.KindReceiver, component.MustNewID("filelog"), "")
+	require.NoError(t, err)
+
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	require.NoError(t, client.Batch(ctx, storage.SetOperation("batch", []byte("value"))))
+	assert.Equal(t, time.Minute, mr.TTL("receiver_filelog_:key"))
+	assert.Equal(t, time.Minute, mr.TTL("receiver_filelog_:batch"))
+
+	mr.FastForward(time.Minute)
+	value, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestBatch(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	se := newTestExtension(t, mr)
+
+	client, err := se.GetClient(ctx, component.KindExporter, component.MustNewID("otlp"), "")
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "deleted", []byte("value")))
+
+	getOp := storage.GetOperation("key")
+	missingOp := storage.GetOperation("missing")
+	missingOp.Value = []byte("stale")
+	deletedOp := storage.GetOperation("deleted")
+	require.NoError(t, client.Batch(ctx,
+		storage.SetOperation("key", []byte("value")),
+		getOp,
+		missingOp,
+		storage.DeleteOperation("deleted"),
+		deletedOp,
+	))
+	assert.Equal(t, []byte("value"), getOp.Value)
+	assert.Nil(t, missingOp.Value)
+	assert.Nil(t, deletedOp.Value)
+	assert.False(t, mr.Exists("exporter_otlp_:deleted"))
+
+	invalidOp := storage.GetOperation("key")
+	invalidOp.Type = -1
+	err = client.Batch(ctx, storage.SetOperation("key", []byte("new value")), invalidOp)
+	require.EqualError(t, err, "wrong operation type")
+	value, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+}
+
+// failingSetHook fails the SET commands of pipelines, after they were sent.
+type failingSetHook struct{}
+
+func (failingSetHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (failingSetHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return next
+}
+
+func (failingSetHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		err := next(ctx, cmds)
+		for _, cmd := range cmds {
+			if cmd.Name() == "set" {
+				cmd.SetErr(errors.New("set failed"))
+			}
+		}
+		return err
+	}
+}
+
+func TestBatchCommandError(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	se := newTestExtension(t, mr)
+	se.(*redisStorage).client.AddHook(failingSetHook{})
+
+	client, err := se.GetClient(ctx, component.KindExporter, component.MustNewID("otlp"), "")
+	require.NoError(t, err)
+
+	// Exec returns the redis.Nil error of the missing key, which comes first.
+	err = client.Batch(ctx, storage.GetOperation("missing"), storage.SetOperation("key", []byte("value")))
+	require.EqualError(t, err, "set failed")
+}
+
+func TestStartFailsWhenServerIsUnreachable(t *testing.T) {
+	mr := miniredis.NewMiniRedis()
+	require.NoError(t, mr.Start())
+	endpoint := mr.Addr()
+	mr.Close()
+
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	ext, err := f.CreateExtension(context.Background(), extensiontest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.ErrorContains(t, ext.Start(context.Background(), componenttest.NewNopHost()), "failed to connect to")
+	require.NoError(t, ext.Shutdown(context.Background()))
+}
+
+func newTestExtension(t *testing.T, mr *miniredis.Miniredis, opts ...func(*Config)) storage.Extension {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Endpoint = mr.Addr()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	ext, err := f.CreateExtension(context.Background(), extensiontest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, ext.Shutdown(context.Background()))
+	})
+
+	se, ok := ext.(storage.Extension)
+	require.True(t, ok)
+	return se
+}
@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redisstorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/redisstorage"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/extension"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/redisstorage/internal/metadata"
+)
+
+const defaultEndpoint = "localhost:6379"
+
+// NewFactory creates a factory for the Redis storage extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		createExtension,
+		metadata.ExtensionStability,
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Endpoint: defaultEndpoint,
+		TLS: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+	}
+}
+
+func createExtension(
+	_ context.Context,
+	params extension.CreateSettings,
+	cfg component.Config,
+) (extension.Extension, error) {
+	return newRedisStorage(params.Logger, cfg.(*Config)), nil
+}
@@ -0,0 +1,6 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package s3partition renders the partition keys of the objects written by the
+// awss3exporter, so that the awss3receiver lists the same keys.
+package s3partition // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/s3partition"
@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package s3partition // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/s3partition"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// ErrResourceUnknown is returned when a template uses the resource attributes of a key
+// rendered without a resource.
+var ErrResourceUnknown = errors.New("resource attributes are not supported, the keys cannot be listed without knowing their values")
+
+// TimeKey returns the time partition of s3_partition, e.g.
+// year=2024/month=03/day=01/hour=10/minute=05.
+func TimeKey(t time.Time, partition string) string {
+	year, month, day := t.Date()
+	hour, minute, _ := t.Clock()
+
+	if partition == "hour" {
+		return fmt.Sprintf("year=%d/month=%02d/day=%02d/hour=%02d", year, month, day, hour)
+	}
+	return fmt.Sprintf("year=%d/month=%02d/day=%02d/hour=%02d/minute=%02d", year, month, day, hour, minute)
+}
+
+// data is the data available to s3_partition_template.
+type data struct {
+	// Time is the export time.
+	Time time.Time
+	// Signal is one of "logs", "metrics" or "traces".
+	Signal string
+	// Partition is the time partition built from s3_partition.
+	Partition string
+
+	resource    pcommon.Resource
+	hasResource bool
+}
+
+// Resource returns the string value of the resource attribute key, usable within a
+// single segment of the key, or an empty string when the resource does not have it.
+func (d data) Resource(key string) (string, error) {
+	if !d.hasResource {
+		return "", ErrResourceUnknown
+	}
+	if v, ok := d.resource.Attributes().Get(key); ok {
+		return sanitizeSegment(v.AsString()), nil
+	}
+	return "", nil
+}
+
+// sanitizeSegment replaces the path separators, whitespaces and control
+// characters of value, as well as the dots of the "." and ".." segments.
+func sanitizeSegment(value string) string {
+	value = strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || unicode.IsSpace(r) || unicode.IsControl(r) {
+			return '_'
+		}
+		return r
+	}, value)
+	if strings.Trim(value, ".") == "" {
+		return strings.Repeat("_", len(value))
+	}
+	return value
+}
+
+var funcs = template.FuncMap{
+	// default returns value, or def when value is empty:
+	// {{ .Resource "tenant" | default "unknown" }}
+	"default": func(def string, value string) string {
+		if value == "" {
+			return def
+		}
+		return value
+	},
+	// truncate rounds t down to a multiple of the given duration:
+	// {{ (truncate "15m" .Time).Format "15-04" }}
+	"truncate": func(d string, t time.Time) (time.Time, error) {
+		duration, err := time.ParseDuration(d)
+		if err != nil {
+			return t, err
+		}
+		return t.Truncate(duration), nil
+	},
+}
+
+// Partitioner renders the part of the object keys between s3_prefix and file_prefix.
+// Without a template it is the time partition.
+type Partitioner struct {
+	tmpl      *template.Template
+	partition string
+}
+
+// NewPartitioner returns a Partitioner for the s3_partition and s3_partition_template
+// settings. The template is optional.
+func NewPartitioner(partition string, templateText string) (*Partitioner, error) {
+	p := &Partitioner{partition: partition}
+	if templateText == "" {
+		return p, nil
+	}
+	tmpl, err := template.New("s3_partition_template").Option("missingkey=error").Funcs(funcs).Parse(templateText)
+	if err != nil {
+		return nil, err
+	}
+	p.tmpl = tmpl
+	return p, nil
+}
+
+// Templated reports whether the keys depend on a template, and so possibly on the
+// resource.
+func (p *Partitioner) Templated() bool {
+	return p.tmpl != nil
+}
+
+// Key returns the partition key of the objects of a signal holding data of the resource,
+// exported at t.
+func (p *Partitioner) Key(t time.Time, signal string, resource pcommon.Resource) (string, error) {
+	return p.render(data{Time: t, Signal: signal, resource: resource, hasResource: true})
+}
+
+// ListKey returns the partition key of the objects of a signal exported at t, whatever
+// their resource. It fails with ErrResourceUnknown when the template uses resource
+// attributes.
+func (p *Partitioner) ListKey(t time.Time, signal string) (string, error) {
+	return p.render(data{Time: t, Signal: signal})
+}
+
+func (p *Partitioner) render(d data) (string, error) {
+	d.Partition = TimeKey(d.Time, p.partition)
+	if p.tmpl == nil {
+		return d.Partition, nil
+	}
+
+	var sb strings.Builder
+	if err := p.tmpl.Execute(&sb, d); err != nil {
+		return "", fmt.Errorf("failed to render s3_partition_template: %w", err)
+	}
+	// Empty segments, e.g. from missing attributes, are dropped.
+	segments := strings.FieldsFunc(sb.String(), func(r rune) bool { return r == '/' })
+	if len(segments) == 0 {
+		return d.Partition, nil
+	}
+	return strings.Join(segments, "/"), nil
+}
@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package s3partition
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestTimeKey(t *testing.T) {
+	now := time.Date(2024, 3, 1, 10, 37, 12, 0, time.UTC)
+	assert.Equal(t, "year=2024/month=03/day=01/hour=10", TimeKey(now, "hour"))
+	assert.Equal(t, "year=2024/month=03/day=01/hour=10/minute=37", TimeKey(now, "minute"))
+}
+
+func TestKey(t *testing.T) {
+	now := time.Date(2024, 3, 1, 10, 37, 12, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		template string
+		attrs    map[string]any
+		want     string
+		wantErr  bool
+	}{
+		{
+			name: "no template",
+			want: "year=2024/month=03/day=01/hour=10/minute=37",
+		},
+		{
+			name:     "resource attributes",
+			template: `tenant={{ .Resource "tenant" }}/service={{ .Resource "service.name" }}`,
+			attrs:    map[string]any{"tenant": "acme", "service.name": "checkout"},
+			want:     "tenant=acme/service=checkout",
+		},
+		{
+			name:     "default value for missing attribute",
+			template: `tenant={{ .Resource "tenant" | default "unknown" }}`,
+			want:     "tenant=unknown",
+		},
+		{
+			name:     "non string attribute",
+			template: `shard={{ .Resource "shard" }}`,
+			attrs:    map[string]any{"shard": 7},
+			want:     "shard=7",
+		},
+		{
+			name:     "path separators and relative segments",
+			template: `tenant={{ .Resource "tenant" }}/{{ .Resource "service.name" }}/{{ .Resource "host.name" }}`,
+			attrs:    map[string]any{"tenant": "a/../b", "service.name": "..", "host.name": "my host"},
+			want:     "tenant=a_.._b/__/my_host",
+		},
+		{
+			name:     "empty segments",
+			template: `{{ .Resource "tenant" }}//service={{ .Resource "service.name" }}/{{ .Resource "missing" }}`,
+			attrs:    map[string]any{"tenant": "", "service.name": "checkout"},
+			want:     "service=checkout",
+		},
+		{
+			name:     "only empty segments",
+			template: `{{ .Resource "tenant" }}/{{ .Resource "missing" }}`,
+			attrs:    map[string]any{"tenant": ""},
+			want:     "year=2024/month=03/day=01/hour=10/minute=37",
+		},
+		{
+			name:     "time bucket",
+			template: `dt={{ .Time.Format "2006-01-02" }}/bucket={{ (truncate "15m" .Time).Format "15-04" }}`,
+			want:     "dt=2024-03-01/bucket=10-30",
+		},
+		{
+			name:     "signal and default partition",
+			template: `/{{ .Signal }}/{{ .Partition }}/`,
+			want:     "logs/year=2024/month=03/day=01/hour=10/minute=37",
+		},
+		{
+			name:     "invalid duration",
+			template: `{{ truncate "fifteen" .Time }}`,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewPartitioner("minute", tt.template)
+			require.NoError(t, err)
+
+			resource := pcommon.NewResource()
+			require.NoError(t, resource.Attributes().FromRaw(tt.attrs))
+
+			key, err := p.Key(now, "logs", resource)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, key)
+		})
+	}
+}
+
+func TestListKey(t *testing.T) {
+	now := time.Date(2024, 3, 1, 10, 37, 12, 0, time.UTC)
+
+	p, err := NewPartitioner("hour", `{{ .Signal }}/dt={{ .Time.Format "2006-01-02" }}/{{ .Partition }}`)
+	require.NoError(t, err)
+	key, err := p.ListKey(now, "traces")
+	require.NoError(t, err)
+	assert.Equal(t, "traces/dt=2024-03-01/year=2024/month=03/day=01/hour=10", key)
+
+	p, err = NewPartitioner("hour", `tenant={{ .Resource "tenant" | default "unknown" }}`)
+	require.NoError(t, err)
+	_, err = p.ListKey(now, "traces")
+	assert.ErrorIs(t, err, ErrResourceUnknown)
+}
+
+func TestInvalidTemplate(t *testing.T) {
+	_, err := NewPartitioner("minute", `{{ .Resource "tenant" `)
+	assert.Error(t, err)
+}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumerretry // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/consumerretry"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type metricsConsumer struct {
+	consumer.Metrics
+	cfg    Config
+	logger *zap.Logger
+}
+
+func NewMetrics(config Config, logger *zap.Logger, next consumer.Metrics) consumer.Metrics {
+	return &metricsConsumer{
+		Metrics: next,
+		cfg:     config,
+		logger:  logger,
+	}
+}
+
+func (mc *metricsConsumer) ConsumeMetrics(ctx context.Context, metrics pmetric.Metrics) error {
+	if !mc.cfg.Enabled {
+		err := mc.Metrics.ConsumeMetrics(ctx, metrics)
+		if err != nil {
+			mc.logger.Error("ConsumeMetrics() failed. "+
+				"Enable retry_on_failure to slow down reading metrics and avoid dropping.", zap.Error(err))
+		}
+		return err
+	}
+
+	// Do not use NewExponentialBackOff since it calls Reset and the code here must
+	// call Reset after changing the InitialInterval (this saves an unnecessary call to Now).
+	expBackoff := backoff.ExponentialBackOff{
+		MaxElapsedTime:      mc.cfg.MaxElapsedTime,
+		InitialInterval:     mc.cfg.InitialInterval,
+		MaxInterval:         mc.cfg.MaxInterval,
+		RandomizationFactor: backoff.DefaultRandomizationFactor,
+		Multiplier:          backoff.DefaultMultiplier,
+		Stop:                backoff.Stop,
+		Clock:               backoff.SystemClock,
+	}
+	expBackoff.Reset()
+
+	span := trace.SpanFromContext(ctx)
+	retryNum := int64(0)
+	retryableErr := consumererror.Metrics{}
+	for {
+		span.AddEvent(
+			"Sending metrics.",
+			trace.WithAttributes(attribute.Int64("retry_num", retryNum)))
+
+		err := mc.Metrics.ConsumeMetrics(ctx, metrics)
+		if err == nil {
+			return nil
+		}
+
+		if consumererror.IsPermanent(err) {
+			mc.logger.Error(
+				"ConsumeMetrics() failed. The error is not retryable. Dropping data.",
+				zap.Error(err),
+				zap.Int("dropped_items", metrics.DataPointCount()),
+			)
+			return err
+		}
+
+		if errors.As(err, &retryableErr) {
+			metrics = retryableErr.Data()
+		}
+
+		// TODO: take delay from the error once it is available in the consumererror package.
+		backoffDelay := expBackoff.NextBackOff()
+		if backoffDelay == backoff.Stop {
+			mc.logger.Error("Max elapsed time expired. Dropping data.", zap.Error(err), zap.Int("dropped_items",
+				metrics.DataPointCount()))
+			return err
+		}
+
+		backoffDelayStr := backoffDelay.String()
+		span.AddEvent(
+			"ConsumeMetrics() failed. Will retry the request after interval.",
+			trace.WithAttributes(
+				attribute.String("interval", backoffDelayStr),
+				attribute.String("error", err.Error())))
+		mc.logger.Debug(
+			"ConsumeMetrics() failed. Will retry the request after interval.",
+			zap.Error(err),
+			zap.String("interval", backoffDelayStr),
+			zap.Int("data_points_count", metrics.DataPointCount()),
+		)
+		retryNum++
+
+		// back-off, but get interrupted when shutting down or request is cancelled or timed out.
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("context is cancelled or timed out %w", err)
+		case <-time.After(backoffDelay):
+		}
+	}
+}
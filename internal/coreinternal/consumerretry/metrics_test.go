@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumerretry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
+)
+
+func TestConsumeMetrics(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         Config
+		consumer    *MockMetricsRejecter
+		expectedErr error
+	}{
+		{
+			name:        "no_retry_success",
+			expectedErr: nil,
+			cfg:         NewDefaultConfig(),
+			consumer:    NewMockMetricsRejecter(0),
+		},
+		{
+			name:        "permanent_error",
+			expectedErr: consumererror.NewPermanent(errors.New("permanent error")),
+			cfg:         Config{Enabled: true},
+			consumer:    NewMockMetricsRejecter(-1),
+		},
+		{
+			name:        "timeout_error",
+			expectedErr: errors.New("retry later"),
+			cfg: Config{
+				Enabled:         true,
+				InitialInterval: 1 * time.Millisecond,
+				MaxInterval:     5 * time.Millisecond,
+				MaxElapsedTime:  10 * time.Millisecond,
+			},
+			consumer: NewMockMetricsRejecter(20),
+		},
+		{
+			name:        "retry_success",
+			expectedErr: nil,
+			cfg: Config{
+				Enabled:         true,
+				InitialInterval: 1 * time.Millisecond,
+				MaxInterval:     2 * time.Millisecond,
+				MaxElapsedTime:  100 * time.Millisecond,
+			},
+			consumer: NewMockMetricsRejecter(5),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			consumer := NewMetrics(tt.cfg, zap.NewNop(), tt.consumer)
+			err := consumer.ConsumeMetrics(context.Background(), testdata.GenerateMetricsTwoMetrics())
+			assert.Equal(t, tt.expectedErr, err)
+			if err == nil {
+				assert.Equal(t, 1, len(tt.consumer.AllMetrics()))
+				assert.Equal(t, 2, tt.consumer.AllMetrics()[0].MetricCount())
+				if tt.consumer.acceptAfter > 0 {
+					assert.Equal(t, tt.consumer.rejectCount.Load(), tt.consumer.acceptAfter)
+				}
+			} else if tt.consumer.acceptAfter > 0 {
+				assert.Less(t, tt.consumer.rejectCount.Load(), tt.consumer.acceptAfter)
+			}
+		})
+	}
+}
+
+func TestConsumeMetrics_ContextDeadline(t *testing.T) {
+	consumer := NewMetrics(Config{
+		Enabled:         true,
+		InitialInterval: 1 * time.Millisecond,
+		MaxInterval:     5 * time.Millisecond,
+		MaxElapsedTime:  50 * time.Millisecond,
+	}, zap.NewNop(), NewMockMetricsRejecter(10))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	err := consumer.ConsumeMetrics(ctx, testdata.GenerateMetricsTwoMetrics())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "context is cancelled or timed out retry later")
+}
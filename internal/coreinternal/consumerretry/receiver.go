@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumerretry // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/consumerretry"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.uber.org/zap"
+)
+
+// untilShutdownMaxInterval is the upper bound on the backoff interval of NewUntilShutdown.
+const untilShutdownMaxInterval = 30 * time.Second
+
+// Consumers are the next consumers of a receiver. Only those of the signals it receives
+// are set.
+type Consumers struct {
+	Logs    consumer.Logs
+	Metrics consumer.Metrics
+	Traces  consumer.Traces
+}
+
+// NewUntilShutdown wraps the consumers which are set so that the retryable errors of the
+// pipeline are retried, with an exponential backoff from initialInterval up to 30 seconds,
+// until the context of the data is done. It is meant for receivers which let their source
+// deliver the data again when it is not consumed.
+func NewUntilShutdown(initialInterval time.Duration, logger *zap.Logger, next Consumers) Consumers {
+	cfg := Config{
+		Enabled:         true,
+		InitialInterval: initialInterval,
+		MaxInterval:     untilShutdownMaxInterval,
+	}
+	if next.Logs != nil {
+		next.Logs = NewLogs(cfg, logger, next.Logs)
+	}
+	if next.Metrics != nil {
+		next.Metrics = NewMetrics(cfg, logger, next.Metrics)
+	}
+	if next.Traces != nil {
+		next.Traces = NewTraces(cfg, logger, next.Traces)
+	}
+	return next
+}
@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumerretry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
+)
+
+func TestNewUntilShutdown(t *testing.T) {
+	logs := NewMockLogsRejecter(5)
+	next := NewUntilShutdown(time.Millisecond, zap.NewNop(), Consumers{Logs: logs})
+	require.NotNil(t, next.Logs)
+	assert.Nil(t, next.Metrics)
+	assert.Nil(t, next.Traces)
+
+	// Retryable errors are retried without bound on the elapsed time.
+	require.NoError(t, next.Logs.ConsumeLogs(context.Background(), testdata.GenerateLogsManyLogRecordsSameResource(1)))
+	assert.Len(t, logs.AllLogs(), 1)
+
+	// Until the context is done.
+	traces := NewMockTracesRejecter(1 << 30)
+	next = NewUntilShutdown(time.Millisecond, zap.NewNop(), Consumers{Traces: traces})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorContains(t, next.Traces.ConsumeTraces(ctx, testdata.GenerateTracesOneSpan()), "context is cancelled or timed out")
+	assert.Empty(t, traces.AllTraces())
+}
@@ -11,6 +11,8 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
 type MockLogsRejecter struct {
@@ -39,6 +41,58 @@ func (m *MockLogsRejecter) ConsumeLogs(ctx context.Context, logs plog.Logs) erro
 	return m.LogsSink.ConsumeLogs(ctx, logs)
 }
 
+type MockMetricsRejecter struct {
+	consumertest.MetricsSink
+	rejectCount *atomic.Int32
+	acceptAfter int32
+}
+
+// NewMockMetricsRejecter creates new MockMetricsRejecter. acceptAfter is a number of rejects before accepting,
+// 0 means always accept, -1 means always reject with permanent error
+func NewMockMetricsRejecter(acceptAfter int32) *MockMetricsRejecter {
+	return &MockMetricsRejecter{
+		acceptAfter: acceptAfter,
+		rejectCount: &atomic.Int32{},
+	}
+}
+
+func (m *MockMetricsRejecter) ConsumeMetrics(ctx context.Context, metrics pmetric.Metrics) error {
+	if m.acceptAfter < 0 {
+		return consumererror.NewPermanent(errors.New("permanent error"))
+	}
+	if m.rejectCount.Load() < m.acceptAfter {
+		m.rejectCount.Add(1)
+		return errors.New("retry later")
+	}
+	return m.MetricsSink.ConsumeMetrics(ctx, metrics)
+}
+
+type MockTracesRejecter struct {
+	consumertest.TracesSink
+	rejectCount *atomic.Int32
+	acceptAfter int32
+}
+
+// NewMockTracesRejecter creates new MockTracesRejecter. acceptAfter is a number of rejects before accepting,
+// 0 means always accept, -1 means always reject with permanent error
+func NewMockTracesRejecter(acceptAfter int32) *MockTracesRejecter {
+	return &MockTracesRejecter{
+		acceptAfter: acceptAfter,
+		rejectCount: &atomic.Int32{},
+	}
+}
+
+func (m *MockTracesRejecter) ConsumeTraces(ctx context.Context, traces ptrace.Traces) error {
+	if m.acceptAfter < 0 {
+		return consumererror.NewPermanent(errors.New("permanent error"))
+	}
+	if m.rejectCount.Load() < m.acceptAfter {
+		m.rejectCount.Add(1)
+		return errors.New("retry later")
+	}
+	return m.TracesSink.ConsumeTraces(ctx, traces)
+}
+
 // mockPartialLogsRejecter is a mock LogsConsumer that accepts only one logs object and rejects the rest.
 type mockPartialLogsRejecter struct {
 	consumertest.LogsSink
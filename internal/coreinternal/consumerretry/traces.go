@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumerretry // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/consumerretry"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type tracesConsumer struct {
+	consumer.Traces
+	cfg    Config
+	logger *zap.Logger
+}
+
+func NewTraces(config Config, logger *zap.Logger, next consumer.Traces) consumer.Traces {
+	return &tracesConsumer{
+		Traces: next,
+		cfg:    config,
+		logger: logger,
+	}
+}
+
+func (tc *tracesConsumer) ConsumeTraces(ctx context.Context, traces ptrace.Traces) error {
+	if !tc.cfg.Enabled {
+		err := tc.Traces.ConsumeTraces(ctx, traces)
+		if err != nil {
+			tc.logger.Error("ConsumeTraces() failed. "+
+				"Enable retry_on_failure to slow down reading traces and avoid dropping.", zap.Error(err))
+		}
+		return err
+	}
+
+	// Do not use NewExponentialBackOff since it calls Reset and the code here must
+	// call Reset after changing the InitialInterval (this saves an unnecessary call to Now).
+	expBackoff := backoff.ExponentialBackOff{
+		MaxElapsedTime:      tc.cfg.MaxElapsedTime,
+		InitialInterval:     tc.cfg.InitialInterval,
+		MaxInterval:         tc.cfg.MaxInterval,
+		RandomizationFactor: backoff.DefaultRandomizationFactor,
+		Multiplier:          backoff.DefaultMultiplier,
+		Stop:                backoff.Stop,
+		Clock:               backoff.SystemClock,
+	}
+	expBackoff.Reset()
+
+	span := trace.SpanFromContext(ctx)
+	retryNum := int64(0)
+	retryableErr := consumererror.Traces{}
+	for {
+		span.AddEvent(
+			"Sending traces.",
+			trace.WithAttributes(attribute.Int64("retry_num", retryNum)))
+
+		err := tc.Traces.ConsumeTraces(ctx, traces)
+		if err == nil {
+			return nil
+		}
+
+		if consumererror.IsPermanent(err) {
+			tc.logger.Error(
+				"ConsumeTraces() failed. The error is not retryable. Dropping data.",
+				zap.Error(err),
+				zap.Int("dropped_items", traces.SpanCount()),
+			)
+			return err
+		}
+
+		if errors.As(err, &retryableErr) {
+			traces = retryableErr.Data()
+		}
+
+		// TODO: take delay from the error once it is available in the consumererror package.
+		backoffDelay := expBackoff.NextBackOff()
+		if backoffDelay == backoff.Stop {
+			tc.logger.Error("Max elapsed time expired. Dropping data.", zap.Error(err), zap.Int("dropped_items",
+				traces.SpanCount()))
+			return err
+		}
+
+		backoffDelayStr := backoffDelay.String()
+		span.AddEvent(
+			"ConsumeTraces() failed. Will retry the request after interval.",
+			trace.WithAttributes(
+				attribute.String("interval", backoffDelayStr),
+				attribute.String("error", err.Error())))
+		tc.logger.Debug(
+			"ConsumeTraces() failed. Will retry the request after interval.",
+			zap.Error(err),
+			zap.String("interval", backoffDelayStr),
+			zap.Int("spans_count", traces.SpanCount()),
+		)
+		retryNum++
+
+		// back-off, but get interrupted when shutting down or request is cancelled or timed out.
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("context is cancelled or timed out %w", err)
+		case <-time.After(backoffDelay):
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumerretry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
+)
+
+func TestConsumeTraces(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         Config
+		consumer    *MockTracesRejecter
+		expectedErr error
+	}{
+		{
+			name:        "no_retry_success",
+			expectedErr: nil,
+			cfg:         NewDefaultConfig(),
+			consumer:    NewMockTracesRejecter(0),
+		},
+		{
+			name:        "permanent_error",
+			expectedErr: consumererror.NewPermanent(errors.New("permanent error")),
+			cfg:         Config{Enabled: true},
+			consumer:    NewMockTracesRejecter(-1),
+		},
+		{
+			name:        "timeout_error",
+			expectedErr: errors.New("retry later"),
+			cfg: Config{
+				Enabled:         true,
+				InitialInterval: 1 * time.Millisecond,
+				MaxInterval:     5 * time.Millisecond,
+				MaxElapsedTime:  10 * time.Millisecond,
+			},
+			consumer: NewMockTracesRejecter(20),
+		},
+		{
+			name:        "retry_success",
+			expectedErr: nil,
+			cfg: Config{
+				Enabled:         true,
+				InitialInterval: 1 * time.Millisecond,
+				MaxInterval:     2 * time.Millisecond,
+				MaxElapsedTime:  100 * time.Millisecond,
+			},
+			consumer: NewMockTracesRejecter(5),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			consumer := NewTraces(tt.cfg, zap.NewNop(), tt.consumer)
+			err := consumer.ConsumeTraces(context.Background(), testdata.GenerateTracesTwoSpansSameResource())
+			assert.Equal(t, tt.expectedErr, err)
+			if err == nil {
+				assert.Equal(t, 1, len(tt.consumer.AllTraces()))
+				assert.Equal(t, 2, tt.consumer.AllTraces()[0].SpanCount())
+				if tt.consumer.acceptAfter > 0 {
+					assert.Equal(t, tt.consumer.rejectCount.Load(), tt.consumer.acceptAfter)
+				}
+			} else if tt.consumer.acceptAfter > 0 {
+				assert.Less(t, tt.consumer.rejectCount.Load(), tt.consumer.acceptAfter)
+			}
+		})
+	}
+}
+
+func TestConsumeTraces_ContextDeadline(t *testing.T) {
+	consumer := NewTraces(Config{
+		Enabled:         true,
+		InitialInterval: 1 * time.Millisecond,
+		MaxInterval:     5 * time.Millisecond,
+		MaxElapsedTime:  50 * time.Millisecond,
+	}, zap.NewNop(), NewMockTracesRejecter(10))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	err := consumer.ConsumeTraces(ctx, testdata.GenerateTracesTwoSpansSameResource())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "context is cancelled or timed out retry later")
+}
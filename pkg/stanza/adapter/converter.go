@@ -147,23 +147,7 @@ func (c *Converter) workerLoop() {
 				return
 			}
 
-			resourceHashToIdx := make(map[uint64]int)
-
-			pLogs := plog.NewLogs()
-			var sl plog.ScopeLogs
-			for _, e := range entries {
-				resourceID := HashResource(e.Resource)
-				resourceIdx, ok := resourceHashToIdx[resourceID]
-				if !ok {
-					resourceHashToIdx[resourceID] = pLogs.ResourceLogs().Len()
-					rl := pLogs.ResourceLogs().AppendEmpty()
-					upsertToMap(e.Resource, rl.Resource().Attributes())
-					sl = rl.ScopeLogs().AppendEmpty()
-				} else {
-					sl = pLogs.ResourceLogs().At(resourceIdx).ScopeLogs().At(0)
-				}
-				convertInto(e, sl.LogRecords().AppendEmpty())
-			}
+			pLogs := convertEntries(entries)
 
 			// Send plogs directly to flushChan
 			select {
@@ -222,6 +206,28 @@ func (c *Converter) Batch(e []*entry.Entry) error {
 	}
 }
 
+// convertEntries converts a batch of entry.Entry into plog.Logs aggregating them by Resource.
+func convertEntries(entries []*entry.Entry) plog.Logs {
+	resourceHashToIdx := make(map[uint64]int)
+
+	pLogs := plog.NewLogs()
+	var sl plog.ScopeLogs
+	for _, e := range entries {
+		resourceID := HashResource(e.Resource)
+		resourceIdx, ok := resourceHashToIdx[resourceID]
+		if !ok {
+			resourceHashToIdx[resourceID] = pLogs.ResourceLogs().Len()
+			rl := pLogs.ResourceLogs().AppendEmpty()
+			upsertToMap(e.Resource, rl.Resource().Attributes())
+			sl = rl.ScopeLogs().AppendEmpty()
+		} else {
+			sl = pLogs.ResourceLogs().At(resourceIdx).ScopeLogs().At(0)
+		}
+		convertInto(e, sl.LogRecords().AppendEmpty())
+	}
+	return pLogs
+}
+
 // convert converts one entry.Entry into plog.LogRecord allocating it.
 func convert(ent *entry.Entry) plog.LogRecord {
 	dest := plog.NewLogRecord()
@@ -5,6 +5,7 @@ package adapter // import "github.com/open-telemetry/opentelemetry-collector-con
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"time"
 
@@ -23,11 +24,15 @@ type LogEmitter struct {
 	cancel        context.CancelFunc
 	batchMux      sync.Mutex
 	batch         []*entry.Entry
+	batchAcks     []*operator.Acknowledgement
+	acks          map[*entry.Entry][]*operator.Acknowledgement // acknowledgements of the flushed batches, by first entry
 	wg            sync.WaitGroup
 	maxBatchSize  uint
 	flushInterval time.Duration
 }
 
+var errEmitterStopped = errors.New("log emitter stopped")
+
 var (
 	defaultFlushInterval      = 100 * time.Millisecond
 	defaultMaxBatchSize  uint = 100
@@ -74,6 +79,7 @@ func NewLogEmitter(logger *zap.SugaredLogger, opts ...emitterOption) *LogEmitter
 		logChan:       make(chan []*entry.Entry),
 		maxBatchSize:  defaultMaxBatchSize,
 		batch:         make([]*entry.Entry, 0, defaultMaxBatchSize),
+		acks:          map[*entry.Entry][]*operator.Acknowledgement{},
 		flushInterval: defaultFlushInterval,
 		cancel:        func() {},
 	}
@@ -99,6 +105,16 @@ func (e *LogEmitter) Stop() error {
 		e.cancel()
 		e.wg.Wait()
 
+		// The entries of the current batch are not emitted
+		_, acks := e.makeNewBatch()
+		for _, ack := range acks {
+			ack.Release(errEmitterStopped)
+		}
+		// Nor are the flushed batches whose acknowledgements were not taken by the reader
+		for _, ack := range e.takeAllAcknowledgements() {
+			ack.Release(errEmitterStopped)
+		}
+
 		close(e.logChan)
 	})
 
@@ -112,27 +128,32 @@ func (e *LogEmitter) OutChannel() <-chan []*entry.Entry {
 
 // Process will emit an entry to the output channel
 func (e *LogEmitter) Process(ctx context.Context, ent *entry.Entry) error {
-	if oldBatch := e.appendEntry(ent); len(oldBatch) > 0 {
-		e.flush(ctx, oldBatch)
+	ack := operator.AcknowledgementFromContext(ctx)
+	if ack != nil {
+		ack.Hold()
+	}
+	if oldBatch, oldAcks := e.appendEntry(ent, ack); len(oldBatch) > 0 {
+		e.flush(ctx, oldBatch, oldAcks)
 	}
 
 	return nil
 }
 
 // appendEntry appends the entry to the current batch. If maxBatchSize is reached, a new batch will be made, and the old batch
-// (which should be flushed) will be returned
-func (e *LogEmitter) appendEntry(ent *entry.Entry) []*entry.Entry {
+// (which should be flushed) will be returned along with its acknowledgements
+func (e *LogEmitter) appendEntry(ent *entry.Entry, ack *operator.Acknowledgement) ([]*entry.Entry, []*operator.Acknowledgement) {
 	e.batchMux.Lock()
 	defer e.batchMux.Unlock()
 
 	e.batch = append(e.batch, ent)
+	if ack != nil {
+		e.batchAcks = append(e.batchAcks, ack)
+	}
 	if uint(len(e.batch)) >= e.maxBatchSize {
-		var oldBatch []*entry.Entry
-		oldBatch, e.batch = e.batch, make([]*entry.Entry, 0, e.maxBatchSize)
-		return oldBatch
+		return e.swapBatch()
 	}
 
-	return nil
+	return nil, nil
 }
 
 // flusher flushes the current batch every flush interval. Intended to be run as a goroutine
@@ -145,8 +166,8 @@ func (e *LogEmitter) flusher(ctx context.Context) {
 	for {
 		select {
 		case <-ticker.C:
-			if oldBatch := e.makeNewBatch(); len(oldBatch) > 0 {
-				e.flush(ctx, oldBatch)
+			if oldBatch, oldAcks := e.makeNewBatch(); len(oldBatch) > 0 {
+				e.flush(ctx, oldBatch, oldAcks)
 			}
 		case <-ctx.Done():
 			return
@@ -154,24 +175,68 @@ func (e *LogEmitter) flusher(ctx context.Context) {
 	}
 }
 
-// flush flushes the provided batch to the log channel.
-func (e *LogEmitter) flush(ctx context.Context, batch []*entry.Entry) {
+// flush flushes the provided batch to the log channel. The acknowledgements of the batch
+// are released with an error if the emitter is stopped before the batch is read.
+func (e *LogEmitter) flush(ctx context.Context, batch []*entry.Entry, acks []*operator.Acknowledgement) {
+	if len(acks) > 0 {
+		e.batchMux.Lock()
+		e.acks[batch[0]] = acks
+		e.batchMux.Unlock()
+	}
+
 	select {
 	case e.logChan <- batch:
 	case <-ctx.Done():
+		for _, ack := range e.takeAcknowledgements(batch) {
+			ack.Release(errEmitterStopped)
+		}
 	}
 }
 
+// takeAcknowledgements returns the acknowledgements of a flushed batch, which the reader
+// of the log channel must release once the batch was consumed.
+func (e *LogEmitter) takeAcknowledgements(batch []*entry.Entry) []*operator.Acknowledgement {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	e.batchMux.Lock()
+	defer e.batchMux.Unlock()
+
+	acks := e.acks[batch[0]]
+	delete(e.acks, batch[0])
+	return acks
+}
+
+// takeAllAcknowledgements returns the acknowledgements of all the flushed batches which
+// were not taken yet.
+func (e *LogEmitter) takeAllAcknowledgements() []*operator.Acknowledgement {
+	e.batchMux.Lock()
+	defer e.batchMux.Unlock()
+
+	var acks []*operator.Acknowledgement
+	for first, batchAcks := range e.acks {
+		acks = append(acks, batchAcks...)
+		delete(e.acks, first)
+	}
+	return acks
+}
+
 // makeNewBatch replaces the current batch on the log emitter with a new batch, returning the old one
-func (e *LogEmitter) makeNewBatch() []*entry.Entry {
+func (e *LogEmitter) makeNewBatch() ([]*entry.Entry, []*operator.Acknowledgement) {
 	e.batchMux.Lock()
 	defer e.batchMux.Unlock()
 
 	if len(e.batch) == 0 {
-		return nil
+		return nil, nil
 	}
 
-	var oldBatch []*entry.Entry
-	oldBatch, e.batch = e.batch, make([]*entry.Entry, 0, e.maxBatchSize)
-	return oldBatch
+	return e.swapBatch()
+}
+
+// swapBatch replaces the current batch with a new one, returning the old one. It must be called with batchMux held.
+func (e *LogEmitter) swapBatch() ([]*entry.Entry, []*operator.Acknowledgement) {
+	oldBatch, oldAcks := e.batch, e.batchAcks
+	e.batch, e.batchAcks = make([]*entry.Entry, 0, e.maxBatchSize), nil
+	return oldBatch, oldAcks
 }
@@ -12,6 +12,7 @@ import (
 	"go.uber.org/zap/zaptest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 )
 
 func TestLogEmitter(t *testing.T) {
@@ -96,3 +97,59 @@ func TestLogEmitterEmitsOnFlushInterval(t *testing.T) {
 		require.FailNow(t, "Failed to receive log entry before timeout")
 	}
 }
+
+func TestLogEmitterAcknowledgements(t *testing.T) {
+	emitter := NewLogEmitter(zaptest.NewLogger(t).Sugar())
+
+	require.NoError(t, emitter.Start(nil))
+	defer func() {
+		require.NoError(t, emitter.Stop())
+	}()
+
+	done := make(chan error, 1)
+	ack := operator.NewAcknowledgement(func(err error) { done <- err })
+	ctx := operator.ContextWithAcknowledgement(context.Background(), ack)
+	go func() {
+		require.NoError(t, emitter.Process(ctx, complexEntry()))
+		require.NoError(t, emitter.Process(ctx, complexEntry()))
+		ack.Release(nil)
+	}()
+
+	select {
+	case recv := <-emitter.logChan:
+		require.Len(t, recv, 2)
+		acks := emitter.takeAcknowledgements(recv)
+		require.Equal(t, []*operator.Acknowledgement{ack, ack}, acks)
+		require.Empty(t, emitter.takeAcknowledgements(recv))
+		require.Empty(t, done)
+
+		acks[0].Release(nil)
+		acks[1].Release(nil)
+		require.NoError(t, <-done)
+	case <-time.After(time.Second):
+		require.FailNow(t, "Failed to receive log entries before timeout")
+	}
+}
+
+func TestLogEmitterStopReleasesAcknowledgements(t *testing.T) {
+	emitter := NewLogEmitter(zaptest.NewLogger(t).Sugar())
+	require.NoError(t, emitter.Start(nil))
+
+	done := make(chan error, 1)
+	ack := operator.NewAcknowledgement(func(err error) { done <- err })
+	ctx := operator.ContextWithAcknowledgement(context.Background(), ack)
+	require.NoError(t, emitter.Process(ctx, complexEntry()))
+	ack.Release(nil)
+
+	select {
+	case recv := <-emitter.logChan:
+		require.Len(t, recv, 1)
+	case <-time.After(time.Second):
+		require.FailNow(t, "Failed to receive log entries before timeout")
+	}
+
+	// The batch was read, but its acknowledgements were not taken.
+	require.Empty(t, done)
+	require.NoError(t, emitter.Stop())
+	require.ErrorIs(t, <-done, errEmitterStopped)
+}
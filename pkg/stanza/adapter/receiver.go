@@ -11,11 +11,14 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/plog"
 	rcvr "go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/pipeline"
 )
 
@@ -33,6 +36,14 @@ type receiver struct {
 
 	storageID     *component.ID
 	storageClient storage.Client
+
+	acknowledgedChan chan acknowledgedBatch
+}
+
+// acknowledgedBatch is a batch of entries written with acknowledgements.
+type acknowledgedBatch struct {
+	entries []*entry.Entry
+	acks    []*operator.Acknowledgement
 }
 
 // Ensure this receiver adheres to required interface
@@ -53,8 +64,9 @@ func (r *receiver) Start(ctx context.Context, host component.Host) error {
 	}
 
 	r.converter.Start()
+	r.acknowledgedChan = make(chan acknowledgedBatch)
 
-	// Below we're starting 2 loops:
+	// Below we're starting 3 loops:
 	// * one which reads all the logs produced by the emitter and then forwards
 	//   them to converter
 	// ...
@@ -67,6 +79,12 @@ func (r *receiver) Start(ctx context.Context, host component.Host) error {
 	r.wg.Add(1)
 	go r.consumerLoop(rctx)
 
+	// ...
+	// * and a third one which consumes the acknowledged entries forwarded by
+	//   the first one, and releases their acknowledgements.
+	r.wg.Add(1)
+	go r.acknowledgedLoop(rctx)
+
 	// Those 2 loops are started in separate goroutines because batching in
 	// the emitter loop can cause a flush, caused by either reaching the max
 	// flush size or by the configurable ticker which would in turn cause
@@ -95,6 +113,20 @@ func (r *receiver) emitterLoop(ctx context.Context) {
 				continue
 			}
 
+			// Acknowledged entries are consumed by their own loop, so that their
+			// inputs only acknowledge what the consumer accepted without holding
+			// up the entries of the other inputs.
+			if acks := r.emitter.takeAcknowledgements(e); len(acks) > 0 {
+				select {
+				case r.acknowledgedChan <- acknowledgedBatch{entries: e, acks: acks}:
+				case <-doneChan:
+					for _, ack := range acks {
+						ack.Release(errEmitterStopped)
+					}
+				}
+				continue
+			}
+
 			if err := r.converter.Batch(e); err != nil {
 				r.logger.Error("Could not add entry to batch", zap.Error(err))
 			}
@@ -102,6 +134,28 @@ func (r *receiver) emitterLoop(ctx context.Context) {
 	}
 }
 
+// acknowledgedLoop consumes the acknowledged entries read by the emitter loop, and
+// releases their acknowledgements with the error of the consumer.
+func (r *receiver) acknowledgedLoop(ctx context.Context) {
+	defer r.wg.Done()
+
+	// Don't create done channel on every iteration.
+	doneChan := ctx.Done()
+	for {
+		select {
+		case <-doneChan:
+			r.logger.Debug("Acknowledged loop stopped")
+			return
+
+		case batch := <-r.acknowledgedChan:
+			err := r.consumeLogs(ctx, convertEntries(batch.entries))
+			for _, ack := range batch.acks {
+				ack.Release(err)
+			}
+		}
+	}
+}
+
 // consumerLoop reads converter log entries and calls the consumer to consumer them.
 func (r *receiver) consumerLoop(ctx context.Context) {
 	defer r.wg.Done()
@@ -120,17 +174,23 @@ func (r *receiver) consumerLoop(ctx context.Context) {
 				r.logger.Debug("Converter channel got closed")
 				continue
 			}
-			obsrecvCtx := r.obsrecv.StartLogsOp(ctx)
-			logRecordCount := pLogs.LogRecordCount()
-			cErr := r.consumer.ConsumeLogs(ctx, pLogs)
-			if cErr != nil {
-				r.logger.Error("ConsumeLogs() failed", zap.Error(cErr))
-			}
-			r.obsrecv.EndLogsOp(obsrecvCtx, "stanza", logRecordCount, cErr)
+			_ = r.consumeLogs(ctx, pLogs)
 		}
 	}
 }
 
+// consumeLogs calls the consumer to consume the logs, and returns its error.
+func (r *receiver) consumeLogs(ctx context.Context, pLogs plog.Logs) error {
+	obsrecvCtx := r.obsrecv.StartLogsOp(ctx)
+	logRecordCount := pLogs.LogRecordCount()
+	cErr := r.consumer.ConsumeLogs(ctx, pLogs)
+	if cErr != nil {
+		r.logger.Error("ConsumeLogs() failed", zap.Error(cErr))
+	}
+	r.obsrecv.EndLogsOp(obsrecvCtx, "stanza", logRecordCount, cErr)
+	return cErr
+}
+
 // Shutdown is invoked during service shutdown
 func (r *receiver) Shutdown(ctx context.Context) error {
 	if r.cancel == nil {
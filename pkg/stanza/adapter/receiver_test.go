@@ -5,16 +5,20 @@ package adapter
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 	"go.uber.org/zap"
 	"gopkg.in/yaml.v2"
@@ -117,6 +121,81 @@ func TestHandleConsumeRetry(t *testing.T) {
 	require.NoError(t, logsReceiver.Shutdown(context.Background()))
 }
 
+func TestHandleConsumeAcknowledged(t *testing.T) {
+	for _, consumerErr := range []error{nil, errors.New("rejected")} {
+		mockConsumer := &consumertest.LogsSink{}
+		var nextConsumer consumer.Logs = mockConsumer
+		if consumerErr != nil {
+			nextConsumer = consumertest.NewErr(consumerErr)
+		}
+		factory := NewFactory(TestReceiverType{}, component.StabilityLevelDevelopment)
+
+		logsReceiver, err := factory.CreateLogsReceiver(context.Background(), receivertest.NewNopCreateSettings(), factory.CreateDefaultConfig(), nextConsumer)
+		require.NoError(t, err, "receiver should successfully build")
+		require.NoError(t, logsReceiver.Start(context.Background(), componenttest.NewNopHost()))
+
+		done := make(chan error, 1)
+		ack := operator.NewAcknowledgement(func(err error) { done <- err })
+		stanzaReceiver := logsReceiver.(*receiver)
+		require.NoError(t, stanzaReceiver.emitter.Process(operator.ContextWithAcknowledgement(context.Background(), ack), entry.New()))
+		ack.Release(nil)
+
+		select {
+		case err := <-done:
+			require.Equal(t, consumerErr, err)
+			if consumerErr == nil {
+				require.Equal(t, 1, mockConsumer.LogRecordCount())
+			}
+		case <-time.After(time.Second):
+			require.FailNow(t, "Timed out waiting for acknowledgement")
+		}
+		require.NoError(t, logsReceiver.Shutdown(context.Background()))
+	}
+}
+
+func TestHandleConsumeAcknowledgedDoesNotBlock(t *testing.T) {
+	unblock := make(chan struct{})
+	var consumed atomic.Int32
+	nextConsumer, err := consumer.NewLogs(func(_ context.Context, ld plog.Logs) error {
+		if ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str() == "acknowledged" {
+			<-unblock
+		}
+		consumed.Add(int32(ld.LogRecordCount()))
+		return nil
+	})
+	require.NoError(t, err)
+	factory := NewFactory(TestReceiverType{}, component.StabilityLevelDevelopment)
+
+	logsReceiver, err := factory.CreateLogsReceiver(context.Background(), receivertest.NewNopCreateSettings(), factory.CreateDefaultConfig(), nextConsumer)
+	require.NoError(t, err, "receiver should successfully build")
+	require.NoError(t, logsReceiver.Start(context.Background(), componenttest.NewNopHost()))
+
+	done := make(chan error, 1)
+	ack := operator.NewAcknowledgement(func(err error) { done <- err })
+	stanzaReceiver := logsReceiver.(*receiver)
+	acknowledged := entry.New()
+	acknowledged.Body = "acknowledged"
+	require.NoError(t, stanzaReceiver.emitter.Process(operator.ContextWithAcknowledgement(context.Background(), ack), acknowledged))
+	ack.Release(nil)
+
+	// The entries of other inputs are consumed while the acknowledged entry is blocked.
+	other := entry.New()
+	other.Body = "other"
+	stanzaReceiver.emitter.logChan <- []*entry.Entry{other}
+	require.Eventually(t, func() bool { return consumed.Load() == 1 }, time.Second, 5*time.Millisecond)
+	require.Empty(t, done)
+
+	close(unblock)
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		require.FailNow(t, "Timed out waiting for acknowledgement")
+	}
+	require.Equal(t, int32(2), consumed.Load())
+	require.NoError(t, logsReceiver.Shutdown(context.Background()))
+}
+
 func BenchmarkReadLine(b *testing.B) {
 	filePath := filepath.Join(b.TempDir(), "bench.log")
 
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package operator // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+
+import (
+	"context"
+	"sync"
+)
+
+// Acknowledgement notifies an input once the entries it wrote were accepted or rejected
+// by the consumer of the pipeline. It is attached to the context passed to Write, and
+// held by the operators which hand the entries over asynchronously, such as the log emitter.
+//
+// Entries which are dropped by the pipeline are acknowledged once Write returns. Operators
+// which buffer entries without their context, such as recombine, acknowledge them early.
+type Acknowledgement struct {
+	mu      sync.Mutex
+	pending int
+	err     error
+	done    func(error)
+}
+
+// NewAcknowledgement creates an acknowledgement held by its creator, which must release it
+// once the entries were written. done is called once, after the last release, with the
+// first error the acknowledgement was released with.
+func NewAcknowledgement(done func(error)) *Acknowledgement {
+	return &Acknowledgement{pending: 1, done: done}
+}
+
+// Hold delays the acknowledgement until a matching call to Release.
+func (a *Acknowledgement) Hold() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.pending++
+}
+
+// Release releases a hold on the acknowledgement, with the error of the delivery if any.
+func (a *Acknowledgement) Release(err error) {
+	a.mu.Lock()
+	if err != nil && a.err == nil {
+		a.err = err
+	}
+	a.pending--
+	last := a.pending == 0
+	a.mu.Unlock()
+
+	if last {
+		a.done(a.err)
+	}
+}
+
+type acknowledgementKey struct{}
+
+// ContextWithAcknowledgement returns a context holding the acknowledgement of the entries written with it.
+func ContextWithAcknowledgement(ctx context.Context, a *Acknowledgement) context.Context {
+	return context.WithValue(ctx, acknowledgementKey{}, a)
+}
+
+// AcknowledgementFromContext returns the acknowledgement held by the context, or nil.
+func AcknowledgementFromContext(ctx context.Context) *Acknowledgement {
+	a, _ := ctx.Value(acknowledgementKey{}).(*Acknowledgement)
+	return a
+}
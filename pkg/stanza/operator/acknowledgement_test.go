@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcknowledgement(t *testing.T) {
+	var results []error
+	ack := NewAcknowledgement(func(err error) { results = append(results, err) })
+
+	ctx := ContextWithAcknowledgement(context.Background(), ack)
+	require.Same(t, ack, AcknowledgementFromContext(ctx))
+	require.Nil(t, AcknowledgementFromContext(context.Background()))
+
+	ack.Hold()
+	ack.Hold()
+	ack.Release(nil)
+	ack.Release(errors.New("rejected"))
+	require.Empty(t, results)
+
+	ack.Release(nil)
+	require.Equal(t, []error{errors.New("rejected")}, results)
+}
+
+func TestAcknowledgementNotHeld(t *testing.T) {
+	var results []error
+	ack := NewAcknowledgement(func(err error) { results = append(results, err) })
+	ack.Release(nil)
+	require.Equal(t, []error{nil}, results)
+}
@@ -9,6 +9,13 @@ import (
 
 const operatorType = "journald_input"
 
+const (
+	// readerJournalctl reads the journal through the output of the journalctl binary
+	readerJournalctl = "journalctl"
+	// readerNative reads the journal files directly
+	readerNative = "native"
+)
+
 // NewConfig creates a new input config with default values
 func NewConfig() *Config {
 	return NewConfigWithID(operatorType)
@@ -18,6 +25,7 @@ func NewConfig() *Config {
 func NewConfigWithID(operatorID string) *Config {
 	return &Config{
 		InputConfig: helper.NewInputConfig(operatorID, operatorType),
+		Reader:      readerJournalctl,
 		StartAt:     "end",
 		Priority:    "info",
 	}
@@ -27,6 +35,7 @@ func NewConfigWithID(operatorID string) *Config {
 type Config struct {
 	helper.InputConfig `mapstructure:",squash"`
 
+	Reader      string        `mapstructure:"reader,omitempty"`
 	Directory   *string       `mapstructure:"directory,omitempty"`
 	Files       []string      `mapstructure:"files,omitempty"`
 	StartAt     string        `mapstructure:"start_at,omitempty"`
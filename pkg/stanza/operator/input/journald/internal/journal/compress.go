@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package journal // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/journald/internal/journal"
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// maxDataSize is the largest payload journald writes to a data object.
+const maxDataSize = 768 * 1024 * 1024
+
+var errXZUnsupported = errors.New("xz compressed data objects are not supported")
+
+// zstdDecoder is shared by all files: DecodeAll is safe for concurrent use.
+var zstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0), zstd.WithDecoderMaxMemory(maxDataSize))
+
+func decompress(flags uint8, payload []byte) ([]byte, error) {
+	switch {
+	case flags&objectCompressedZSTD != 0:
+		return zstdDecoder.DecodeAll(payload, nil)
+	case flags&objectCompressedLZ4 != 0:
+		return decompressLZ4(payload)
+	case flags&objectCompressedXZ != 0:
+		return nil, errXZUnsupported
+	default:
+		return payload, nil
+	}
+}
+
+// decompressLZ4 decodes the LZ4 payloads written by journald: the size of the decompressed
+// data as a little endian 64 bit integer, followed by a single LZ4 block.
+func decompressLZ4(payload []byte) ([]byte, error) {
+	if len(payload) < 8 {
+		return nil, errors.New("lz4: payload too short")
+	}
+	size := binary.LittleEndian.Uint64(payload)
+	if size > maxDataSize {
+		return nil, fmt.Errorf("lz4: decompressed size %d is too large", size)
+	}
+	src := payload[8:]
+	dst := make([]byte, 0, size)
+	for i := 0; i < len(src); {
+		token := src[i]
+		i++
+
+		literals, err := lz4Length(src, &i, int(token>>4))
+		if err != nil {
+			return nil, err
+		}
+		if literals > len(src)-i {
+			return nil, errors.New("lz4: literals out of bounds")
+		}
+		dst = append(dst, src[i:i+literals]...)
+		i += literals
+		if i == len(src) {
+			// The last sequence only holds literals
+			break
+		}
+
+		if i+2 > len(src) {
+			return nil, errors.New("lz4: truncated match offset")
+		}
+		offset := int(binary.LittleEndian.Uint16(src[i:]))
+		i += 2
+		if offset == 0 || offset > len(dst) {
+			return nil, errors.New("lz4: invalid match offset")
+		}
+		matchLen, err := lz4Length(src, &i, int(token&0x0f))
+		if err != nil {
+			return nil, err
+		}
+		matchLen += 4
+		if uint64(len(dst)+matchLen) > size {
+			return nil, errors.New("lz4: decompressed data exceeds its declared size")
+		}
+		// Matches may overlap the bytes they produce, so copy one byte at a time
+		start := len(dst) - offset
+		for j := 0; j < matchLen; j++ {
+			dst = append(dst, dst[start+j])
+		}
+	}
+	if uint64(len(dst)) != size {
+		return nil, fmt.Errorf("lz4: decompressed %d bytes, expected %d", len(dst), size)
+	}
+	return dst, nil
+}
+
+func lz4Length(src []byte, i *int, length int) (int, error) {
+	if length != 0x0f {
+		return length, nil
+	}
+	for {
+		if *i >= len(src) {
+			return 0, errors.New("lz4: truncated length")
+		}
+		b := src[*i]
+		*i++
+		length += int(b)
+		if length > maxDataSize {
+			return 0, errors.New("lz4: length too large")
+		}
+		if b != 0xff {
+			return length, nil
+		}
+	}
+}
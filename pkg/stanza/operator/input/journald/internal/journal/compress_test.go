@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package journal
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func lz4Payload(size uint64, block ...byte) []byte {
+	return append(binary.LittleEndian.AppendUint64(nil, size), block...)
+}
+
+func TestDecompressLZ4(t *testing.T) {
+	tests := []struct {
+		name     string
+		payload  []byte
+		expected string
+		err      string
+	}{
+		{
+			name:     "overlapping match",
+			payload:  lz4Payload(13, 0x35, 'a', 'b', 'c', 3, 0, 0x10, 'X'),
+			expected: "abcabcabcabcX",
+		},
+		{
+			name:     "long literals",
+			payload:  lz4Payload(20, append([]byte{0xf0, 5}, strings.Repeat("y", 20)...)...),
+			expected: strings.Repeat("y", 20),
+		},
+		{
+			name:    "too short",
+			payload: []byte{1, 2},
+			err:     "lz4: payload too short",
+		},
+		{
+			name:    "invalid offset",
+			payload: lz4Payload(13, 0x35, 'a', 'b', 'c', 4, 0, 0x10, 'X'),
+			err:     "lz4: invalid match offset",
+		},
+		{
+			name:    "literals out of bounds",
+			payload: lz4Payload(13, 0x50, 'a'),
+			err:     "lz4: literals out of bounds",
+		},
+		{
+			name:    "size mismatch",
+			payload: lz4Payload(14, 0x35, 'a', 'b', 'c', 3, 0, 0x10, 'X'),
+			err:     "lz4: decompressed 13 bytes, expected 14",
+		},
+		{
+			name:    "exceeds size",
+			payload: lz4Payload(5, 0x35, 'a', 'b', 'c', 3, 0, 0x10, 'X'),
+			err:     "lz4: decompressed data exceeds its declared size",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := decompress(objectCompressedLZ4, tt.payload)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(data))
+		})
+	}
+}
+
+func TestDecompressXZ(t *testing.T) {
+	_, err := decompress(objectCompressedXZ, []byte{1})
+	assert.ErrorIs(t, err, errXZUnsupported)
+}
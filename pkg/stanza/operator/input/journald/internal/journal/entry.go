@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package journal // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/journald/internal/journal"
+
+import (
+	"cmp"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ID128 is a 128 bit identifier, such as a boot ID or a sequence number ID.
+type ID128 [16]byte
+
+// String returns the identifier formatted the way journalctl does.
+func (id ID128) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+func parseID128(s string) (ID128, error) {
+	var id ID128
+	if hex.DecodedLen(len(s)) != len(id) {
+		return id, fmt.Errorf("invalid id %q", s)
+	}
+	if _, err := hex.Decode(id[:], []byte(s)); err != nil {
+		return id, fmt.Errorf("invalid id %q: %w", s, err)
+	}
+	return id, nil
+}
+
+// Field is a field of a journal entry.
+type Field struct {
+	Name  string
+	Value []byte
+}
+
+// Entry is a journal entry. Its fields are read separately with File.Fields.
+type Entry struct {
+	Cursor
+
+	items []uint64
+}
+
+// Cursor is the position of an entry in the journal. Its string form is compatible
+// with the cursors printed and accepted by journalctl.
+type Cursor struct {
+	SeqnumID  ID128
+	Seqnum    uint64
+	BootID    ID128
+	Monotonic uint64
+	Realtime  uint64
+	XorHash   uint64
+}
+
+// String formats the cursor like the __CURSOR field written by journalctl.
+func (c Cursor) String() string {
+	return fmt.Sprintf("s=%s;i=%x;b=%s;m=%x;t=%x;x=%x", c.SeqnumID, c.Seqnum, c.BootID, c.Monotonic, c.Realtime, c.XorHash)
+}
+
+// ParseCursor parses a cursor written by Cursor.String or by journalctl.
+func ParseCursor(s string) (Cursor, error) {
+	var c Cursor
+	seen := map[string]bool{}
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return c, fmt.Errorf("invalid cursor %q", s)
+		}
+		var err error
+		switch key {
+		case "s":
+			c.SeqnumID, err = parseID128(value)
+		case "i":
+			c.Seqnum, err = strconv.ParseUint(value, 16, 64)
+		case "b":
+			c.BootID, err = parseID128(value)
+		case "m":
+			c.Monotonic, err = strconv.ParseUint(value, 16, 64)
+		case "t":
+			c.Realtime, err = strconv.ParseUint(value, 16, 64)
+		case "x":
+			c.XorHash, err = strconv.ParseUint(value, 16, 64)
+		default:
+			// Newer versions of systemd may add fields to the cursor
+			continue
+		}
+		if err != nil {
+			return c, fmt.Errorf("invalid cursor %q: %w", s, err)
+		}
+		seen[key] = true
+	}
+	for _, key := range []string{"s", "i", "b", "m", "t", "x"} {
+		if !seen[key] {
+			return c, fmt.Errorf("invalid cursor %q: missing %q", s, key)
+		}
+	}
+	return c, nil
+}
+
+// Compare orders two entries the way journalctl interleaves the entries of several files:
+// by sequence number when they share a sequence number ID, by monotonic time when they
+// share a boot, and by realtime otherwise. Entries sharing a sequence number ID and a
+// sequence number are the same entry.
+func Compare(a, b Cursor) int {
+	if a.SeqnumID == b.SeqnumID {
+		return cmp.Compare(a.Seqnum, b.Seqnum)
+	}
+	if a.BootID == b.BootID {
+		if r := cmp.Compare(a.Monotonic, b.Monotonic); r != 0 {
+			return r
+		}
+	}
+	if r := cmp.Compare(a.Realtime, b.Realtime); r != 0 {
+		return r
+	}
+	return cmp.Compare(a.XorHash, b.XorHash)
+}
@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package journal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCursor(t *testing.T) {
+	const s = "s=b1e713b587ae4001a9ca482c4b12c005;i=1eed30;b=c4fa36de06824d21835c05ff80c54468;m=9f9d630205;t=5a369604ee333;x=16c2d4fd4fdb7c36"
+	cursor, err := ParseCursor(s)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0x1eed30), cursor.Seqnum)
+	assert.Equal(t, "c4fa36de06824d21835c05ff80c54468", cursor.BootID.String())
+	assert.Equal(t, uint64(0x9f9d630205), cursor.Monotonic)
+	assert.Equal(t, uint64(0x5a369604ee333), cursor.Realtime)
+	assert.Equal(t, s, cursor.String())
+
+	_, err = ParseCursor("s=b1e713b587ae4001a9ca482c4b12c005;i=1eed30;b=c4fa36de06824d21835c05ff80c54468;m=9f9d630205;t=5a369604ee333;x=16c2d4fd4fdb7c36;z=1")
+	assert.NoError(t, err)
+
+	for _, invalid := range []string{
+		"",
+		"s=b1e713b587ae4001a9ca482c4b12c005;i=1eed30",
+		"s=b1e7;i=1eed30;b=c4fa36de06824d21835c05ff80c54468;m=9f9d630205;t=5a369604ee333;x=16c2d4fd4fdb7c36",
+		"s=b1e713b587ae4001a9ca482c4b12c005;i=xyz;b=c4fa36de06824d21835c05ff80c54468;m=9f9d630205;t=5a369604ee333;x=16c2d4fd4fdb7c36",
+	} {
+		_, err = ParseCursor(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	a := Cursor{SeqnumID: ID128{1}, Seqnum: 1, BootID: ID128{1}, Monotonic: 20, Realtime: 20}
+	b := Cursor{SeqnumID: ID128{1}, Seqnum: 2, BootID: ID128{1}, Monotonic: 10, Realtime: 10}
+	// The sequence number wins within the same sequence
+	assert.Equal(t, -1, Compare(a, b))
+	assert.Equal(t, 1, Compare(b, a))
+	assert.Equal(t, 0, Compare(a, a))
+	assert.Equal(t, 0, Compare(a, Cursor{SeqnumID: ID128{1}, Seqnum: 1}))
+
+	// then the monotonic time within the same boot
+	b.SeqnumID = ID128{2}
+	assert.Equal(t, 1, Compare(a, b))
+
+	// and the realtime otherwise
+	b.BootID = ID128{2}
+	b.Realtime = 30
+	assert.Equal(t, -1, Compare(a, b))
+}
@@ -0,0 +1,310 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package journal reads the entries of systemd journal files.
+// See https://systemd.io/JOURNAL_FILE_FORMAT/ for a description of the format.
+package journal // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/journald/internal/journal"
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+const signature = "LPKSHHRH"
+
+// Incompatible header flags
+const (
+	headerCompressedXZ   = 1 << 0
+	headerCompressedLZ4  = 1 << 1
+	headerKeyedHash      = 1 << 2
+	headerCompressedZSTD = 1 << 3
+	headerCompact        = 1 << 4
+
+	supportedHeaderFlags = headerCompressedXZ | headerCompressedLZ4 | headerKeyedHash | headerCompressedZSTD | headerCompact
+)
+
+// Object flags
+const (
+	objectCompressedXZ   = 1 << 0
+	objectCompressedLZ4  = 1 << 1
+	objectCompressedZSTD = 1 << 2
+)
+
+// Object types
+const (
+	objectData       = 1
+	objectEntry      = 3
+	objectEntryArray = 6
+)
+
+const (
+	// headerReadSize covers all the header fields used by the reader.
+	headerReadSize   = 192
+	objectHeaderSize = 16
+	// maxEntrySize bounds the size of the entry objects read into memory.
+	maxEntrySize = 64 * 1024 * 1024
+)
+
+var errInvalidSignature = errors.New("invalid journal file signature")
+
+type header struct {
+	incompatibleFlags uint32
+	fileID            ID128
+	seqnumID          ID128
+	headerSize        uint64
+	arenaSize         uint64
+	nEntries          uint64
+	entryArrayOffset  uint64
+}
+
+func (h header) compact() bool {
+	return h.incompatibleFlags&headerCompact != 0
+}
+
+// File reads the entries of a journal file in order, including the entries
+// appended by journald after the file was opened.
+type File struct {
+	file   *os.File
+	header header
+
+	// Position in the chain of entry arrays
+	arrayOffset uint64
+	arrayItems  uint64
+	arrayIndex  uint64
+	read        uint64
+}
+
+// Open opens the journal file at path.
+func Open(path string) (*File, error) {
+	file, err := os.Open(path) // #nosec - the operator reads the journal files it is configured with
+	if err != nil {
+		return nil, err
+	}
+	f := &File{file: file}
+	if err = f.readHeader(); err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return f, nil
+}
+
+// ID returns the identifier of the file, which is preserved when journald renames it.
+func (f *File) ID() ID128 {
+	return f.header.fileID
+}
+
+// Close closes the file.
+func (f *File) Close() error {
+	return f.file.Close()
+}
+
+func (f *File) readHeader() error {
+	buf := make([]byte, headerReadSize)
+	if _, err := f.file.ReadAt(buf, 0); err != nil {
+		if errors.Is(err, io.EOF) {
+			return errors.New("journal file header is truncated")
+		}
+		return err
+	}
+	if !bytes.Equal(buf[:8], []byte(signature)) {
+		return errInvalidSignature
+	}
+
+	var h header
+	h.incompatibleFlags = binary.LittleEndian.Uint32(buf[12:])
+	copy(h.fileID[:], buf[24:40])
+	copy(h.seqnumID[:], buf[72:88])
+	h.headerSize = binary.LittleEndian.Uint64(buf[88:])
+	h.arenaSize = binary.LittleEndian.Uint64(buf[96:])
+	h.nEntries = binary.LittleEndian.Uint64(buf[152:])
+	h.entryArrayOffset = binary.LittleEndian.Uint64(buf[176:])
+
+	if unsupported := h.incompatibleFlags &^ supportedHeaderFlags; unsupported != 0 {
+		return fmt.Errorf("unsupported journal file flags 0x%x", unsupported)
+	}
+	if h.headerSize < headerReadSize {
+		return fmt.Errorf("journal file header size %d is too small", h.headerSize)
+	}
+	if f.header.headerSize != 0 && h.fileID != f.header.fileID {
+		return errors.New("journal file was replaced")
+	}
+	f.header = h
+	return nil
+}
+
+// Next returns the next entry of the file, or io.EOF when all its entries have been read.
+// Entries appended to the file are returned by later calls.
+func (f *File) Next() (*Entry, error) {
+	if f.read >= f.header.nEntries {
+		if err := f.readHeader(); err != nil {
+			return nil, err
+		}
+		if f.read >= f.header.nEntries {
+			return nil, io.EOF
+		}
+	}
+
+	offset, err := f.nextEntryOffset()
+	if err != nil {
+		return nil, err
+	}
+	f.read++
+	return f.readEntry(offset)
+}
+
+// nextEntryOffset walks the chain of entry arrays to the offset of the next entry.
+func (f *File) nextEntryOffset() (uint64, error) {
+	itemSize := uint64(8)
+	if f.header.compact() {
+		itemSize = 4
+	}
+
+	for {
+		if f.arrayOffset == 0 {
+			if err := f.enterArray(f.header.entryArrayOffset, itemSize); err != nil {
+				return 0, err
+			}
+		}
+		if f.arrayIndex < f.arrayItems {
+			buf := make([]byte, itemSize)
+			if err := f.readAt(buf, f.arrayOffset+24+f.arrayIndex*itemSize); err != nil {
+				return 0, err
+			}
+			f.arrayIndex++
+			var offset uint64
+			if itemSize == 4 {
+				offset = uint64(binary.LittleEndian.Uint32(buf))
+			} else {
+				offset = binary.LittleEndian.Uint64(buf)
+			}
+			if offset == 0 {
+				return 0, errors.New("entry array is shorter than the number of entries")
+			}
+			return offset, nil
+		}
+
+		buf := make([]byte, 8)
+		if err := f.readAt(buf, f.arrayOffset+16); err != nil {
+			return 0, err
+		}
+		if err := f.enterArray(binary.LittleEndian.Uint64(buf), itemSize); err != nil {
+			return 0, err
+		}
+	}
+}
+
+func (f *File) enterArray(offset, itemSize uint64) error {
+	if offset == 0 {
+		return errors.New("entry array is missing")
+	}
+	typ, _, size, err := f.readObjectHeader(offset)
+	if err != nil {
+		return err
+	}
+	if typ != objectEntryArray || size < 24 {
+		return fmt.Errorf("invalid entry array object at offset %d", offset)
+	}
+	f.arrayOffset = offset
+	f.arrayItems = (size - 24) / itemSize
+	f.arrayIndex = 0
+	return nil
+}
+
+func (f *File) readEntry(offset uint64) (*Entry, error) {
+	typ, _, size, err := f.readObjectHeader(offset)
+	if err != nil {
+		return nil, err
+	}
+	if typ != objectEntry || size < 64 || size > maxEntrySize {
+		return nil, fmt.Errorf("invalid entry object at offset %d", offset)
+	}
+	buf := make([]byte, size)
+	if err = f.readAt(buf, offset); err != nil {
+		return nil, err
+	}
+
+	e := &Entry{}
+	e.SeqnumID = f.header.seqnumID
+	e.Seqnum = binary.LittleEndian.Uint64(buf[16:])
+	e.Realtime = binary.LittleEndian.Uint64(buf[24:])
+	e.Monotonic = binary.LittleEndian.Uint64(buf[32:])
+	copy(e.BootID[:], buf[40:56])
+	e.XorHash = binary.LittleEndian.Uint64(buf[56:])
+
+	items := buf[64:]
+	if f.header.compact() {
+		e.items = make([]uint64, 0, len(items)/4)
+		for ; len(items) >= 4; items = items[4:] {
+			e.items = append(e.items, uint64(binary.LittleEndian.Uint32(items)))
+		}
+	} else {
+		e.items = make([]uint64, 0, len(items)/16)
+		for ; len(items) >= 16; items = items[16:] {
+			e.items = append(e.items, binary.LittleEndian.Uint64(items))
+		}
+	}
+	return e, nil
+}
+
+// Fields reads the fields of an entry returned by Next, in the order they are stored.
+func (f *File) Fields(e *Entry) ([]Field, error) {
+	payloadOffset := uint64(64)
+	if f.header.compact() {
+		payloadOffset = 72
+	}
+
+	fields := make([]Field, 0, len(e.items))
+	for _, offset := range e.items {
+		typ, flags, size, err := f.readObjectHeader(offset)
+		if err != nil {
+			return nil, err
+		}
+		if typ != objectData || size < payloadOffset || size-payloadOffset > maxDataSize {
+			return nil, fmt.Errorf("invalid data object at offset %d", offset)
+		}
+		payload := make([]byte, size-payloadOffset)
+		if err = f.readAt(payload, offset+payloadOffset); err != nil {
+			return nil, err
+		}
+		if payload, err = decompress(flags, payload); err != nil {
+			return nil, fmt.Errorf("data object at offset %d: %w", offset, err)
+		}
+
+		name, value, ok := bytes.Cut(payload, []byte("="))
+		if !ok {
+			// journald never writes such fields, skip them like journalctl does
+			continue
+		}
+		fields = append(fields, Field{Name: string(name), Value: value})
+	}
+	return fields, nil
+}
+
+func (f *File) readObjectHeader(offset uint64) (typ uint8, flags uint8, size uint64, err error) {
+	if offset%8 != 0 || offset < f.header.headerSize {
+		return 0, 0, 0, fmt.Errorf("invalid object offset %d", offset)
+	}
+	buf := make([]byte, objectHeaderSize)
+	if err = f.readAt(buf, offset); err != nil {
+		return 0, 0, 0, err
+	}
+	size = binary.LittleEndian.Uint64(buf[8:])
+	if size < objectHeaderSize || offset+size > f.header.headerSize+f.header.arenaSize {
+		return 0, 0, 0, fmt.Errorf("invalid object size %d at offset %d", size, offset)
+	}
+	return buf[0], buf[1], size, nil
+}
+
+func (f *File) readAt(buf []byte, offset uint64) error {
+	if _, err := f.file.ReadAt(buf, int64(offset)); err != nil {
+		if errors.Is(err, io.EOF) {
+			return fmt.Errorf("journal file is truncated at offset %d", offset)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package journal
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/journald/internal/journaltest"
+)
+
+func readAll(t *testing.T, f *File) []map[string]string {
+	var entries []map[string]string
+	for {
+		e, err := f.Next()
+		if err == io.EOF {
+			return entries
+		}
+		require.NoError(t, err)
+		fields, err := f.Fields(e)
+		require.NoError(t, err)
+		entry := map[string]string{"__CURSOR": e.Cursor.String()}
+		for _, field := range fields {
+			entry[field.Name] = string(field.Value)
+		}
+		entries = append(entries, entry)
+	}
+}
+
+func TestFile(t *testing.T) {
+	for _, opts := range []journaltest.Options{
+		{},
+		{Compact: true},
+		{CompressThreshold: 32},
+		{Compact: true, CompressThreshold: 32, ArrayCapacity: 1},
+	} {
+		t.Run(fmt.Sprintf("compact=%t,compress=%d", opts.Compact, opts.CompressThreshold), func(t *testing.T) {
+			opts.SeqnumID = ID128{1}
+			opts.BootID = ID128{2}
+			path := filepath.Join(t.TempDir(), "system.journal")
+			w, err := journaltest.Create(path, opts)
+			require.NoError(t, err)
+			defer func() { require.NoError(t, w.Close()) }()
+
+			f, err := Open(path)
+			require.NoError(t, err)
+			defer func() { require.NoError(t, f.Close()) }()
+
+			_, err = f.Next()
+			require.Equal(t, io.EOF, err)
+
+			long := strings.Repeat("x", 100)
+			for i := 0; i < 6; i++ {
+				require.NoError(t, w.Append(journaltest.Entry{
+					Fields:    []string{fmt.Sprintf("MESSAGE=message %d", i), "LONG=" + long, "EMPTY="},
+					Realtime:  1700000000000000 + uint64(i),
+					Monotonic: 1000 + uint64(i),
+				}))
+			}
+
+			entries := readAll(t, f)
+			require.Len(t, entries, 6)
+			assert.Equal(t, map[string]string{
+				"MESSAGE":  "message 0",
+				"LONG":     long,
+				"EMPTY":    "",
+				"__CURSOR": entries[0]["__CURSOR"],
+			}, entries[0])
+			cursor, err := ParseCursor(entries[5]["__CURSOR"])
+			require.NoError(t, err)
+			assert.Equal(t, ID128{1}, cursor.SeqnumID)
+			assert.Equal(t, uint64(6), cursor.Seqnum)
+			assert.Equal(t, ID128{2}, cursor.BootID)
+			assert.Equal(t, uint64(1005), cursor.Monotonic)
+			assert.Equal(t, uint64(1700000000000005), cursor.Realtime)
+
+			// Entries appended after the end of the file was reached are read as well
+			require.NoError(t, w.Append(journaltest.Entry{Fields: []string{"MESSAGE=appended"}, Realtime: 1700000000000006}))
+			entries = readAll(t, f)
+			require.Len(t, entries, 1)
+			assert.Equal(t, "appended", entries[0]["MESSAGE"])
+		})
+	}
+}
+
+func TestFileID(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "system.journal")
+	w, err := journaltest.Create(path, journaltest.Options{})
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, f.Close()) }()
+
+	// journald renames the files it archives
+	archived := filepath.Join(dir, "system@archived.journal")
+	require.NoError(t, os.Rename(path, archived))
+	g, err := Open(archived)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, g.Close()) }()
+	assert.Equal(t, f.ID(), g.ID())
+}
+
+func TestOpenInvalid(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := Open(filepath.Join(dir, "missing.journal"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	truncated := filepath.Join(dir, "truncated.journal")
+	require.NoError(t, os.WriteFile(truncated, []byte(signature), 0o600))
+	_, err = Open(truncated)
+	require.ErrorContains(t, err, "journal file header is truncated")
+
+	invalid := filepath.Join(dir, "invalid.journal")
+	require.NoError(t, os.WriteFile(invalid, make([]byte, 512), 0o600))
+	_, err = Open(invalid)
+	require.ErrorIs(t, err, errInvalidSignature)
+
+	unsupported := filepath.Join(dir, "unsupported.journal")
+	w, err := journaltest.Create(unsupported, journaltest.Options{})
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	data, err := os.ReadFile(unsupported)
+	require.NoError(t, err)
+	data[12] |= 1 << 5
+	require.NoError(t, os.WriteFile(unsupported, data, 0o600))
+	_, err = Open(unsupported)
+	require.ErrorContains(t, err, "unsupported journal file flags 0x20")
+}
@@ -0,0 +1,322 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package journaltest writes journal files for tests.
+package journaltest // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/journald/internal/journaltest"
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"hash/fnv"
+	"os"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	headerSize       = 264
+	objectHeaderSize = 16
+	hashTableItems   = 64
+
+	headerCompressedZSTD = 1 << 3
+	headerCompact        = 1 << 4
+
+	objectCompressedZSTD = 1 << 2
+
+	objectData           = 1
+	objectEntry          = 3
+	objectDataHashTable  = 4
+	objectFieldHashTable = 5
+	objectEntryArray     = 6
+
+	stateOffline = 0
+	stateOnline  = 1
+)
+
+// Options configures the files written by a Writer.
+type Options struct {
+	// Compact writes the compact variant of the format introduced in systemd 252.
+	Compact bool
+	// CompressThreshold compresses the data objects at least this large with zstd. Zero disables compression.
+	CompressThreshold int
+	// SeqnumID identifies the sequence of entry numbers the file belongs to.
+	SeqnumID [16]byte
+	// BootID is the boot ID of the entries.
+	BootID [16]byte
+	// ArrayCapacity is the number of items of each entry array, 4 when zero.
+	ArrayCapacity int
+}
+
+// Entry is an entry written to a journal file.
+type Entry struct {
+	// Fields of the entry, formatted as NAME=value.
+	Fields []string
+	// Seqnum is the sequence number of the entry, the one following the previous entry when zero.
+	Seqnum    uint64
+	Realtime  uint64
+	Monotonic uint64
+}
+
+// Writer appends entries to a journal file. The file header is updated after
+// each entry, so the file can be read while it is written.
+type Writer struct {
+	file *os.File
+	opts Options
+	zstd *zstd.Encoder
+
+	end        uint64
+	nObjects   uint64
+	nEntries   uint64
+	nData      uint64
+	nArrays    uint64
+	headSeqnum uint64
+	tailSeqnum uint64
+	headTime   uint64
+	tailTime   uint64
+	tailMono   uint64
+	tailObject uint64
+
+	firstArray  uint64
+	lastArray   uint64
+	arrayUsed   int
+	dataTable   uint64
+	fieldTable  uint64
+	headerState byte
+	flags       uint32
+	fileID      [16]byte
+}
+
+// Create creates a journal file at path.
+func Create(path string, opts Options) (*Writer, error) {
+	if opts.ArrayCapacity == 0 {
+		opts.ArrayCapacity = 4
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	w := &Writer{file: file, opts: opts, end: headerSize, headerState: stateOnline}
+	if opts.Compact {
+		w.flags |= headerCompact
+	}
+	if opts.CompressThreshold > 0 {
+		w.flags |= headerCompressedZSTD
+		// journald only decompresses frames which declare their content size
+		if w.zstd, err = zstd.NewWriter(nil, zstd.WithSingleSegment(true)); err != nil {
+			return nil, errors.Join(err, file.Close())
+		}
+	}
+	if _, err = rand.Read(w.fileID[:]); err != nil {
+		return nil, errors.Join(err, file.Close())
+	}
+
+	table := make([]byte, hashTableItems*16)
+	if w.dataTable, err = w.appendObject(objectDataHashTable, 0, table); err != nil {
+		return nil, errors.Join(err, file.Close())
+	}
+	if w.fieldTable, err = w.appendObject(objectFieldHashTable, 0, table); err != nil {
+		return nil, errors.Join(err, file.Close())
+	}
+	if err = w.writeHeader(); err != nil {
+		return nil, errors.Join(err, file.Close())
+	}
+	return w, nil
+}
+
+// Append writes an entry at the end of the file.
+func (w *Writer) Append(e Entry) error {
+	seqnum := e.Seqnum
+	if seqnum == 0 {
+		seqnum = w.tailSeqnum + 1
+	}
+
+	offsets := make([]uint64, 0, len(e.Fields))
+	hashes := make([]uint64, 0, len(e.Fields))
+	var xorHash uint64
+	for _, field := range e.Fields {
+		offset, hash, err := w.appendData([]byte(field))
+		if err != nil {
+			return err
+		}
+		offsets = append(offsets, offset)
+		hashes = append(hashes, hash)
+		xorHash ^= hash
+	}
+
+	body := make([]byte, 48)
+	binary.LittleEndian.PutUint64(body[0:], seqnum)
+	binary.LittleEndian.PutUint64(body[8:], e.Realtime)
+	binary.LittleEndian.PutUint64(body[16:], e.Monotonic)
+	copy(body[24:40], w.opts.BootID[:])
+	binary.LittleEndian.PutUint64(body[40:], xorHash)
+	for i, offset := range offsets {
+		if w.opts.Compact {
+			body = binary.LittleEndian.AppendUint32(body, uint32(offset))
+		} else {
+			body = binary.LittleEndian.AppendUint64(body, offset)
+			body = binary.LittleEndian.AppendUint64(body, hashes[i])
+		}
+	}
+	entryOffset, err := w.appendObject(objectEntry, 0, body)
+	if err != nil {
+		return err
+	}
+
+	// Each data object is only referenced by the entry it was written for
+	for _, offset := range offsets {
+		buf := make([]byte, 8)
+		binary.LittleEndian.PutUint64(buf, entryOffset)
+		if err = w.writeAt(buf, offset+40); err != nil {
+			return err
+		}
+		binary.LittleEndian.PutUint64(buf, 1)
+		if err = w.writeAt(buf, offset+56); err != nil {
+			return err
+		}
+	}
+
+	if err = w.linkEntry(entryOffset); err != nil {
+		return err
+	}
+
+	if w.nEntries == 0 {
+		w.headSeqnum = seqnum
+		w.headTime = e.Realtime
+	}
+	w.nEntries++
+	w.tailSeqnum = seqnum
+	w.tailTime = e.Realtime
+	w.tailMono = e.Monotonic
+	return w.writeHeader()
+}
+
+// linkEntry adds an entry to the chain of entry arrays.
+func (w *Writer) linkEntry(entryOffset uint64) error {
+	itemSize := 8
+	if w.opts.Compact {
+		itemSize = 4
+	}
+	if w.lastArray == 0 || w.arrayUsed == w.opts.ArrayCapacity {
+		array, err := w.appendObject(objectEntryArray, 0, make([]byte, 8+w.opts.ArrayCapacity*itemSize))
+		if err != nil {
+			return err
+		}
+		if w.lastArray == 0 {
+			w.firstArray = array
+		} else {
+			buf := make([]byte, 8)
+			binary.LittleEndian.PutUint64(buf, array)
+			if err = w.writeAt(buf, w.lastArray+16); err != nil {
+				return err
+			}
+		}
+		w.lastArray = array
+		w.arrayUsed = 0
+		w.nArrays++
+	}
+
+	buf := make([]byte, itemSize)
+	if w.opts.Compact {
+		binary.LittleEndian.PutUint32(buf, uint32(entryOffset))
+	} else {
+		binary.LittleEndian.PutUint64(buf, entryOffset)
+	}
+	if err := w.writeAt(buf, w.lastArray+24+uint64(w.arrayUsed*itemSize)); err != nil {
+		return err
+	}
+	w.arrayUsed++
+	return nil
+}
+
+func (w *Writer) appendData(payload []byte) (offset uint64, hash uint64, err error) {
+	h := fnv.New64a()
+	_, _ = h.Write(payload)
+	hash = h.Sum64()
+
+	var flags uint8
+	if w.zstd != nil && len(payload) >= w.opts.CompressThreshold {
+		payload = w.zstd.EncodeAll(payload, nil)
+		flags = objectCompressedZSTD
+	}
+
+	fixed := 48
+	if w.opts.Compact {
+		fixed = 56
+	}
+	body := make([]byte, fixed, fixed+len(payload))
+	binary.LittleEndian.PutUint64(body, hash)
+	body = append(body, payload...)
+	if offset, err = w.appendObject(objectData, flags, body); err != nil {
+		return 0, 0, err
+	}
+	w.nData++
+	return offset, hash, nil
+}
+
+func (w *Writer) appendObject(typ, flags uint8, body []byte) (uint64, error) {
+	offset := w.end
+	size := uint64(objectHeaderSize + len(body))
+	buf := make([]byte, objectHeaderSize, size)
+	buf[0] = typ
+	buf[1] = flags
+	binary.LittleEndian.PutUint64(buf[8:], size)
+	buf = append(buf, body...)
+	// Objects are 8 byte aligned
+	for len(buf)%8 != 0 {
+		buf = append(buf, 0)
+	}
+	if err := w.writeAt(buf, offset); err != nil {
+		return 0, err
+	}
+	w.end += uint64(len(buf))
+	w.nObjects++
+	w.tailObject = offset
+	return offset, nil
+}
+
+func (w *Writer) writeHeader() error {
+	buf := make([]byte, headerSize)
+	copy(buf, "LPKSHHRH")
+	binary.LittleEndian.PutUint32(buf[12:], w.flags)
+	buf[16] = w.headerState
+	copy(buf[24:40], w.fileID[:])
+	copy(buf[56:72], w.opts.BootID[:])
+	copy(buf[72:88], w.opts.SeqnumID[:])
+	binary.LittleEndian.PutUint64(buf[88:], headerSize)
+	binary.LittleEndian.PutUint64(buf[96:], w.end-headerSize)
+	binary.LittleEndian.PutUint64(buf[104:], w.dataTable+objectHeaderSize)
+	binary.LittleEndian.PutUint64(buf[112:], hashTableItems*16)
+	binary.LittleEndian.PutUint64(buf[120:], w.fieldTable+objectHeaderSize)
+	binary.LittleEndian.PutUint64(buf[128:], hashTableItems*16)
+	binary.LittleEndian.PutUint64(buf[136:], w.tailObject)
+	binary.LittleEndian.PutUint64(buf[144:], w.nObjects)
+	binary.LittleEndian.PutUint64(buf[152:], w.nEntries)
+	binary.LittleEndian.PutUint64(buf[160:], w.tailSeqnum)
+	binary.LittleEndian.PutUint64(buf[168:], w.headSeqnum)
+	binary.LittleEndian.PutUint64(buf[176:], w.firstArray)
+	binary.LittleEndian.PutUint64(buf[184:], w.headTime)
+	binary.LittleEndian.PutUint64(buf[192:], w.tailTime)
+	binary.LittleEndian.PutUint64(buf[200:], w.tailMono)
+	binary.LittleEndian.PutUint64(buf[208:], w.nData)
+	binary.LittleEndian.PutUint64(buf[232:], w.nArrays)
+	if w.opts.Compact {
+		binary.LittleEndian.PutUint32(buf[256:], uint32(w.lastArray))
+		binary.LittleEndian.PutUint32(buf[260:], uint32(w.arrayUsed))
+	}
+	return w.writeAt(buf, 0)
+}
+
+func (w *Writer) writeAt(buf []byte, offset uint64) error {
+	_, err := w.file.WriteAt(buf, int64(offset))
+	return err
+}
+
+// Close marks the file offline and closes it.
+func (w *Writer) Close() error {
+	w.headerState = stateOffline
+	if w.zstd != nil {
+		_ = w.zstd.Close()
+	}
+	return errors.Join(w.writeHeader(), w.file.Close())
+}
@@ -40,6 +40,14 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		return nil, err
 	}
 
+	switch c.Reader {
+	case readerJournalctl:
+	case readerNative:
+		return c.buildNative(inputOperator)
+	default:
+		return nil, fmt.Errorf("invalid value '%s' for parameter 'reader'", c.Reader)
+	}
+
 	args, err := c.buildArgs()
 	if err != nil {
 		return nil, err
@@ -0,0 +1,392 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+
+package journald // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/journald"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/journald/internal/journal"
+)
+
+const (
+	// nativePollInterval is how often the journal files are checked for new entries and rotations.
+	nativePollInterval = 200 * time.Millisecond
+
+	// valueThreshold is the size from which journalctl omits the values of fields unless --all is set.
+	valueThreshold = 4096
+)
+
+var journalDirectories = []string{"/run/log/journal", "/var/log/journal"}
+
+func (c Config) buildNative(inputOperator helper.InputOperator) (operator.Operator, error) {
+	switch c.StartAt {
+	case "end", "beginning":
+	default:
+		return nil, fmt.Errorf("invalid value '%s' for parameter 'start_at'", c.StartAt)
+	}
+
+	filter, err := c.buildFilter()
+	if err != nil {
+		return nil, err
+	}
+
+	return &NativeInput{
+		InputOperator: inputOperator,
+		patterns:      c.journalPatterns(),
+		filter:        filter,
+		startAtEnd:    c.StartAt == "end",
+		all:           c.All,
+		pollInterval:  nativePollInterval,
+		ignored:       map[string]os.FileInfo{},
+	}, nil
+}
+
+// journalPatterns returns the glob patterns of the journal files to read.
+func (c Config) journalPatterns() []string {
+	switch {
+	case c.Directory != nil:
+		return []string{
+			filepath.Join(*c.Directory, "*.journal"),
+			filepath.Join(*c.Directory, "*", "*.journal"),
+		}
+	case len(c.Files) > 0:
+		return c.Files
+	}
+
+	// Like journalctl, only read the journal of the local machine by default
+	machineID := "*"
+	if id, err := os.ReadFile("/etc/machine-id"); err == nil && len(strings.TrimSpace(string(id))) == 32 {
+		machineID = strings.TrimSpace(string(id))
+	}
+	patterns := make([]string, 0, len(journalDirectories))
+	for _, dir := range journalDirectories {
+		patterns = append(patterns, filepath.Join(dir, machineID, "*.journal"))
+	}
+	return patterns
+}
+
+// NativeInput is an operator that reads logs from the journal files directly, without journalctl
+type NativeInput struct {
+	helper.InputOperator
+
+	patterns     []string
+	filter       *filter
+	startAtEnd   bool
+	all          bool
+	pollInterval time.Duration
+
+	persister operator.Persister
+	files     []*journalFile
+	// ignored holds the files which could not be opened, until they are modified
+	ignored map[string]os.FileInfo
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+type journalFile struct {
+	*journal.File
+
+	path string
+	info os.FileInfo
+	head *journal.Entry
+	// failed is set once the file could not be read, its remaining entries are skipped
+	failed  bool
+	removed bool
+}
+
+// Start will start reading the journal files.
+func (operator *NativeInput) Start(persister operator.Persister) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	operator.cancel = cancel
+	operator.persister = persister
+
+	// Start after the cursor if there is a saved offset
+	savedCursor, err := persister.Get(ctx, lastReadCursorKey)
+	if err != nil {
+		return fmt.Errorf("failed to get journal state: %w", err)
+	}
+	var after *journal.Cursor
+	if savedCursor != nil {
+		cursor, err := journal.ParseCursor(string(savedCursor))
+		if err != nil {
+			operator.Warnw("Ignoring invalid journal cursor", zap.Error(err))
+		} else {
+			after = &cursor
+		}
+	}
+
+	operator.discover(true, after)
+	if len(operator.files) == 0 {
+		operator.Warnw("No journal files found", "patterns", operator.patterns)
+	}
+
+	operator.wg.Add(1)
+	go operator.run(ctx)
+	return nil
+}
+
+func (operator *NativeInput) run(ctx context.Context) {
+	defer operator.wg.Done()
+	defer operator.closeFiles()
+
+	ticker := time.NewTicker(operator.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		operator.readEntries(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			operator.discover(false, nil)
+		}
+	}
+}
+
+// discover opens the journal files created since the last call, and marks the removed ones.
+// At startup, the files are positioned after the saved cursor, or at their end when
+// starting at the end; the files created later are read from their beginning.
+func (operator *NativeInput) discover(startup bool, after *journal.Cursor) {
+	for _, f := range operator.files {
+		f.removed = true
+	}
+
+	for _, path := range operator.paths() {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		if f := operator.fileFor(info); f != nil {
+			// journald renames the files it archives
+			f.path = path
+			f.removed = false
+			continue
+		}
+		if ignored, ok := operator.ignored[path]; ok && os.SameFile(ignored, info) && ignored.ModTime().Equal(info.ModTime()) {
+			continue
+		}
+
+		jf, err := journal.Open(path)
+		if err != nil {
+			operator.Warnw("Failed to open journal file", "path", path, zap.Error(err))
+			operator.ignored[path] = info
+			continue
+		}
+		delete(operator.ignored, path)
+
+		f := &journalFile{File: jf, path: path, info: info}
+		if startup && (after != nil || operator.startAtEnd) {
+			if err = f.seek(after); err != nil {
+				operator.Errorw("Failed to read journal file", "path", path, zap.Error(err))
+				f.failed = true
+			}
+		}
+		operator.files = append(operator.files, f)
+	}
+}
+
+func (operator *NativeInput) paths() []string {
+	var paths []string
+	seen := map[string]bool{}
+	for _, pattern := range operator.patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			operator.Warnw("Invalid journal file pattern", "pattern", pattern, zap.Error(err))
+			continue
+		}
+		for _, path := range matches {
+			if !seen[path] {
+				seen[path] = true
+				paths = append(paths, path)
+			}
+		}
+	}
+	return paths
+}
+
+func (operator *NativeInput) fileFor(info os.FileInfo) *journalFile {
+	for _, f := range operator.files {
+		if os.SameFile(f.info, info) {
+			return f
+		}
+	}
+	return nil
+}
+
+// seek skips the entries up to the cursor, or all the entries when the cursor is nil.
+func (f *journalFile) seek(after *journal.Cursor) error {
+	for {
+		e, err := f.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if after != nil && journal.Compare(e.Cursor, *after) > 0 {
+			f.head = e
+			return nil
+		}
+	}
+}
+
+// readEntries writes the new entries of all the files, interleaved in the order of the journal.
+func (operator *NativeInput) readEntries(ctx context.Context) {
+	for ctx.Err() == nil {
+		f := operator.nextFile()
+		if f == nil {
+			break
+		}
+		e := f.head
+		f.head = nil
+		operator.processEntry(ctx, f, e)
+	}
+
+	// The removed files were read to their end
+	files := operator.files[:0]
+	for _, f := range operator.files {
+		if f.removed {
+			if err := f.Close(); err != nil {
+				operator.Warnw("Failed to close journal file", "path", f.path, zap.Error(err))
+			}
+			continue
+		}
+		files = append(files, f)
+	}
+	operator.files = files
+}
+
+// nextFile returns the file holding the next entry of the journal, or nil when all the files were read.
+func (operator *NativeInput) nextFile() *journalFile {
+	var next *journalFile
+	for _, f := range operator.files {
+		if f.head == nil && !f.failed {
+			e, err := f.Next()
+			switch {
+			case err == nil:
+				f.head = e
+			case errors.Is(err, io.EOF):
+			default:
+				operator.Errorw("Failed to read journal file", "path", f.path, zap.Error(err))
+				f.failed = true
+			}
+		}
+		if f.head != nil && (next == nil || journal.Compare(f.head.Cursor, next.head.Cursor) < 0) {
+			next = f
+		}
+	}
+	return next
+}
+
+func (operator *NativeInput) processEntry(ctx context.Context, f *journalFile, e *journal.Entry) {
+	fields, err := f.Fields(e)
+	if err != nil {
+		operator.Warnw("Failed to read journal entry", "path", f.path, zap.Error(err))
+		return
+	}
+
+	values := make(map[string][][]byte, len(fields))
+	for _, field := range fields {
+		values[field.Name] = append(values[field.Name], field.Value)
+	}
+	if !operator.filter.match(values) {
+		return
+	}
+
+	entry, err := operator.NewEntry(operator.entryBody(e, fields))
+	if err != nil {
+		operator.Warnw("Failed to create entry", zap.Error(err))
+		return
+	}
+	entry.Timestamp = time.UnixMicro(int64(e.Realtime))
+
+	if err := operator.persister.Set(ctx, lastReadCursorKey, []byte(e.Cursor.String())); err != nil {
+		operator.Warnw("Failed to set offset", zap.Error(err))
+	}
+	operator.Write(ctx, entry)
+}
+
+// entryBody builds the same body as the JSON output of journalctl, except that
+// binary values are bytes rather than arrays of numbers.
+func (operator *NativeInput) entryBody(e *journal.Entry, fields []journal.Field) map[string]any {
+	body := make(map[string]any, len(fields)+3)
+	for _, field := range fields {
+		value := operator.fieldValue(field.Value)
+		switch previous := body[field.Name].(type) {
+		case nil:
+			if _, ok := body[field.Name]; ok {
+				body[field.Name] = []any{nil, value}
+			} else {
+				body[field.Name] = value
+			}
+		case []any:
+			body[field.Name] = append(previous, value)
+		default:
+			body[field.Name] = []any{previous, value}
+		}
+	}
+	body["__CURSOR"] = e.Cursor.String()
+	body["__MONOTONIC_TIMESTAMP"] = strconv.FormatUint(e.Monotonic, 10)
+	body["_BOOT_ID"] = e.BootID.String()
+	return body
+}
+
+func (operator *NativeInput) fieldValue(value []byte) any {
+	if !operator.all && len(value) >= valueThreshold {
+		return nil
+	}
+	if printable(value) {
+		return string(value)
+	}
+	return append([]byte(nil), value...)
+}
+
+// printable reports whether a value is valid UTF-8 without control characters other than tabs and newlines.
+func printable(value []byte) bool {
+	for len(value) > 0 {
+		r, size := utf8.DecodeRune(value)
+		if r == utf8.RuneError && size <= 1 {
+			return false
+		}
+		if (r < ' ' && r != '\t' && r != '\n') || (r >= 0x7f && r <= 0x9f) {
+			return false
+		}
+		value = value[size:]
+	}
+	return true
+}
+
+func (operator *NativeInput) closeFiles() {
+	for _, f := range operator.files {
+		if err := f.Close(); err != nil {
+			operator.Warnw("Failed to close journal file", "path", f.path, zap.Error(err))
+		}
+	}
+	operator.files = nil
+}
+
+// Stop will stop reading the journal files.
+func (operator *NativeInput) Stop() error {
+	if operator.cancel != nil {
+		operator.cancel()
+	}
+	operator.wg.Wait()
+	return nil
+}
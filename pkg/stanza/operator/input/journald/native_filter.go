@@ -0,0 +1,220 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+
+package journald // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/journald"
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// coredumpMessageID is the MESSAGE_ID of the entries systemd-coredump writes about crashed units.
+const coredumpMessageID = "fc2e22bc6ee647b6b90729ab34a250b1"
+
+var priorities = []string{"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug"}
+
+var unitSuffixes = []string{
+	".service", ".socket", ".target", ".device", ".mount", ".automount",
+	".swap", ".timer", ".path", ".slice", ".scope",
+}
+
+// filter selects journal entries the same way as the journalctl options built by buildArgs.
+type filter struct {
+	units       []string
+	identifiers []string
+	minPriority int
+	maxPriority int
+	matches     []MatchConfig
+	grep        *regexp.Regexp
+	dmesg       bool
+	bootID      string
+}
+
+func (c Config) buildFilter() (*filter, error) {
+	f := &filter{
+		identifiers: c.Identifiers,
+		matches:     c.Matches,
+		dmesg:       c.Dmesg,
+	}
+
+	for _, unit := range c.Units {
+		f.units = append(f.units, mangleUnit(unit))
+	}
+
+	var err error
+	if f.minPriority, f.maxPriority, err = parsePriorities(c.Priority); err != nil {
+		return nil, err
+	}
+
+	// Validate the field names of the matches
+	if _, err = c.buildMatchesConfig(); err != nil {
+		return nil, err
+	}
+
+	if c.Grep != "" {
+		pattern := c.Grep
+		// Like journalctl, the pattern is case insensitive unless it contains uppercase characters
+		if strings.IndexFunc(pattern, unicode.IsUpper) < 0 {
+			pattern = "(?i)" + pattern
+		}
+		if f.grep, err = regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("invalid value '%s' for parameter 'grep': %w", c.Grep, err)
+		}
+	}
+
+	if c.Dmesg {
+		bootID, err := os.ReadFile("/proc/sys/kernel/random/boot_id")
+		if err != nil {
+			return nil, fmt.Errorf("read boot id: %w", err)
+		}
+		f.bootID = strings.ReplaceAll(strings.TrimSpace(string(bootID)), "-", "")
+	}
+
+	return f, nil
+}
+
+// mangleUnit completes unit names without a type with the service type, as journalctl does.
+func mangleUnit(unit string) string {
+	if strings.ContainsAny(unit, "*?[") {
+		return unit
+	}
+	for _, suffix := range unitSuffixes {
+		if strings.HasSuffix(unit, suffix) {
+			return unit
+		}
+	}
+	return unit + ".service"
+}
+
+// parsePriorities parses a priority or a range of priorities. A single priority selects
+// itself and all the more important priorities.
+func parsePriorities(value string) (int, int, error) {
+	invalid := fmt.Errorf("invalid value '%s' for parameter 'priority'", value)
+
+	from, to, isRange := strings.Cut(value, "..")
+	if !isRange {
+		priority, ok := parsePriority(value)
+		if !ok {
+			return 0, 0, invalid
+		}
+		return 0, priority, nil
+	}
+
+	minPriority, minOK := parsePriority(from)
+	maxPriority, maxOK := parsePriority(to)
+	if !minOK || !maxOK {
+		return 0, 0, invalid
+	}
+	if minPriority > maxPriority {
+		minPriority, maxPriority = maxPriority, minPriority
+	}
+	return minPriority, maxPriority, nil
+}
+
+func parsePriority(value string) (int, bool) {
+	for i, name := range priorities {
+		if value == name {
+			return i, true
+		}
+	}
+	priority, err := strconv.Atoi(value)
+	if err != nil || priority < 0 || priority >= len(priorities) {
+		return 0, false
+	}
+	return priority, true
+}
+
+// match reports whether an entry, given as the values of each of its fields, is selected.
+func (f *filter) match(fields map[string][][]byte) bool {
+	if !f.matchPriority(fields) {
+		return false
+	}
+
+	if len(f.units) > 0 && !f.matchUnits(fields) {
+		return false
+	}
+
+	if len(f.identifiers) > 0 && !anyOf(f.identifiers, func(identifier string) bool {
+		return hasValue(fields, "SYSLOG_IDENTIFIER", identifier)
+	}) {
+		return false
+	}
+
+	if len(f.matches) > 0 && !anyOf(f.matches, func(mc MatchConfig) bool {
+		for key, value := range mc {
+			if !hasValue(fields, key, value) {
+				return false
+			}
+		}
+		return true
+	}) {
+		return false
+	}
+
+	if f.grep != nil && !anyOf(fields["MESSAGE"], f.grep.Match) {
+		return false
+	}
+
+	if f.dmesg && (!hasValue(fields, "_TRANSPORT", "kernel") || !hasValue(fields, "_BOOT_ID", f.bootID)) {
+		return false
+	}
+
+	return true
+}
+
+func (f *filter) matchPriority(fields map[string][][]byte) bool {
+	return anyOf(fields["PRIORITY"], func(value []byte) bool {
+		priority, err := strconv.Atoi(string(value))
+		return err == nil && priority >= f.minPriority && priority <= f.maxPriority
+	})
+}
+
+// matchUnits selects the entries written by the units, and the entries systemd and
+// systemd-coredump write about them.
+func (f *filter) matchUnits(fields map[string][][]byte) bool {
+	root := hasValue(fields, "_UID", "0")
+	return anyOf(f.units, func(unit string) bool {
+		matchUnit := func(value []byte) bool {
+			matched, _ := path.Match(unit, string(value))
+			return matched
+		}
+		switch {
+		case anyOf(fields["_SYSTEMD_UNIT"], matchUnit):
+			return true
+		case root && hasValue(fields, "MESSAGE_ID", coredumpMessageID) && anyOf(fields["COREDUMP_UNIT"], matchUnit):
+			return true
+		case hasValue(fields, "_PID", "1") && anyOf(fields["UNIT"], matchUnit):
+			return true
+		case root && anyOf(fields["OBJECT_SYSTEMD_UNIT"], matchUnit):
+			return true
+		case strings.HasSuffix(unit, ".slice") && anyOf(fields["_SYSTEMD_SLICE"], matchUnit):
+			return true
+		}
+		return false
+	})
+}
+
+func hasValue(fields map[string][][]byte, name, value string) bool {
+	for _, v := range fields[name] {
+		if string(v) == value {
+			return true
+		}
+	}
+	return false
+}
+
+func anyOf[T any](values []T, predicate func(T) bool) bool {
+	for _, value := range values {
+		if predicate(value) {
+			return true
+		}
+	}
+	return false
+}
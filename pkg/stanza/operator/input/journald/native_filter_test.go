@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+
+package journald
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilter(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Config   func(cfg *Config)
+		Fields   []string
+		Expected bool
+	}{
+		{
+			Name:     "default priority",
+			Config:   func(cfg *Config) {},
+			Fields:   []string{"PRIORITY=6"},
+			Expected: true,
+		},
+		{
+			Name:     "debug priority",
+			Config:   func(cfg *Config) {},
+			Fields:   []string{"PRIORITY=7"},
+			Expected: false,
+		},
+		{
+			Name:     "missing priority",
+			Config:   func(cfg *Config) {},
+			Fields:   []string{"MESSAGE=test"},
+			Expected: false,
+		},
+		{
+			Name:     "priority range",
+			Config:   func(cfg *Config) { cfg.Priority = "warning..err" },
+			Fields:   []string{"PRIORITY=2"},
+			Expected: false,
+		},
+		{
+			Name:     "priority in range",
+			Config:   func(cfg *Config) { cfg.Priority = "3..warning" },
+			Fields:   []string{"PRIORITY=4"},
+			Expected: true,
+		},
+		{
+			Name:     "unit",
+			Config:   func(cfg *Config) { cfg.Units = []string{"ssh"} },
+			Fields:   []string{"PRIORITY=6", "_SYSTEMD_UNIT=ssh.service"},
+			Expected: true,
+		},
+		{
+			Name:     "other unit",
+			Config:   func(cfg *Config) { cfg.Units = []string{"ssh"} },
+			Fields:   []string{"PRIORITY=6", "_SYSTEMD_UNIT=sshd.service"},
+			Expected: false,
+		},
+		{
+			Name:     "unit glob",
+			Config:   func(cfg *Config) { cfg.Units = []string{"user@*.service"} },
+			Fields:   []string{"PRIORITY=6", "_SYSTEMD_UNIT=user@1000.service"},
+			Expected: true,
+		},
+		{
+			Name:     "unit message from systemd",
+			Config:   func(cfg *Config) { cfg.Units = []string{"ssh.service"} },
+			Fields:   []string{"PRIORITY=6", "_PID=1", "UNIT=ssh.service"},
+			Expected: true,
+		},
+		{
+			Name:     "unit message from other process",
+			Config:   func(cfg *Config) { cfg.Units = []string{"ssh.service"} },
+			Fields:   []string{"PRIORITY=6", "_PID=2", "UNIT=ssh.service"},
+			Expected: false,
+		},
+		{
+			Name:     "unit coredump",
+			Config:   func(cfg *Config) { cfg.Units = []string{"ssh"} },
+			Fields:   []string{"PRIORITY=2", "_UID=0", "MESSAGE_ID=fc2e22bc6ee647b6b90729ab34a250b1", "COREDUMP_UNIT=ssh.service"},
+			Expected: true,
+		},
+		{
+			Name:     "unit object",
+			Config:   func(cfg *Config) { cfg.Units = []string{"ssh"} },
+			Fields:   []string{"PRIORITY=6", "_UID=0", "OBJECT_SYSTEMD_UNIT=ssh.service"},
+			Expected: true,
+		},
+		{
+			Name:     "slice",
+			Config:   func(cfg *Config) { cfg.Units = []string{"user-1000.slice"} },
+			Fields:   []string{"PRIORITY=6", "_SYSTEMD_SLICE=user-1000.slice"},
+			Expected: true,
+		},
+		{
+			Name:     "identifiers",
+			Config:   func(cfg *Config) { cfg.Identifiers = []string{"wireplumber", "systemd"} },
+			Fields:   []string{"PRIORITY=6", "SYSLOG_IDENTIFIER=systemd"},
+			Expected: true,
+		},
+		{
+			Name:     "other identifier",
+			Config:   func(cfg *Config) { cfg.Identifiers = []string{"wireplumber"} },
+			Fields:   []string{"PRIORITY=6", "SYSLOG_IDENTIFIER=systemd"},
+			Expected: false,
+		},
+		{
+			Name: "matches",
+			Config: func(cfg *Config) {
+				cfg.Matches = []MatchConfig{
+					{"_SYSTEMD_UNIT": "dbus.service"},
+					{"_SYSTEMD_UNIT": "user@1000.service", "_UID": "1000"},
+				}
+			},
+			Fields:   []string{"PRIORITY=6", "_SYSTEMD_UNIT=user@1000.service", "_UID=1000"},
+			Expected: true,
+		},
+		{
+			Name: "partial match",
+			Config: func(cfg *Config) {
+				cfg.Matches = []MatchConfig{
+					{"_SYSTEMD_UNIT": "user@1000.service", "_UID": "1000"},
+				}
+			},
+			Fields:   []string{"PRIORITY=6", "_SYSTEMD_UNIT=user@1000.service", "_UID=0"},
+			Expected: false,
+		},
+		{
+			Name: "units and matches",
+			Config: func(cfg *Config) {
+				cfg.Units = []string{"ssh"}
+				cfg.Matches = []MatchConfig{{"_UID": "1000"}}
+			},
+			Fields:   []string{"PRIORITY=6", "_SYSTEMD_UNIT=ssh.service", "_UID=0"},
+			Expected: false,
+		},
+		{
+			Name:     "grep",
+			Config:   func(cfg *Config) { cfg.Grep = "fail(ed|ure)" },
+			Fields:   []string{"PRIORITY=6", "MESSAGE=Connection FAILED"},
+			Expected: true,
+		},
+		{
+			Name:     "grep case sensitive",
+			Config:   func(cfg *Config) { cfg.Grep = "Failed" },
+			Fields:   []string{"PRIORITY=6", "MESSAGE=Connection FAILED"},
+			Expected: false,
+		},
+		{
+			Name:     "dmesg",
+			Config:   func(cfg *Config) { cfg.Dmesg = true },
+			Fields:   []string{"PRIORITY=6", "_TRANSPORT=journal"},
+			Expected: false,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.Name, func(t *testing.T) {
+			cfg := NewConfigWithID("my_journald_input")
+			tt.Config(cfg)
+			f, err := cfg.buildFilter()
+			require.NoError(t, err)
+
+			fields := map[string][][]byte{}
+			for _, field := range tt.Fields {
+				name, value, _ := strings.Cut(field, "=")
+				fields[name] = append(fields[name], []byte(value))
+			}
+			assert.Equal(t, tt.Expected, f.match(fields))
+		})
+	}
+}
+
+func TestMangleUnit(t *testing.T) {
+	assert.Equal(t, "ssh.service", mangleUnit("ssh"))
+	assert.Equal(t, "ssh.socket", mangleUnit("ssh.socket"))
+	assert.Equal(t, "user@*", mangleUnit("user@*"))
+}
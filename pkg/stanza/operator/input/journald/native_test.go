@@ -0,0 +1,296 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+
+package journald
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/journald/internal/journal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/journald/internal/journaltest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+var (
+	testSeqnumID = journal.ID128{1}
+	testBootID   = journal.ID128{2}
+)
+
+func newTestJournal(t *testing.T, path string) *journaltest.Writer {
+	w, err := journaltest.Create(path, journaltest.Options{
+		Compact:           true,
+		CompressThreshold: 64,
+		SeqnumID:          testSeqnumID,
+		BootID:            testBootID,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = w.Close() })
+	return w
+}
+
+func appendMessage(t *testing.T, w *journaltest.Writer, seqnum uint64, message string, fields ...string) {
+	require.NoError(t, w.Append(journaltest.Entry{
+		Fields:    append([]string{"MESSAGE=" + message, "PRIORITY=6"}, fields...),
+		Seqnum:    seqnum,
+		Realtime:  1700000000000000 + seqnum,
+		Monotonic: 1000 + seqnum,
+	}))
+}
+
+func startNativeInput(t *testing.T, persister operator.Persister, configure func(cfg *Config)) <-chan *entry.Entry {
+	cfg := NewConfigWithID("my_journald_input")
+	cfg.OutputIDs = []string{"output"}
+	cfg.Reader = readerNative
+	configure(cfg)
+
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	op.(*NativeInput).pollInterval = 10 * time.Millisecond
+
+	mockOutput := testutil.NewMockOperator("output")
+	received := make(chan *entry.Entry, 100)
+	mockOutput.On("Process", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		received <- args.Get(1).(*entry.Entry)
+	}).Return(nil)
+	require.NoError(t, op.SetOutputs([]operator.Operator{mockOutput}))
+
+	require.NoError(t, op.Start(persister))
+	t.Cleanup(func() { require.NoError(t, op.Stop()) })
+	return received
+}
+
+func expectMessages(t *testing.T, received <-chan *entry.Entry, messages ...string) {
+	for _, message := range messages {
+		select {
+		case e := <-received:
+			require.Equal(t, message, e.Body.(map[string]any)["MESSAGE"])
+		case <-time.After(time.Second):
+			require.FailNow(t, "Timed out waiting for entry to be read", message)
+		}
+	}
+	select {
+	case e := <-received:
+		require.FailNow(t, "Unexpected entry", e.Body)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestNativeInput(t *testing.T) {
+	dir := t.TempDir()
+	system := newTestJournal(t, filepath.Join(dir, "system.journal"))
+	user := newTestJournal(t, filepath.Join(dir, "user-1000.journal"))
+	appendMessage(t, system, 1, "first", "_SYSTEMD_UNIT=ssh.service", "TAG=a", "TAG=b")
+	appendMessage(t, user, 2, "second")
+	appendMessage(t, system, 3, "third")
+
+	persister := testutil.NewUnscopedMockPersister()
+	received := startNativeInput(t, persister, func(cfg *Config) {
+		cfg.Directory = &dir
+		cfg.StartAt = "beginning"
+	})
+
+	select {
+	case e := <-received:
+		body := e.Body.(map[string]any)
+		cursor, err := journal.ParseCursor(body["__CURSOR"].(string))
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), cursor.Seqnum)
+		require.Equal(t, map[string]any{
+			"MESSAGE":               "first",
+			"PRIORITY":              "6",
+			"_SYSTEMD_UNIT":         "ssh.service",
+			"TAG":                   []any{"a", "b"},
+			"__CURSOR":              body["__CURSOR"],
+			"__MONOTONIC_TIMESTAMP": "1001",
+			"_BOOT_ID":              "02000000000000000000000000000000",
+		}, body)
+		require.Equal(t, time.UnixMicro(1700000000000001), e.Timestamp)
+	case <-time.After(time.Second):
+		require.FailNow(t, "Timed out waiting for entry to be read")
+	}
+	expectMessages(t, received, "second", "third")
+
+	// Entries appended to the files are read as well
+	appendMessage(t, user, 4, "fourth")
+	expectMessages(t, received, "fourth")
+
+	cursor, err := persister.Get(context.Background(), lastReadCursorKey)
+	require.NoError(t, err)
+	parsed, err := journal.ParseCursor(string(cursor))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), parsed.Seqnum)
+}
+
+func TestNativeInputStartAtEnd(t *testing.T) {
+	dir := t.TempDir()
+	system := newTestJournal(t, filepath.Join(dir, "system.journal"))
+	appendMessage(t, system, 1, "existing")
+
+	received := startNativeInput(t, testutil.NewUnscopedMockPersister(), func(cfg *Config) {
+		cfg.Directory = &dir
+	})
+	expectMessages(t, received)
+
+	appendMessage(t, system, 2, "appended")
+	expectMessages(t, received, "appended")
+
+	// Files created after startup are read from their beginning
+	user := newTestJournal(t, filepath.Join(dir, "user-1000.journal"))
+	appendMessage(t, user, 3, "new file")
+	expectMessages(t, received, "new file")
+}
+
+func TestNativeInputCursor(t *testing.T) {
+	dir := t.TempDir()
+	system := newTestJournal(t, filepath.Join(dir, "system.journal"))
+	user := newTestJournal(t, filepath.Join(dir, "user-1000.journal"))
+	appendMessage(t, system, 1, "first")
+	appendMessage(t, user, 2, "second")
+	appendMessage(t, system, 3, "third")
+	appendMessage(t, user, 4, "fourth")
+
+	persister := testutil.NewUnscopedMockPersister()
+	cursor := journal.Cursor{SeqnumID: testSeqnumID, Seqnum: 2, BootID: testBootID}
+	require.NoError(t, persister.Set(context.Background(), lastReadCursorKey, []byte(cursor.String())))
+
+	received := startNativeInput(t, persister, func(cfg *Config) {
+		cfg.Files = []string{filepath.Join(dir, "*.journal")}
+	})
+	expectMessages(t, received, "third", "fourth")
+}
+
+func TestNativeInputRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "system.journal")
+	system := newTestJournal(t, path)
+	appendMessage(t, system, 1, "first")
+
+	received := startNativeInput(t, testutil.NewUnscopedMockPersister(), func(cfg *Config) {
+		cfg.Directory = &dir
+		cfg.StartAt = "beginning"
+	})
+	expectMessages(t, received, "first")
+
+	// journald archives the file under a new name and creates a new one
+	require.NoError(t, os.Rename(path, filepath.Join(dir, "system@0000000000000001-0000000000000002.journal")))
+	appendMessage(t, system, 2, "archived")
+	rotated := newTestJournal(t, path)
+	appendMessage(t, rotated, 3, "rotated")
+	expectMessages(t, received, "archived", "rotated")
+
+	// Removed files are closed
+	require.NoError(t, os.Remove(filepath.Join(dir, "system@0000000000000001-0000000000000002.journal")))
+	appendMessage(t, rotated, 4, "last")
+	expectMessages(t, received, "last")
+}
+
+func TestNativeInputFilters(t *testing.T) {
+	dir := t.TempDir()
+	system := newTestJournal(t, filepath.Join(dir, "system.journal"))
+	appendMessage(t, system, 1, "ssh", "_SYSTEMD_UNIT=ssh.service")
+	appendMessage(t, system, 2, "kubelet", "_SYSTEMD_UNIT=kubelet.service")
+	require.NoError(t, system.Append(journaltest.Entry{
+		Fields: []string{"MESSAGE=debug", "PRIORITY=7", "_SYSTEMD_UNIT=ssh.service"},
+		Seqnum: 3,
+	}))
+
+	received := startNativeInput(t, testutil.NewUnscopedMockPersister(), func(cfg *Config) {
+		cfg.Directory = &dir
+		cfg.StartAt = "beginning"
+		cfg.Units = []string{"ssh"}
+	})
+	expectMessages(t, received, "ssh")
+}
+
+func TestNativeInputBody(t *testing.T) {
+	long := strings.Repeat("x", valueThreshold)
+	for _, all := range []bool{false, true} {
+		dir := t.TempDir()
+		system := newTestJournal(t, filepath.Join(dir, "system.journal"))
+		appendMessage(t, system, 1, "message", "LONG="+long, "BINARY=a\x01b")
+
+		received := startNativeInput(t, testutil.NewUnscopedMockPersister(), func(cfg *Config) {
+			cfg.Directory = &dir
+			cfg.StartAt = "beginning"
+			cfg.All = all
+		})
+
+		select {
+		case e := <-received:
+			body := e.Body.(map[string]any)
+			assert.Equal(t, []byte("a\x01b"), body["BINARY"])
+			if all {
+				assert.Equal(t, long, body["LONG"])
+			} else {
+				assert.Contains(t, body, "LONG")
+				assert.Nil(t, body["LONG"])
+			}
+		case <-time.After(time.Second):
+			require.FailNow(t, "Timed out waiting for entry to be read")
+		}
+	}
+}
+
+func TestBuildNativeConfig(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		Config        func(cfg *Config)
+		ExpectedError string
+	}{
+		{
+			Name:          "invalid reader",
+			Config:        func(cfg *Config) { cfg.Reader = "systemd" },
+			ExpectedError: "invalid value 'systemd' for parameter 'reader'",
+		},
+		{
+			Name:          "invalid start_at",
+			Config:        func(cfg *Config) { cfg.StartAt = "middle" },
+			ExpectedError: "invalid value 'middle' for parameter 'start_at'",
+		},
+		{
+			Name:          "invalid priority",
+			Config:        func(cfg *Config) { cfg.Priority = "verbose" },
+			ExpectedError: "invalid value 'verbose' for parameter 'priority'",
+		},
+		{
+			Name:          "invalid priority range",
+			Config:        func(cfg *Config) { cfg.Priority = "err..8" },
+			ExpectedError: "invalid value 'err..8' for parameter 'priority'",
+		},
+		{
+			Name:          "invalid grep",
+			Config:        func(cfg *Config) { cfg.Grep = "(" },
+			ExpectedError: "invalid value '(' for parameter 'grep'",
+		},
+		{
+			Name: "invalid match",
+			Config: func(cfg *Config) {
+				cfg.Matches = []MatchConfig{{"-SYSTEMD_UNIT": "dbus.service"}}
+			},
+			ExpectedError: "'-SYSTEMD_UNIT' is not a valid Systemd field name",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.Name, func(t *testing.T) {
+			cfg := NewConfigWithID("my_journald_input")
+			cfg.Reader = readerNative
+			tt.Config(cfg)
+			_, err := cfg.Build(testutil.Logger(t))
+			require.ErrorContains(t, err, tt.ExpectedError)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package relp
+
+import (
+	"path/filepath"
+	"testing"
+
+	"go.opentelemetry.io/collector/config/configtls"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/operatortest"
+)
+
+func TestUnmarshal(t *testing.T) {
+	operatortest.ConfigUnmarshalTests{
+		DefaultConfig: NewConfig(),
+		TestsFile:     filepath.Join(".", "testdata", "config.yaml"),
+		Tests: []operatortest.ConfigUnmarshalTest{
+			{
+				Name:      "default",
+				ExpectErr: false,
+				Expect:    NewConfig(),
+			},
+			{
+				Name:      "all",
+				ExpectErr: false,
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.MaxLogSize = 1000000
+					cfg.ListenAddress = "10.0.0.1:2514"
+					cfg.AddAttributes = true
+					cfg.Encoding = "utf-8"
+					cfg.TLS = &configtls.TLSServerSetting{
+						TLSSetting: configtls.TLSSetting{
+							CertFile: "foo",
+							KeyFile:  "foo2",
+							CAFile:   "foo3",
+						},
+						ClientCAFile: "foo4",
+					}
+					return cfg
+				}(),
+			},
+		},
+	}.Run(t)
+}
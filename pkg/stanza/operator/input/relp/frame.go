@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package relp // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/relp"
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+const (
+	// maxCommandLength is the maximum length of a command name.
+	maxCommandLength = 32
+
+	// maxDigits is the maximum number of digits of the transaction number and data length.
+	// Transaction numbers wrap to 1 after 999999999.
+	maxDigits = 9
+)
+
+const (
+	commandOpen        = "open"
+	commandClose       = "close"
+	commandSyslog      = "syslog"
+	commandResponse    = "rsp"
+	commandServerClose = "serverclose"
+)
+
+// frame is a RELP frame: TXNR SP COMMAND SP DATALEN [SP DATA] LF
+type frame struct {
+	txnr    uint64
+	command string
+	data    []byte
+}
+
+// readFrame reads a frame whose data is at most maxDataLen bytes long.
+func readFrame(r *bufio.Reader, maxDataLen int) (frame, error) {
+	var f frame
+
+	txnr, _, err := readNumber(r, ' ')
+	if err != nil {
+		return f, err
+	}
+	f.txnr = txnr
+
+	command, err := readCommand(r)
+	if err != nil {
+		return f, err
+	}
+	f.command = command
+
+	dataLen, separator, err := readNumber(r, ' ', '\n')
+	if err != nil {
+		return f, err
+	}
+	if dataLen > uint64(maxDataLen) {
+		return f, fmt.Errorf("invalid relp frame: data length %d exceeds %d bytes", dataLen, maxDataLen)
+	}
+	if separator == '\n' {
+		// The data and its separator are omitted when there is no data
+		if dataLen != 0 {
+			return f, fmt.Errorf("invalid relp frame: missing data")
+		}
+		return f, nil
+	}
+
+	if dataLen == 0 {
+		return f, readTrailer(r)
+	}
+	f.data = make([]byte, dataLen)
+	if _, err = io.ReadFull(r, f.data); err != nil {
+		return f, unexpectedEOF(err)
+	}
+	return f, readTrailer(r)
+}
+
+// readNumber reads a number of at most maxDigits digits, followed by one of the separators,
+// and returns the number and its separator.
+func readNumber(r *bufio.Reader, separators ...byte) (uint64, byte, error) {
+	var digits []byte
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			if len(digits) > 0 {
+				err = unexpectedEOF(err)
+			}
+			return 0, 0, err
+		}
+		for _, separator := range separators {
+			if b == separator && len(digits) > 0 {
+				n, err := strconv.ParseUint(string(digits), 10, 64)
+				return n, separator, err
+			}
+		}
+		if b < '0' || b > '9' || len(digits) == maxDigits {
+			return 0, 0, fmt.Errorf("invalid relp frame: unexpected character %q in number", b)
+		}
+		digits = append(digits, b)
+	}
+}
+
+func readCommand(r *bufio.Reader) (string, error) {
+	var command []byte
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return "", unexpectedEOF(err)
+		}
+		if b == ' ' && len(command) > 0 {
+			return string(command), nil
+		}
+		if (b < 'a' || b > 'z') && (b < 'A' || b > 'Z') || len(command) == maxCommandLength {
+			return "", fmt.Errorf("invalid relp frame: unexpected character %q in command", b)
+		}
+		command = append(command, b)
+	}
+}
+
+func readTrailer(r *bufio.Reader) error {
+	b, err := r.ReadByte()
+	if err != nil {
+		return unexpectedEOF(err)
+	}
+	if b != '\n' {
+		return fmt.Errorf("invalid relp frame: unexpected character %q instead of trailer", b)
+	}
+	return nil
+}
+
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// appendFrame appends the encoding of a frame to b.
+func appendFrame(b []byte, f frame) []byte {
+	b = strconv.AppendUint(b, f.txnr, 10)
+	b = append(b, ' ')
+	b = append(b, f.command...)
+	b = append(b, ' ')
+	b = strconv.AppendInt(b, int64(len(f.data)), 10)
+	if len(f.data) > 0 {
+		b = append(b, ' ')
+		b = append(b, f.data...)
+	}
+	return append(b, '\n')
+}
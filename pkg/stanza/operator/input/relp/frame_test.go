@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package relp
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadFrame(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected []frame
+	}{
+		{
+			name:  "open",
+			input: "1 open 86 relp_version=0\nrelp_software=librelp,1.10.0,http://librelp.adiscon.com\ncommands=syslog\n",
+			expected: []frame{
+				{txnr: 1, command: "open", data: []byte("relp_version=0\nrelp_software=librelp,1.10.0,http://librelp.adiscon.com\ncommands=syslog")},
+			},
+		},
+		{
+			name:  "messages",
+			input: "2 syslog 5 hello\n3 syslog 12 hello\nworld!\n",
+			expected: []frame{
+				{txnr: 2, command: "syslog", data: []byte("hello")},
+				{txnr: 3, command: "syslog", data: []byte("hello\nworld!")},
+			},
+		},
+		{
+			name:     "no data",
+			input:    "4 close 0\n",
+			expected: []frame{{txnr: 4, command: "close"}},
+		},
+		{
+			name:     "empty data",
+			input:    "4 close 0 \n",
+			expected: []frame{{txnr: 4, command: "close"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := bufio.NewReader(strings.NewReader(tc.input))
+			for _, expected := range tc.expected {
+				f, err := readFrame(r, 1024)
+				require.NoError(t, err)
+				assert.Equal(t, expected, f)
+			}
+			_, err := readFrame(r, 1024)
+			assert.ErrorIs(t, err, io.EOF)
+		})
+	}
+}
+
+func TestReadInvalidFrame(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "invalid transaction number",
+			input:    "a syslog 5 hello\n",
+			expected: "unexpected character 'a' in number",
+		},
+		{
+			name:     "transaction number out of range",
+			input:    "1000000000 syslog 5 hello\n",
+			expected: "unexpected character '0' in number",
+		},
+		{
+			name:     "invalid command",
+			input:    "1 sys-log 5 hello\n",
+			expected: "unexpected character '-' in command",
+		},
+		{
+			name:     "data too long",
+			input:    "1 syslog 2000 hello\n",
+			expected: "data length 2000 exceeds 1024 bytes",
+		},
+		{
+			name:     "missing data",
+			input:    "1 syslog 5\n",
+			expected: "missing data",
+		},
+		{
+			name:     "missing trailer",
+			input:    "1 syslog 4 hello\n",
+			expected: "unexpected character 'o' instead of trailer",
+		},
+		{
+			name:     "truncated",
+			input:    "1 syslog 5 hel",
+			expected: io.ErrUnexpectedEOF.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := readFrame(bufio.NewReader(strings.NewReader(tc.input)), 1024)
+			assert.ErrorContains(t, err, tc.expected)
+		})
+	}
+}
+
+func TestAppendFrame(t *testing.T) {
+	b := appendFrame(nil, frame{txnr: 2, command: commandResponse, data: []byte("200 OK")})
+	b = appendFrame(b, frame{command: commandServerClose})
+	assert.Equal(t, "2 rsp 6 200 OK\n0 serverclose 0\n", string(b))
+}
@@ -0,0 +1,419 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package relp // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/relp"
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jpillora/backoff"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.uber.org/zap"
+	"golang.org/x/text/encoding"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/decode"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const (
+	operatorType = "relp_input"
+
+	// DefaultMaxLogSize is the max size of a message if MaxLogSize is not set
+	DefaultMaxLogSize = 1024 * 1024
+
+	// writeTimeout bounds the time spent sending a response to a client which does not read them
+	writeTimeout = 10 * time.Second
+
+	// software is the software reported to the clients when opening a session
+	software = "opentelemetry-collector-contrib"
+)
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new RELP input config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new RELP input config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		InputConfig: helper.NewInputConfig(operatorID, operatorType),
+		BaseConfig: BaseConfig{
+			Encoding: "utf-8",
+		},
+	}
+}
+
+// Config is the configuration of a RELP input operator.
+type Config struct {
+	helper.InputConfig `mapstructure:",squash"`
+	BaseConfig         `mapstructure:",squash"`
+}
+
+// BaseConfig is the detailed configuration of a RELP input operator.
+type BaseConfig struct {
+	MaxLogSize    helper.ByteSize             `mapstructure:"max_log_size,omitempty"`
+	ListenAddress string                      `mapstructure:"listen_address,omitempty"`
+	TLS           *configtls.TLSServerSetting `mapstructure:"tls,omitempty"`
+	AddAttributes bool                        `mapstructure:"add_attributes,omitempty"`
+	Encoding      string                      `mapstructure:"encoding,omitempty"`
+}
+
+// Build will build a RELP input operator.
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	inputOperator, err := c.InputConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.MaxLogSize == 0 {
+		c.MaxLogSize = DefaultMaxLogSize
+	}
+
+	if c.ListenAddress == "" {
+		return nil, fmt.Errorf("missing required parameter 'listen_address'")
+	}
+
+	// validate the input address
+	if _, err = net.ResolveTCPAddr("tcp", c.ListenAddress); err != nil {
+		return nil, fmt.Errorf("failed to resolve listen_address: %w", err)
+	}
+
+	enc, err := decode.LookupEncoding(c.Encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	var resolver *helper.IPResolver
+	if c.AddAttributes {
+		resolver = helper.NewIPResolver()
+	}
+
+	relpInput := &Input{
+		InputOperator: inputOperator,
+		address:       c.ListenAddress,
+		maxLogSize:    int(c.MaxLogSize),
+		addAttributes: c.AddAttributes,
+		encoding:      enc,
+		backoff: backoff.Backoff{
+			Max: 3 * time.Second,
+		},
+		resolver: resolver,
+	}
+
+	if c.TLS != nil {
+		relpInput.tls, err = c.TLS.LoadTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return relpInput, nil
+}
+
+// Input is an operator that receives log entries from RELP clients, such as the omrelp
+// module of rsyslog. Messages are acknowledged to the clients once the entries written
+// for them were accepted by the consumer of the pipeline, and sent again by the clients
+// otherwise.
+type Input struct {
+	helper.InputOperator
+	address       string
+	maxLogSize    int
+	addAttributes bool
+
+	listener net.Listener
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+	tls      *tls.Config
+	backoff  backoff.Backoff
+
+	encoding encoding.Encoding
+	resolver *helper.IPResolver
+}
+
+// Start will start listening for RELP sessions.
+func (t *Input) Start(_ operator.Persister) error {
+	if err := t.configureListener(); err != nil {
+		return fmt.Errorf("failed to listen on interface: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.cancel = cancel
+	t.goListen(ctx)
+	return nil
+}
+
+func (t *Input) configureListener() error {
+	if t.tls == nil {
+		listener, err := net.Listen("tcp", t.address)
+		if err != nil {
+			return fmt.Errorf("failed to configure tcp listener: %w", err)
+		}
+		t.listener = listener
+		return nil
+	}
+
+	t.tls.Time = time.Now
+	t.tls.Rand = rand.Reader
+
+	listener, err := tls.Listen("tcp", t.address, t.tls)
+	if err != nil {
+		return fmt.Errorf("failed to configure tls listener: %w", err)
+	}
+
+	t.listener = listener
+	return nil
+}
+
+// goListen will listen for tcp connections.
+func (t *Input) goListen(ctx context.Context) {
+	t.wg.Add(1)
+
+	go func() {
+		defer t.wg.Done()
+
+		for {
+			conn, err := t.listener.Accept()
+			if err != nil {
+				select {
+				case <-ctx.Done():
+					return
+				default:
+					t.Debugw("Listener accept error", zap.Error(err))
+					time.Sleep(t.backoff.Duration())
+					continue
+				}
+			}
+			t.backoff.Reset()
+
+			t.Debugf("Received connection: %s", conn.RemoteAddr().String())
+			subctx, cancel := context.WithCancel(ctx)
+			s := &session{
+				Input:  t,
+				conn:   conn,
+				cancel: cancel,
+				notify: make(chan struct{}, 1),
+			}
+			t.wg.Add(2)
+			go s.writeResponses(subctx, ctx)
+			go s.handleFrames(subctx)
+		}
+	}()
+}
+
+// session is a RELP session over a connection.
+type session struct {
+	*Input
+	conn   net.Conn
+	cancel context.CancelFunc
+
+	// pending counts the messages which were not acknowledged yet
+	pending sync.WaitGroup
+
+	mu        sync.Mutex
+	responses []byte
+	notify    chan struct{}
+}
+
+// handleFrames reads the frames of the session, until the client closes it.
+func (s *session) handleFrames(ctx context.Context) {
+	defer s.wg.Done()
+	defer s.cancel()
+
+	dec := decode.New(s.encoding)
+	reader := bufio.NewReader(s.conn)
+	open := false
+	for {
+		f, err := readFrame(reader, s.maxLogSize)
+		if err != nil {
+			if !errors.Is(err, io.EOF) && ctx.Err() == nil {
+				s.Errorw("Failed to read RELP frame", zap.Error(err))
+			}
+			return
+		}
+
+		switch {
+		case f.command == commandOpen:
+			open = true
+			s.respond(f.txnr, "200 OK\nrelp_version=0\nrelp_software="+software+"\ncommands="+commandSyslog)
+		case !open:
+			s.Errorw("Received RELP command before the session was opened", "command", f.command)
+			return
+		case f.command == commandSyslog:
+			s.handleMessage(ctx, dec, f)
+		case f.command == commandClose:
+			// The client expects the responses of its messages before the one of the close command
+			if !s.waitPending(ctx) {
+				return
+			}
+			s.respond(f.txnr, "200 OK")
+			if err := s.flushResponses(); err != nil {
+				s.Debugw("Failed to close RELP session", zap.Error(err))
+			}
+			return
+		default:
+			s.respond(f.txnr, "500 command not supported: "+f.command)
+		}
+	}
+}
+
+// waitPending waits until all the messages of the session were acknowledged, and
+// reports whether they were before the session ended.
+func (s *session) waitPending(ctx context.Context) bool {
+	done := make(chan struct{})
+	go func() {
+		s.pending.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// handleMessage writes the entry of a syslog message, and acknowledges the message once
+// the entry was accepted or rejected by the consumer of the pipeline.
+func (s *session) handleMessage(ctx context.Context, dec *decode.Decoder, f frame) {
+	s.pending.Add(1)
+	ack := operator.NewAcknowledgement(func(err error) {
+		defer s.pending.Done()
+		if err != nil {
+			s.respond(f.txnr, "500 "+err.Error())
+			return
+		}
+		s.respond(f.txnr, "200 OK")
+	})
+
+	decoded, err := dec.Decode(f.data)
+	if err != nil {
+		s.Errorw("Failed to decode data", zap.Error(err))
+		ack.Release(err)
+		return
+	}
+
+	entry, err := s.NewEntry(string(decoded))
+	if err != nil {
+		s.Errorw("Failed to create entry", zap.Error(err))
+		ack.Release(err)
+		return
+	}
+
+	if s.addAttributes {
+		entry.AddAttribute("net.transport", "IP.TCP")
+		if addr, ok := s.conn.RemoteAddr().(*net.TCPAddr); ok {
+			ip := addr.IP.String()
+			entry.AddAttribute("net.peer.ip", ip)
+			entry.AddAttribute("net.peer.port", strconv.FormatInt(int64(addr.Port), 10))
+			entry.AddAttribute("net.peer.name", s.resolver.GetHostFromIP(ip))
+		}
+
+		if addr, ok := s.conn.LocalAddr().(*net.TCPAddr); ok {
+			ip := addr.IP.String()
+			entry.AddAttribute("net.host.ip", ip)
+			entry.AddAttribute("net.host.port", strconv.FormatInt(int64(addr.Port), 10))
+			entry.AddAttribute("net.host.name", s.resolver.GetHostFromIP(ip))
+		}
+	}
+
+	s.Write(operator.ContextWithAcknowledgement(ctx, ack), entry)
+	ack.Release(nil)
+}
+
+// respond queues a response to the frame of the transaction number. Responses are
+// queued rather than written, since messages are acknowledged by the pipeline.
+func (s *session) respond(txnr uint64, data string) {
+	s.mu.Lock()
+	s.responses = appendFrame(s.responses, frame{txnr: txnr, command: commandResponse, data: []byte(data)})
+	s.mu.Unlock()
+
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+// writeResponses writes the queued responses until the session ends. When the input
+// is stopped, the client is told to close the session after the last responses.
+func (s *session) writeResponses(ctx context.Context, inputCtx context.Context) {
+	defer s.wg.Done()
+	defer func() {
+		s.Debugf("Closing connection: %s", s.conn.RemoteAddr().String())
+		if err := s.conn.Close(); err != nil {
+			s.Errorf("Failed to close connection: %s", err)
+		}
+	}()
+
+	for {
+		select {
+		case <-s.notify:
+			if err := s.flushResponses(); err != nil {
+				s.Errorw("Failed to send RELP responses", zap.Error(err))
+				s.cancel()
+				return
+			}
+		case <-ctx.Done():
+			if inputCtx.Err() != nil {
+				s.mu.Lock()
+				s.responses = appendFrame(s.responses, frame{command: commandServerClose})
+				s.mu.Unlock()
+				if err := s.flushResponses(); err != nil {
+					s.Debugw("Failed to close RELP session", zap.Error(err))
+				}
+			}
+			return
+		}
+	}
+}
+
+// flushResponses writes the queued responses.
+func (s *session) flushResponses() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.responses) == 0 {
+		return nil
+	}
+	if err := s.conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return err
+	}
+	_, err := s.conn.Write(s.responses)
+	s.responses = s.responses[:0]
+	return err
+}
+
+// Stop will stop listening for RELP sessions.
+func (t *Input) Stop() error {
+	if t.cancel == nil {
+		return nil
+	}
+	t.cancel()
+
+	if t.listener != nil {
+		if err := t.listener.Close(); err != nil {
+			t.Errorf("failed to close TCP connection: %s", err)
+		}
+	}
+
+	t.wg.Wait()
+	if t.resolver != nil {
+		t.resolver.Stop()
+	}
+	return nil
+}
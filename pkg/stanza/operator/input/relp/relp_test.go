@@ -0,0 +1,207 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package relp
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+type acknowledgedEntry struct {
+	entry *entry.Entry
+	ack   *operator.Acknowledgement
+}
+
+// startInput starts an input whose output holds the acknowledgements of the entries, like the log emitter.
+func startInput(t *testing.T, configure func(cfg *Config)) (*Input, <-chan acknowledgedEntry) {
+	cfg := NewConfigWithID("test_id")
+	cfg.ListenAddress = "localhost:0"
+	configure(cfg)
+
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	mockOutput := testutil.Operator{}
+	relpInput := op.(*Input)
+	relpInput.InputOperator.OutputOperators = []operator.Operator{&mockOutput}
+
+	received := make(chan acknowledgedEntry, 10)
+	mockOutput.On("Process", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		ack := operator.AcknowledgementFromContext(args.Get(0).(context.Context))
+		require.NotNil(t, ack)
+		ack.Hold()
+		received <- acknowledgedEntry{entry: args.Get(1).(*entry.Entry), ack: ack}
+	}).Return(nil)
+
+	require.NoError(t, relpInput.Start(testutil.NewUnscopedMockPersister()))
+	return relpInput, received
+}
+
+func openSession(t *testing.T, relpInput *Input) (net.Conn, *bufio.Reader) {
+	conn, err := net.Dial("tcp", relpInput.listener.Addr().String())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	reader := bufio.NewReader(conn)
+	writeFrame(t, conn, frame{txnr: 1, command: commandOpen, data: []byte("relp_version=0\nrelp_software=librelp\ncommands=syslog")})
+	expectResponse(t, reader, frame{txnr: 1, command: commandResponse, data: []byte("200 OK\nrelp_version=0\nrelp_software=opentelemetry-collector-contrib\ncommands=syslog")})
+	return conn, reader
+}
+
+func writeFrame(t *testing.T, conn net.Conn, f frame) {
+	_, err := conn.Write(appendFrame(nil, f))
+	require.NoError(t, err)
+}
+
+func expectResponse(t *testing.T, reader *bufio.Reader, expected frame) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f, err := readFrame(reader, 1024)
+		require.NoError(t, err)
+		require.Equal(t, expected, f)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		require.FailNow(t, "Timed out waiting for response")
+	}
+}
+
+func expectEntry(t *testing.T, received <-chan acknowledgedEntry, body string) *operator.Acknowledgement {
+	select {
+	case e := <-received:
+		require.Equal(t, body, e.entry.Body)
+		return e.ack
+	case <-time.After(time.Second):
+		require.FailNow(t, "Timed out waiting for message to be written")
+		return nil
+	}
+}
+
+func TestInput(t *testing.T) {
+	relpInput, received := startInput(t, func(_ *Config) {})
+	defer func() {
+		require.NoError(t, relpInput.Stop(), "expected to stop relp input operator without error")
+	}()
+
+	conn, reader := openSession(t, relpInput)
+	writeFrame(t, conn, frame{txnr: 2, command: commandSyslog, data: []byte("<86>first message")})
+	writeFrame(t, conn, frame{txnr: 3, command: commandSyslog, data: []byte("<86>second\nmessage")})
+	first := expectEntry(t, received, "<86>first message")
+	second := expectEntry(t, received, "<86>second\nmessage")
+
+	// Messages are acknowledged once their entries are consumed
+	second.Release(nil)
+	expectResponse(t, reader, frame{txnr: 3, command: commandResponse, data: []byte("200 OK")})
+	first.Release(errors.New("rejected"))
+	expectResponse(t, reader, frame{txnr: 2, command: commandResponse, data: []byte("500 rejected")})
+
+	writeFrame(t, conn, frame{txnr: 4, command: "starttls"})
+	expectResponse(t, reader, frame{txnr: 4, command: commandResponse, data: []byte("500 command not supported: starttls")})
+
+	writeFrame(t, conn, frame{txnr: 5, command: commandSyslog, data: []byte("<86>last message")})
+	writeFrame(t, conn, frame{txnr: 6, command: commandClose})
+	last := expectEntry(t, received, "<86>last message")
+	last.Release(nil)
+	expectResponse(t, reader, frame{txnr: 5, command: commandResponse, data: []byte("200 OK")})
+	expectResponse(t, reader, frame{txnr: 6, command: commandResponse, data: []byte("200 OK")})
+
+	_, err := reader.ReadByte()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestInputAttributes(t *testing.T) {
+	relpInput, received := startInput(t, func(cfg *Config) { cfg.AddAttributes = true })
+	defer func() {
+		require.NoError(t, relpInput.Stop(), "expected to stop relp input operator without error")
+	}()
+
+	conn, _ := openSession(t, relpInput)
+	writeFrame(t, conn, frame{txnr: 2, command: commandSyslog, data: []byte("message")})
+
+	select {
+	case e := <-received:
+		require.Equal(t, "IP.TCP", e.entry.Attributes["net.transport"])
+		require.Equal(t, "127.0.0.1", e.entry.Attributes["net.peer.ip"])
+		require.Equal(t, "127.0.0.1", e.entry.Attributes["net.host.ip"])
+		e.ack.Release(nil)
+	case <-time.After(time.Second):
+		require.FailNow(t, "Timed out waiting for message to be written")
+	}
+}
+
+func TestInputNotOpen(t *testing.T) {
+	relpInput, _ := startInput(t, func(_ *Config) {})
+	defer func() {
+		require.NoError(t, relpInput.Stop(), "expected to stop relp input operator without error")
+	}()
+
+	conn, err := net.Dial("tcp", relpInput.listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	writeFrame(t, conn, frame{txnr: 1, command: commandSyslog, data: []byte("message")})
+	_, err = bufio.NewReader(conn).ReadByte()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestInputServerClose(t *testing.T) {
+	relpInput, received := startInput(t, func(_ *Config) {})
+
+	conn, reader := openSession(t, relpInput)
+	writeFrame(t, conn, frame{txnr: 2, command: commandSyslog, data: []byte("message")})
+	expectEntry(t, received, "message").Release(nil)
+	expectResponse(t, reader, frame{txnr: 2, command: commandResponse, data: []byte("200 OK")})
+
+	require.NoError(t, relpInput.Stop())
+	expectResponse(t, reader, frame{command: commandServerClose})
+}
+
+func TestBuild(t *testing.T) {
+	testCases := []struct {
+		name          string
+		config        func(cfg *Config)
+		expectedError string
+	}{
+		{
+			name:          "missing listen address",
+			config:        func(cfg *Config) { cfg.ListenAddress = "" },
+			expectedError: "missing required parameter 'listen_address'",
+		},
+		{
+			name:          "invalid listen address",
+			config:        func(cfg *Config) { cfg.ListenAddress = "localhost:port" },
+			expectedError: "failed to resolve listen_address",
+		},
+		{
+			name:          "invalid encoding",
+			config:        func(cfg *Config) { cfg.Encoding = "invalid" },
+			expectedError: "unsupported encoding 'invalid'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test_id")
+			cfg.ListenAddress = "localhost:2514"
+			tc.config(cfg)
+			_, err := cfg.Build(testutil.Logger(t))
+			require.ErrorContains(t, err, tc.expectedError)
+		})
+	}
+}
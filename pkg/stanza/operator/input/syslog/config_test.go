@@ -9,6 +9,7 @@ import (
 
 	"go.opentelemetry.io/collector/config/configtls"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/relp"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/tcp"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/udp"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/operatortest"
@@ -66,6 +67,41 @@ func TestUnmarshal(t *testing.T) {
 					return cfg
 				}(),
 			},
+			{
+				Name:      "tls",
+				ExpectErr: false,
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Protocol = "rfc5424"
+					cfg.TLS = NewTLSConfig()
+					cfg.TLS.ListenAddress = "10.0.0.1:6514"
+					cfg.TLS.MaxLogSize = 1000000
+					cfg.TLS.AddAttributes = true
+					cfg.TLS.CertFile = "foo"
+					cfg.TLS.KeyFile = "foo2"
+					cfg.TLS.ClientCAFile = "foo4"
+					return cfg
+				}(),
+			},
+			{
+				Name:      "relp",
+				ExpectErr: false,
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Protocol = "rfc5424"
+					cfg.RELP = &relp.NewConfig().BaseConfig
+					cfg.RELP.ListenAddress = "10.0.0.1:2514"
+					cfg.RELP.MaxLogSize = 1000000
+					cfg.RELP.AddAttributes = true
+					cfg.RELP.TLS = &configtls.TLSServerSetting{
+						TLSSetting: configtls.TLSSetting{
+							CertFile: "foo",
+							KeyFile:  "foo2",
+						},
+					}
+					return cfg
+				}(),
+			},
 		},
 	}.Run(t)
 }
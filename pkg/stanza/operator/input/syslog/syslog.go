@@ -10,11 +10,13 @@ import (
 	"regexp"
 	"strconv"
 
+	"go.opentelemetry.io/collector/config/configtls"
 	"go.uber.org/zap"
 	"golang.org/x/text/encoding"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/relp"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/tcp"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/udp"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/syslog"
@@ -41,8 +43,39 @@ func NewConfigWithID(operatorID string) *Config {
 type Config struct {
 	helper.InputConfig `mapstructure:",squash"`
 	syslog.BaseConfig  `mapstructure:",squash"`
-	TCP                *tcp.BaseConfig `mapstructure:"tcp"`
-	UDP                *udp.BaseConfig `mapstructure:"udp"`
+	TCP                *tcp.BaseConfig  `mapstructure:"tcp"`
+	UDP                *udp.BaseConfig  `mapstructure:"udp"`
+	TLS                *TLSConfig       `mapstructure:"tls"`
+	RELP               *relp.BaseConfig `mapstructure:"relp"`
+}
+
+// TLSConfig is the configuration of syslog over TLS as defined by RFC 5425,
+// where messages are always framed with octet counting.
+type TLSConfig struct {
+	ListenAddress              string          `mapstructure:"listen_address,omitempty"`
+	MaxLogSize                 helper.ByteSize `mapstructure:"max_log_size,omitempty"`
+	AddAttributes              bool            `mapstructure:"add_attributes,omitempty"`
+	Encoding                   string          `mapstructure:"encoding,omitempty"`
+	configtls.TLSServerSetting `mapstructure:",squash"`
+}
+
+// NewTLSConfig creates a new RFC 5425 config with default values
+func NewTLSConfig() *TLSConfig {
+	return &TLSConfig{
+		Encoding: "utf-8",
+	}
+}
+
+// tcpConfig returns the configuration of the tcp input receiving the messages.
+func (c TLSConfig) tcpConfig() tcp.BaseConfig {
+	tlsSetting := c.TLSServerSetting
+	return tcp.BaseConfig{
+		ListenAddress: c.ListenAddress,
+		MaxLogSize:    c.MaxLogSize,
+		AddAttributes: c.AddAttributes,
+		Encoding:      c.Encoding,
+		TLS:           &tlsSetting,
+	}
 }
 
 func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
@@ -51,8 +84,28 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		return nil, err
 	}
 
+	transports := 0
+	for _, set := range []bool{c.TCP != nil, c.UDP != nil, c.TLS != nil, c.RELP != nil} {
+		if set {
+			transports++
+		}
+	}
+	if transports > 1 {
+		return nil, errors.New("only one of tcp, udp, tls or relp can be configured")
+	}
+
 	syslogParserCfg := syslog.NewConfigWithID(inputBase.ID() + "_internal_tcp")
 	syslogParserCfg.BaseConfig = c.BaseConfig
+	if c.TLS != nil {
+		// RFC 5425 requires octet counting
+		if syslogParserCfg.NonTransparentFramingTrailer != nil {
+			return nil, errors.New("non_transparent_framing is not compatible with TLS")
+		}
+		syslogParserCfg.EnableOctetCounting = true
+	}
+	if c.RELP != nil && (syslogParserCfg.EnableOctetCounting || syslogParserCfg.NonTransparentFramingTrailer != nil) {
+		return nil, errors.New("octet_counting and non_transparent_framing is not compatible with RELP")
+	}
 	syslogParserCfg.SetID(inputBase.ID() + "_internal_parser")
 	syslogParserCfg.OutputIDs = c.OutputIDs
 	syslogParser, err := syslogParserCfg.Build(logger)
@@ -60,9 +113,13 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		return nil, fmt.Errorf("failed to resolve syslog config: %w", err)
 	}
 
-	if c.TCP != nil {
+	if c.TCP != nil || c.TLS != nil {
 		tcpInputCfg := tcp.NewConfigWithID(inputBase.ID() + "_internal_tcp")
-		tcpInputCfg.BaseConfig = *c.TCP
+		if c.TCP != nil {
+			tcpInputCfg.BaseConfig = *c.TCP
+		} else {
+			tcpInputCfg.BaseConfig = c.TLS.tcpConfig()
+		}
 		if syslogParserCfg.EnableOctetCounting {
 			tcpInputCfg.SplitFuncBuilder = OctetSplitFuncBuilder
 		}
@@ -111,29 +168,57 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		}, nil
 	}
 
-	return nil, fmt.Errorf("need tcp config or udp config")
+	if c.RELP != nil {
+		relpInputCfg := relp.NewConfigWithID(inputBase.ID() + "_internal_relp")
+		relpInputCfg.BaseConfig = *c.RELP
+
+		relpInput, err := relpInputCfg.Build(logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve relp config: %w", err)
+		}
+
+		relpInput.SetOutputIDs([]string{syslogParser.ID()})
+		if err := relpInput.SetOutputs([]operator.Operator{syslogParser}); err != nil {
+			return nil, fmt.Errorf("failed to set outputs")
+		}
+
+		return &Input{
+			InputOperator: inputBase,
+			relp:          relpInput.(*relp.Input),
+			parser:        syslogParser.(*syslog.Parser),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("need tcp, udp, tls or relp config")
 }
 
-// Input is an operator that listens for log entries over tcp.
+// Input is an operator that listens for log entries over tcp, udp or relp.
 type Input struct {
 	helper.InputOperator
 	tcp    *tcp.Input
 	udp    *udp.Input
+	relp   *relp.Input
 	parser *syslog.Parser
 }
 
-// Start will start listening for log entries over tcp or udp.
+// Start will start listening for log entries over tcp, udp or relp.
 func (t *Input) Start(p operator.Persister) error {
-	if t.tcp != nil {
+	switch {
+	case t.tcp != nil:
 		return t.tcp.Start(p)
+	case t.relp != nil:
+		return t.relp.Start(p)
 	}
 	return t.udp.Start(p)
 }
 
 // Stop will stop listening for messages.
 func (t *Input) Stop() error {
-	if t.tcp != nil {
+	switch {
+	case t.tcp != nil:
 		return t.tcp.Stop()
+	case t.relp != nil:
+		return t.relp.Stop()
 	}
 	return t.udp.Stop()
 }
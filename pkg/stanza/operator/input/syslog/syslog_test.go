@@ -4,8 +4,19 @@
 package syslog
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
+	"io"
+	"math/big"
 	"net"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -13,6 +24,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/relp"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/tcp"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/udp"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/syslog"
@@ -84,7 +96,19 @@ func TestInput(t *testing.T) {
 				InputTest(t, NewConfigWithUDP(&cfg), tc)
 			})
 		}
+
+		if tc.ValidForTCP && !cfg.EnableOctetCounting && cfg.NonTransparentFramingTrailer == nil {
+			t.Run(fmt.Sprintf("RELP-%s", tc.Name), func(t *testing.T) {
+				InputTest(t, NewConfigWithRELP(&cfg), tc)
+			})
+		}
 	}
+
+	t.Run(fmt.Sprintf("TLS-%s", OctetCase.Name), func(t *testing.T) {
+		cfg := OctetCase.Config.BaseConfig
+		cfg.EnableOctetCounting = false
+		InputTest(t, NewConfigWithTLS(t, &cfg), OctetCase)
+	})
 }
 
 func InputTest(t *testing.T, cfg *Config, tc syslog.Case) {
@@ -108,15 +132,36 @@ func InputTest(t *testing.T, cfg *Config, tc syslog.Case) {
 		conn, err = net.Dial("udp", cfg.UDP.ListenAddress)
 		require.NoError(t, err)
 	}
+	if cfg.TLS != nil {
+		conn, err = tls.Dial("tcp", cfg.TLS.ListenAddress, &tls.Config{InsecureSkipVerify: true})
+		require.NoError(t, err)
+	}
+	if cfg.RELP != nil {
+		conn, err = net.Dial("tcp", cfg.RELP.ListenAddress)
+		require.NoError(t, err)
+	}
 
-	if v, ok := tc.Input.Body.(string); ok {
-		_, err = conn.Write([]byte(v))
-	} else {
-		_, err = conn.Write(tc.Input.Body.([]byte))
+	input, ok := tc.Input.Body.([]byte)
+	if v, isString := tc.Input.Body.(string); isString {
+		input, ok = []byte(v), true
 	}
+	require.True(t, ok)
+	if cfg.RELP != nil {
+		input = []byte(fmt.Sprintf("1 open 0\n2 syslog %d %s\n", len(input), input))
+	}
+	_, err = conn.Write(input)
+	require.NoError(t, err)
 
+	if cfg.RELP != nil {
+		// The message is acknowledged once written
+		offers := "200 OK\nrelp_version=0\nrelp_software=opentelemetry-collector-contrib\ncommands=syslog"
+		expected := fmt.Sprintf("1 rsp %d %s\n2 rsp 6 200 OK\n", len(offers), offers)
+		responses := make([]byte, len(expected))
+		_, err = io.ReadFull(conn, responses)
+		require.NoError(t, err)
+		require.Equal(t, expected, string(responses))
+	}
 	conn.Close()
-	require.NoError(t, err)
 
 	defer func() {
 		require.NoError(t, p.Stop())
@@ -151,6 +196,28 @@ func TestSyslogIDs(t *testing.T) {
 		require.Equal(t, []string{"fake"}, syslogInputOp.parser.GetOutputIDs())
 		require.Equal(t, []string{"fake"}, syslogInputOp.GetOutputIDs())
 	})
+	t.Run("TLS", func(t *testing.T) {
+		// RFC 5425 carries RFC 5424 messages
+		syslogCfg := basicConfig()
+		syslogCfg.Protocol = syslog.RFC5424
+		cfg := NewConfigWithTLS(t, syslogCfg)
+		op, err := cfg.Build(testutil.Logger(t))
+		require.NoError(t, err)
+		syslogInputOp := op.(*Input)
+		require.Equal(t, "test_syslog_internal_tcp", syslogInputOp.tcp.ID())
+		require.Equal(t, []string{syslogInputOp.parser.ID()}, syslogInputOp.tcp.GetOutputIDs())
+	})
+	t.Run("RELP", func(t *testing.T) {
+		cfg := NewConfigWithRELP(basicConfig())
+		op, err := cfg.Build(testutil.Logger(t))
+		require.NoError(t, err)
+		syslogInputOp := op.(*Input)
+		require.Equal(t, "test_syslog_internal_relp", syslogInputOp.relp.ID())
+		require.Equal(t, "test_syslog_internal_parser", syslogInputOp.parser.ID())
+		require.Equal(t, []string{syslogInputOp.parser.ID()}, syslogInputOp.relp.GetOutputIDs())
+		require.Equal(t, []string{"fake"}, syslogInputOp.parser.GetOutputIDs())
+		require.Equal(t, []string{"fake"}, syslogInputOp.GetOutputIDs())
+	})
 	t.Run("UDP", func(t *testing.T) {
 		cfg := NewConfigWithUDP(basicConfig())
 		op, err := cfg.Build(testutil.Logger(t))
@@ -173,6 +240,50 @@ func NewConfigWithTCP(syslogCfg *syslog.BaseConfig) *Config {
 	return cfg
 }
 
+func NewConfigWithTLS(t *testing.T, syslogCfg *syslog.BaseConfig) *Config {
+	certFile, keyFile := writeTestCertificate(t)
+	cfg := NewConfigWithID("test_syslog")
+	cfg.BaseConfig = *syslogCfg
+	cfg.TLS = NewTLSConfig()
+	cfg.TLS.ListenAddress = ":16514"
+	cfg.TLS.CertFile = certFile
+	cfg.TLS.KeyFile = keyFile
+	cfg.OutputIDs = []string{"fake"}
+	return cfg
+}
+
+func NewConfigWithRELP(syslogCfg *syslog.BaseConfig) *Config {
+	cfg := NewConfigWithID("test_syslog")
+	cfg.BaseConfig = *syslogCfg
+	cfg.RELP = &relp.NewConfigWithID("test_syslog_relp").BaseConfig
+	cfg.RELP.ListenAddress = ":12514"
+	cfg.OutputIDs = []string{"fake"}
+	return cfg
+}
+
+// writeTestCertificate writes a self-signed certificate and its key, and returns their paths.
+func writeTestCertificate(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "test.crt"), filepath.Join(dir, "test.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certFile, keyFile
+}
+
 func NewConfigWithUDP(syslogCfg *syslog.BaseConfig) *Config {
 	cfg := NewConfigWithID("test_syslog")
 	cfg.BaseConfig = *syslogCfg
@@ -182,6 +293,36 @@ func NewConfigWithUDP(syslogCfg *syslog.BaseConfig) *Config {
 	return cfg
 }
 
+func TestBuildFraming(t *testing.T) {
+	octetCounting := syslog.NewConfigWithID("test_syslog_parser").BaseConfig
+	octetCounting.Protocol = syslog.RFC5424
+	octetCounting.EnableOctetCounting = true
+	_, err := NewConfigWithRELP(&octetCounting).Build(testutil.Logger(t))
+	require.ErrorContains(t, err, "not compatible with RELP")
+
+	trailer := "LF"
+	nonTransparent := syslog.NewConfigWithID("test_syslog_parser").BaseConfig
+	nonTransparent.Protocol = syslog.RFC5424
+	nonTransparent.NonTransparentFramingTrailer = &trailer
+	_, err = NewConfigWithTLS(t, &nonTransparent).Build(testutil.Logger(t))
+	require.ErrorContains(t, err, "not compatible with TLS")
+}
+
+func TestBuildMultipleTransports(t *testing.T) {
+	syslogCfg := syslog.NewConfigWithID("test_syslog_parser").BaseConfig
+	syslogCfg.Protocol = syslog.RFC5424
+
+	cfg := NewConfigWithTLS(t, &syslogCfg)
+	cfg.TCP = &tcp.NewConfigWithID("test_syslog_tcp").BaseConfig
+	_, err := cfg.Build(testutil.Logger(t))
+	require.ErrorContains(t, err, "only one of tcp, udp, tls or relp can be configured")
+
+	cfg = NewConfigWithUDP(&syslogCfg)
+	cfg.RELP = &relp.NewConfigWithID("test_syslog_relp").BaseConfig
+	_, err = cfg.Build(testutil.Logger(t))
+	require.ErrorContains(t, err, "only one of tcp, udp, tls or relp can be configured")
+}
+
 func TestOctetFramingSplitFunc(t *testing.T) {
 	testCases := []struct {
 		name  string
@@ -0,0 +1,271 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewrite // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite"
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
+
+	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite/writev2"
+)
+
+// TimeSeriesV2 is a Remote Write 2.0 time series before its strings are
+// interned into the symbols table of a request. It is kept separate from
+// writev2.TimeSeries so that series can be batched into several requests,
+// each with its own symbols table.
+type TimeSeriesV2 struct {
+	Labels     []prompb.Label
+	Samples    []prompb.Sample
+	Histograms []prompb.Histogram
+	Exemplars  []prompb.Exemplar
+	Metadata   MetadataV2
+	// CreatedTimestamp is the start time of the series in milliseconds, or 0
+	// when the series has no start time.
+	CreatedTimestamp int64
+}
+
+// MetadataV2 is the metric metadata sent inline with each Remote Write 2.0
+// time series.
+type MetadataV2 struct {
+	Type writev2.MetricType
+	Help string
+	Unit string
+}
+
+// FromMetricsV2 converts pmetric.Metrics to Prometheus Remote Write 2.0 time
+// series. Metadata is attached to every series instead of being sent
+// separately, and start timestamps are sent as created timestamps instead of
+// _created series, so settings.ExportCreatedMetric and settings.SendMetadata
+// are ignored.
+func FromMetricsV2(md pmetric.Metrics, settings Settings) (tsMap map[string]*TimeSeriesV2, errs error) {
+	tsMap = make(map[string]*TimeSeriesV2)
+	settings.ExportCreatedMetric = false
+
+	resourceMetricsSlice := md.ResourceMetrics()
+	for i := 0; i < resourceMetricsSlice.Len(); i++ {
+		resourceMetrics := resourceMetricsSlice.At(i)
+		resource := resourceMetrics.Resource()
+		scopeMetricsSlice := resourceMetrics.ScopeMetrics()
+		// keep track of the most recent timestamp in the ResourceMetrics for
+		// use with the "target" info metric
+		var mostRecentTimestamp pcommon.Timestamp
+		for j := 0; j < scopeMetricsSlice.Len(); j++ {
+			metricSlice := scopeMetricsSlice.At(j).Metrics()
+
+			for k := 0; k < metricSlice.Len(); k++ {
+				metric := metricSlice.At(k)
+				mostRecentTimestamp = maxTimestamp(mostRecentTimestamp, mostRecentTimestampInMetric(metric))
+
+				if !isValidAggregationTemporality(metric) {
+					errs = multierr.Append(errs, fmt.Errorf("invalid temporality and type combination for metric %q", metric.Name()))
+					continue
+				}
+
+				promName := prometheustranslator.BuildCompliantName(metric, settings.Namespace, settings.AddMetricSuffixes)
+				metadata := MetadataV2{
+					// prompb.MetricMetadata_MetricType and writev2.MetricType
+					// share the same values.
+					Type: writev2.MetricType(otelMetricTypeToPromMetricType(metric)),
+					Help: metric.Description(),
+					Unit: metric.Unit(),
+				}
+
+				// Each data point is converted on its own so that its start
+				// timestamp can be attached to the series it produced.
+				var series map[string]*prompb.TimeSeries
+				//exhaustive:enforce
+				switch metric.Type() {
+				case pmetric.MetricTypeGauge:
+					dataPoints := metric.Gauge().DataPoints()
+					if dataPoints.Len() == 0 {
+						errs = multierr.Append(errs, fmt.Errorf("empty data points. %s is dropped", metric.Name()))
+					}
+					for x := 0; x < dataPoints.Len(); x++ {
+						series = make(map[string]*prompb.TimeSeries)
+						addSingleGaugeNumberDataPoint(dataPoints.At(x), resource, metric, settings, series, promName)
+						mergeTimeSeriesV2(tsMap, series, metadata, 0)
+					}
+				case pmetric.MetricTypeSum:
+					dataPoints := metric.Sum().DataPoints()
+					if dataPoints.Len() == 0 {
+						errs = multierr.Append(errs, fmt.Errorf("empty data points. %s is dropped", metric.Name()))
+					}
+					for x := 0; x < dataPoints.Len(); x++ {
+						series = make(map[string]*prompb.TimeSeries)
+						addSingleSumNumberDataPoint(dataPoints.At(x), resource, metric, settings, series, promName)
+						mergeTimeSeriesV2(tsMap, series, metadata, dataPoints.At(x).StartTimestamp())
+					}
+				case pmetric.MetricTypeHistogram:
+					dataPoints := metric.Histogram().DataPoints()
+					if dataPoints.Len() == 0 {
+						errs = multierr.Append(errs, fmt.Errorf("empty data points. %s is dropped", metric.Name()))
+					}
+					for x := 0; x < dataPoints.Len(); x++ {
+						series = make(map[string]*prompb.TimeSeries)
+						addSingleHistogramDataPoint(dataPoints.At(x), resource, metric, settings, series, promName)
+						mergeTimeSeriesV2(tsMap, series, metadata, dataPoints.At(x).StartTimestamp())
+					}
+				case pmetric.MetricTypeExponentialHistogram:
+					dataPoints := metric.ExponentialHistogram().DataPoints()
+					if dataPoints.Len() == 0 {
+						errs = multierr.Append(errs, fmt.Errorf("empty data points. %s is dropped", metric.Name()))
+					}
+					for x := 0; x < dataPoints.Len(); x++ {
+						series = make(map[string]*prompb.TimeSeries)
+						errs = multierr.Append(
+							errs,
+							addSingleExponentialHistogramDataPoint(
+								promName,
+								dataPoints.At(x),
+								resource,
+								settings,
+								series,
+							),
+						)
+						mergeTimeSeriesV2(tsMap, series, metadata, dataPoints.At(x).StartTimestamp())
+					}
+				case pmetric.MetricTypeSummary:
+					dataPoints := metric.Summary().DataPoints()
+					if dataPoints.Len() == 0 {
+						errs = multierr.Append(errs, fmt.Errorf("empty data points. %s is dropped", metric.Name()))
+					}
+					for x := 0; x < dataPoints.Len(); x++ {
+						series = make(map[string]*prompb.TimeSeries)
+						addSingleSummaryDataPoint(dataPoints.At(x), resource, metric, settings, series, promName)
+						mergeTimeSeriesV2(tsMap, series, metadata, dataPoints.At(x).StartTimestamp())
+					}
+				default:
+					errs = multierr.Append(errs, errors.New("unsupported metric type"))
+				}
+			}
+		}
+		series := make(map[string]*prompb.TimeSeries)
+		addResourceTargetInfo(resource, settings, mostRecentTimestamp, series)
+		mergeTimeSeriesV2(tsMap, series, MetadataV2{Type: writev2.MetricTypeGauge}, 0)
+	}
+
+	return
+}
+
+// mergeTimeSeriesV2 adds the series converted from a single data point to
+// tsMap, attaching the metric metadata and the data point start timestamp.
+func mergeTimeSeriesV2(tsMap map[string]*TimeSeriesV2, series map[string]*prompb.TimeSeries, metadata MetadataV2, startTimestamp pcommon.Timestamp) {
+	var createdTimestamp int64
+	if startTimestamp != 0 {
+		createdTimestamp = convertTimeStamp(startTimestamp)
+	}
+	for sig, ts := range series {
+		v2, ok := tsMap[sig]
+		if !ok {
+			tsMap[sig] = &TimeSeriesV2{
+				Labels:           ts.Labels,
+				Samples:          ts.Samples,
+				Histograms:       ts.Histograms,
+				Exemplars:        ts.Exemplars,
+				Metadata:         metadata,
+				CreatedTimestamp: createdTimestamp,
+			}
+			continue
+		}
+		v2.Samples = append(v2.Samples, ts.Samples...)
+		v2.Histograms = append(v2.Histograms, ts.Histograms...)
+		v2.Exemplars = append(v2.Exemplars, ts.Exemplars...)
+		if createdTimestamp > v2.CreatedTimestamp {
+			v2.CreatedTimestamp = createdTimestamp
+		}
+	}
+}
+
+// ToWriteRequestV2 builds a Remote Write 2.0 request from series, interning
+// the label names and values, exemplar labels, help and unit strings into the
+// request symbols table.
+func ToWriteRequestV2(series []*TimeSeriesV2) *writev2.Request {
+	symbols := writev2.NewSymbolTable()
+	req := &writev2.Request{
+		Timeseries: make([]writev2.TimeSeries, 0, len(series)),
+	}
+	for _, ts := range series {
+		out := writev2.TimeSeries{
+			LabelsRefs: symbolizeLabels(symbols, ts.Labels),
+			Metadata: writev2.Metadata{
+				Type:    ts.Metadata.Type,
+				HelpRef: symbols.Symbolize(ts.Metadata.Help),
+				UnitRef: symbols.Symbolize(ts.Metadata.Unit),
+			},
+			CreatedTimestamp: ts.CreatedTimestamp,
+		}
+		if len(ts.Samples) > 0 {
+			out.Samples = make([]writev2.Sample, 0, len(ts.Samples))
+			for _, s := range ts.Samples {
+				out.Samples = append(out.Samples, writev2.Sample{Value: s.Value, Timestamp: s.Timestamp})
+			}
+		}
+		if len(ts.Histograms) > 0 {
+			out.Histograms = make([]writev2.Histogram, 0, len(ts.Histograms))
+			for _, h := range ts.Histograms {
+				out.Histograms = append(out.Histograms, histogramToV2(h))
+			}
+		}
+		if len(ts.Exemplars) > 0 {
+			out.Exemplars = make([]writev2.Exemplar, 0, len(ts.Exemplars))
+			for _, e := range ts.Exemplars {
+				out.Exemplars = append(out.Exemplars, writev2.Exemplar{
+					LabelsRefs: symbolizeLabels(symbols, e.Labels),
+					Value:      e.Value,
+					Timestamp:  e.Timestamp,
+				})
+			}
+		}
+		req.Timeseries = append(req.Timeseries, out)
+	}
+	req.Symbols = symbols.Symbols()
+	return req
+}
+
+func symbolizeLabels(symbols *writev2.SymbolsTable, labels []prompb.Label) []uint32 {
+	if len(labels) == 0 {
+		return nil
+	}
+	refs := make([]uint32, 0, 2*len(labels))
+	for _, l := range labels {
+		refs = append(refs, symbols.Symbolize(l.Name), symbols.Symbolize(l.Value))
+	}
+	return refs
+}
+
+// histogramToV2 converts an integer native histogram. The histograms produced
+// from exponential histograms never use the float counters.
+func histogramToV2(h prompb.Histogram) writev2.Histogram {
+	return writev2.Histogram{
+		Count:          h.GetCountInt(),
+		Sum:            h.Sum,
+		Schema:         h.Schema,
+		ZeroThreshold:  h.ZeroThreshold,
+		ZeroCount:      h.GetZeroCountInt(),
+		NegativeSpans:  bucketSpansToV2(h.NegativeSpans),
+		NegativeDeltas: h.NegativeDeltas,
+		PositiveSpans:  bucketSpansToV2(h.PositiveSpans),
+		PositiveDeltas: h.PositiveDeltas,
+		// prompb.Histogram_ResetHint and writev2.ResetHint share the same values.
+		ResetHint: writev2.ResetHint(h.ResetHint),
+		Timestamp: h.Timestamp,
+	}
+}
+
+func bucketSpansToV2(spans []prompb.BucketSpan) []writev2.BucketSpan {
+	if len(spans) == 0 {
+		return nil
+	}
+	out := make([]writev2.BucketSpan, 0, len(spans))
+	for _, s := range spans {
+		out = append(out, writev2.BucketSpan{Offset: s.Offset, Length: s.Length})
+	}
+	return out
+}
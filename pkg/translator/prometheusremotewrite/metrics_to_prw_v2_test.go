@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewrite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite/writev2"
+)
+
+func TestFromMetricsV2(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	ts := start.Add(time.Minute)
+
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "svc")
+	rm.Resource().Attributes().PutStr("host.name", "host")
+	metrics := rm.ScopeMetrics().AppendEmpty().Metrics()
+
+	sum := metrics.AppendEmpty()
+	sum.SetName("requests")
+	sum.SetDescription("number of requests")
+	sum.SetUnit("1")
+	sum.SetEmptySum().SetIsMonotonic(true)
+	sum.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := sum.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+	dp.SetIntValue(10)
+
+	gauge := metrics.AppendEmpty()
+	gauge.SetName("temperature")
+	gauge.SetUnit("Cel")
+	gauge.SetEmptyGauge()
+	gdp := gauge.Gauge().DataPoints().AppendEmpty()
+	gdp.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+	gdp.SetDoubleValue(21.5)
+
+	expHist := metrics.AppendEmpty()
+	expHist.SetName("latency")
+	expHist.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	hdp := expHist.ExponentialHistogram().DataPoints().AppendEmpty()
+	hdp.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+	hdp.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+	hdp.SetScale(0)
+	hdp.SetCount(4)
+	hdp.SetSum(10)
+	hdp.SetZeroCount(1)
+	hdp.Positive().BucketCounts().FromRaw([]uint64{1, 2})
+
+	tsMap, err := FromMetricsV2(md, Settings{ExportCreatedMetric: true})
+	require.NoError(t, err)
+
+	byName := make(map[string]*TimeSeriesV2)
+	for _, series := range tsMap {
+		for _, l := range series.Labels {
+			if l.Name == model.MetricNameLabel {
+				byName[l.Value] = series
+			}
+		}
+	}
+	// No _created series are produced: the start time is sent inline.
+	require.Len(t, byName, 4)
+	assert.NotContains(t, byName, "requests_created")
+
+	requests := byName["requests"]
+	require.NotNil(t, requests)
+	assert.Equal(t, MetadataV2{Type: writev2.MetricTypeCounter, Help: "number of requests", Unit: "1"}, requests.Metadata)
+	assert.Equal(t, start.UnixMilli(), requests.CreatedTimestamp)
+	assert.Equal(t, []prompb.Sample{{Value: 10, Timestamp: ts.UnixMilli()}}, requests.Samples)
+
+	temperature := byName["temperature"]
+	require.NotNil(t, temperature)
+	assert.Equal(t, MetadataV2{Type: writev2.MetricTypeGauge, Unit: "Cel"}, temperature.Metadata)
+	assert.Zero(t, temperature.CreatedTimestamp)
+
+	latency := byName["latency"]
+	require.NotNil(t, latency)
+	assert.Equal(t, writev2.MetricTypeHistogram, latency.Metadata.Type)
+	assert.Equal(t, start.UnixMilli(), latency.CreatedTimestamp)
+	require.Len(t, latency.Histograms, 1)
+	assert.Equal(t, uint64(4), latency.Histograms[0].GetCountInt())
+
+	targetInfo := byName["target_info"]
+	require.NotNil(t, targetInfo)
+	assert.Equal(t, writev2.MetricTypeGauge, targetInfo.Metadata.Type)
+}
+
+func TestFromMetricsV2MergesDataPoints(t *testing.T) {
+	md := pmetric.NewMetrics()
+	metric := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("requests")
+	metric.SetEmptySum().SetIsMonotonic(true)
+	metric.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	for i := 1; i <= 2; i++ {
+		dp := metric.Sum().DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(pcommon.Timestamp(uint64(i) * uint64(time.Second)))
+		dp.SetTimestamp(pcommon.Timestamp(uint64(i+10) * uint64(time.Second)))
+		dp.SetIntValue(int64(i))
+	}
+
+	tsMap, err := FromMetricsV2(md, Settings{})
+	require.NoError(t, err)
+	require.Len(t, tsMap, 1)
+	for _, series := range tsMap {
+		assert.Len(t, series.Samples, 2)
+		assert.Equal(t, int64(2000), series.CreatedTimestamp)
+	}
+}
+
+func TestToWriteRequestV2(t *testing.T) {
+	series := []*TimeSeriesV2{
+		{
+			Labels:  getPromLabels(model.MetricNameLabel, "requests", "job", "api"),
+			Samples: []prompb.Sample{getSample(1, 1000)},
+			Exemplars: []prompb.Exemplar{
+				{Labels: getPromLabels("trace_id", "abc"), Value: 1, Timestamp: 900},
+			},
+			Metadata:         MetadataV2{Type: writev2.MetricTypeCounter, Help: "number of requests"},
+			CreatedTimestamp: 500,
+		},
+		{
+			Labels: getPromLabels(model.MetricNameLabel, "latency", "job", "api"),
+			Histograms: []prompb.Histogram{
+				{
+					Count:          &prompb.Histogram_CountInt{CountInt: 3},
+					Sum:            4,
+					Schema:         1,
+					ZeroThreshold:  defaultZeroThreshold,
+					ZeroCount:      &prompb.Histogram_ZeroCountInt{ZeroCountInt: 1},
+					PositiveSpans:  []prompb.BucketSpan{{Offset: 1, Length: 2}},
+					PositiveDeltas: []int64{1, 0},
+					Timestamp:      1000,
+				},
+			},
+			Metadata: MetadataV2{Type: writev2.MetricTypeHistogram},
+		},
+	}
+
+	req := ToWriteRequestV2(series)
+	assert.Equal(t, []string{"", "__name__", "requests", "job", "api", "number of requests", "trace_id", "abc", "latency"}, req.Symbols)
+
+	require.Len(t, req.Timeseries, 2)
+	assert.Equal(t, writev2.TimeSeries{
+		LabelsRefs:       []uint32{1, 2, 3, 4},
+		Samples:          []writev2.Sample{{Value: 1, Timestamp: 1000}},
+		Exemplars:        []writev2.Exemplar{{LabelsRefs: []uint32{6, 7}, Value: 1, Timestamp: 900}},
+		Metadata:         writev2.Metadata{Type: writev2.MetricTypeCounter, HelpRef: 5},
+		CreatedTimestamp: 500,
+	}, req.Timeseries[0])
+	assert.Equal(t, writev2.TimeSeries{
+		LabelsRefs: []uint32{1, 8, 3, 4},
+		Histograms: []writev2.Histogram{
+			{
+				Count:          3,
+				Sum:            4,
+				Schema:         1,
+				ZeroThreshold:  defaultZeroThreshold,
+				ZeroCount:      1,
+				PositiveSpans:  []writev2.BucketSpan{{Offset: 1, Length: 2}},
+				PositiveDeltas: []int64{1, 0},
+				Timestamp:      1000,
+			},
+		},
+		Metadata: writev2.Metadata{Type: writev2.MetricTypeHistogram},
+	}, req.Timeseries[1])
+
+	b, err := req.Marshal()
+	require.NoError(t, err)
+	var decoded writev2.Request
+	require.NoError(t, decoded.Unmarshal(b))
+	assert.Equal(t, req, &decoded)
+}
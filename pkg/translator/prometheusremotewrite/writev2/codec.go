@@ -0,0 +1,539 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package writev2 // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite/writev2"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+var errTruncated = errors.New("writev2: truncated message")
+
+// Marshal encodes the request in the protobuf wire format.
+func (r *Request) Marshal() ([]byte, error) {
+	var b []byte
+	for _, s := range r.Symbols {
+		b = protowire.AppendTag(b, 4, protowire.BytesType)
+		b = protowire.AppendString(b, s)
+	}
+	for i := range r.Timeseries {
+		b = protowire.AppendTag(b, 5, protowire.BytesType)
+		b = protowire.AppendBytes(b, r.Timeseries[i].marshal())
+	}
+	return b, nil
+}
+
+func (ts *TimeSeries) marshal() []byte {
+	var b []byte
+	b = appendPackedUint32(b, 1, ts.LabelsRefs)
+	for _, s := range ts.Samples {
+		b = protowire.AppendTag(b, 2, protowire.BytesType)
+		b = protowire.AppendBytes(b, s.marshal())
+	}
+	for i := range ts.Histograms {
+		b = protowire.AppendTag(b, 3, protowire.BytesType)
+		b = protowire.AppendBytes(b, ts.Histograms[i].marshal())
+	}
+	for i := range ts.Exemplars {
+		b = protowire.AppendTag(b, 4, protowire.BytesType)
+		b = protowire.AppendBytes(b, ts.Exemplars[i].marshal())
+	}
+	if m := ts.Metadata.marshal(); len(m) > 0 {
+		b = protowire.AppendTag(b, 5, protowire.BytesType)
+		b = protowire.AppendBytes(b, m)
+	}
+	b = appendInt64(b, 6, ts.CreatedTimestamp)
+	return b
+}
+
+func (s Sample) marshal() []byte {
+	var b []byte
+	b = appendDouble(b, 1, s.Value)
+	b = appendInt64(b, 2, s.Timestamp)
+	return b
+}
+
+func (e *Exemplar) marshal() []byte {
+	var b []byte
+	b = appendPackedUint32(b, 1, e.LabelsRefs)
+	b = appendDouble(b, 2, e.Value)
+	b = appendInt64(b, 3, e.Timestamp)
+	return b
+}
+
+func (m Metadata) marshal() []byte {
+	var b []byte
+	if m.Type != MetricTypeUnspecified {
+		b = protowire.AppendTag(b, 1, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(m.Type))
+	}
+	b = appendUint64(b, 3, uint64(m.HelpRef))
+	b = appendUint64(b, 4, uint64(m.UnitRef))
+	return b
+}
+
+func (h *Histogram) marshal() []byte {
+	var b []byte
+	// The counts are always written so that the receiver sees an integer
+	// histogram, even when they are 0.
+	b = protowire.AppendTag(b, 1, protowire.VarintType)
+	b = protowire.AppendVarint(b, h.Count)
+	b = appendDouble(b, 3, h.Sum)
+	if h.Schema != 0 {
+		b = protowire.AppendTag(b, 4, protowire.VarintType)
+		b = protowire.AppendVarint(b, protowire.EncodeZigZag(int64(h.Schema)))
+	}
+	b = appendDouble(b, 5, h.ZeroThreshold)
+	b = protowire.AppendTag(b, 6, protowire.VarintType)
+	b = protowire.AppendVarint(b, h.ZeroCount)
+	for _, s := range h.NegativeSpans {
+		b = protowire.AppendTag(b, 8, protowire.BytesType)
+		b = protowire.AppendBytes(b, s.marshal())
+	}
+	b = appendPackedSint64(b, 9, h.NegativeDeltas)
+	for _, s := range h.PositiveSpans {
+		b = protowire.AppendTag(b, 11, protowire.BytesType)
+		b = protowire.AppendBytes(b, s.marshal())
+	}
+	b = appendPackedSint64(b, 12, h.PositiveDeltas)
+	if h.ResetHint != ResetHintUnknown {
+		b = protowire.AppendTag(b, 14, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(h.ResetHint))
+	}
+	b = appendInt64(b, 15, h.Timestamp)
+	return b
+}
+
+func (s BucketSpan) marshal() []byte {
+	var b []byte
+	if s.Offset != 0 {
+		b = protowire.AppendTag(b, 1, protowire.VarintType)
+		b = protowire.AppendVarint(b, protowire.EncodeZigZag(int64(s.Offset)))
+	}
+	b = appendUint64(b, 2, uint64(s.Length))
+	return b
+}
+
+func appendDouble(b []byte, num protowire.Number, v float64) []byte {
+	if v == 0 && !math.Signbit(v) {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.Fixed64Type)
+	return protowire.AppendFixed64(b, math.Float64bits(v))
+}
+
+func appendInt64(b []byte, num protowire.Number, v int64) []byte {
+	return appendUint64(b, num, uint64(v))
+}
+
+func appendUint64(b []byte, num protowire.Number, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+func appendPackedUint32(b []byte, num protowire.Number, vs []uint32) []byte {
+	if len(vs) == 0 {
+		return b
+	}
+	var packed []byte
+	for _, v := range vs {
+		packed = protowire.AppendVarint(packed, uint64(v))
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, packed)
+}
+
+func appendPackedSint64(b []byte, num protowire.Number, vs []int64) []byte {
+	if len(vs) == 0 {
+		return b
+	}
+	var packed []byte
+	for _, v := range vs {
+		packed = protowire.AppendVarint(packed, protowire.EncodeZigZag(v))
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, packed)
+}
+
+// Size returns the length of the encoded request without encoding it.
+func (r *Request) Size() int {
+	n := 0
+	for _, s := range r.Symbols {
+		n += protowire.SizeTag(4) + protowire.SizeBytes(len(s))
+	}
+	for i := range r.Timeseries {
+		n += protowire.SizeTag(5) + protowire.SizeBytes(r.Timeseries[i].size())
+	}
+	return n
+}
+
+func (ts *TimeSeries) size() int {
+	n := sizePackedUint32(1, ts.LabelsRefs)
+	for _, s := range ts.Samples {
+		n += protowire.SizeTag(2) + protowire.SizeBytes(s.size())
+	}
+	for i := range ts.Histograms {
+		n += protowire.SizeTag(3) + protowire.SizeBytes(ts.Histograms[i].size())
+	}
+	for i := range ts.Exemplars {
+		n += protowire.SizeTag(4) + protowire.SizeBytes(ts.Exemplars[i].size())
+	}
+	if m := ts.Metadata.size(); m > 0 {
+		n += protowire.SizeTag(5) + protowire.SizeBytes(m)
+	}
+	n += sizeUint64(6, uint64(ts.CreatedTimestamp))
+	return n
+}
+
+func (s Sample) size() int {
+	return sizeDouble(1, s.Value) + sizeUint64(2, uint64(s.Timestamp))
+}
+
+func (e *Exemplar) size() int {
+	return sizePackedUint32(1, e.LabelsRefs) + sizeDouble(2, e.Value) + sizeUint64(3, uint64(e.Timestamp))
+}
+
+func (m Metadata) size() int {
+	n := 0
+	if m.Type != MetricTypeUnspecified {
+		n += protowire.SizeTag(1) + protowire.SizeVarint(uint64(m.Type))
+	}
+	n += sizeUint64(3, uint64(m.HelpRef))
+	n += sizeUint64(4, uint64(m.UnitRef))
+	return n
+}
+
+func (h *Histogram) size() int {
+	n := protowire.SizeTag(1) + protowire.SizeVarint(h.Count)
+	n += sizeDouble(3, h.Sum)
+	if h.Schema != 0 {
+		n += protowire.SizeTag(4) + protowire.SizeVarint(protowire.EncodeZigZag(int64(h.Schema)))
+	}
+	n += sizeDouble(5, h.ZeroThreshold)
+	n += protowire.SizeTag(6) + protowire.SizeVarint(h.ZeroCount)
+	for _, s := range h.NegativeSpans {
+		n += protowire.SizeTag(8) + protowire.SizeBytes(s.size())
+	}
+	n += sizePackedSint64(9, h.NegativeDeltas)
+	for _, s := range h.PositiveSpans {
+		n += protowire.SizeTag(11) + protowire.SizeBytes(s.size())
+	}
+	n += sizePackedSint64(12, h.PositiveDeltas)
+	if h.ResetHint != ResetHintUnknown {
+		n += protowire.SizeTag(14) + protowire.SizeVarint(uint64(h.ResetHint))
+	}
+	n += sizeUint64(15, uint64(h.Timestamp))
+	return n
+}
+
+func (s BucketSpan) size() int {
+	n := 0
+	if s.Offset != 0 {
+		n += protowire.SizeTag(1) + protowire.SizeVarint(protowire.EncodeZigZag(int64(s.Offset)))
+	}
+	n += sizeUint64(2, uint64(s.Length))
+	return n
+}
+
+func sizeDouble(num protowire.Number, v float64) int {
+	if v == 0 && !math.Signbit(v) {
+		return 0
+	}
+	return protowire.SizeTag(num) + protowire.SizeFixed64()
+}
+
+func sizeUint64(num protowire.Number, v uint64) int {
+	if v == 0 {
+		return 0
+	}
+	return protowire.SizeTag(num) + protowire.SizeVarint(v)
+}
+
+func sizePackedUint32(num protowire.Number, vs []uint32) int {
+	if len(vs) == 0 {
+		return 0
+	}
+	n := 0
+	for _, v := range vs {
+		n += protowire.SizeVarint(uint64(v))
+	}
+	return protowire.SizeTag(num) + protowire.SizeBytes(n)
+}
+
+func sizePackedSint64(num protowire.Number, vs []int64) int {
+	if len(vs) == 0 {
+		return 0
+	}
+	n := 0
+	for _, v := range vs {
+		n += protowire.SizeVarint(protowire.EncodeZigZag(v))
+	}
+	return protowire.SizeTag(num) + protowire.SizeBytes(n)
+}
+
+// Unmarshal decodes a request from the protobuf wire format. Unknown fields,
+// and the float histogram counters that are not supported, are skipped.
+func (r *Request) Unmarshal(b []byte) error {
+	*r = Request{}
+	return unmarshalFields(b, func(num protowire.Number, typ protowire.Type, v []byte) (int, error) {
+		switch {
+		case num == 4 && typ == protowire.BytesType:
+			s, n := protowire.ConsumeString(v)
+			r.Symbols = append(r.Symbols, s)
+			return n, nil
+		case num == 5 && typ == protowire.BytesType:
+			msg, n := protowire.ConsumeBytes(v)
+			if n < 0 {
+				return n, nil
+			}
+			var ts TimeSeries
+			if err := ts.unmarshal(msg); err != nil {
+				return n, err
+			}
+			r.Timeseries = append(r.Timeseries, ts)
+			return n, nil
+		}
+		return protowire.ConsumeFieldValue(num, typ, v), nil
+	})
+}
+
+func (ts *TimeSeries) unmarshal(b []byte) error {
+	return unmarshalFields(b, func(num protowire.Number, typ protowire.Type, v []byte) (int, error) {
+		switch {
+		case num == 1:
+			return consumeUint32s(typ, v, &ts.LabelsRefs)
+		case num == 2 && typ == protowire.BytesType:
+			msg, n := protowire.ConsumeBytes(v)
+			var s Sample
+			if n >= 0 {
+				if err := s.unmarshal(msg); err != nil {
+					return n, err
+				}
+				ts.Samples = append(ts.Samples, s)
+			}
+			return n, nil
+		case num == 3 && typ == protowire.BytesType:
+			msg, n := protowire.ConsumeBytes(v)
+			var h Histogram
+			if n >= 0 {
+				if err := h.unmarshal(msg); err != nil {
+					return n, err
+				}
+				ts.Histograms = append(ts.Histograms, h)
+			}
+			return n, nil
+		case num == 4 && typ == protowire.BytesType:
+			msg, n := protowire.ConsumeBytes(v)
+			var e Exemplar
+			if n >= 0 {
+				if err := e.unmarshal(msg); err != nil {
+					return n, err
+				}
+				ts.Exemplars = append(ts.Exemplars, e)
+			}
+			return n, nil
+		case num == 5 && typ == protowire.BytesType:
+			msg, n := protowire.ConsumeBytes(v)
+			if n >= 0 {
+				return n, ts.Metadata.unmarshal(msg)
+			}
+			return n, nil
+		case num == 6 && typ == protowire.VarintType:
+			x, n := protowire.ConsumeVarint(v)
+			ts.CreatedTimestamp = int64(x)
+			return n, nil
+		}
+		return protowire.ConsumeFieldValue(num, typ, v), nil
+	})
+}
+
+func (s *Sample) unmarshal(b []byte) error {
+	return unmarshalFields(b, func(num protowire.Number, typ protowire.Type, v []byte) (int, error) {
+		switch {
+		case num == 1 && typ == protowire.Fixed64Type:
+			x, n := protowire.ConsumeFixed64(v)
+			s.Value = math.Float64frombits(x)
+			return n, nil
+		case num == 2 && typ == protowire.VarintType:
+			x, n := protowire.ConsumeVarint(v)
+			s.Timestamp = int64(x)
+			return n, nil
+		}
+		return protowire.ConsumeFieldValue(num, typ, v), nil
+	})
+}
+
+func (e *Exemplar) unmarshal(b []byte) error {
+	return unmarshalFields(b, func(num protowire.Number, typ protowire.Type, v []byte) (int, error) {
+		switch {
+		case num == 1:
+			return consumeUint32s(typ, v, &e.LabelsRefs)
+		case num == 2 && typ == protowire.Fixed64Type:
+			x, n := protowire.ConsumeFixed64(v)
+			e.Value = math.Float64frombits(x)
+			return n, nil
+		case num == 3 && typ == protowire.VarintType:
+			x, n := protowire.ConsumeVarint(v)
+			e.Timestamp = int64(x)
+			return n, nil
+		}
+		return protowire.ConsumeFieldValue(num, typ, v), nil
+	})
+}
+
+func (m *Metadata) unmarshal(b []byte) error {
+	return unmarshalFields(b, func(num protowire.Number, typ protowire.Type, v []byte) (int, error) {
+		if typ != protowire.VarintType {
+			return protowire.ConsumeFieldValue(num, typ, v), nil
+		}
+		x, n := protowire.ConsumeVarint(v)
+		switch num {
+		case 1:
+			m.Type = MetricType(x)
+		case 3:
+			m.HelpRef = uint32(x)
+		case 4:
+			m.UnitRef = uint32(x)
+		}
+		return n, nil
+	})
+}
+
+func (h *Histogram) unmarshal(b []byte) error {
+	return unmarshalFields(b, func(num protowire.Number, typ protowire.Type, v []byte) (int, error) {
+		switch typ {
+		case protowire.VarintType:
+			x, n := protowire.ConsumeVarint(v)
+			switch num {
+			case 1:
+				h.Count = x
+			case 4:
+				h.Schema = int32(protowire.DecodeZigZag(x))
+			case 6:
+				h.ZeroCount = x
+			case 9:
+				h.NegativeDeltas = append(h.NegativeDeltas, protowire.DecodeZigZag(x))
+			case 12:
+				h.PositiveDeltas = append(h.PositiveDeltas, protowire.DecodeZigZag(x))
+			case 14:
+				h.ResetHint = ResetHint(x)
+			case 15:
+				h.Timestamp = int64(x)
+			}
+			return n, nil
+		case protowire.Fixed64Type:
+			x, n := protowire.ConsumeFixed64(v)
+			switch num {
+			case 3:
+				h.Sum = math.Float64frombits(x)
+			case 5:
+				h.ZeroThreshold = math.Float64frombits(x)
+			}
+			return n, nil
+		case protowire.BytesType:
+			switch num {
+			case 8, 11:
+				msg, n := protowire.ConsumeBytes(v)
+				if n < 0 {
+					return n, nil
+				}
+				var s BucketSpan
+				if err := s.unmarshal(msg); err != nil {
+					return n, err
+				}
+				if num == 8 {
+					h.NegativeSpans = append(h.NegativeSpans, s)
+				} else {
+					h.PositiveSpans = append(h.PositiveSpans, s)
+				}
+				return n, nil
+			case 9:
+				return consumeSint64s(v, &h.NegativeDeltas)
+			case 12:
+				return consumeSint64s(v, &h.PositiveDeltas)
+			}
+		}
+		return protowire.ConsumeFieldValue(num, typ, v), nil
+	})
+}
+
+func (s *BucketSpan) unmarshal(b []byte) error {
+	return unmarshalFields(b, func(num protowire.Number, typ protowire.Type, v []byte) (int, error) {
+		if typ != protowire.VarintType {
+			return protowire.ConsumeFieldValue(num, typ, v), nil
+		}
+		x, n := protowire.ConsumeVarint(v)
+		switch num {
+		case 1:
+			s.Offset = int32(protowire.DecodeZigZag(x))
+		case 2:
+			s.Length = uint32(x)
+		}
+		return n, nil
+	})
+}
+
+// unmarshalFields calls fn for every field in b. fn returns the number of
+// bytes it consumed from the field value, or a negative protowire error code.
+func unmarshalFields(b []byte, fn func(num protowire.Number, typ protowire.Type, v []byte) (int, error)) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return fmt.Errorf("writev2: %w", protowire.ParseError(n))
+		}
+		b = b[n:]
+		n, err := fn(num, typ, b)
+		if err != nil {
+			return err
+		}
+		if n < 0 {
+			return fmt.Errorf("writev2: %w", protowire.ParseError(n))
+		}
+		if n > len(b) {
+			return errTruncated
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+func consumeUint32s(typ protowire.Type, v []byte, out *[]uint32) (int, error) {
+	if typ == protowire.VarintType {
+		x, n := protowire.ConsumeVarint(v)
+		*out = append(*out, uint32(x))
+		return n, nil
+	}
+	if typ != protowire.BytesType {
+		return protowire.ConsumeFieldValue(1, typ, v), nil
+	}
+	packed, n := protowire.ConsumeBytes(v)
+	for len(packed) > 0 {
+		x, m := protowire.ConsumeVarint(packed)
+		if m < 0 {
+			return m, nil
+		}
+		*out = append(*out, uint32(x))
+		packed = packed[m:]
+	}
+	return n, nil
+}
+
+func consumeSint64s(v []byte, out *[]int64) (int, error) {
+	packed, n := protowire.ConsumeBytes(v)
+	for len(packed) > 0 {
+		x, m := protowire.ConsumeVarint(packed)
+		if m < 0 {
+			return m, nil
+		}
+		*out = append(*out, protowire.DecodeZigZag(x))
+		packed = packed[m:]
+	}
+	return n, nil
+}
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package writev2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSymbolsTable(t *testing.T) {
+	st := NewSymbolTable()
+	assert.Equal(t, uint32(0), st.Symbolize(""))
+	assert.Equal(t, uint32(1), st.Symbolize("__name__"))
+	assert.Equal(t, uint32(2), st.Symbolize("foo"))
+	assert.Equal(t, uint32(1), st.Symbolize("__name__"))
+	assert.Equal(t, []uint32{1, 2, 3, 2}, st.SymbolizeLabels([]string{"__name__", "foo", "job", "foo"}, nil))
+	assert.Equal(t, []string{"", "__name__", "foo", "job"}, st.Symbols())
+}
+
+func TestRequestRoundTrip(t *testing.T) {
+	req := &Request{
+		Symbols: []string{"", "__name__", "test_metric", "help text", "seconds"},
+		Timeseries: []TimeSeries{
+			{
+				LabelsRefs: []uint32{1, 2},
+				Samples: []Sample{
+					{Value: 1.5, Timestamp: 1000},
+					{Value: -2, Timestamp: 2000},
+				},
+				Exemplars: []Exemplar{
+					{LabelsRefs: []uint32{1, 2}, Value: 3, Timestamp: 1500},
+				},
+				Metadata:         Metadata{Type: MetricTypeCounter, HelpRef: 3, UnitRef: 4},
+				CreatedTimestamp: 500,
+			},
+			{
+				LabelsRefs: []uint32{1, 2},
+				Histograms: []Histogram{
+					{
+						Count:          10,
+						Sum:            42.5,
+						Schema:         -2,
+						ZeroThreshold:  1e-128,
+						ZeroCount:      1,
+						NegativeSpans:  []BucketSpan{{Offset: -3, Length: 2}},
+						NegativeDeltas: []int64{2, -1},
+						PositiveSpans:  []BucketSpan{{Offset: 0, Length: 1}, {Offset: 2, Length: 2}},
+						PositiveDeltas: []int64{4, -2, 1},
+						ResetHint:      ResetHintGauge,
+						Timestamp:      3000,
+					},
+				},
+				Metadata: Metadata{Type: MetricTypeHistogram},
+			},
+		},
+	}
+
+	b, err := req.Marshal()
+	require.NoError(t, err)
+
+	assert.Equal(t, len(b), req.Size())
+
+	var got Request
+	require.NoError(t, got.Unmarshal(b))
+	assert.Equal(t, req, &got)
+}
+
+func TestUnmarshalTruncated(t *testing.T) {
+	req := &Request{
+		Symbols:    []string{"", "a"},
+		Timeseries: []TimeSeries{{LabelsRefs: []uint32{1, 1}, Samples: []Sample{{Value: 1, Timestamp: 1}}}},
+	}
+	b, err := req.Marshal()
+	require.NoError(t, err)
+
+	var got Request
+	assert.Error(t, got.Unmarshal(b[:len(b)-3]))
+}
@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package writev2 // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite/writev2"
+
+// SymbolsTable interns the strings of a Request. The zero value is not usable,
+// use NewSymbolTable.
+type SymbolsTable struct {
+	symbols []string
+	refs    map[string]uint32
+}
+
+// NewSymbolTable returns a table that already holds the empty string at
+// reference 0, as required by the protocol.
+func NewSymbolTable() *SymbolsTable {
+	return &SymbolsTable{
+		symbols: []string{""},
+		refs:    map[string]uint32{"": 0},
+	}
+}
+
+// Symbolize returns the reference of str, adding it to the table if needed.
+func (t *SymbolsTable) Symbolize(str string) uint32 {
+	if ref, ok := t.refs[str]; ok {
+		return ref
+	}
+	ref := uint32(len(t.symbols))
+	t.symbols = append(t.symbols, str)
+	t.refs[str] = ref
+	return ref
+}
+
+// SymbolizeLabels appends the name and value references of each pair in
+// labels to buf and returns it. labels holds alternating names and values.
+func (t *SymbolsTable) SymbolizeLabels(labels []string, buf []uint32) []uint32 {
+	for _, s := range labels {
+		buf = append(buf, t.Symbolize(s))
+	}
+	return buf
+}
+
+// Symbols returns the interned strings, indexed by reference.
+func (t *SymbolsTable) Symbols() []string {
+	return t.symbols
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package writev2 implements the io.prometheus.write.v2.Request message of the
+// Prometheus Remote Write 2.0 protocol.
+//
+// See https://prometheus.io/docs/specs/remote_write_spec_2_0/
+package writev2 // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite/writev2"
+
+// MetricType is the type of the metric family a time series belongs to.
+type MetricType int32
+
+const (
+	MetricTypeUnspecified    MetricType = 0
+	MetricTypeCounter        MetricType = 1
+	MetricTypeGauge          MetricType = 2
+	MetricTypeHistogram      MetricType = 3
+	MetricTypeGaugeHistogram MetricType = 4
+	MetricTypeSummary        MetricType = 5
+	MetricTypeInfo           MetricType = 6
+	MetricTypeStateset       MetricType = 7
+)
+
+// ResetHint tells the receiver whether a histogram sample is a counter reset.
+type ResetHint int32
+
+const (
+	ResetHintUnknown ResetHint = 0
+	ResetHintYes     ResetHint = 1
+	ResetHintNo      ResetHint = 2
+	ResetHintGauge   ResetHint = 3
+)
+
+// Request is a Remote Write 2.0 request. All strings referenced by the time
+// series are interned in Symbols, whose first entry must be the empty string.
+type Request struct {
+	Symbols    []string
+	Timeseries []TimeSeries
+}
+
+// TimeSeries is a single series with its samples and metadata. LabelsRefs holds
+// pairs of name and value references into the request symbols.
+type TimeSeries struct {
+	LabelsRefs []uint32
+	Samples    []Sample
+	Histograms []Histogram
+	Exemplars  []Exemplar
+	Metadata   Metadata
+	// CreatedTimestamp is the time in milliseconds the series was created or
+	// last reset, or 0 when unknown.
+	CreatedTimestamp int64
+}
+
+// Sample is a float sample with a timestamp in milliseconds.
+type Sample struct {
+	Value     float64
+	Timestamp int64
+}
+
+// Exemplar is an exemplar whose labels reference the request symbols.
+type Exemplar struct {
+	LabelsRefs []uint32
+	Value      float64
+	Timestamp  int64
+}
+
+// Metadata describes the metric family of a series. HelpRef and UnitRef
+// reference the request symbols.
+type Metadata struct {
+	Type    MetricType
+	HelpRef uint32
+	UnitRef uint32
+}
+
+// Histogram is a native histogram sample. Only the integer counters are
+// supported, which is what OTLP exponential histograms translate to.
+type Histogram struct {
+	Count          uint64
+	Sum            float64
+	Schema         int32
+	ZeroThreshold  float64
+	ZeroCount      uint64
+	NegativeSpans  []BucketSpan
+	NegativeDeltas []int64
+	PositiveSpans  []BucketSpan
+	PositiveDeltas []int64
+	ResetHint      ResetHint
+	Timestamp      int64
+}
+
+// BucketSpan is a run of consecutive buckets of a native histogram.
+type BucketSpan struct {
+	Offset int32
+	Length uint32
+}
@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package awss3receiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awss3receiver"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+const checkpointKey = "checkpoint"
+
+// checkpoint records the last object consumed by the receiver.
+type checkpoint struct {
+	Partition time.Time `json:"partition"`
+	LastKey   string    `json:"last_key"`
+}
+
+func getStorageClient(ctx context.Context, host component.Host, storageID *component.ID, componentID component.ID, signal string) (storage.Client, error) {
+	if storageID == nil {
+		return storage.NewNopClient(), nil
+	}
+
+	extension, ok := host.GetExtensions()[*storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExtension, ok := extension.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	// The logs, metrics and traces receivers share the component ID, the
+	// signal keeps their checkpoints apart.
+	return storageExtension.GetClient(ctx, component.KindReceiver, componentID, signal)
+}
+
+func loadCheckpoint(ctx context.Context, client storage.Client) (*checkpoint, error) {
+	data, err := client.Get(ctx, checkpointKey)
+	if err != nil || data == nil {
+		return nil, err
+	}
+	cp := &checkpoint{}
+	if err = json.Unmarshal(data, cp); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint: %w", err)
+	}
+	return cp, nil
+}
+
+func saveCheckpoint(ctx context.Context, client storage.Client, cp checkpoint) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	return client.Set(ctx, checkpointKey, data)
+}
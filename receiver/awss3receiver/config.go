@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package awss3receiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awss3receiver"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/multierr"
+)
+
+// S3DownloaderConfig contains aws s3 downloader related config to locate the
+// objects written by the awss3exporter.
+type S3DownloaderConfig struct {
+	Region      string `mapstructure:"region"`
+	S3Bucket    string `mapstructure:"s3_bucket"`
+	S3Prefix    string `mapstructure:"s3_prefix"`
+	S3Partition string `mapstructure:"s3_partition"`
+	// S3PartitionTemplate mirrors the s3_partition_template of the exporter.
+	// Templates using resource attributes are not supported.
+	S3PartitionTemplate string `mapstructure:"s3_partition_template"`
+	FilePrefix          string `mapstructure:"file_prefix"`
+	Endpoint            string `mapstructure:"endpoint"`
+	// S3ForcePathStyle sets the value for force path style.
+	S3ForcePathStyle bool `mapstructure:"s3_force_path_style"`
+	DisableSSL       bool `mapstructure:"disable_ssl"`
+}
+
+// Config contains the main configuration options for the s3 receiver.
+type Config struct {
+	S3Downloader S3DownloaderConfig `mapstructure:"s3downloader"`
+	// StartTime and EndTime bound the time partitions that are replayed.
+	// Both accept RFC3339 or "2006-01-02 15:04" and are interpreted in UTC.
+	StartTime string `mapstructure:"starttime"`
+	EndTime   string `mapstructure:"endtime"`
+	// Encoding is the extension used to decode the objects. When not set the
+	// format is derived from the object suffix (.json or .binpb).
+	Encoding *component.ID `mapstructure:"encoding"`
+	// StorageID is the extension used to checkpoint the replay progress.
+	StorageID *component.ID `mapstructure:"storage"`
+	// StopOnError stops the replay at the first object which cannot be replayed,
+	// instead of skipping it.
+	StopOnError bool `mapstructure:"stop_on_error"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+var timeLayouts = []string{time.RFC3339, "2006-01-02 15:04"}
+
+func parseTime(value string, name string) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unable to parse %s %q, expected RFC3339 or \"2006-01-02 15:04\"", name, value)
+}
+
+func (c *Config) Validate() error {
+	var errs error
+	if c.S3Downloader.S3Bucket == "" {
+		errs = multierr.Append(errs, errors.New("bucket is required"))
+	}
+	if c.S3Downloader.S3Partition != "hour" && c.S3Downloader.S3Partition != "minute" {
+		errs = multierr.Append(errs, errors.New("s3_partition must be either 'hour' or 'minute'"))
+	}
+	if c.S3Downloader.S3PartitionTemplate != "" {
+		if err := validatePartitionTemplate(c.S3Downloader.S3Partition, c.S3Downloader.S3PartitionTemplate); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("invalid s3_partition_template: %w", err))
+		}
+	}
+
+	startTime, err := parseTime(c.StartTime, "starttime")
+	if err != nil {
+		errs = multierr.Append(errs, err)
+	}
+	endTime, err := parseTime(c.EndTime, "endtime")
+	if err != nil {
+		errs = multierr.Append(errs, err)
+	}
+	if errs == nil && endTime.Before(startTime) {
+		errs = multierr.Append(errs, errors.New("endtime must be after starttime"))
+	}
+	return errs
+}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package awss3receiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awss3receiver/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	encoding := component.MustNewID("foo")
+	storageID := component.MustNewID("file_storage")
+
+	tests := []struct {
+		id           component.ID
+		expected     component.Config
+		errorMessage string
+	}{
+		{
+			id: component.NewID(metadata.Type),
+			expected: &Config{
+				S3Downloader: S3DownloaderConfig{
+					Region:      "us-east-1",
+					S3Bucket:    "archive",
+					S3Prefix:    "otel",
+					S3Partition: "hour",
+					FilePrefix:  "collector-a_",
+				},
+				StartTime: "2024-03-01 10:00",
+				EndTime:   "2024-03-01T12:30:00Z",
+				Encoding:  &encoding,
+				StorageID: &storageID,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "minimal"),
+			expected: &Config{
+				S3Downloader: S3DownloaderConfig{
+					Region:      "us-east-1",
+					S3Bucket:    "archive",
+					S3Partition: "minute",
+				},
+				StartTime: "2024-03-01 10:00",
+				EndTime:   "2024-03-01 11:00",
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "no_bucket"),
+			errorMessage: "bucket is required",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "bad_partition"),
+			errorMessage: "s3_partition must be either 'hour' or 'minute'",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "bad_time"),
+			errorMessage: `unable to parse starttime "yesterday"`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "reversed_time"),
+			errorMessage: "endtime must be after starttime",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "resource_template"),
+			errorMessage: "invalid s3_partition_template",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			if tt.errorMessage != "" {
+				assert.ErrorContains(t, component.ValidateConfig(cfg), tt.errorMessage)
+				return
+			}
+
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	expected := time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC)
+
+	got, err := parseTime("2024-03-01 10:30", "starttime")
+	require.NoError(t, err)
+	assert.Equal(t, expected, got)
+
+	got, err = parseTime("2024-03-01T11:30:00+01:00", "starttime")
+	require.NoError(t, err)
+	assert.Equal(t, expected, got)
+}
@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package awss3receiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awss3receiver"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awss3receiver/internal/metadata"
+)
+
+// NewFactory creates a factory for the S3 receiver.
+func NewFactory() receiver.Factory {
+	return receiver.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		receiver.WithTraces(createTracesReceiver, metadata.TracesStability),
+		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability),
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		S3Downloader: S3DownloaderConfig{
+			Region:      "us-east-1",
+			S3Partition: "minute",
+		},
+	}
+}
+
+func createTracesReceiver(_ context.Context, settings receiver.CreateSettings, cfg component.Config, nextConsumer consumer.Traces) (receiver.Traces, error) {
+	return newTracesReceiver(cfg.(*Config), settings, nextConsumer)
+}
+
+func createMetricsReceiver(_ context.Context, settings receiver.CreateSettings, cfg component.Config, nextConsumer consumer.Metrics) (receiver.Metrics, error) {
+	return newMetricsReceiver(cfg.(*Config), settings, nextConsumer)
+}
+
+func createLogsReceiver(_ context.Context, settings receiver.CreateSettings, cfg component.Config, nextConsumer consumer.Logs) (receiver.Logs, error) {
+	return newLogsReceiver(cfg.(*Config), settings, nextConsumer)
+}
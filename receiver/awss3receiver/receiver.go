@@ -0,0 +1,273 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package awss3receiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awss3receiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/cenkalti/backoff/v4"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/consumerretry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awss3receiver/internal/metadata"
+)
+
+const (
+	defaultRetryInterval = time.Second
+	maxRetryInterval     = 30 * time.Second
+)
+
+// consumeFunc decodes data and passes it to the next consumer.
+type consumeFunc func(ctx context.Context, unmarshaler *s3Unmarshaler, data []byte) error
+
+// awss3Receiver replays the objects of one signal.
+type awss3Receiver struct {
+	config   *Config
+	settings receiver.CreateSettings
+	logger   *zap.Logger
+	signal   string
+	next     consumerretry.Consumers
+	consume  consumeFunc
+	obsrecv  *receiverhelper.ObsReport
+
+	// newClient and retryInterval are replaced in tests.
+	newClient     func(config *Config) (s3Client, error)
+	retryInterval time.Duration
+
+	reader        *s3Reader
+	unmarshaler   *s3Unmarshaler
+	storageClient storage.Client
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newAWSS3Receiver(config *Config, settings receiver.CreateSettings, signal string) (*awss3Receiver, error) {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             settings.ID,
+		Transport:              "s3",
+		ReceiverCreateSettings: settings,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &awss3Receiver{
+		config:   config,
+		settings: settings,
+		logger:   settings.Logger,
+		signal:   signal,
+		obsrecv:  obsrecv,
+		newClient: func(config *Config) (s3Client, error) {
+			return newAWSS3Client(config)
+		},
+		retryInterval: defaultRetryInterval,
+	}, nil
+}
+
+func newLogsReceiver(config *Config, settings receiver.CreateSettings, nextConsumer consumer.Logs) (*awss3Receiver, error) {
+	r, err := newAWSS3Receiver(config, settings, "logs")
+	if err != nil {
+		return nil, err
+	}
+	r.next.Logs = nextConsumer
+	r.consume = func(ctx context.Context, unmarshaler *s3Unmarshaler, data []byte) error {
+		if unmarshaler.logsUnmarshaler == nil {
+			return errors.New("encoding does not support logs")
+		}
+		ld, err := unmarshaler.logsUnmarshaler.UnmarshalLogs(data)
+		if err != nil {
+			return err
+		}
+		ctx = r.obsrecv.StartLogsOp(ctx)
+		err = r.next.Logs.ConsumeLogs(ctx, ld)
+		r.obsrecv.EndLogsOp(ctx, metadata.Type.String(), ld.LogRecordCount(), err)
+		return err
+	}
+	return r, nil
+}
+
+func newMetricsReceiver(config *Config, settings receiver.CreateSettings, nextConsumer consumer.Metrics) (*awss3Receiver, error) {
+	r, err := newAWSS3Receiver(config, settings, "metrics")
+	if err != nil {
+		return nil, err
+	}
+	r.next.Metrics = nextConsumer
+	r.consume = func(ctx context.Context, unmarshaler *s3Unmarshaler, data []byte) error {
+		if unmarshaler.metricsUnmarshaler == nil {
+			return errors.New("encoding does not support metrics")
+		}
+		md, err := unmarshaler.metricsUnmarshaler.UnmarshalMetrics(data)
+		if err != nil {
+			return err
+		}
+		ctx = r.obsrecv.StartMetricsOp(ctx)
+		err = r.next.Metrics.ConsumeMetrics(ctx, md)
+		r.obsrecv.EndMetricsOp(ctx, metadata.Type.String(), md.DataPointCount(), err)
+		return err
+	}
+	return r, nil
+}
+
+func newTracesReceiver(config *Config, settings receiver.CreateSettings, nextConsumer consumer.Traces) (*awss3Receiver, error) {
+	r, err := newAWSS3Receiver(config, settings, "traces")
+	if err != nil {
+		return nil, err
+	}
+	r.next.Traces = nextConsumer
+	r.consume = func(ctx context.Context, unmarshaler *s3Unmarshaler, data []byte) error {
+		if unmarshaler.tracesUnmarshaler == nil {
+			return errors.New("encoding does not support traces")
+		}
+		td, err := unmarshaler.tracesUnmarshaler.UnmarshalTraces(data)
+		if err != nil {
+			return err
+		}
+		ctx = r.obsrecv.StartTracesOp(ctx)
+		err = r.next.Traces.ConsumeTraces(ctx, td)
+		r.obsrecv.EndTracesOp(ctx, metadata.Type.String(), td.SpanCount(), err)
+		return err
+	}
+	return r, nil
+}
+
+func (r *awss3Receiver) Start(_ context.Context, host component.Host) error {
+	client, err := r.newClient(r.config)
+	if err != nil {
+		return err
+	}
+	if r.reader, err = newS3Reader(r.config, client); err != nil {
+		return err
+	}
+	if r.config.Encoding != nil {
+		if r.unmarshaler, err = newUnmarshalerFromEncoding(r.config.Encoding, host); err != nil {
+			return err
+		}
+	}
+
+	// Errors of the pipeline are retried until the receiver is shut down, the checkpoint
+	// is only advanced once an object is consumed.
+	r.next = consumerretry.NewUntilShutdown(r.retryInterval, r.logger, r.next)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	if r.storageClient, err = getStorageClient(ctx, host, r.config.StorageID, r.settings.ID, r.signal); err != nil {
+		cancel()
+		return err
+	}
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		r.replay(ctx)
+	}()
+	return nil
+}
+
+func (r *awss3Receiver) Shutdown(ctx context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	if r.storageClient != nil {
+		return r.storageClient.Close(ctx)
+	}
+	return nil
+}
+
+func (r *awss3Receiver) replay(ctx context.Context) {
+	cp, err := loadCheckpoint(ctx, r.storageClient)
+	if err != nil {
+		r.logger.Error("Failed to load checkpoint, replaying from starttime", zap.Error(err))
+		cp = nil
+	}
+
+	err = r.reader.readAll(ctx, r.signal, cp, func(partition time.Time, key string) error {
+		unmarshaler := r.unmarshaler
+		if unmarshaler == nil {
+			var err error
+			if unmarshaler, err = unmarshalerForKey(key); err != nil {
+				r.logger.Warn("Skipping object, configure an encoding extension to read it", zap.String("key", key), zap.Error(err))
+			}
+		}
+		if unmarshaler != nil {
+			if err := r.processObject(ctx, key, unmarshaler); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				if r.config.StopOnError {
+					// The checkpoint is not advanced, the object is replayed
+					// again after a restart.
+					return fmt.Errorf("failed to replay object %q: %w", key, err)
+				}
+				// Only errors which retrying does not fix are left, the
+				// object is skipped.
+				r.logger.Error("Failed to replay object, skipping it", zap.String("key", key), zap.Error(err))
+			}
+		}
+		// The object has been processed, record it even when shutting down.
+		if err := saveCheckpoint(context.Background(), r.storageClient, checkpoint{Partition: partition, LastKey: key}); err != nil {
+			r.logger.Warn("Failed to save checkpoint", zap.String("key", key), zap.Error(err))
+		}
+		return nil
+	})
+	switch {
+	case errors.Is(err, context.Canceled):
+	case err != nil:
+		r.logger.Error("Replay of archived telemetry stopped", zap.String("signal", r.signal), zap.Error(err))
+	default:
+		r.logger.Info("Replay of archived telemetry completed", zap.String("signal", r.signal))
+	}
+}
+
+func (r *awss3Receiver) processObject(ctx context.Context, key string, unmarshaler *s3Unmarshaler) error {
+	data, err := r.getObject(ctx, key)
+	if err != nil {
+		return err
+	}
+	if data, err = decompress(data); err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return r.consume(ctx, unmarshaler, data)
+}
+
+// getObject downloads an object, retrying until ctx is done. Client errors, e.g. a
+// missing object or a denied access, are permanent.
+func (r *awss3Receiver) getObject(ctx context.Context, key string) ([]byte, error) {
+	expBackoff := backoff.NewExponentialBackOff()
+	expBackoff.InitialInterval = r.retryInterval
+	expBackoff.MaxInterval = maxRetryInterval
+	expBackoff.MaxElapsedTime = 0
+	return backoff.RetryNotifyWithData(func() ([]byte, error) {
+		data, err := r.reader.client.getObject(ctx, key)
+		if isClientError(err) {
+			return nil, backoff.Permanent(consumererror.NewPermanent(err))
+		}
+		return data, err
+	}, backoff.WithContext(expBackoff, ctx), func(err error, interval time.Duration) {
+		r.logger.Warn("Failed to download object, retrying", zap.String("key", key), zap.Duration("interval", interval), zap.Error(err))
+	})
+}
+
+// isClientError reports whether err is a 4xx response of S3 other than a timeout or
+// throttling.
+func isClientError(err error) bool {
+	var reqErr awserr.RequestFailure
+	if !errors.As(err, &reqErr) {
+		return false
+	}
+	code := reqErr.StatusCode()
+	return code >= 400 && code < 500 && code != http.StatusRequestTimeout && code != http.StatusTooManyRequests
+}
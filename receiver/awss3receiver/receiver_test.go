@@ -0,0 +1,437 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package awss3receiver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/s3partition"
+)
+
+var partitionStart = time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+// fakeS3Client serves objects from memory.
+type fakeS3Client struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func newFakeS3Client() *fakeS3Client {
+	return &fakeS3Client{objects: map[string][]byte{}}
+}
+
+func (c *fakeS3Client) put(key string, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.objects[key] = data
+}
+
+func (c *fakeS3Client) listObjects(_ context.Context, prefix string, startAfter string) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var keys []string
+	for key := range c.objects {
+		if strings.HasPrefix(key, prefix) && key > startAfter {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (c *fakeS3Client) getObject(_ context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	data, ok := c.objects[key]
+	if !ok {
+		return nil, awserr.NewRequestFailure(awserr.New(s3.ErrCodeNoSuchKey, "The specified key does not exist.", nil), http.StatusNotFound, "")
+	}
+	return data, nil
+}
+
+func objectKey(t time.Time, signal string, id string, format string) string {
+	return "otel/" + s3partition.TimeKey(t.In(time.Local), "minute") + "/" + signal + "_" + id + "." + format
+}
+
+func testLogs(body string) plog.Logs {
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr(body)
+	return ld
+}
+
+func marshalLogsJSON(t *testing.T, body string) []byte {
+	data, err := (&plog.JSONMarshaler{}).MarshalLogs(testLogs(body))
+	require.NoError(t, err)
+	return data
+}
+
+func gzipData(t *testing.T, data []byte) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func testConfig() *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.S3Downloader.S3Bucket = "archive"
+	cfg.S3Downloader.S3Prefix = "otel"
+	cfg.StartTime = "2024-03-01 10:00"
+	cfg.EndTime = "2024-03-01 10:30"
+	return cfg
+}
+
+func startReceiver(t *testing.T, r *awss3Receiver, client s3Client, host component.Host) {
+	r.newClient = func(*Config) (s3Client, error) { return client, nil }
+	r.retryInterval = time.Millisecond
+	require.NoError(t, r.Start(context.Background(), host))
+}
+
+func bodies(logs []plog.Logs) []string {
+	var result []string
+	for _, ld := range logs {
+		result = append(result, ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+	}
+	return result
+}
+
+func TestReplayLogs(t *testing.T) {
+	client := newFakeS3Client()
+	client.put(objectKey(partitionStart, "logs", "1", "json"), marshalLogsJSON(t, "json"))
+	protoData, err := (&plog.ProtoMarshaler{}).MarshalLogs(testLogs("proto"))
+	require.NoError(t, err)
+	client.put(objectKey(partitionStart.Add(5*time.Minute), "logs", "2", "binpb.gz"), gzipData(t, protoData))
+	client.put(objectKey(partitionStart.Add(6*time.Minute), "logs", "3", "txt"), []byte("unknown format"))
+	client.put(objectKey(partitionStart.Add(7*time.Minute), "metrics", "4", "json"), []byte("{}"))
+	client.put(objectKey(partitionStart.Add(time.Hour), "logs", "5", "json"), marshalLogsJSON(t, "out of range"))
+
+	sink := new(consumertest.LogsSink)
+	r, err := newLogsReceiver(testConfig(), receivertest.NewNopCreateSettings(), sink)
+	require.NoError(t, err)
+	startReceiver(t, r, client, componenttest.NewNopHost())
+	defer func() { require.NoError(t, r.Shutdown(context.Background())) }()
+
+	require.Eventually(t, func() bool { return len(sink.AllLogs()) == 2 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"json", "proto"}, bodies(sink.AllLogs()))
+}
+
+func TestReplayMetricsAndTraces(t *testing.T) {
+	client := newFakeS3Client()
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+	data, err := (&pmetric.JSONMarshaler{}).MarshalMetrics(md)
+	require.NoError(t, err)
+	client.put(objectKey(partitionStart, "metrics", "1", "json"), data)
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	data, err = (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+	client.put(objectKey(partitionStart, "traces", "1", "binpb"), data)
+
+	metricsSink := new(consumertest.MetricsSink)
+	mr, err := newMetricsReceiver(testConfig(), receivertest.NewNopCreateSettings(), metricsSink)
+	require.NoError(t, err)
+	startReceiver(t, mr, client, componenttest.NewNopHost())
+	defer func() { require.NoError(t, mr.Shutdown(context.Background())) }()
+
+	tracesSink := new(consumertest.TracesSink)
+	tr, err := newTracesReceiver(testConfig(), receivertest.NewNopCreateSettings(), tracesSink)
+	require.NoError(t, err)
+	startReceiver(t, tr, client, componenttest.NewNopHost())
+	defer func() { require.NoError(t, tr.Shutdown(context.Background())) }()
+
+	require.Eventually(t, func() bool {
+		return metricsSink.DataPointCount() == 1 && tracesSink.SpanCount() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestReplayResumesFromCheckpoint(t *testing.T) {
+	client := newFakeS3Client()
+	client.put(objectKey(partitionStart, "logs", "2", "json"), marshalLogsJSON(t, "first"))
+
+	cfg := testConfig()
+	storageID := storagetest.NewStorageID("checkpoints")
+	cfg.StorageID = &storageID
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("checkpoints", t.TempDir())
+
+	sink := new(consumertest.LogsSink)
+	r, err := newLogsReceiver(cfg, receivertest.NewNopCreateSettings(), sink)
+	require.NoError(t, err)
+	startReceiver(t, r, client, host)
+	require.Eventually(t, func() bool { return len(sink.AllLogs()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, r.Shutdown(context.Background()))
+
+	// objects sorting before the checkpoint of the same partition are not replayed.
+	client.put(objectKey(partitionStart, "logs", "1", "json"), marshalLogsJSON(t, "skipped"))
+	client.put(objectKey(partitionStart, "logs", "3", "json"), marshalLogsJSON(t, "second"))
+	client.put(objectKey(partitionStart.Add(time.Minute), "logs", "1", "json"), marshalLogsJSON(t, "third"))
+
+	sink.Reset()
+	r, err = newLogsReceiver(cfg, receivertest.NewNopCreateSettings(), sink)
+	require.NoError(t, err)
+	startReceiver(t, r, client, host)
+	defer func() { require.NoError(t, r.Shutdown(context.Background())) }()
+
+	require.Eventually(t, func() bool { return len(sink.AllLogs()) == 2 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"second", "third"}, bodies(sink.AllLogs()))
+}
+
+func TestReplayConsumerErrors(t *testing.T) {
+	client := newFakeS3Client()
+	client.put(objectKey(partitionStart, "logs", "1", "json"), marshalLogsJSON(t, "transient"))
+	client.put(objectKey(partitionStart, "logs", "2", "json"), marshalLogsJSON(t, "permanent"))
+	client.put(objectKey(partitionStart, "logs", "3", "json"), marshalLogsJSON(t, "after"))
+
+	var mu sync.Mutex
+	var consumed []string
+	failures, rejections := 0, 0
+	next, err := consumer.NewLogs(func(_ context.Context, ld plog.Logs) error {
+		mu.Lock()
+		defer mu.Unlock()
+		body := bodies([]plog.Logs{ld})[0]
+		switch {
+		case body == "permanent":
+			rejections++
+			return consumererror.NewPermanent(errors.New("rejected"))
+		case body == "transient" && failures < 2:
+			failures++
+			return errors.New("try again")
+		}
+		consumed = append(consumed, body)
+		return nil
+	})
+	require.NoError(t, err)
+
+	r, err := newLogsReceiver(testConfig(), receivertest.NewNopCreateSettings(), next)
+	require.NoError(t, err)
+	startReceiver(t, r, client, componenttest.NewNopHost())
+	defer func() { require.NoError(t, r.Shutdown(context.Background())) }()
+
+	// transient errors are retried, the object rejected with a permanent error is skipped.
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(consumed) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"transient", "after"}, consumed)
+	assert.Equal(t, 2, failures)
+	assert.Equal(t, 1, rejections)
+}
+
+func TestReplayStopOnError(t *testing.T) {
+	client := newFakeS3Client()
+	client.put(objectKey(partitionStart, "logs", "1", "json"), marshalLogsJSON(t, "transient"))
+	client.put(objectKey(partitionStart, "logs", "2", "json"), marshalLogsJSON(t, "permanent"))
+	client.put(objectKey(partitionStart, "logs", "3", "json"), marshalLogsJSON(t, "after"))
+
+	cfg := testConfig()
+	cfg.StopOnError = true
+	storageID := storagetest.NewStorageID("checkpoints")
+	cfg.StorageID = &storageID
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("checkpoints", t.TempDir())
+
+	var mu sync.Mutex
+	var consumed []string
+	failures, rejections := 0, 0
+	rejectPermanent := true
+	next, err := consumer.NewLogs(func(_ context.Context, ld plog.Logs) error {
+		mu.Lock()
+		defer mu.Unlock()
+		body := bodies([]plog.Logs{ld})[0]
+		switch {
+		case body == "permanent" && rejectPermanent:
+			rejections++
+			return consumererror.NewPermanent(errors.New("rejected"))
+		case body == "transient" && failures < 2:
+			failures++
+			return errors.New("try again")
+		}
+		consumed = append(consumed, body)
+		return nil
+	})
+	require.NoError(t, err)
+
+	r, err := newLogsReceiver(cfg, receivertest.NewNopCreateSettings(), next)
+	require.NoError(t, err)
+	startReceiver(t, r, client, host)
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return rejections == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, r.Shutdown(context.Background()))
+
+	// transient errors are retried, the permanent error stops the replay.
+	assert.Equal(t, []string{"transient"}, consumed)
+	assert.Equal(t, 2, failures)
+
+	// the rejected object was not checkpointed and is replayed after a restart.
+	mu.Lock()
+	rejectPermanent = false
+	mu.Unlock()
+	r, err = newLogsReceiver(cfg, receivertest.NewNopCreateSettings(), next)
+	require.NoError(t, err)
+	startReceiver(t, r, client, host)
+	defer func() { require.NoError(t, r.Shutdown(context.Background())) }()
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(consumed) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"transient", "permanent", "after"}, consumed)
+}
+
+// flakyS3Client fails the first downloads.
+type flakyS3Client struct {
+	*fakeS3Client
+	failures int
+}
+
+func (c *flakyS3Client) getObject(ctx context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	if c.failures > 0 {
+		c.failures--
+		c.mu.Unlock()
+		return nil, errors.New("connection reset")
+	}
+	c.mu.Unlock()
+	return c.fakeS3Client.getObject(ctx, key)
+}
+
+func TestReplayRetriesDownloads(t *testing.T) {
+	client := &flakyS3Client{fakeS3Client: newFakeS3Client(), failures: 2}
+	client.put(objectKey(partitionStart, "logs", "1", "json"), marshalLogsJSON(t, "first"))
+	client.put(objectKey(partitionStart, "logs", "2", "json"), marshalLogsJSON(t, "second"))
+
+	sink := new(consumertest.LogsSink)
+	r, err := newLogsReceiver(testConfig(), receivertest.NewNopCreateSettings(), sink)
+	require.NoError(t, err)
+	startReceiver(t, r, client, componenttest.NewNopHost())
+	defer func() { require.NoError(t, r.Shutdown(context.Background())) }()
+
+	require.Eventually(t, func() bool { return len(sink.AllLogs()) == 2 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"first", "second"}, bodies(sink.AllLogs()))
+}
+
+// listingS3Client also lists keys of objects it does not have.
+type listingS3Client struct {
+	*fakeS3Client
+	missing []string
+}
+
+func (c *listingS3Client) listObjects(ctx context.Context, prefix string, startAfter string) ([]string, error) {
+	keys, err := c.fakeS3Client.listObjects(ctx, prefix, startAfter)
+	for _, key := range c.missing {
+		if strings.HasPrefix(key, prefix) && key > startAfter {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, err
+}
+
+func TestReplaySkipsFailedObjects(t *testing.T) {
+	client := &listingS3Client{
+		fakeS3Client: newFakeS3Client(),
+		missing:      []string{objectKey(partitionStart, "logs", "1", "json")},
+	}
+	client.put(objectKey(partitionStart, "logs", "2", "json.gz"), []byte{0x1f, 0x8b, 0x00})
+	client.put(objectKey(partitionStart, "logs", "3", "json"), []byte("not json"))
+	client.put(objectKey(partitionStart, "logs", "4", "json"), marshalLogsJSON(t, "after"))
+
+	cfg := testConfig()
+	storageID := storagetest.NewStorageID("checkpoints")
+	cfg.StorageID = &storageID
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("checkpoints", t.TempDir())
+
+	sink := new(consumertest.LogsSink)
+	r, err := newLogsReceiver(cfg, receivertest.NewNopCreateSettings(), sink)
+	require.NoError(t, err)
+	startReceiver(t, r, client, host)
+
+	// the missing object is not retried, the corrupt objects are skipped.
+	require.Eventually(t, func() bool { return len(sink.AllLogs()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"after"}, bodies(sink.AllLogs()))
+	require.NoError(t, r.Shutdown(context.Background()))
+
+	// the skipped objects are checkpointed and not replayed after a restart.
+	client.put(objectKey(partitionStart, "logs", "5", "json"), marshalLogsJSON(t, "new"))
+	sink.Reset()
+	r, err = newLogsReceiver(cfg, receivertest.NewNopCreateSettings(), sink)
+	require.NoError(t, err)
+	startReceiver(t, r, client, host)
+	defer func() { require.NoError(t, r.Shutdown(context.Background())) }()
+
+	require.Eventually(t, func() bool { return len(sink.AllLogs()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"new"}, bodies(sink.AllLogs()))
+}
+
+type logsEncodingExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+	plog.JSONUnmarshaler
+}
+
+func TestReplayWithEncodingExtension(t *testing.T) {
+	client := newFakeS3Client()
+	// the awss3exporter uses the encoding ID as file format.
+	client.put(objectKey(partitionStart, "logs", "1", "json_log_encoding"), marshalLogsJSON(t, "encoded"))
+
+	cfg := testConfig()
+	encodingID := component.MustNewID("json_log_encoding")
+	cfg.Encoding = &encodingID
+	host := storagetest.NewStorageHost().WithExtension(encodingID, &logsEncodingExtension{})
+
+	sink := new(consumertest.LogsSink)
+	r, err := newLogsReceiver(cfg, receivertest.NewNopCreateSettings(), sink)
+	require.NoError(t, err)
+	startReceiver(t, r, client, host)
+	defer func() { require.NoError(t, r.Shutdown(context.Background())) }()
+
+	require.Eventually(t, func() bool { return len(sink.AllLogs()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"encoded"}, bodies(sink.AllLogs()))
+}
+
+func TestStartWithUnknownEncoding(t *testing.T) {
+	cfg := testConfig()
+	encodingID := component.MustNewID("missing")
+	cfg.Encoding = &encodingID
+
+	r, err := newLogsReceiver(cfg, receivertest.NewNopCreateSettings(), consumertest.NewNop())
+	require.NoError(t, err)
+	r.newClient = func(*Config) (s3Client, error) { return newFakeS3Client(), nil }
+	assert.ErrorContains(t, r.Start(context.Background(), componenttest.NewNopHost()), `unknown encoding "missing"`)
+	require.NoError(t, r.Shutdown(context.Background()))
+}
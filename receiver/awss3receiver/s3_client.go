@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package awss3receiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awss3receiver"
+
+import (
+	"context"
+	"io"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// s3Client is the subset of the S3 API used by the receiver.
+type s3Client interface {
+	// listObjects returns the keys under prefix that sort after startAfter,
+	// in lexicographical order.
+	listObjects(ctx context.Context, prefix string, startAfter string) ([]string, error)
+	getObject(ctx context.Context, key string) ([]byte, error)
+}
+
+type awsS3Client struct {
+	bucket string
+	client *s3.S3
+}
+
+func getSessionConfig(config *Config) *aws.Config {
+	sessionConfig := &aws.Config{
+		Region:           aws.String(config.S3Downloader.Region),
+		S3ForcePathStyle: &config.S3Downloader.S3ForcePathStyle,
+		DisableSSL:       &config.S3Downloader.DisableSSL,
+	}
+
+	endpoint := config.S3Downloader.Endpoint
+	if endpoint != "" {
+		sessionConfig.Endpoint = aws.String(endpoint)
+	}
+
+	return sessionConfig
+}
+
+func newAWSS3Client(config *Config) (*awsS3Client, error) {
+	sess, err := session.NewSession(getSessionConfig(config))
+	if err != nil {
+		return nil, err
+	}
+	return &awsS3Client{
+		bucket: config.S3Downloader.S3Bucket,
+		client: s3.New(sess),
+	}, nil
+}
+
+func (c *awsS3Client) listObjects(ctx context.Context, prefix string, startAfter string) ([]string, error) {
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(c.bucket),
+		Prefix: aws.String(prefix),
+	}
+	if startAfter != "" {
+		input.StartAfter = aws.String(startAfter)
+	}
+
+	var keys []string
+	err := c.client.ListObjectsV2PagesWithContext(ctx, input, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, object := range page.Contents {
+			keys = append(keys, aws.StringValue(object.Key))
+		}
+		return true
+	})
+	return keys, err
+}
+
+func (c *awsS3Client) getObject(ctx context.Context, key string) ([]byte, error) {
+	output, err := c.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer output.Body.Close()
+	return io.ReadAll(output.Body)
+}
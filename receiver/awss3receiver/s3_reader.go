@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package awss3receiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awss3receiver"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/s3partition"
+)
+
+// s3Reader walks the time partitions written by the awss3exporter between a
+// start and an end time.
+type s3Reader struct {
+	client     s3Client
+	s3Prefix   string
+	filePrefix string
+	partition  string
+	startTime  time.Time
+	endTime    time.Time
+
+	// partitioner renders the keys of the partitions, see s3_partition_template.
+	partitioner *s3partition.Partitioner
+
+	// location is the time zone of the keys, the awss3exporter renders them
+	// in local time.
+	location *time.Location
+}
+
+func newS3Reader(config *Config, client s3Client) (*s3Reader, error) {
+	startTime, err := parseTime(config.StartTime, "starttime")
+	if err != nil {
+		return nil, err
+	}
+	endTime, err := parseTime(config.EndTime, "endtime")
+	if err != nil {
+		return nil, err
+	}
+	partitioner, err := s3partition.NewPartitioner(config.S3Downloader.S3Partition, config.S3Downloader.S3PartitionTemplate)
+	if err != nil {
+		return nil, err
+	}
+	return &s3Reader{
+		client:     client,
+		s3Prefix:   config.S3Downloader.S3Prefix,
+		filePrefix: config.S3Downloader.FilePrefix,
+		partition:  config.S3Downloader.S3Partition,
+		startTime:  startTime,
+		endTime:    endTime,
+		location:   time.Local,
+
+		partitioner: partitioner,
+	}, nil
+}
+
+// validatePartitionTemplate parses text and renders it once, which fails if
+// the template uses resource attributes.
+func validatePartitionTemplate(partition string, text string) error {
+	p, err := s3partition.NewPartitioner(partition, text)
+	if err != nil {
+		return err
+	}
+	_, err = p.ListKey(time.Now(), "logs")
+	return err
+}
+
+func (r *s3Reader) step() time.Duration {
+	if r.partition == "hour" {
+		return time.Hour
+	}
+	return time.Minute
+}
+
+// partitionKey mirrors the partition key of the awss3exporter.
+func (r *s3Reader) partitionKey(t time.Time, signal string) (string, error) {
+	return r.partitioner.ListKey(t.In(r.location), signal)
+}
+
+// readAll calls fn with every object of signal, in partition order and
+// lexicographical order within a partition. Reading resumes after cp when it
+// is set. Errors returned by fn stop the walk.
+//
+// The partitions are visited every s3_partition. A template coarser than that
+// renders the same key for consecutive partitions, which is listed once.
+func (r *s3Reader) readAll(ctx context.Context, signal string, cp *checkpoint, fn func(partition time.Time, key string) error) error {
+	step := r.step()
+	start := r.startTime.Truncate(step)
+	if cp != nil && cp.Partition.After(start) {
+		start = cp.Partition
+	}
+
+	previousKey := ""
+	for t := start; !t.After(r.endTime); t = t.Add(step) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		partitionKey, err := r.partitionKey(t, signal)
+		if err != nil {
+			return err
+		}
+		if partitionKey == previousKey {
+			continue
+		}
+		previousKey = partitionKey
+
+		startAfter := ""
+		if cp != nil && t.Equal(cp.Partition) {
+			startAfter = cp.LastKey
+		}
+		prefix := r.s3Prefix + "/" + partitionKey + "/" + r.filePrefix + signal + "_"
+		keys, err := r.client.listObjects(ctx, prefix, startAfter)
+		if err != nil {
+			return fmt.Errorf("failed to list objects for partition %s: %w", partitionKey, err)
+		}
+		for _, key := range keys {
+			if err = fn(t, key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package awss3receiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingS3Client records the listed prefixes.
+type recordingS3Client struct {
+	*fakeS3Client
+	prefixes    []string
+	startAfters []string
+}
+
+func (c *recordingS3Client) listObjects(ctx context.Context, prefix string, startAfter string) ([]string, error) {
+	c.prefixes = append(c.prefixes, prefix)
+	c.startAfters = append(c.startAfters, startAfter)
+	return c.fakeS3Client.listObjects(ctx, prefix, startAfter)
+}
+
+func TestReadAllPartitions(t *testing.T) {
+	tests := []struct {
+		name       string
+		partition  string
+		template   string
+		location   *time.Location
+		startTime  string
+		endTime    string
+		cp         *checkpoint
+		prefixes   []string
+		startAfter []string
+	}{
+		{
+			name:      "hour",
+			partition: "hour",
+			startTime: "2024-03-01 22:30",
+			endTime:   "2024-03-02 00:10",
+			prefixes: []string{
+				"otel/year=2024/month=03/day=01/hour=22/app_logs_",
+				"otel/year=2024/month=03/day=01/hour=23/app_logs_",
+				"otel/year=2024/month=03/day=02/hour=00/app_logs_",
+			},
+			startAfter: []string{"", "", ""},
+		},
+		{
+			name:      "minute",
+			partition: "minute",
+			startTime: "2024-03-01 10:58",
+			endTime:   "2024-03-01 11:00",
+			prefixes: []string{
+				"otel/year=2024/month=03/day=01/hour=10/minute=58/app_logs_",
+				"otel/year=2024/month=03/day=01/hour=10/minute=59/app_logs_",
+				"otel/year=2024/month=03/day=01/hour=11/minute=00/app_logs_",
+			},
+			startAfter: []string{"", "", ""},
+		},
+		{
+			name:      "resume from checkpoint",
+			partition: "minute",
+			startTime: "2024-03-01 10:58",
+			endTime:   "2024-03-01 11:00",
+			cp: &checkpoint{
+				Partition: time.Date(2024, 3, 1, 10, 59, 0, 0, time.UTC),
+				LastKey:   "otel/year=2024/month=03/day=01/hour=10/minute=59/app_logs_5.json",
+			},
+			prefixes: []string{
+				"otel/year=2024/month=03/day=01/hour=10/minute=59/app_logs_",
+				"otel/year=2024/month=03/day=01/hour=11/minute=00/app_logs_",
+			},
+			startAfter: []string{"otel/year=2024/month=03/day=01/hour=10/minute=59/app_logs_5.json", ""},
+		},
+		{
+			name:      "local time",
+			partition: "hour",
+			location:  time.FixedZone("UTC+1", 3600),
+			startTime: "2024-03-01 22:30",
+			endTime:   "2024-03-01 23:10",
+			prefixes: []string{
+				"otel/year=2024/month=03/day=01/hour=23/app_logs_",
+				"otel/year=2024/month=03/day=02/hour=00/app_logs_",
+			},
+			startAfter: []string{"", ""},
+		},
+		{
+			name:      "template",
+			partition: "hour",
+			template:  `/{{ .Signal }}/dt={{ .Time.Format "2006-01-02" }}/{{ .Partition }}/`,
+			startTime: "2024-03-01 23:00",
+			endTime:   "2024-03-02 00:00",
+			prefixes: []string{
+				"otel/logs/dt=2024-03-01/year=2024/month=03/day=01/hour=23/app_logs_",
+				"otel/logs/dt=2024-03-02/year=2024/month=03/day=02/hour=00/app_logs_",
+			},
+			startAfter: []string{"", ""},
+		},
+		{
+			name:      "template coarser than the partition",
+			partition: "minute",
+			template:  `dt={{ .Time.Format "2006-01-02" }}/bucket={{ (truncate "15m" .Time).Format "15-04" }}`,
+			startTime: "2024-03-01 10:10",
+			endTime:   "2024-03-01 10:40",
+			cp: &checkpoint{
+				Partition: time.Date(2024, 3, 1, 10, 15, 0, 0, time.UTC),
+				LastKey:   "otel/dt=2024-03-01/bucket=10-15/app_logs_5.json",
+			},
+			prefixes: []string{
+				"otel/dt=2024-03-01/bucket=10-15/app_logs_",
+				"otel/dt=2024-03-01/bucket=10-30/app_logs_",
+			},
+			startAfter: []string{"otel/dt=2024-03-01/bucket=10-15/app_logs_5.json", ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig()
+			cfg.S3Downloader.S3Partition = tt.partition
+			cfg.S3Downloader.FilePrefix = "app_"
+			cfg.S3Downloader.S3PartitionTemplate = tt.template
+			cfg.StartTime = tt.startTime
+			cfg.EndTime = tt.endTime
+
+			client := &recordingS3Client{fakeS3Client: newFakeS3Client()}
+			reader, err := newS3Reader(cfg, client)
+			require.NoError(t, err)
+			reader.location = time.UTC
+			if tt.location != nil {
+				reader.location = tt.location
+			}
+			require.NoError(t, reader.readAll(context.Background(), "logs", tt.cp, func(time.Time, string) error { return nil }))
+			assert.Equal(t, tt.prefixes, client.prefixes)
+			assert.Equal(t, tt.startAfter, client.startAfters)
+		})
+	}
+}
+
+func TestReadAllStopsOnCancel(t *testing.T) {
+	client := newFakeS3Client()
+	client.put(objectKey(partitionStart, "logs", "1", "json"), nil)
+	reader, err := newS3Reader(testConfig(), client)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err = reader.readAll(ctx, "logs", nil, func(time.Time, string) error {
+		cancel()
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
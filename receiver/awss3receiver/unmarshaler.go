@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package awss3receiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awss3receiver"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var errUnknownFormat = errors.New("unknown object format")
+
+// s3Unmarshaler decodes the objects written by the awss3exporter.
+type s3Unmarshaler struct {
+	logsUnmarshaler    plog.Unmarshaler
+	metricsUnmarshaler pmetric.Unmarshaler
+	tracesUnmarshaler  ptrace.Unmarshaler
+}
+
+var (
+	jsonUnmarshaler = &s3Unmarshaler{
+		logsUnmarshaler:    &plog.JSONUnmarshaler{},
+		metricsUnmarshaler: &pmetric.JSONUnmarshaler{},
+		tracesUnmarshaler:  &ptrace.JSONUnmarshaler{},
+	}
+	protoUnmarshaler = &s3Unmarshaler{
+		logsUnmarshaler:    &plog.ProtoUnmarshaler{},
+		metricsUnmarshaler: &pmetric.ProtoUnmarshaler{},
+		tracesUnmarshaler:  &ptrace.ProtoUnmarshaler{},
+	}
+)
+
+func newUnmarshalerFromEncoding(encoding *component.ID, host component.Host) (*s3Unmarshaler, error) {
+	e, ok := host.GetExtensions()[*encoding]
+	if !ok {
+		return nil, fmt.Errorf("unknown encoding %q", encoding)
+	}
+	unmarshaler := &s3Unmarshaler{}
+	// cast with ok to avoid panics.
+	unmarshaler.logsUnmarshaler, _ = e.(plog.Unmarshaler)
+	unmarshaler.metricsUnmarshaler, _ = e.(pmetric.Unmarshaler)
+	unmarshaler.tracesUnmarshaler, _ = e.(ptrace.Unmarshaler)
+	return unmarshaler, nil
+}
+
+// unmarshalerForKey picks the unmarshaler matching the file format the
+// awss3exporter marshalers append to the object key.
+func unmarshalerForKey(key string) (*s3Unmarshaler, error) {
+	key = strings.TrimSuffix(key, ".gz")
+	switch {
+	case strings.HasSuffix(key, ".json"):
+		return jsonUnmarshaler, nil
+	case strings.HasSuffix(key, ".binpb"):
+		return protoUnmarshaler, nil
+	default:
+		return nil, errUnknownFormat
+	}
+}
+
+// decompress gunzips data when it starts with the gzip magic number. The
+// check is done on the content rather than on the ".gz" suffix since some
+// clients transparently decode objects stored with a gzip content encoding.
+func decompress(data []byte) ([]byte, error) {
+	if len(data) < 2 || data[0] != 0x1f || data[1] != 0x8b {
+		return data, nil
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver
+
+import (
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+
+	mqtt "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/hooks/auth"
+	"github.com/mochi-mqtt/server/v2/listeners"
+	"github.com/mochi-mqtt/server/v2/packets"
+	"github.com/stretchr/testify/require"
+)
+
+// testBroker is an embedded broker which exposes the sessions of its clients.
+type testBroker struct {
+	t           *testing.T
+	server      *mqtt.Server
+	listener    *listeners.TCP
+	connections *connectionsHook
+	acks        *acknowledgementsHook
+}
+
+// connectionsHook counts the connections of the clients.
+type connectionsHook struct {
+	mqtt.HookBase
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func (h *connectionsHook) ID() string {
+	return "connections"
+}
+
+func (h *connectionsHook) Provides(b byte) bool {
+	return b == mqtt.OnSessionEstablished
+}
+
+func (h *connectionsHook) OnSessionEstablished(cl *mqtt.Client, _ packets.Packet) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.counts[cl.ID]++
+}
+
+// acknowledgementsHook records the packet IDs of the QoS 1 messages sent to the clients,
+// and of the acknowledgements of the clients, in order.
+type acknowledgementsHook struct {
+	mqtt.HookBase
+	mu    sync.Mutex
+	sent  map[string][]uint16
+	acked map[string][]uint16
+}
+
+func (h *acknowledgementsHook) ID() string {
+	return "acknowledgements"
+}
+
+func (h *acknowledgementsHook) Provides(b byte) bool {
+	return b == mqtt.OnPacketSent || b == mqtt.OnQosComplete
+}
+
+func (h *acknowledgementsHook) OnPacketSent(cl *mqtt.Client, pk packets.Packet, _ []byte) {
+	if pk.FixedHeader.Type != packets.Publish || pk.FixedHeader.Qos != 1 {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.sent[cl.ID] = append(h.sent[cl.ID], pk.PacketID)
+}
+
+func (h *acknowledgementsHook) OnQosComplete(cl *mqtt.Client, pk packets.Packet) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.acked[cl.ID] = append(h.acked[cl.ID], pk.PacketID)
+}
+
+func startBroker(t *testing.T) *testBroker {
+	server := mqtt.New(&mqtt.Options{
+		InlineClient: true,
+		Logger:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+	require.NoError(t, server.AddHook(new(auth.AllowHook), nil))
+	connections := &connectionsHook{counts: map[string]int{}}
+	require.NoError(t, server.AddHook(connections, nil))
+	acks := &acknowledgementsHook{sent: map[string][]uint16{}, acked: map[string][]uint16{}}
+	require.NoError(t, server.AddHook(acks, nil))
+	listener := listeners.NewTCP("tcp", "127.0.0.1:0", nil)
+	require.NoError(t, server.AddListener(listener))
+	require.NoError(t, server.Serve())
+	t.Cleanup(func() { require.NoError(t, server.Close()) })
+	return &testBroker{t: t, server: server, listener: listener, connections: connections, acks: acks}
+}
+
+func (b *testBroker) Addr() string {
+	return b.listener.Address()
+}
+
+// Publish publishes a message to the subscribers of topic.
+func (b *testBroker) Publish(topic string, payload []byte, qos byte) {
+	require.NoError(b.t, b.server.Publish(topic, payload, false, qos))
+}
+
+// Subscribed reports whether the session of a client is subscribed to filter.
+func (b *testBroker) Subscribed(clientID string, filter string) bool {
+	cl, ok := b.server.Clients.Get(clientID)
+	if !ok {
+		return false
+	}
+	_, ok = cl.State.Subscriptions.Get(filter)
+	return ok
+}
+
+// Unacknowledged returns the number of messages sent, or to be sent, to a client which
+// were not acknowledged.
+func (b *testBroker) Unacknowledged(clientID string) int {
+	cl, ok := b.server.Clients.Get(clientID)
+	if !ok {
+		return 0
+	}
+	return cl.State.Inflight.Len()
+}
+
+// Connections returns the number of times a client connected.
+func (b *testBroker) Connections(clientID string) int {
+	b.connections.mu.Lock()
+	defer b.connections.mu.Unlock()
+	return b.connections.counts[clientID]
+}
+
+// Acknowledgements returns the packet IDs of the QoS 1 messages sent to a client, and of
+// the acknowledgements of the client, in order.
+func (b *testBroker) Acknowledgements(clientID string) (sent []uint16, acked []uint16) {
+	b.acks.mu.Lock()
+	defer b.acks.mu.Unlock()
+	return append([]uint16(nil), b.acks.sent[clientID]...), append([]uint16(nil), b.acks.acked[clientID]...)
+}
+
+// Disconnect closes the connection of a client, keeping its session.
+func (b *testBroker) Disconnect(clientID string) {
+	cl, ok := b.server.Clients.Get(clientID)
+	require.True(b.t, ok)
+	// The reason code is returned as an error
+	_ = b.server.DisconnectClient(cl, packets.ErrAdministrativeAction)
+}
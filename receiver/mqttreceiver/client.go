@@ -0,0 +1,222 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver"
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/eclipse/paho.golang/autopaho"
+	"github.com/eclipse/paho.golang/paho"
+	pahomqtt "github.com/eclipse/paho.mqtt.golang"
+	"go.uber.org/zap"
+)
+
+// messageHandler consumes a message and reports whether it must be acknowledged.
+type messageHandler func(topic string, payload []byte) bool
+
+// client is a connection to the broker, which is established again when it is lost.
+// The topics are subscribed to on every connection, and the messages passed to the
+// handler.
+type client interface {
+	disconnect(ctx context.Context) error
+}
+
+// clientSettings are the settings of the client of a receiver.
+type clientSettings struct {
+	config        *Config
+	clientID      string
+	tlsConfig     *tls.Config
+	logger        *zap.Logger
+	retryInterval time.Duration
+	handler       messageHandler
+}
+
+func newClient(set clientSettings) (client, error) {
+	if set.config.ProtocolVersion == protocolVersion5 {
+		return newClientV5(set)
+	}
+	return newClientV311(set), nil
+}
+
+// messageQueueSize is the number of received messages an MQTT 3.1.1 client queues
+// before it stops reading from the connection.
+const messageQueueSize = 100
+
+// clientV311 is an MQTT 3.1.1 client.
+type clientV311 struct {
+	client  pahomqtt.Client
+	timeout time.Duration
+	stop    chan struct{}
+	wg      sync.WaitGroup
+}
+
+func newClientV311(set clientSettings) *clientV311 {
+	scheme := "tcp"
+	if set.tlsConfig != nil {
+		scheme = "ssl"
+	}
+	c := &clientV311{timeout: set.config.Timeout, stop: make(chan struct{})}
+	messages := make(chan pahomqtt.Message, messageQueueSize)
+	filters := make(map[string]byte, len(set.config.Topics))
+	for _, topic := range set.config.Topics {
+		filters[topic.Filter] = byte(set.config.QoS)
+	}
+
+	options := pahomqtt.NewClientOptions().
+		AddBroker(scheme + "://" + set.config.Endpoint).
+		SetProtocolVersion(4).
+		SetClientID(set.clientID).
+		SetUsername(set.config.Username).
+		SetPassword(string(set.config.Password)).
+		SetTLSConfig(set.tlsConfig).
+		SetCleanSession(set.config.CleanSession).
+		SetKeepAlive(set.config.KeepAlive).
+		SetConnectTimeout(set.config.Timeout).
+		SetWriteTimeout(set.config.Timeout).
+		SetConnectRetry(true).
+		SetConnectRetryInterval(set.retryInterval).
+		SetAutoReconnect(true).
+		SetMaxReconnectInterval(maxRetryInterval).
+		// The messages are queued in the order they are received, since the handler of the
+		// client must not block, and handled one at a time so that their acknowledgements are
+		// sent in that order.
+		SetOrderMatters(true).
+		SetAutoAckDisabled(true).
+		SetDefaultPublishHandler(func(_ pahomqtt.Client, msg pahomqtt.Message) {
+			select {
+			case messages <- msg:
+			case <-c.stop:
+			}
+		}).
+		SetOnConnectHandler(func(client pahomqtt.Client) {
+			// The subscriptions have no callback so that the messages of overlapping filters
+			// are passed once to the default handler.
+			token := client.SubscribeMultiple(filters, nil)
+			if !token.WaitTimeout(set.config.Timeout) {
+				set.logger.Error("Timed out subscribing to MQTT topics", zap.String("endpoint", set.config.Endpoint))
+				return
+			}
+			if err := token.Error(); err != nil {
+				set.logger.Error("Failed to subscribe to MQTT topics", zap.String("endpoint", set.config.Endpoint), zap.Error(err))
+				return
+			}
+			for filter, code := range token.(*pahomqtt.SubscribeToken).Result() {
+				if code >= 0x80 {
+					set.logger.Error("MQTT broker rejected the subscription", zap.String("endpoint", set.config.Endpoint), zap.String("filter", filter))
+				}
+			}
+			set.logger.Info("Subscribed to MQTT topics", zap.String("endpoint", set.config.Endpoint), zap.String("client_id", set.clientID))
+		}).
+		SetConnectionLostHandler(func(_ pahomqtt.Client, err error) {
+			set.logger.Warn("MQTT connection lost, reconnecting", zap.String("endpoint", set.config.Endpoint), zap.Error(err))
+		})
+
+	c.client = pahomqtt.NewClient(options)
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		for {
+			select {
+			case msg := <-messages:
+				if set.handler(msg.Topic(), msg.Payload()) {
+					msg.Ack()
+				}
+			case <-c.stop:
+				return
+			}
+		}
+	}()
+	// The connection is retried in the background until the client disconnects
+	c.client.Connect()
+	return c
+}
+
+func (c *clientV311) disconnect(context.Context) error {
+	// The queued messages are not acknowledged, the broker sends them again.
+	close(c.stop)
+	c.wg.Wait()
+	c.client.Disconnect(uint(c.timeout.Milliseconds()))
+	return nil
+}
+
+// clientV5 is an MQTT 5 client.
+type clientV5 struct {
+	conn *autopaho.ConnectionManager
+}
+
+func newClientV5(set clientSettings) (*clientV5, error) {
+	scheme := "mqtt"
+	if set.tlsConfig != nil {
+		scheme = "tls"
+	}
+	serverURL, err := url.Parse(scheme + "://" + set.config.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint %q: %w", set.config.Endpoint, err)
+	}
+	subscriptions := make([]paho.SubscribeOptions, 0, len(set.config.Topics))
+	for _, topic := range set.config.Topics {
+		subscriptions = append(subscriptions, paho.SubscribeOptions{Topic: topic.Filter, QoS: byte(set.config.QoS)})
+	}
+	var sessionExpiry uint32
+	if !set.config.CleanSession {
+		sessionExpiry = uint32(set.config.SessionExpiry / time.Second)
+	}
+
+	conn, err := autopaho.NewConnection(context.Background(), autopaho.ClientConfig{
+		ServerUrls:                    []*url.URL{serverURL},
+		TlsCfg:                        set.tlsConfig,
+		KeepAlive:                     uint16(set.config.KeepAlive / time.Second),
+		CleanStartOnInitialConnection: set.config.CleanSession,
+		SessionExpiryInterval:         sessionExpiry,
+		ConnectRetryDelay:             set.retryInterval,
+		ConnectTimeout:                set.config.Timeout,
+		ConnectUsername:               set.config.Username,
+		ConnectPassword:               []byte(set.config.Password),
+		OnConnectionUp: func(cm *autopaho.ConnectionManager, connack *paho.Connack) {
+			ctx, cancel := context.WithTimeout(context.Background(), set.config.Timeout)
+			defer cancel()
+			if _, err := cm.Subscribe(ctx, &paho.Subscribe{Subscriptions: subscriptions}); err != nil {
+				set.logger.Error("Failed to subscribe to MQTT topics", zap.String("endpoint", set.config.Endpoint), zap.Error(err))
+				return
+			}
+			set.logger.Info("Subscribed to MQTT topics", zap.String("endpoint", set.config.Endpoint), zap.String("client_id", set.clientID), zap.Bool("session_present", connack.SessionPresent))
+		},
+		OnConnectError: func(err error) {
+			set.logger.Warn("MQTT connection failed, reconnecting", zap.String("endpoint", set.config.Endpoint), zap.Duration("interval", set.retryInterval), zap.Error(err))
+		},
+		ClientConfig: paho.ClientConfig{
+			ClientID:      set.clientID,
+			PacketTimeout: set.config.Timeout,
+			// The acknowledgements must be sent in order, the messages are handled one at a time.
+			EnableManualAcknowledgment: true,
+			OnPublishReceived: []func(paho.PublishReceived) (bool, error){
+				func(pr paho.PublishReceived) (bool, error) {
+					if !set.handler(pr.Packet.Topic, pr.Packet.Payload) {
+						return true, nil
+					}
+					return true, pr.Client.Ack(pr.Packet)
+				},
+			},
+			OnClientError: func(err error) {
+				set.logger.Warn("MQTT connection lost, reconnecting", zap.String("endpoint", set.config.Endpoint), zap.Error(err))
+			},
+			OnServerDisconnect: func(d *paho.Disconnect) {
+				set.logger.Warn("MQTT broker closed the connection, reconnecting", zap.String("endpoint", set.config.Endpoint), zap.Uint8("reason_code", d.ReasonCode))
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &clientV5{conn: conn}, nil
+}
+
+func (c *clientV5) disconnect(ctx context.Context) error {
+	return c.conn.Disconnect(ctx)
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver/internal/mqtt"
+)
+
+const (
+	protocolVersion311 = "3.1.1"
+	protocolVersion5   = "5"
+)
+
+// Config defines configuration for the MQTT receiver.
+type Config struct {
+	// Endpoint is the host and port of the broker (default localhost:1883).
+	Endpoint string `mapstructure:"endpoint"`
+	// ProtocolVersion is the version of MQTT used, "3.1.1" or "5" (default "3.1.1").
+	ProtocolVersion string `mapstructure:"protocol_version"`
+	// ClientID identifies the session of the receiver on the broker. When not set, it is
+	// derived from the component ID and the signal.
+	ClientID string              `mapstructure:"client_id"`
+	Username string              `mapstructure:"username"`
+	Password configopaque.String `mapstructure:"password"`
+
+	TLS configtls.TLSClientSetting `mapstructure:"tls,omitempty"`
+
+	// CleanSession discards the session kept by the broker, and the messages published
+	// while the receiver was disconnected.
+	CleanSession bool `mapstructure:"clean_session"`
+	// SessionExpiry is the time MQTT 5 brokers keep the session after the receiver
+	// disconnected, unless clean_session is set (default 1h).
+	SessionExpiry time.Duration `mapstructure:"session_expiry"`
+	// KeepAlive is the interval of the keep alive pings (default 30s).
+	KeepAlive time.Duration `mapstructure:"keep_alive"`
+	// Timeout bounds the time spent connecting to the broker and sending packets (default 10s).
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// QoS is the maximum QoS of the subscriptions, 0 or 1 (default 1). Messages of QoS 1
+	// are acknowledged once they were consumed by the pipeline.
+	QoS int `mapstructure:"qos"`
+	// Topics are the topic filters the receiver subscribes to.
+	Topics []TopicConfig `mapstructure:"topics"`
+	// Encoding is the extension used to decode the payloads. When not set the payloads
+	// are decoded as OTLP protobuf.
+	Encoding *component.ID `mapstructure:"encoding"`
+}
+
+// TopicConfig defines a subscription of the receiver.
+type TopicConfig struct {
+	// Filter is the topic filter, which may contain wildcards.
+	Filter string `mapstructure:"filter"`
+	// ResourceAttributes are the names of the resource attributes set from the levels
+	// of the topics, by position. Levels with empty names are skipped.
+	ResourceAttributes []string `mapstructure:"resource_attributes"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks the receiver configuration is valid
+func (cfg *Config) Validate() error {
+	var errs error
+	if cfg.Endpoint == "" {
+		errs = multierr.Append(errs, errors.New("endpoint must be specified"))
+	}
+	if cfg.ProtocolVersion != protocolVersion311 && cfg.ProtocolVersion != protocolVersion5 {
+		errs = multierr.Append(errs, fmt.Errorf("protocol_version must be either %q or %q", protocolVersion311, protocolVersion5))
+	}
+	if cfg.KeepAlive < 0 || cfg.KeepAlive > math.MaxUint16*time.Second {
+		errs = multierr.Append(errs, errors.New("keep_alive must be between 0s and 65535s"))
+	}
+	if cfg.QoS != 0 && cfg.QoS != 1 {
+		errs = multierr.Append(errs, errors.New("qos must be either 0 or 1"))
+	}
+	if len(cfg.Topics) == 0 {
+		errs = multierr.Append(errs, errors.New("at least one topic must be specified"))
+	}
+	for _, topic := range cfg.Topics {
+		if err := mqtt.ValidateFilter(topic.Filter); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("invalid topic filter %q: %w", topic.Filter, err))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	encoding := component.MustNewID("foo")
+
+	tests := []struct {
+		id           component.ID
+		expected     component.Config
+		errorMessage string
+	}{
+		{
+			id: component.NewID(metadata.Type),
+			expected: &Config{
+				Endpoint:        "broker:8883",
+				ProtocolVersion: "5",
+				ClientID:        "collector-a",
+				Username:        "collector",
+				Password:        "secret",
+				TLS: configtls.TLSClientSetting{
+					TLSSetting: configtls.TLSSetting{
+						CAFile: "ca.pem",
+					},
+				},
+				SessionExpiry: 24 * time.Hour,
+				KeepAlive:     time.Minute,
+				Timeout:       5 * time.Second,
+				QoS:           0,
+				Topics: []TopicConfig{
+					{
+						Filter:             "sites/+/devices/+/telemetry",
+						ResourceAttributes: []string{"", "site.name", "", "device.id"},
+					},
+					{
+						Filter: "$share/collectors/gateways/#",
+					},
+				},
+				Encoding: &encoding,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "minimal"),
+			expected: &Config{
+				Endpoint:        "localhost:1883",
+				ProtocolVersion: "3.1.1",
+				TLS: configtls.TLSClientSetting{
+					Insecure: true,
+				},
+				SessionExpiry: time.Hour,
+				KeepAlive:     30 * time.Second,
+				Timeout:       10 * time.Second,
+				QoS:           1,
+				Topics:        []TopicConfig{{Filter: "devices/#"}},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "no_topics"),
+			errorMessage: "at least one topic must be specified",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "bad_filter"),
+			errorMessage: `invalid topic filter "devices/#/telemetry": multi-level wildcard '#' must be the last level of the topic filter`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "bad_protocol_version"),
+			errorMessage: `protocol_version must be either "3.1.1" or "5"`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "bad_qos"),
+			errorMessage: "qos must be either 0 or 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			if tt.errorMessage != "" {
+				assert.ErrorContains(t, component.ValidateConfig(cfg), tt.errorMessage)
+				return
+			}
+
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver/internal/metadata"
+)
+
+const (
+	defaultEndpoint      = "localhost:1883"
+	defaultSessionExpiry = time.Hour
+	defaultKeepAlive     = 30 * time.Second
+	defaultTimeout       = 10 * time.Second
+)
+
+// NewFactory creates a factory for the MQTT receiver.
+func NewFactory() receiver.Factory {
+	return receiver.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		receiver.WithTraces(createTracesReceiver, metadata.TracesStability),
+		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability),
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Endpoint:        defaultEndpoint,
+		ProtocolVersion: protocolVersion311,
+		TLS: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+		SessionExpiry: defaultSessionExpiry,
+		KeepAlive:     defaultKeepAlive,
+		Timeout:       defaultTimeout,
+		QoS:           1,
+	}
+}
+
+func createTracesReceiver(_ context.Context, settings receiver.CreateSettings, cfg component.Config, nextConsumer consumer.Traces) (receiver.Traces, error) {
+	return newTracesReceiver(cfg.(*Config), settings, nextConsumer)
+}
+
+func createMetricsReceiver(_ context.Context, settings receiver.CreateSettings, cfg component.Config, nextConsumer consumer.Metrics) (receiver.Metrics, error) {
+	return newMetricsReceiver(cfg.(*Config), settings, nextConsumer)
+}
+
+func createLogsReceiver(_ context.Context, settings receiver.CreateSettings, cfg component.Config, nextConsumer consumer.Logs) (receiver.Logs, error) {
+	return newLogsReceiver(cfg.(*Config), settings, nextConsumer)
+}
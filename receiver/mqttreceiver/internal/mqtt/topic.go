@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package mqtt validates and matches the topic filters of the subscriptions.
+package mqtt // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver/internal/mqtt"
+
+import (
+	"errors"
+	"strings"
+)
+
+const sharePrefix = "$share/"
+
+// ValidateFilter checks a topic filter, which may be a shared subscription.
+func ValidateFilter(filter string) error {
+	if strings.HasPrefix(filter, sharePrefix) {
+		group, shared, ok := strings.Cut(strings.TrimPrefix(filter, sharePrefix), "/")
+		if !ok || group == "" || strings.ContainsAny(group, "+#") {
+			return errors.New("invalid shared subscription, expected $share/<group>/<filter>")
+		}
+		filter = shared
+	}
+	if filter == "" {
+		return errors.New("topic filter must not be empty")
+	}
+
+	levels := strings.Split(filter, "/")
+	for i, level := range levels {
+		switch {
+		case level == "#" && i != len(levels)-1:
+			return errors.New("multi-level wildcard '#' must be the last level of the topic filter")
+		case level != "#" && strings.Contains(level, "#"):
+			return errors.New("multi-level wildcard '#' must occupy a whole level of the topic filter")
+		case level != "+" && strings.Contains(level, "+"):
+			return errors.New("single-level wildcard '+' must occupy a whole level of the topic filter")
+		}
+	}
+	return nil
+}
+
+// MatchTopic reports whether a topic name matches a valid topic filter.
+func MatchTopic(filter string, topic string) bool {
+	if strings.HasPrefix(filter, sharePrefix) {
+		_, filter, _ = strings.Cut(strings.TrimPrefix(filter, sharePrefix), "/")
+	}
+
+	filterLevels := strings.Split(filter, "/")
+	topicLevels := strings.Split(topic, "/")
+	// Wildcards do not match the topics reserved by the brokers
+	if strings.HasPrefix(topic, "$") && (filterLevels[0] == "+" || filterLevels[0] == "#") {
+		return false
+	}
+
+	for i, level := range filterLevels {
+		if level == "#" {
+			return true
+		}
+		if i >= len(topicLevels) || (level != "+" && level != topicLevels[i]) {
+			return false
+		}
+	}
+	return len(filterLevels) == len(topicLevels)
+}
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateFilter(t *testing.T) {
+	testCases := []struct {
+		filter   string
+		expected string
+	}{
+		{filter: "devices/+/telemetry"},
+		{filter: "devices/#"},
+		{filter: "#"},
+		{filter: "+"},
+		{filter: "$share/collectors/devices/+/telemetry"},
+		{filter: "", expected: "topic filter must not be empty"},
+		{filter: "devices/#/telemetry", expected: "multi-level wildcard '#' must be the last level of the topic filter"},
+		{filter: "devices/a#", expected: "multi-level wildcard '#' must occupy a whole level of the topic filter"},
+		{filter: "devices/a+/telemetry", expected: "single-level wildcard '+' must occupy a whole level of the topic filter"},
+		{filter: "$share/collectors", expected: "invalid shared subscription, expected $share/<group>/<filter>"},
+		{filter: "$share/+/devices", expected: "invalid shared subscription, expected $share/<group>/<filter>"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.filter, func(t *testing.T) {
+			err := ValidateFilter(tc.filter)
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expected)
+			}
+		})
+	}
+}
+
+func TestMatchTopic(t *testing.T) {
+	testCases := []struct {
+		filter   string
+		topic    string
+		expected bool
+	}{
+		{filter: "devices/a/telemetry", topic: "devices/a/telemetry", expected: true},
+		{filter: "devices/a/telemetry", topic: "devices/b/telemetry"},
+		{filter: "devices/+/telemetry", topic: "devices/a/telemetry", expected: true},
+		{filter: "devices/+/telemetry", topic: "devices/a/b/telemetry"},
+		{filter: "devices/+", topic: "devices"},
+		{filter: "devices/+", topic: "devices/", expected: true},
+		{filter: "devices/#", topic: "devices", expected: true},
+		{filter: "devices/#", topic: "devices/a/b", expected: true},
+		{filter: "#", topic: "devices/a", expected: true},
+		{filter: "#", topic: "$SYS/uptime"},
+		{filter: "+/uptime", topic: "$SYS/uptime"},
+		{filter: "$SYS/#", topic: "$SYS/uptime", expected: true},
+		{filter: "$share/collectors/devices/+", topic: "devices/a", expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.filter+" "+tc.topic, func(t *testing.T) {
+			assert.Equal(t, tc.expected, MatchTopic(tc.filter, tc.topic))
+		})
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/consumerretry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver/internal/mqtt"
+)
+
+const (
+	transport = "mqtt"
+
+	defaultRetryInterval = time.Second
+	maxRetryInterval     = 30 * time.Second
+)
+
+// mqttReceiver subscribes to the topics of one signal.
+type mqttReceiver struct {
+	config   *Config
+	settings receiver.CreateSettings
+	logger   *zap.Logger
+	signal   string
+	clientID string
+	obsrecv  *receiverhelper.ObsReport
+
+	next consumerretry.Consumers
+
+	// retryInterval is replaced in tests.
+	retryInterval time.Duration
+
+	unmarshaler *payloadUnmarshaler
+	client      client
+
+	// mu prevents messages from being handled once the receiver is shut down.
+	mu       sync.Mutex
+	ctx      context.Context
+	cancel   context.CancelFunc
+	handlers sync.WaitGroup
+}
+
+func newMQTTReceiver(config *Config, settings receiver.CreateSettings, signal string) (*mqttReceiver, error) {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             settings.ID,
+		Transport:              transport,
+		ReceiverCreateSettings: settings,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	clientID := config.ClientID
+	if clientID == "" {
+		// Receivers of different signals need their own sessions
+		clientID = fmt.Sprintf("otelcol-%s-%s", settings.ID, signal)
+	}
+	return &mqttReceiver{
+		config:        config,
+		settings:      settings,
+		logger:        settings.Logger,
+		signal:        signal,
+		clientID:      clientID,
+		obsrecv:       obsrecv,
+		retryInterval: defaultRetryInterval,
+	}, nil
+}
+
+func newLogsReceiver(config *Config, settings receiver.CreateSettings, nextConsumer consumer.Logs) (*mqttReceiver, error) {
+	r, err := newMQTTReceiver(config, settings, "logs")
+	if err != nil {
+		return nil, err
+	}
+	r.next.Logs = nextConsumer
+	return r, nil
+}
+
+func newMetricsReceiver(config *Config, settings receiver.CreateSettings, nextConsumer consumer.Metrics) (*mqttReceiver, error) {
+	r, err := newMQTTReceiver(config, settings, "metrics")
+	if err != nil {
+		return nil, err
+	}
+	r.next.Metrics = nextConsumer
+	return r, nil
+}
+
+func newTracesReceiver(config *Config, settings receiver.CreateSettings, nextConsumer consumer.Traces) (*mqttReceiver, error) {
+	r, err := newMQTTReceiver(config, settings, "traces")
+	if err != nil {
+		return nil, err
+	}
+	r.next.Traces = nextConsumer
+	return r, nil
+}
+
+func (r *mqttReceiver) Start(_ context.Context, host component.Host) error {
+	r.unmarshaler = protoUnmarshaler
+	if r.config.Encoding != nil {
+		var err error
+		if r.unmarshaler, err = newUnmarshalerFromEncoding(r.config.Encoding, host); err != nil {
+			return err
+		}
+		if !r.unmarshaler.supports(r.signal) {
+			return fmt.Errorf("encoding %q does not support %s", r.config.Encoding, r.signal)
+		}
+	}
+
+	tlsConfig, err := r.config.TLS.LoadTLSConfig()
+	if err != nil {
+		return err
+	}
+
+	// Errors of the pipeline are retried until the receiver is shut down. A message is only
+	// acknowledged to the broker once consumed, so the broker delivers it again when the
+	// session resumes.
+	r.next = consumerretry.NewUntilShutdown(r.retryInterval, r.logger, r.next)
+
+	r.ctx, r.cancel = context.WithCancel(context.Background())
+	r.client, err = newClient(clientSettings{
+		config:        r.config,
+		clientID:      r.clientID,
+		tlsConfig:     tlsConfig,
+		logger:        r.logger,
+		retryInterval: r.retryInterval,
+		handler:       r.handle,
+	})
+	return err
+}
+
+func (r *mqttReceiver) Shutdown(ctx context.Context) error {
+	if r.cancel == nil {
+		return nil
+	}
+	r.mu.Lock()
+	r.cancel()
+	r.mu.Unlock()
+	r.handlers.Wait()
+	if r.client == nil {
+		return nil
+	}
+	return r.client.disconnect(ctx)
+}
+
+// handle consumes a message and reports whether it must be acknowledged. Messages being
+// consumed when the receiver is shut down are not acknowledged, so that the broker sends
+// them again.
+func (r *mqttReceiver) handle(topic string, payload []byte) bool {
+	r.mu.Lock()
+	if r.ctx.Err() != nil {
+		r.mu.Unlock()
+		return false
+	}
+	r.handlers.Add(1)
+	r.mu.Unlock()
+	defer r.handlers.Done()
+
+	err := r.consume(r.ctx, topic, payload)
+	if r.ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		r.logger.Error("Failed to process message, dropping it", zap.String("topic", topic), zap.Error(err))
+	}
+	return true
+}
+
+// consume decodes the payload of a message, sets the resource attributes of its topic
+// and passes it to the next consumer.
+func (r *mqttReceiver) consume(ctx context.Context, topic string, payload []byte) error {
+	attributes := r.topicAttributes(topic)
+	switch {
+	case r.next.Logs != nil:
+		ld, err := r.unmarshaler.logsUnmarshaler.UnmarshalLogs(payload)
+		if err != nil {
+			return err
+		}
+		for i := 0; i < ld.ResourceLogs().Len(); i++ {
+			setAttributes(ld.ResourceLogs().At(i).Resource(), attributes)
+		}
+		ctx = r.obsrecv.StartLogsOp(ctx)
+		err = r.next.Logs.ConsumeLogs(ctx, ld)
+		r.obsrecv.EndLogsOp(ctx, metadata.Type.String(), ld.LogRecordCount(), err)
+		return err
+	case r.next.Metrics != nil:
+		md, err := r.unmarshaler.metricsUnmarshaler.UnmarshalMetrics(payload)
+		if err != nil {
+			return err
+		}
+		for i := 0; i < md.ResourceMetrics().Len(); i++ {
+			setAttributes(md.ResourceMetrics().At(i).Resource(), attributes)
+		}
+		ctx = r.obsrecv.StartMetricsOp(ctx)
+		err = r.next.Metrics.ConsumeMetrics(ctx, md)
+		r.obsrecv.EndMetricsOp(ctx, metadata.Type.String(), md.DataPointCount(), err)
+		return err
+	default:
+		td, err := r.unmarshaler.tracesUnmarshaler.UnmarshalTraces(payload)
+		if err != nil {
+			return err
+		}
+		for i := 0; i < td.ResourceSpans().Len(); i++ {
+			setAttributes(td.ResourceSpans().At(i).Resource(), attributes)
+		}
+		ctx = r.obsrecv.StartTracesOp(ctx)
+		err = r.next.Traces.ConsumeTraces(ctx, td)
+		r.obsrecv.EndTracesOp(ctx, metadata.Type.String(), td.SpanCount(), err)
+		return err
+	}
+}
+
+// topicAttributes returns the resource attributes set from the levels of a topic, using
+// the first topic filter it matches.
+func (r *mqttReceiver) topicAttributes(topic string) map[string]string {
+	for _, t := range r.config.Topics {
+		if len(t.ResourceAttributes) == 0 || !mqtt.MatchTopic(t.Filter, topic) {
+			continue
+		}
+		attributes := make(map[string]string, len(t.ResourceAttributes))
+		for i, level := range strings.Split(topic, "/") {
+			if i < len(t.ResourceAttributes) && t.ResourceAttributes[i] != "" {
+				attributes[t.ResourceAttributes[i]] = level
+			}
+		}
+		return attributes
+	}
+	return nil
+}
+
+func setAttributes(resource pcommon.Resource, attributes map[string]string) {
+	for k, v := range attributes {
+		resource.Attributes().PutStr(k, v)
+	}
+}
@@ -0,0 +1,384 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+func testConfig(broker *testBroker) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = broker.Addr()
+	cfg.Timeout = time.Second
+	cfg.Topics = []TopicConfig{
+		{
+			Filter:             "sites/+/devices/+/telemetry",
+			ResourceAttributes: []string{"", "site.name", "", "device.id"},
+		},
+		{
+			Filter: "gateways/#",
+		},
+	}
+	return cfg
+}
+
+// startReceiver starts the receiver and waits for its subscriptions.
+func startReceiver(t *testing.T, broker *testBroker, r *mqttReceiver, host component.Host) {
+	r.retryInterval = 10 * time.Millisecond
+	require.NoError(t, r.Start(context.Background(), host))
+	require.Eventually(t, func() bool {
+		return broker.Subscribed(r.clientID, r.config.Topics[0].Filter)
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func testLogs(body string) plog.Logs {
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr(body)
+	return ld
+}
+
+func marshalLogs(t *testing.T, body string) []byte {
+	data, err := (&plog.ProtoMarshaler{}).MarshalLogs(testLogs(body))
+	require.NoError(t, err)
+	return data
+}
+
+func bodies(logs []plog.Logs) []string {
+	var result []string
+	for _, ld := range logs {
+		result = append(result, ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+	}
+	return result
+}
+
+func TestReceiveLogs(t *testing.T) {
+	for _, version := range []string{protocolVersion311, protocolVersion5} {
+		t.Run(version, func(t *testing.T) {
+			broker := startBroker(t)
+			cfg := testConfig(broker)
+			cfg.ProtocolVersion = version
+
+			sink := new(consumertest.LogsSink)
+			r, err := newLogsReceiver(cfg, receivertest.NewNopCreateSettings(), sink)
+			require.NoError(t, err)
+			startReceiver(t, broker, r, componenttest.NewNopHost())
+			defer func() { require.NoError(t, r.Shutdown(context.Background())) }()
+
+			broker.Publish("sites/paris/devices/sensor-1/telemetry", marshalLogs(t, "device"), 1)
+			broker.Publish("gateways/gw-1", marshalLogs(t, "gateway"), 1)
+			require.Eventually(t, func() bool { return len(sink.AllLogs()) == 2 }, 5*time.Second, 10*time.Millisecond)
+			// MQTT 3.1.1 messages are consumed concurrently
+			attributes := map[string]map[string]any{}
+			for _, ld := range sink.AllLogs() {
+				attributes[bodies([]plog.Logs{ld})[0]] = ld.ResourceLogs().At(0).Resource().Attributes().AsRaw()
+			}
+			assert.Equal(t, map[string]map[string]any{
+				"device": {
+					"site.name": "paris",
+					"device.id": "sensor-1",
+				},
+				"gateway": {},
+			}, attributes)
+			assert.Eventually(t, func() bool { return broker.Unacknowledged(r.clientID) == 0 }, 5*time.Second, 10*time.Millisecond)
+		})
+	}
+}
+
+func TestReceiveMetricsAndTraces(t *testing.T) {
+	broker := startBroker(t)
+
+	// The payloads of the signals are published to different topics
+	metricsConfig := testConfig(broker)
+	metricsConfig.Topics = metricsConfig.Topics[:1]
+	tracesConfig := testConfig(broker)
+	tracesConfig.Topics = tracesConfig.Topics[1:]
+
+	metricsSink := new(consumertest.MetricsSink)
+	mr, err := newMetricsReceiver(metricsConfig, receivertest.NewNopCreateSettings(), metricsSink)
+	require.NoError(t, err)
+	startReceiver(t, broker, mr, componenttest.NewNopHost())
+	defer func() { require.NoError(t, mr.Shutdown(context.Background())) }()
+
+	tracesSink := new(consumertest.TracesSink)
+	tr, err := newTracesReceiver(tracesConfig, receivertest.NewNopCreateSettings(), tracesSink)
+	require.NoError(t, err)
+	startReceiver(t, broker, tr, componenttest.NewNopHost())
+	defer func() { require.NoError(t, tr.Shutdown(context.Background())) }()
+
+	// Receivers of different signals use their own sessions
+	assert.NotEqual(t, mr.clientID, tr.clientID)
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+	data, err := (&pmetric.ProtoMarshaler{}).MarshalMetrics(md)
+	require.NoError(t, err)
+	broker.Publish("sites/paris/devices/sensor-1/telemetry", data, 1)
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	data, err = (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+	broker.Publish("gateways/gw-1", data, 1)
+
+	require.Eventually(t, func() bool {
+		return metricsSink.DataPointCount() == 1 && tracesSink.SpanCount() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	deviceID, _ := metricsSink.AllMetrics()[0].ResourceMetrics().At(0).Resource().Attributes().Get("device.id")
+	assert.Equal(t, "sensor-1", deviceID.Str())
+}
+
+func TestAcknowledgeAfterConsume(t *testing.T) {
+	broker := startBroker(t)
+
+	release := make(chan struct{})
+	consumed := make(chan struct{}, 1)
+	next, err := consumer.NewLogs(func(ctx context.Context, _ plog.Logs) error {
+		consumed <- struct{}{}
+		select {
+		case <-release:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+	require.NoError(t, err)
+
+	r, err := newLogsReceiver(testConfig(broker), receivertest.NewNopCreateSettings(), next)
+	require.NoError(t, err)
+	startReceiver(t, broker, r, componenttest.NewNopHost())
+	defer func() { require.NoError(t, r.Shutdown(context.Background())) }()
+
+	broker.Publish("gateways/gw-1", marshalLogs(t, "log"), 1)
+	select {
+	case <-consumed:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "Timed out waiting for the message to be consumed")
+	}
+	assert.Equal(t, 1, broker.Unacknowledged(r.clientID))
+
+	close(release)
+	assert.Eventually(t, func() bool { return broker.Unacknowledged(r.clientID) == 0 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestAcknowledgeInOrder(t *testing.T) {
+	for _, version := range []string{protocolVersion311, protocolVersion5} {
+		t.Run(version, func(t *testing.T) {
+			broker := startBroker(t)
+			cfg := testConfig(broker)
+			cfg.ProtocolVersion = version
+
+			release := make(chan struct{})
+			next, err := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+				if bodies([]plog.Logs{ld})[0] != "first" {
+					return nil
+				}
+				select {
+				case <-release:
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			})
+			require.NoError(t, err)
+
+			r, err := newLogsReceiver(cfg, receivertest.NewNopCreateSettings(), next)
+			require.NoError(t, err)
+			startReceiver(t, broker, r, componenttest.NewNopHost())
+			defer func() { require.NoError(t, r.Shutdown(context.Background())) }()
+
+			for _, body := range []string{"first", "second", "third"} {
+				broker.Publish("gateways/gw-1", marshalLogs(t, body), 1)
+			}
+			require.Eventually(t, func() bool {
+				sent, _ := broker.Acknowledgements(r.clientID)
+				return len(sent) == 3
+			}, 5*time.Second, 10*time.Millisecond)
+
+			// The messages following the first one are not acknowledged before it
+			assert.Never(t, func() bool {
+				_, acked := broker.Acknowledgements(r.clientID)
+				return len(acked) > 0
+			}, 200*time.Millisecond, 10*time.Millisecond)
+
+			close(release)
+			require.Eventually(t, func() bool {
+				_, acked := broker.Acknowledgements(r.clientID)
+				return len(acked) == 3
+			}, 5*time.Second, 10*time.Millisecond)
+			sent, acked := broker.Acknowledgements(r.clientID)
+			assert.Equal(t, sent, acked)
+		})
+	}
+}
+
+func TestRedeliveryAfterShutdown(t *testing.T) {
+	broker := startBroker(t)
+	cfg := testConfig(broker)
+	// The receivers resume the same session
+	cfg.ClientID = "collector"
+
+	attempts := make(chan struct{}, 10)
+	failing, err := consumer.NewLogs(func(context.Context, plog.Logs) error {
+		select {
+		case attempts <- struct{}{}:
+		default:
+		}
+		return errors.New("try again")
+	})
+	require.NoError(t, err)
+
+	r, err := newLogsReceiver(cfg, receivertest.NewNopCreateSettings(), failing)
+	require.NoError(t, err)
+	startReceiver(t, broker, r, componenttest.NewNopHost())
+	broker.Publish("gateways/gw-1", marshalLogs(t, "log"), 1)
+	select {
+	case <-attempts:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "Timed out waiting for the message to be consumed")
+	}
+	require.NoError(t, r.Shutdown(context.Background()))
+
+	// The message which was never consumed is kept in the session
+	assert.Equal(t, 1, broker.Unacknowledged(r.clientID))
+
+	sink := new(consumertest.LogsSink)
+	r, err = newLogsReceiver(cfg, receivertest.NewNopCreateSettings(), sink)
+	require.NoError(t, err)
+	startReceiver(t, broker, r, componenttest.NewNopHost())
+	defer func() { require.NoError(t, r.Shutdown(context.Background())) }()
+
+	require.Eventually(t, func() bool { return len(sink.AllLogs()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool { return broker.Unacknowledged(r.clientID) == 0 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestConsumerErrors(t *testing.T) {
+	broker := startBroker(t)
+
+	var mu sync.Mutex
+	var consumed []string
+	failures := 0
+	next, err := consumer.NewLogs(func(_ context.Context, ld plog.Logs) error {
+		mu.Lock()
+		defer mu.Unlock()
+		body := bodies([]plog.Logs{ld})[0]
+		switch {
+		case body == "permanent":
+			return consumererror.NewPermanent(errors.New("rejected"))
+		case failures < 2:
+			failures++
+			return errors.New("try again")
+		}
+		consumed = append(consumed, body)
+		return nil
+	})
+	require.NoError(t, err)
+
+	r, err := newLogsReceiver(testConfig(broker), receivertest.NewNopCreateSettings(), next)
+	require.NoError(t, err)
+	startReceiver(t, broker, r, componenttest.NewNopHost())
+	defer func() { require.NoError(t, r.Shutdown(context.Background())) }()
+
+	// Messages which cannot be decoded or are rejected are dropped
+	broker.Publish("gateways/gw-1", []byte("not protobuf"), 1)
+	broker.Publish("gateways/gw-1", marshalLogs(t, "permanent"), 1)
+	broker.Publish("gateways/gw-1", marshalLogs(t, "transient"), 1)
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(consumed) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"transient"}, consumed)
+	assert.Equal(t, 2, failures)
+	assert.Eventually(t, func() bool { return broker.Unacknowledged(r.clientID) == 0 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestReconnect(t *testing.T) {
+	broker := startBroker(t)
+
+	sink := new(consumertest.LogsSink)
+	r, err := newLogsReceiver(testConfig(broker), receivertest.NewNopCreateSettings(), sink)
+	require.NoError(t, err)
+	startReceiver(t, broker, r, componenttest.NewNopHost())
+	defer func() { require.NoError(t, r.Shutdown(context.Background())) }()
+
+	require.Equal(t, 1, broker.Connections(r.clientID))
+	broker.Disconnect(r.clientID)
+	// The receiver connects again and resumes its session
+	require.Eventually(t, func() bool { return broker.Connections(r.clientID) == 2 }, 5*time.Second, 10*time.Millisecond)
+	broker.Publish("gateways/gw-1", marshalLogs(t, "log"), 1)
+
+	require.Eventually(t, func() bool { return len(sink.AllLogs()) == 1 }, 5*time.Second, 10*time.Millisecond)
+}
+
+type logsEncodingExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+	plog.JSONUnmarshaler
+}
+
+type extensionsHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+func TestReceiveWithEncodingExtension(t *testing.T) {
+	broker := startBroker(t)
+	cfg := testConfig(broker)
+	encodingID := component.MustNewID("json_log_encoding")
+	cfg.Encoding = &encodingID
+	host := extensionsHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{encodingID: &logsEncodingExtension{}},
+	}
+
+	sink := new(consumertest.LogsSink)
+	r, err := newLogsReceiver(cfg, receivertest.NewNopCreateSettings(), sink)
+	require.NoError(t, err)
+	startReceiver(t, broker, r, host)
+	defer func() { require.NoError(t, r.Shutdown(context.Background())) }()
+
+	data, err := (&plog.JSONMarshaler{}).MarshalLogs(testLogs("encoded"))
+	require.NoError(t, err)
+	broker.Publish("gateways/gw-1", data, 1)
+	require.Eventually(t, func() bool { return len(sink.AllLogs()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"encoded"}, bodies(sink.AllLogs()))
+
+	// The extension cannot decode metrics
+	mr, err := newMetricsReceiver(cfg, receivertest.NewNopCreateSettings(), consumertest.NewNop())
+	require.NoError(t, err)
+	assert.EqualError(t, mr.Start(context.Background(), host), `encoding "json_log_encoding" does not support metrics`)
+	require.NoError(t, mr.Shutdown(context.Background()))
+}
+
+func TestStartWithUnknownEncoding(t *testing.T) {
+	cfg := testConfig(startBroker(t))
+	encodingID := component.MustNewID("missing")
+	cfg.Encoding = &encodingID
+
+	r, err := newLogsReceiver(cfg, receivertest.NewNopCreateSettings(), consumertest.NewNop())
+	require.NoError(t, err)
+	assert.ErrorContains(t, r.Start(context.Background(), componenttest.NewNopHost()), `unknown encoding "missing"`)
+	require.NoError(t, r.Shutdown(context.Background()))
+}
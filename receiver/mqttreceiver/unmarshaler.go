@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// payloadUnmarshaler decodes the payloads of the messages.
+type payloadUnmarshaler struct {
+	logsUnmarshaler    plog.Unmarshaler
+	metricsUnmarshaler pmetric.Unmarshaler
+	tracesUnmarshaler  ptrace.Unmarshaler
+}
+
+var protoUnmarshaler = &payloadUnmarshaler{
+	logsUnmarshaler:    &plog.ProtoUnmarshaler{},
+	metricsUnmarshaler: &pmetric.ProtoUnmarshaler{},
+	tracesUnmarshaler:  &ptrace.ProtoUnmarshaler{},
+}
+
+func newUnmarshalerFromEncoding(encoding *component.ID, host component.Host) (*payloadUnmarshaler, error) {
+	e, ok := host.GetExtensions()[*encoding]
+	if !ok {
+		return nil, fmt.Errorf("unknown encoding %q", encoding)
+	}
+	unmarshaler := &payloadUnmarshaler{}
+	// cast with ok to avoid panics.
+	unmarshaler.logsUnmarshaler, _ = e.(plog.Unmarshaler)
+	unmarshaler.metricsUnmarshaler, _ = e.(pmetric.Unmarshaler)
+	unmarshaler.tracesUnmarshaler, _ = e.(ptrace.Unmarshaler)
+	return unmarshaler, nil
+}
+
+// supports reports whether the payloads of the signal can be decoded.
+func (u *payloadUnmarshaler) supports(signal string) bool {
+	switch signal {
+	case "logs":
+		return u.logsUnmarshaler != nil
+	case "metrics":
+		return u.metricsUnmarshaler != nil
+	case "traces":
+		return u.tracesUnmarshaler != nil
+	}
+	return false
+}
@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/natsreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.uber.org/multierr"
+)
+
+const (
+	deliverPolicyAll = "all"
+	deliverPolicyNew = "new"
+)
+
+// Config defines configuration for the NATS receiver.
+type Config struct {
+	// Endpoint is the URL of the NATS server (default nats://localhost:4222).
+	Endpoint       string                     `mapstructure:"endpoint"`
+	Authentication Authentication             `mapstructure:"auth"`
+	TLS            configtls.TLSClientSetting `mapstructure:"tls,omitempty"`
+
+	// Stream is the JetStream stream the receiver consumes.
+	Stream string `mapstructure:"stream"`
+	// Consumer is the name of the durable consumer. When not set, it is derived from
+	// the signal.
+	Consumer string `mapstructure:"consumer"`
+	// Subjects are the subjects consumed. When not set, all the subjects of the stream
+	// are consumed.
+	Subjects []SubjectConfig `mapstructure:"subjects"`
+	// Encoding of the payloads, otlp_proto or otlp_json (default otlp_proto).
+	Encoding string `mapstructure:"encoding"`
+	// DeliverPolicy is where a new consumer starts in the stream, all or new (default all).
+	DeliverPolicy string `mapstructure:"deliver_policy"`
+	// AckWait is the time the server waits for an acknowledgement before delivering a
+	// message again (default 30s).
+	AckWait time.Duration `mapstructure:"ack_wait"`
+	// MaxAckPending is the maximum number of messages delivered but not acknowledged
+	// yet (default 1000).
+	MaxAckPending int `mapstructure:"max_ack_pending"`
+}
+
+// Authentication defines the credentials sent to the NATS server.
+type Authentication struct {
+	Username string              `mapstructure:"username"`
+	Password configopaque.String `mapstructure:"password"`
+	Token    configopaque.String `mapstructure:"token"`
+	// CredentialsFile is a file holding a user JWT and NKey seed.
+	CredentialsFile string `mapstructure:"credentials_file"`
+}
+
+// SubjectConfig defines a subject consumed by the receiver.
+type SubjectConfig struct {
+	// Subject may contain the * and > wildcards.
+	Subject string `mapstructure:"subject"`
+	// ResourceAttributes are the names of the resource attributes set from the tokens
+	// of the subjects, by position. Tokens with empty names are skipped.
+	ResourceAttributes []string `mapstructure:"resource_attributes"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks the receiver configuration is valid
+func (cfg *Config) Validate() error {
+	var errs error
+	if cfg.Endpoint == "" {
+		errs = multierr.Append(errs, errors.New("endpoint must be specified"))
+	}
+	if cfg.Stream == "" {
+		errs = multierr.Append(errs, errors.New("stream must be specified"))
+	}
+	if strings.ContainsAny(cfg.Consumer, " .*>/\\") {
+		errs = multierr.Append(errs, fmt.Errorf("invalid consumer name %q", cfg.Consumer))
+	}
+	for i, subject := range cfg.Subjects {
+		if err := validateSubject(subject.Subject); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("invalid subject %q: %w", subject.Subject, err))
+			continue
+		}
+		// The server rejects consumers with overlapping filter subjects
+		for _, other := range cfg.Subjects[:i] {
+			if overlapSubjects(subject.Subject, other.Subject) {
+				errs = multierr.Append(errs, fmt.Errorf("subject %q overlaps with subject %q", subject.Subject, other.Subject))
+			}
+		}
+	}
+	if _, ok := unmarshalers[cfg.Encoding]; !ok {
+		errs = multierr.Append(errs, fmt.Errorf("unsupported encoding %q", cfg.Encoding))
+	}
+	if cfg.DeliverPolicy != deliverPolicyAll && cfg.DeliverPolicy != deliverPolicyNew {
+		errs = multierr.Append(errs, fmt.Errorf("deliver_policy must be either %q or %q", deliverPolicyAll, deliverPolicyNew))
+	}
+	if cfg.AckWait <= 0 {
+		errs = multierr.Append(errs, errors.New("ack_wait must be positive"))
+	}
+	if cfg.MaxAckPending <= 0 {
+		errs = multierr.Append(errs, errors.New("max_ack_pending must be positive"))
+	}
+	return errs
+}
+
+func (cfg *Config) connectOptions() ([]nats.Option, error) {
+	options := []nats.Option{
+		nats.Name("otelcol"),
+		// The client reconnects in the background until the receiver is shut down
+		nats.MaxReconnects(-1),
+		nats.RetryOnFailedConnect(true),
+	}
+	tlsConfig, err := cfg.TLS.LoadTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		options = append(options, nats.Secure(tlsConfig))
+	}
+	auth := cfg.Authentication
+	if auth.Username != "" {
+		options = append(options, nats.UserInfo(auth.Username, string(auth.Password)))
+	}
+	if auth.Token != "" {
+		options = append(options, nats.Token(string(auth.Token)))
+	}
+	if auth.CredentialsFile != "" {
+		options = append(options, nats.UserCredentials(auth.CredentialsFile))
+	}
+	return options, nil
+}
+
+func (cfg *Config) consumerConfig(name string) jetstream.ConsumerConfig {
+	consumerConfig := jetstream.ConsumerConfig{
+		Durable:       name,
+		AckPolicy:     jetstream.AckExplicitPolicy,
+		AckWait:       cfg.AckWait,
+		MaxAckPending: cfg.MaxAckPending,
+	}
+	if cfg.DeliverPolicy == deliverPolicyNew {
+		consumerConfig.DeliverPolicy = jetstream.DeliverNewPolicy
+	}
+	for _, subject := range cfg.Subjects {
+		consumerConfig.FilterSubjects = append(consumerConfig.FilterSubjects, subject.Subject)
+	}
+	return consumerConfig
+}
@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/natsreceiver/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id           component.ID
+		expected     component.Config
+		errorMessage string
+	}{
+		{
+			id: component.NewID(metadata.Type),
+			expected: &Config{
+				Endpoint: "nats://nats:4222",
+				Authentication: Authentication{
+					Username: "collector",
+					Password: "secret",
+				},
+				TLS: configtls.TLSClientSetting{
+					TLSSetting: configtls.TLSSetting{
+						CAFile: "ca.pem",
+					},
+				},
+				Stream:   "telemetry",
+				Consumer: "collector",
+				Subjects: []SubjectConfig{
+					{
+						Subject:            "telemetry.*.logs",
+						ResourceAttributes: []string{"", "service.name"},
+					},
+					{
+						Subject: "events.>",
+					},
+				},
+				Encoding:      "otlp_json",
+				DeliverPolicy: "new",
+				AckWait:       time.Minute,
+				MaxAckPending: 100,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "minimal"),
+			expected: &Config{
+				Endpoint: "nats://localhost:4222",
+				TLS: configtls.TLSClientSetting{
+					Insecure: true,
+				},
+				Stream:        "telemetry",
+				Encoding:      "otlp_proto",
+				DeliverPolicy: "all",
+				AckWait:       30 * time.Second,
+				MaxAckPending: 1000,
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "no_stream"),
+			errorMessage: "stream must be specified",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "bad_subject"),
+			errorMessage: `invalid subject "telemetry.>.logs": wildcard '>' must be the last token of the subject`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "overlapping_subjects"),
+			errorMessage: `subject "telemetry.>" overlaps with subject "telemetry.*.logs"`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "bad_consumer"),
+			errorMessage: `invalid consumer name "otelcol.logs"`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "bad_encoding"),
+			errorMessage: `unsupported encoding "jaeger_proto"`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "bad_deliver_policy"),
+			errorMessage: `deliver_policy must be either "all" or "new"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			if tt.errorMessage != "" {
+				assert.ErrorContains(t, component.ValidateConfig(cfg), tt.errorMessage)
+				return
+			}
+
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestConsumerConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Subjects = []SubjectConfig{{Subject: "telemetry.*.logs"}, {Subject: "events.>"}}
+	cfg.DeliverPolicy = deliverPolicyNew
+
+	assert.Equal(t, jetstream.ConsumerConfig{
+		Durable:        "collector",
+		DeliverPolicy:  jetstream.DeliverNewPolicy,
+		AckPolicy:      jetstream.AckExplicitPolicy,
+		AckWait:        30 * time.Second,
+		MaxAckPending:  1000,
+		FilterSubjects: []string{"telemetry.*.logs", "events.>"},
+	}, cfg.consumerConfig("collector"))
+}
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/natsreceiver"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/natsreceiver/internal/metadata"
+)
+
+const (
+	defaultEndpoint      = "nats://localhost:4222"
+	defaultAckWait       = 30 * time.Second
+	defaultMaxAckPending = 1000
+)
+
+// NewFactory creates a factory for the NATS receiver.
+func NewFactory() receiver.Factory {
+	return receiver.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		receiver.WithTraces(createTracesReceiver, metadata.TracesStability),
+		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability),
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Endpoint: defaultEndpoint,
+		TLS: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+		Encoding:      encodingOTLPProto,
+		DeliverPolicy: deliverPolicyAll,
+		AckWait:       defaultAckWait,
+		MaxAckPending: defaultMaxAckPending,
+	}
+}
+
+func createTracesReceiver(_ context.Context, settings receiver.CreateSettings, cfg component.Config, nextConsumer consumer.Traces) (receiver.Traces, error) {
+	return newTracesReceiver(cfg.(*Config), settings, nextConsumer)
+}
+
+func createMetricsReceiver(_ context.Context, settings receiver.CreateSettings, cfg component.Config, nextConsumer consumer.Metrics) (receiver.Metrics, error) {
+	return newMetricsReceiver(cfg.(*Config), settings, nextConsumer)
+}
+
+func createLogsReceiver(_ context.Context, settings receiver.CreateSettings, cfg component.Config, nextConsumer consumer.Logs) (receiver.Logs, error) {
+	return newLogsReceiver(cfg.(*Config), settings, nextConsumer)
+}
@@ -0,0 +1,305 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/natsreceiver"
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/consumerretry"
+)
+
+const (
+	transport = "nats"
+
+	defaultRetryInterval = time.Second
+	maxRetryInterval     = 30 * time.Second
+)
+
+// natsReceiver consumes the messages of one signal from a durable consumer.
+type natsReceiver struct {
+	config       *Config
+	settings     receiver.CreateSettings
+	logger       *zap.Logger
+	consumerName string
+	unmarshaler  *payloadUnmarshaler
+	obsrecv      *receiverhelper.ObsReport
+
+	next consumerretry.Consumers
+
+	// retryInterval is replaced in tests.
+	retryInterval time.Duration
+
+	conn   *nats.Conn
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newNATSReceiver(config *Config, settings receiver.CreateSettings, signal string) (*natsReceiver, error) {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             settings.ID,
+		Transport:              transport,
+		ReceiverCreateSettings: settings,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	consumerName := config.Consumer
+	if consumerName == "" {
+		// Receivers of different signals need their own consumers
+		consumerName = defaultConsumerName(settings.ID, signal)
+	}
+	return &natsReceiver{
+		config:        config,
+		settings:      settings,
+		logger:        settings.Logger,
+		consumerName:  consumerName,
+		unmarshaler:   unmarshalers[config.Encoding],
+		obsrecv:       obsrecv,
+		retryInterval: defaultRetryInterval,
+	}, nil
+}
+
+// defaultConsumerName returns a consumer name unique to the receiver and the signal.
+func defaultConsumerName(id component.ID, signal string) string {
+	name := "otelcol_" + id.Type().String()
+	if id.Name() != "" {
+		name += "_" + id.Name()
+	}
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(" .*>/\\", r) {
+			return '_'
+		}
+		return r
+	}, name+"_"+signal)
+}
+
+func newLogsReceiver(config *Config, settings receiver.CreateSettings, nextConsumer consumer.Logs) (*natsReceiver, error) {
+	r, err := newNATSReceiver(config, settings, "logs")
+	if err != nil {
+		return nil, err
+	}
+	r.next.Logs = nextConsumer
+	return r, nil
+}
+
+func newMetricsReceiver(config *Config, settings receiver.CreateSettings, nextConsumer consumer.Metrics) (*natsReceiver, error) {
+	r, err := newNATSReceiver(config, settings, "metrics")
+	if err != nil {
+		return nil, err
+	}
+	r.next.Metrics = nextConsumer
+	return r, nil
+}
+
+func newTracesReceiver(config *Config, settings receiver.CreateSettings, nextConsumer consumer.Traces) (*natsReceiver, error) {
+	r, err := newNATSReceiver(config, settings, "traces")
+	if err != nil {
+		return nil, err
+	}
+	r.next.Traces = nextConsumer
+	return r, nil
+}
+
+func (r *natsReceiver) Start(_ context.Context, _ component.Host) error {
+	options, err := r.config.connectOptions()
+	if err != nil {
+		return err
+	}
+	if r.conn, err = nats.Connect(r.config.Endpoint, options...); err != nil {
+		return err
+	}
+	js, err := jetstream.New(r.conn)
+	if err != nil {
+		return err
+	}
+
+	// Errors of the pipeline are retried until the receiver is shut down, while the ack
+	// wait of the message is extended. A message is only acked once consumed, it is naked
+	// when the receiver shuts down so that it is redelivered, and terminated on permanent
+	// errors so that the stream does not redeliver it.
+	r.next = consumerretry.NewUntilShutdown(r.retryInterval, r.logger, r.next)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		r.run(ctx, js)
+	}()
+	return nil
+}
+
+func (r *natsReceiver) Shutdown(_ context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	if r.conn != nil {
+		r.conn.Close()
+	}
+	return nil
+}
+
+// run consumes the stream until the receiver is shut down.
+func (r *natsReceiver) run(ctx context.Context, js jetstream.JetStream) {
+	interval := r.retryInterval
+	for {
+		consumed, err := r.consumeMessages(ctx, js)
+		if ctx.Err() != nil {
+			return
+		}
+		if consumed {
+			interval = r.retryInterval
+		}
+		r.logger.Warn("Failed to consume the NATS stream, retrying", zap.String("stream", r.config.Stream), zap.String("consumer", r.consumerName), zap.Duration("interval", interval), zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+		interval = min(2*interval, maxRetryInterval)
+	}
+}
+
+// consumeMessages creates or updates the durable consumer and processes its messages
+// until ctx is done or the consumer fails, and reports whether the consumer was created.
+func (r *natsReceiver) consumeMessages(ctx context.Context, js jetstream.JetStream) (bool, error) {
+	c, err := js.CreateOrUpdateConsumer(ctx, r.config.Stream, r.config.consumerConfig(r.consumerName))
+	if err != nil {
+		return false, err
+	}
+	messages, err := c.Messages()
+	if err != nil {
+		return false, err
+	}
+	defer messages.Stop()
+	stop := context.AfterFunc(ctx, messages.Stop)
+	defer stop()
+
+	for {
+		msg, err := messages.Next()
+		if err != nil {
+			return true, err
+		}
+		r.handle(ctx, msg)
+	}
+}
+
+// handle consumes a message and acknowledges it once the pipeline accepted it. The ack
+// wait of the message is extended while it is being consumed.
+func (r *natsReceiver) handle(ctx context.Context, msg jetstream.Msg) {
+	ticker := time.NewTicker(r.config.AckWait / 2)
+	defer ticker.Stop()
+	done := make(chan error, 1)
+	go func() {
+		done <- r.consume(ctx, msg)
+	}()
+
+	var err error
+	for consuming := true; consuming; {
+		select {
+		case <-ticker.C:
+			if err = msg.InProgress(); err != nil {
+				r.logger.Debug("Failed to extend the ack wait of the message", zap.String("subject", msg.Subject()), zap.Error(err))
+			}
+		case err = <-done:
+			consuming = false
+		}
+	}
+
+	switch {
+	case ctx.Err() != nil:
+		// The message is delivered again once the receiver resumes consuming.
+		err = msg.Nak()
+	case err != nil:
+		r.logger.Error("Failed to process message, dropping it", zap.String("subject", msg.Subject()), zap.Error(err))
+		err = msg.Term()
+	default:
+		err = msg.Ack()
+	}
+	if err != nil {
+		r.logger.Warn("Failed to acknowledge message, it will be delivered again", zap.String("subject", msg.Subject()), zap.Error(err))
+	}
+}
+
+// consume decodes the payload of a message, sets the resource attributes of its subject
+// and passes it to the next consumer.
+func (r *natsReceiver) consume(ctx context.Context, msg jetstream.Msg) error {
+	attributes := r.subjectAttributes(msg.Subject())
+	switch {
+	case r.next.Logs != nil:
+		ld, err := r.unmarshaler.logsUnmarshaler.UnmarshalLogs(msg.Data())
+		if err != nil {
+			return err
+		}
+		for i := 0; i < ld.ResourceLogs().Len(); i++ {
+			setAttributes(ld.ResourceLogs().At(i).Resource(), attributes)
+		}
+		ctx = r.obsrecv.StartLogsOp(ctx)
+		err = r.next.Logs.ConsumeLogs(ctx, ld)
+		r.obsrecv.EndLogsOp(ctx, r.config.Encoding, ld.LogRecordCount(), err)
+		return err
+	case r.next.Metrics != nil:
+		md, err := r.unmarshaler.metricsUnmarshaler.UnmarshalMetrics(msg.Data())
+		if err != nil {
+			return err
+		}
+		for i := 0; i < md.ResourceMetrics().Len(); i++ {
+			setAttributes(md.ResourceMetrics().At(i).Resource(), attributes)
+		}
+		ctx = r.obsrecv.StartMetricsOp(ctx)
+		err = r.next.Metrics.ConsumeMetrics(ctx, md)
+		r.obsrecv.EndMetricsOp(ctx, r.config.Encoding, md.DataPointCount(), err)
+		return err
+	default:
+		td, err := r.unmarshaler.tracesUnmarshaler.UnmarshalTraces(msg.Data())
+		if err != nil {
+			return err
+		}
+		for i := 0; i < td.ResourceSpans().Len(); i++ {
+			setAttributes(td.ResourceSpans().At(i).Resource(), attributes)
+		}
+		ctx = r.obsrecv.StartTracesOp(ctx)
+		err = r.next.Traces.ConsumeTraces(ctx, td)
+		r.obsrecv.EndTracesOp(ctx, r.config.Encoding, td.SpanCount(), err)
+		return err
+	}
+}
+
+// subjectAttributes returns the resource attributes set from the tokens of a subject,
+// using the configured subject it matches.
+func (r *natsReceiver) subjectAttributes(subject string) map[string]string {
+	for _, s := range r.config.Subjects {
+		if len(s.ResourceAttributes) == 0 || !matchSubject(s.Subject, subject) {
+			continue
+		}
+		attributes := make(map[string]string, len(s.ResourceAttributes))
+		for i, token := range strings.Split(subject, ".") {
+			if i < len(s.ResourceAttributes) && s.ResourceAttributes[i] != "" {
+				attributes[s.ResourceAttributes[i]] = token
+			}
+		}
+		return attributes
+	}
+	return nil
+}
+
+func setAttributes(resource pcommon.Resource, attributes map[string]string) {
+	for k, v := range attributes {
+		resource.Attributes().PutStr(k, v)
+	}
+}
@@ -0,0 +1,368 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsreceiver
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/natsreceiver/internal/metadata"
+)
+
+const testStream = "telemetry"
+
+// startServer starts an in-process NATS server with JetStream enabled.
+func startServer(t *testing.T) *server.Server {
+	s, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      server.RANDOM_PORT,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+		NoLog:     true,
+		NoSigs:    true,
+	})
+	require.NoError(t, err)
+	s.Start()
+	require.True(t, s.ReadyForConnections(5*time.Second))
+	t.Cleanup(func() {
+		s.Shutdown()
+		s.WaitForShutdown()
+	})
+	return s
+}
+
+// connect returns a JetStream client of the server.
+func connect(t *testing.T, s *server.Server) jetstream.JetStream {
+	conn, err := nats.Connect(s.ClientURL())
+	require.NoError(t, err)
+	t.Cleanup(conn.Close)
+	js, err := jetstream.New(conn)
+	require.NoError(t, err)
+	return js
+}
+
+func createStream(t *testing.T, js jetstream.JetStream) {
+	_, err := js.CreateStream(context.Background(), jetstream.StreamConfig{
+		Name:     testStream,
+		Subjects: []string{"telemetry.>"},
+	})
+	require.NoError(t, err)
+}
+
+func publish(t *testing.T, js jetstream.JetStream, subject string, data []byte) {
+	_, err := js.Publish(context.Background(), subject, data)
+	require.NoError(t, err)
+}
+
+func testConfig(s *server.Server) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = s.ClientURL()
+	cfg.Stream = testStream
+	return cfg
+}
+
+func startReceiver(t *testing.T, r *natsReceiver) {
+	r.retryInterval = time.Millisecond
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+}
+
+func testLogs(body string) plog.Logs {
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr(body)
+	return ld
+}
+
+func marshalLogs(t *testing.T, body string) []byte {
+	data, err := (&plog.ProtoMarshaler{}).MarshalLogs(testLogs(body))
+	require.NoError(t, err)
+	return data
+}
+
+func bodies(logs []plog.Logs) []string {
+	var result []string
+	for _, ld := range logs {
+		result = append(result, ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+	}
+	return result
+}
+
+// consumerInfo returns the state of the consumer of a receiver.
+func consumerInfo(t *testing.T, js jetstream.JetStream, r *natsReceiver) *jetstream.ConsumerInfo {
+	c, err := js.Consumer(context.Background(), testStream, r.consumerName)
+	require.NoError(t, err)
+	info, err := c.Info(context.Background())
+	require.NoError(t, err)
+	return info
+}
+
+func TestReceiveLogs(t *testing.T) {
+	s := startServer(t)
+	js := connect(t, s)
+	createStream(t, js)
+
+	cfg := testConfig(s)
+	cfg.Subjects = []SubjectConfig{
+		{Subject: "telemetry.*.logs", ResourceAttributes: []string{"", "service.name"}},
+		{Subject: "telemetry.events"},
+	}
+	sink := new(consumertest.LogsSink)
+	r, err := newLogsReceiver(cfg, receivertest.NewNopCreateSettings(), sink)
+	require.NoError(t, err)
+	startReceiver(t, r)
+	defer func() { require.NoError(t, r.Shutdown(context.Background())) }()
+
+	publish(t, js, "telemetry.checkout.logs", marshalLogs(t, "first"))
+	publish(t, js, "telemetry.events", marshalLogs(t, "second"))
+
+	require.Eventually(t, func() bool { return len(sink.AllLogs()) == 2 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"first", "second"}, bodies(sink.AllLogs()))
+
+	attributes := sink.AllLogs()[0].ResourceLogs().At(0).Resource().Attributes().AsRaw()
+	assert.Equal(t, map[string]any{"service.name": "checkout"}, attributes)
+	assert.Equal(t, 0, sink.AllLogs()[1].ResourceLogs().At(0).Resource().Attributes().Len())
+
+	require.Eventually(t, func() bool {
+		info := consumerInfo(t, js, r)
+		return info.NumAckPending == 0 && info.AckFloor.Stream == 2
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestReceiveJSON(t *testing.T) {
+	s := startServer(t)
+	js := connect(t, s)
+	createStream(t, js)
+
+	cfg := testConfig(s)
+	cfg.Encoding = encodingOTLPJSON
+	sink := new(consumertest.LogsSink)
+	r, err := newLogsReceiver(cfg, receivertest.NewNopCreateSettings(), sink)
+	require.NoError(t, err)
+	startReceiver(t, r)
+	defer func() { require.NoError(t, r.Shutdown(context.Background())) }()
+
+	data, err := (&plog.JSONMarshaler{}).MarshalLogs(testLogs("json"))
+	require.NoError(t, err)
+	publish(t, js, "telemetry.logs", data)
+
+	require.Eventually(t, func() bool { return len(sink.AllLogs()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"json"}, bodies(sink.AllLogs()))
+}
+
+func TestReceiveMetricsAndTraces(t *testing.T) {
+	s := startServer(t)
+	js := connect(t, s)
+	createStream(t, js)
+
+	metricsCfg := testConfig(s)
+	metricsCfg.Subjects = []SubjectConfig{{Subject: "telemetry.metrics"}}
+	metricsSink := new(consumertest.MetricsSink)
+	mr, err := newMetricsReceiver(metricsCfg, receivertest.NewNopCreateSettings(), metricsSink)
+	require.NoError(t, err)
+	startReceiver(t, mr)
+	defer func() { require.NoError(t, mr.Shutdown(context.Background())) }()
+
+	tracesCfg := testConfig(s)
+	tracesCfg.Subjects = []SubjectConfig{{Subject: "telemetry.traces"}}
+	tracesSink := new(consumertest.TracesSink)
+	tr, err := newTracesReceiver(tracesCfg, receivertest.NewNopCreateSettings(), tracesSink)
+	require.NoError(t, err)
+	startReceiver(t, tr)
+	defer func() { require.NoError(t, tr.Shutdown(context.Background())) }()
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+	data, err := (&pmetric.ProtoMarshaler{}).MarshalMetrics(md)
+	require.NoError(t, err)
+	publish(t, js, "telemetry.metrics", data)
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	data, err = (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+	publish(t, js, "telemetry.traces", data)
+
+	require.Eventually(t, func() bool {
+		return metricsSink.DataPointCount() == 1 && tracesSink.SpanCount() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestAcknowledgeAfterConsume(t *testing.T) {
+	s := startServer(t)
+	js := connect(t, s)
+	createStream(t, js)
+
+	consuming := make(chan struct{})
+	release := make(chan struct{})
+	next, err := consumer.NewLogs(func(context.Context, plog.Logs) error {
+		close(consuming)
+		<-release
+		return nil
+	})
+	require.NoError(t, err)
+
+	r, err := newLogsReceiver(testConfig(s), receivertest.NewNopCreateSettings(), next)
+	require.NoError(t, err)
+	startReceiver(t, r)
+	defer func() { require.NoError(t, r.Shutdown(context.Background())) }()
+
+	publish(t, js, "telemetry.logs", marshalLogs(t, "pending"))
+	<-consuming
+	assert.Equal(t, 1, consumerInfo(t, js, r).NumAckPending)
+
+	close(release)
+	require.Eventually(t, func() bool {
+		return consumerInfo(t, js, r).NumAckPending == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestExtendAckWait(t *testing.T) {
+	s := startServer(t)
+	js := connect(t, s)
+	createStream(t, js)
+
+	cfg := testConfig(s)
+	cfg.AckWait = 100 * time.Millisecond
+	var calls atomic.Int32
+	slow, err := consumer.NewLogs(func(context.Context, plog.Logs) error {
+		calls.Add(1)
+		time.Sleep(5 * cfg.AckWait)
+		return nil
+	})
+	require.NoError(t, err)
+
+	r, err := newLogsReceiver(cfg, receivertest.NewNopCreateSettings(), slow)
+	require.NoError(t, err)
+	startReceiver(t, r)
+	defer func() { require.NoError(t, r.Shutdown(context.Background())) }()
+
+	publish(t, js, "telemetry.logs", marshalLogs(t, "slow"))
+	require.Eventually(t, func() bool {
+		info := consumerInfo(t, js, r)
+		return info.AckFloor.Consumer == 1 && info.NumAckPending == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	// The message was not delivered again while it was being consumed
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, 0, consumerInfo(t, js, r).NumRedelivered)
+}
+
+func TestRedeliveryAfterShutdown(t *testing.T) {
+	s := startServer(t)
+	js := connect(t, s)
+	createStream(t, js)
+
+	cfg := testConfig(s)
+	cfg.Consumer = "collector"
+
+	consuming := make(chan struct{})
+	var once sync.Once
+	failing, err := consumer.NewLogs(func(context.Context, plog.Logs) error {
+		once.Do(func() { close(consuming) })
+		return errors.New("unavailable")
+	})
+	require.NoError(t, err)
+	r, err := newLogsReceiver(cfg, receivertest.NewNopCreateSettings(), failing)
+	require.NoError(t, err)
+	startReceiver(t, r)
+
+	publish(t, js, "telemetry.logs", marshalLogs(t, "redelivered"))
+	<-consuming
+	require.NoError(t, r.Shutdown(context.Background()))
+
+	sink := new(consumertest.LogsSink)
+	r, err = newLogsReceiver(cfg, receivertest.NewNopCreateSettings(), sink)
+	require.NoError(t, err)
+	startReceiver(t, r)
+	defer func() { require.NoError(t, r.Shutdown(context.Background())) }()
+
+	require.Eventually(t, func() bool { return len(sink.AllLogs()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"redelivered"}, bodies(sink.AllLogs()))
+}
+
+func TestConsumerErrors(t *testing.T) {
+	s := startServer(t)
+	js := connect(t, s)
+	createStream(t, js)
+
+	var mu sync.Mutex
+	var consumed []string
+	failures := 0
+	next, err := consumer.NewLogs(func(_ context.Context, ld plog.Logs) error {
+		mu.Lock()
+		defer mu.Unlock()
+		body := bodies([]plog.Logs{ld})[0]
+		switch {
+		case body == "permanent":
+			return consumererror.NewPermanent(errors.New("rejected"))
+		case failures < 2:
+			failures++
+			return errors.New("try again")
+		}
+		consumed = append(consumed, body)
+		return nil
+	})
+	require.NoError(t, err)
+
+	r, err := newLogsReceiver(testConfig(s), receivertest.NewNopCreateSettings(), next)
+	require.NoError(t, err)
+	startReceiver(t, r)
+	defer func() { require.NoError(t, r.Shutdown(context.Background())) }()
+
+	publish(t, js, "telemetry.logs", []byte("not protobuf"))
+	publish(t, js, "telemetry.logs", marshalLogs(t, "permanent"))
+	publish(t, js, "telemetry.logs", marshalLogs(t, "transient"))
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(consumed) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"transient"}, consumed)
+	assert.Equal(t, 2, failures)
+
+	// Terminated messages are not delivered again
+	require.Eventually(t, func() bool {
+		info := consumerInfo(t, js, r)
+		return info.NumAckPending == 0 && info.NumRedelivered == 0 && info.AckFloor.Stream == 3
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestWaitForStream(t *testing.T) {
+	s := startServer(t)
+	js := connect(t, s)
+
+	sink := new(consumertest.LogsSink)
+	r, err := newLogsReceiver(testConfig(s), receivertest.NewNopCreateSettings(), sink)
+	require.NoError(t, err)
+	startReceiver(t, r)
+	defer func() { require.NoError(t, r.Shutdown(context.Background())) }()
+
+	createStream(t, js)
+	publish(t, js, "telemetry.logs", marshalLogs(t, "late"))
+
+	require.Eventually(t, func() bool { return len(sink.AllLogs()) == 1 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestDefaultConsumerName(t *testing.T) {
+	assert.Equal(t, "otelcol_nats_logs", defaultConsumerName(component.NewID(metadata.Type), "logs"))
+	assert.Equal(t, "otelcol_nats_edge_1_0_metrics", defaultConsumerName(component.NewIDWithName(metadata.Type, "edge.1/0"), "metrics"))
+}
@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/natsreceiver"
+
+import (
+	"errors"
+	"strings"
+)
+
+// validateSubject checks a subject is valid for filtering a consumer.
+func validateSubject(subject string) error {
+	if subject == "" {
+		return errors.New("subject must not be empty")
+	}
+	if strings.ContainsAny(subject, " \t\r\n") {
+		return errors.New("subject must not contain whitespaces")
+	}
+	tokens := strings.Split(subject, ".")
+	for i, token := range tokens {
+		switch {
+		case token == "":
+			return errors.New("subject must not contain empty tokens")
+		case token == ">" && i != len(tokens)-1:
+			return errors.New("wildcard '>' must be the last token of the subject")
+		case token != "*" && token != ">" && strings.ContainsAny(token, "*>"):
+			return errors.New("wildcards must occupy a whole token of the subject")
+		}
+	}
+	return nil
+}
+
+// matchSubject reports whether a subject matches a subject with wildcards.
+func matchSubject(filter string, subject string) bool {
+	filterTokens := strings.Split(filter, ".")
+	tokens := strings.Split(subject, ".")
+	for i, filterToken := range filterTokens {
+		switch {
+		case filterToken == ">":
+			return len(tokens) > i
+		case i >= len(tokens):
+			return false
+		case filterToken != "*" && filterToken != tokens[i]:
+			return false
+		}
+	}
+	return len(tokens) == len(filterTokens)
+}
+
+// overlapSubjects reports whether a subject can match both subjects with wildcards.
+func overlapSubjects(a string, b string) bool {
+	aTokens := strings.Split(a, ".")
+	bTokens := strings.Split(b, ".")
+	for i := 0; i < len(aTokens) && i < len(bTokens); i++ {
+		switch {
+		case aTokens[i] == ">" || bTokens[i] == ">":
+			return true
+		case aTokens[i] != "*" && bTokens[i] != "*" && aTokens[i] != bTokens[i]:
+			return false
+		}
+	}
+	return len(aTokens) == len(bTokens)
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSubject(t *testing.T) {
+	testCases := []struct {
+		subject  string
+		expected string
+	}{
+		{subject: "telemetry.logs"},
+		{subject: "telemetry.*.logs"},
+		{subject: "telemetry.>"},
+		{subject: ">"},
+		{subject: "", expected: "subject must not be empty"},
+		{subject: "telemetry logs", expected: "subject must not contain whitespaces"},
+		{subject: "telemetry..logs", expected: "subject must not contain empty tokens"},
+		{subject: "telemetry.", expected: "subject must not contain empty tokens"},
+		{subject: "telemetry.>.logs", expected: "wildcard '>' must be the last token of the subject"},
+		{subject: "telemetry.a*", expected: "wildcards must occupy a whole token of the subject"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.subject, func(t *testing.T) {
+			err := validateSubject(tc.subject)
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expected)
+			}
+		})
+	}
+}
+
+func TestMatchSubject(t *testing.T) {
+	testCases := []struct {
+		filter   string
+		subject  string
+		expected bool
+	}{
+		{filter: "telemetry.a.logs", subject: "telemetry.a.logs", expected: true},
+		{filter: "telemetry.a.logs", subject: "telemetry.b.logs"},
+		{filter: "telemetry.*.logs", subject: "telemetry.a.logs", expected: true},
+		{filter: "telemetry.*.logs", subject: "telemetry.a.b.logs"},
+		{filter: "telemetry.*", subject: "telemetry"},
+		{filter: "telemetry.>", subject: "telemetry"},
+		{filter: "telemetry.>", subject: "telemetry.a", expected: true},
+		{filter: "telemetry.>", subject: "telemetry.a.b", expected: true},
+		{filter: ">", subject: "telemetry.a", expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.filter+" "+tc.subject, func(t *testing.T) {
+			assert.Equal(t, tc.expected, matchSubject(tc.filter, tc.subject))
+		})
+	}
+}
+
+func TestOverlapSubjects(t *testing.T) {
+	testCases := []struct {
+		a        string
+		b        string
+		expected bool
+	}{
+		{a: "telemetry.logs", b: "telemetry.logs", expected: true},
+		{a: "telemetry.logs", b: "telemetry.metrics"},
+		{a: "telemetry.*.logs", b: "telemetry.checkout.logs", expected: true},
+		{a: "telemetry.*.logs", b: "telemetry.*.metrics"},
+		{a: "telemetry.*", b: "telemetry.*.logs"},
+		{a: "telemetry.>", b: "telemetry.*.logs", expected: true},
+		{a: "telemetry.>", b: "events.>"},
+		{a: ">", b: "events.logs", expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.a+" "+tc.b, func(t *testing.T) {
+			assert.Equal(t, tc.expected, overlapSubjects(tc.a, tc.b))
+			assert.Equal(t, tc.expected, overlapSubjects(tc.b, tc.a))
+		})
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/natsreceiver"
+
+import (
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	encodingOTLPProto = "otlp_proto"
+	encodingOTLPJSON  = "otlp_json"
+)
+
+// payloadUnmarshaler decodes the payloads of the messages.
+type payloadUnmarshaler struct {
+	logsUnmarshaler    plog.Unmarshaler
+	metricsUnmarshaler pmetric.Unmarshaler
+	tracesUnmarshaler  ptrace.Unmarshaler
+}
+
+// unmarshalers are the supported encodings.
+var unmarshalers = map[string]*payloadUnmarshaler{
+	encodingOTLPProto: {
+		logsUnmarshaler:    &plog.ProtoUnmarshaler{},
+		metricsUnmarshaler: &pmetric.ProtoUnmarshaler{},
+		tracesUnmarshaler:  &ptrace.ProtoUnmarshaler{},
+	},
+	encodingOTLPJSON: {
+		logsUnmarshaler:    &plog.JSONUnmarshaler{},
+		metricsUnmarshaler: &pmetric.JSONUnmarshaler{},
+		tracesUnmarshaler:  &ptrace.JSONUnmarshaler{},
+	},
+}
@@ -5,17 +5,22 @@ package otlpjsonfilereceiver // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"context"
+	"errors"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/emit"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/otlpjsonfilereceiver/internal/metadata"
 )
 
@@ -36,6 +41,45 @@ func NewFactory() receiver.Factory {
 type Config struct {
 	fileconsumer.Config `mapstructure:",squash"`
 	StorageID           *component.ID `mapstructure:"storage"`
+	// Replay replays the files from the beginning with the pacing of their timestamps,
+	// instead of following them.
+	Replay *ReplayConfig `mapstructure:"replay"`
+}
+
+// Unmarshal sets the default replay settings when replay is configured.
+func (c *Config) Unmarshal(componentParser *confmap.Conf) error {
+	if componentParser == nil {
+		// Nothing to do if there is no config given.
+		return nil
+	}
+
+	if componentParser.IsSet("replay") {
+		c.Replay = newReplayConfig()
+	}
+
+	return componentParser.Unmarshal(c)
+}
+
+// Validate checks the replay settings.
+func (c *Config) Validate() error {
+	if c.Replay == nil {
+		return nil
+	}
+	if c.Replay.Speed < 0 {
+		return errors.New("replay speed must not be negative")
+	}
+	if c.StorageID != nil {
+		return errors.New("storage is not supported when replaying files")
+	}
+	return nil
+}
+
+// buildInput builds the file consumer, or the replay input when replay is configured.
+func (c *Config) buildInput(logger *zap.SugaredLogger, r *replayer, emit emit.Callback) (input, error) {
+	if c.Replay == nil {
+		return c.Config.Build(logger, emit)
+	}
+	return c.buildReplayInput(logger, r, emit)
 }
 
 func createDefaultConfig() component.Config {
@@ -44,8 +88,14 @@ func createDefaultConfig() component.Config {
 	}
 }
 
+// input reads the tokens of the files.
+type input interface {
+	Start(persister operator.Persister) error
+	Stop() error
+}
+
 type otlpjsonfilereceiver struct {
-	input     *fileconsumer.Manager
+	input     input
 	id        component.ID
 	storageID *component.ID
 }
@@ -73,7 +123,11 @@ func createLogsReceiver(_ context.Context, settings receiver.CreateSettings, con
 		return nil, err
 	}
 	cfg := configuration.(*Config)
-	input, err := cfg.Config.Build(settings.Logger.Sugar(), func(ctx context.Context, token []byte, _ map[string]any) error {
+	var r *replayer
+	if cfg.Replay != nil {
+		r = newReplayer(cfg.Replay)
+	}
+	input, err := cfg.buildInput(settings.Logger.Sugar(), r, func(ctx context.Context, token []byte, _ map[string]any) error {
 		ctx = obsrecv.StartLogsOp(ctx)
 		var l plog.Logs
 		l, err = logsUnmarshaler.UnmarshalLogs(token)
@@ -82,7 +136,12 @@ func createLogsReceiver(_ context.Context, settings receiver.CreateSettings, con
 		} else {
 			logRecordCount := l.LogRecordCount()
 			if logRecordCount != 0 {
-				err = logs.ConsumeLogs(ctx, l)
+				if r != nil {
+					err = r.replayLogs(ctx, l)
+				}
+				if err == nil {
+					err = logs.ConsumeLogs(ctx, l)
+				}
 			}
 			obsrecv.EndLogsOp(ctx, metadata.Type.String(), logRecordCount, err)
 		}
@@ -106,7 +165,11 @@ func createMetricsReceiver(_ context.Context, settings receiver.CreateSettings,
 		return nil, err
 	}
 	cfg := configuration.(*Config)
-	input, err := cfg.Config.Build(settings.Logger.Sugar(), func(ctx context.Context, token []byte, _ map[string]any) error {
+	var r *replayer
+	if cfg.Replay != nil {
+		r = newReplayer(cfg.Replay)
+	}
+	input, err := cfg.buildInput(settings.Logger.Sugar(), r, func(ctx context.Context, token []byte, _ map[string]any) error {
 		ctx = obsrecv.StartMetricsOp(ctx)
 		var m pmetric.Metrics
 		m, err = metricsUnmarshaler.UnmarshalMetrics(token)
@@ -114,7 +177,12 @@ func createMetricsReceiver(_ context.Context, settings receiver.CreateSettings,
 			obsrecv.EndMetricsOp(ctx, metadata.Type.String(), 0, err)
 		} else {
 			if m.ResourceMetrics().Len() != 0 {
-				err = metrics.ConsumeMetrics(ctx, m)
+				if r != nil {
+					err = r.replayMetrics(ctx, m)
+				}
+				if err == nil {
+					err = metrics.ConsumeMetrics(ctx, m)
+				}
 			}
 			obsrecv.EndMetricsOp(ctx, metadata.Type.String(), m.MetricCount(), err)
 		}
@@ -138,7 +206,11 @@ func createTracesReceiver(_ context.Context, settings receiver.CreateSettings, c
 		return nil, err
 	}
 	cfg := configuration.(*Config)
-	input, err := cfg.Config.Build(settings.Logger.Sugar(), func(ctx context.Context, token []byte, _ map[string]any) error {
+	var r *replayer
+	if cfg.Replay != nil {
+		r = newReplayer(cfg.Replay)
+	}
+	input, err := cfg.buildInput(settings.Logger.Sugar(), r, func(ctx context.Context, token []byte, _ map[string]any) error {
 		ctx = obsrecv.StartTracesOp(ctx)
 		var t ptrace.Traces
 		t, err = tracesUnmarshaler.UnmarshalTraces(token)
@@ -146,7 +218,12 @@ func createTracesReceiver(_ context.Context, settings receiver.CreateSettings, c
 			obsrecv.EndTracesOp(ctx, metadata.Type.String(), 0, err)
 		} else {
 			if t.ResourceSpans().Len() != 0 {
-				err = traces.ConsumeTraces(ctx, t)
+				if r != nil {
+					err = r.replayTraces(ctx, t)
+				}
+				if err == nil {
+					err = traces.ConsumeTraces(ctx, t)
+				}
 			}
 			obsrecv.EndTracesOp(ctx, metadata.Type.String(), t.SpanCount(), err)
 		}
@@ -16,6 +16,7 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -116,6 +117,50 @@ func TestFileLogsReceiver(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestFileLogsReceiverReplay(t *testing.T) {
+	tempFolder := t.TempDir()
+	factory := NewFactory()
+	cfg := createDefaultConfig().(*Config)
+	cfg.Config.Include = []string{filepath.Join(tempFolder, "*")}
+	cfg.Replay = &ReplayConfig{
+		Speed:           10,
+		ShiftTimestamps: true,
+		Loop:            true,
+	}
+
+	start := time.Now().Add(-24 * time.Hour)
+	marshaler := &plog.JSONMarshaler{}
+	var b []byte
+	for i := 0; i < 2; i++ {
+		ld := testdata.GenerateLogsOneLogRecord()
+		ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).SetTimestamp(pcommon.NewTimestampFromTime(start.Add(time.Duration(i) * time.Second)))
+		payload, err := marshaler.MarshalLogs(ld)
+		require.NoError(t, err)
+		b = append(b, payload...)
+		b = append(b, '\n')
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(tempFolder, "logs.json"), b, 0600))
+
+	sink := new(consumertest.LogsSink)
+	receiver, err := factory.CreateLogsReceiver(context.Background(), receivertest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	before := time.Now()
+	require.NoError(t, receiver.Start(context.Background(), nil))
+
+	// The files are replayed again once they were all replayed
+	require.Eventually(t, func() bool { return sink.LogRecordCount() >= 4 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, receiver.Shutdown(context.Background()))
+
+	timestamps := make([]time.Time, 0, len(sink.AllLogs()))
+	for _, ld := range sink.AllLogs() {
+		timestamps = append(timestamps, ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Timestamp().AsTime())
+	}
+	assert.WithinDuration(t, before, timestamps[0], time.Second)
+	// The offsets are scaled by the speed of the replay
+	assert.WithinDuration(t, timestamps[0].Add(100*time.Millisecond), timestamps[1], 50*time.Millisecond)
+	assert.True(t, timestamps[2].After(timestamps[1]))
+}
+
 func testdataConfigYamlAsMap() *Config {
 	return &Config{
 		Config: fileconsumer.Config{
@@ -153,6 +198,65 @@ func TestLoadConfig(t *testing.T) {
 	assert.Equal(t, testdataConfigYamlAsMap(), cfg)
 }
 
+func TestLoadReplayConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+
+	sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "replay").String())
+	require.NoError(t, err)
+	require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+	expected := createDefaultConfig().(*Config)
+	expected.Include = []string{"/var/log/*.log"}
+	expected.Replay = &ReplayConfig{
+		Speed:           2,
+		ShiftTimestamps: true,
+		Loop:            true,
+	}
+	assert.Equal(t, expected, cfg)
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
+func TestValidateReplayConfig(t *testing.T) {
+	storageID := component.MustNewID("file_storage")
+	testCases := []struct {
+		name          string
+		config        func(cfg *Config)
+		expectedError string
+	}{
+		{
+			name:   "valid",
+			config: func(_ *Config) {},
+		},
+		{
+			name:          "negative speed",
+			config:        func(cfg *Config) { cfg.Replay.Speed = -1 },
+			expectedError: "replay speed must not be negative",
+		},
+		{
+			name:          "storage",
+			config:        func(cfg *Config) { cfg.StorageID = &storageID },
+			expectedError: "storage is not supported when replaying files",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Replay = newReplayConfig()
+			tc.config(cfg)
+			err := cfg.Validate()
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
 func TestFileMixedSignals(t *testing.T) {
 	tempFolder := t.TempDir()
 	factory := NewFactory()
@@ -0,0 +1,374 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpjsonfilereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/otlpjsonfilereceiver"
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/emit"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/matcher"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+)
+
+// ReplayConfig configures the replay of the files.
+type ReplayConfig struct {
+	// Speed is the multiplier applied to the original pacing of the payloads.
+	// 0 replays the payloads as fast as possible.
+	Speed float64 `mapstructure:"speed"`
+	// ShiftTimestamps shifts the timestamps of the payloads to the time they are replayed,
+	// preserving their offsets.
+	ShiftTimestamps bool `mapstructure:"shift_timestamps"`
+	// Loop replays the files again once they were all replayed.
+	Loop bool `mapstructure:"loop"`
+}
+
+func newReplayConfig() *ReplayConfig {
+	return &ReplayConfig{
+		Speed:           1,
+		ShiftTimestamps: true,
+	}
+}
+
+// replayer paces the payloads by their timestamps and shifts them to the time they are replayed.
+type replayer struct {
+	speed float64
+	shift bool
+
+	mu sync.Mutex
+	// start is the time the first payload was replayed at, and first its timestamp
+	start time.Time
+	first time.Time
+}
+
+func newReplayer(cfg *ReplayConfig) *replayer {
+	return &replayer{speed: cfg.Speed, shift: cfg.ShiftTimestamps}
+}
+
+// reset starts the pacing over from the next payload.
+func (r *replayer) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.start = time.Time{}
+}
+
+// wait blocks until the payload with the timestamp is due, and returns the offset
+// between the time it is replayed at and its timestamp.
+func (r *replayer) wait(ctx context.Context, ts pcommon.Timestamp) (time.Duration, error) {
+	if ts == 0 {
+		return 0, nil
+	}
+	original := ts.AsTime()
+
+	r.mu.Lock()
+	if r.start.IsZero() {
+		r.start = time.Now()
+		r.first = original
+	}
+	elapsed := original.Sub(r.first)
+	if r.speed > 0 {
+		elapsed = time.Duration(float64(elapsed) / r.speed)
+	}
+	due := r.start.Add(elapsed)
+	r.mu.Unlock()
+
+	if d := time.Until(due); r.speed > 0 && d > 0 {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return due.Sub(original), nil
+}
+
+func (r *replayer) replayLogs(ctx context.Context, l plog.Logs) error {
+	var earliest pcommon.Timestamp
+	forEachLogRecord(l, func(lr plog.LogRecord) {
+		ts := lr.Timestamp()
+		if ts == 0 {
+			ts = lr.ObservedTimestamp()
+		}
+		earliest = earlier(earliest, ts)
+	})
+
+	offset, err := r.wait(ctx, earliest)
+	if err != nil || !r.shift || offset == 0 {
+		return err
+	}
+	forEachLogRecord(l, func(lr plog.LogRecord) {
+		lr.SetTimestamp(shift(lr.Timestamp(), offset))
+		lr.SetObservedTimestamp(shift(lr.ObservedTimestamp(), offset))
+	})
+	return nil
+}
+
+func (r *replayer) replayMetrics(ctx context.Context, m pmetric.Metrics) error {
+	var earliest pcommon.Timestamp
+	forEachDataPoint(m, func(dp dataPoint, _ pmetric.ExemplarSlice) {
+		earliest = earlier(earliest, dp.Timestamp())
+	})
+
+	offset, err := r.wait(ctx, earliest)
+	if err != nil || !r.shift || offset == 0 {
+		return err
+	}
+	forEachDataPoint(m, func(dp dataPoint, exemplars pmetric.ExemplarSlice) {
+		dp.SetStartTimestamp(shift(dp.StartTimestamp(), offset))
+		dp.SetTimestamp(shift(dp.Timestamp(), offset))
+		for i := 0; i < exemplars.Len(); i++ {
+			exemplars.At(i).SetTimestamp(shift(exemplars.At(i).Timestamp(), offset))
+		}
+	})
+	return nil
+}
+
+func (r *replayer) replayTraces(ctx context.Context, t ptrace.Traces) error {
+	var earliest pcommon.Timestamp
+	forEachSpan(t, func(span ptrace.Span) {
+		earliest = earlier(earliest, span.StartTimestamp())
+	})
+
+	offset, err := r.wait(ctx, earliest)
+	if err != nil || !r.shift || offset == 0 {
+		return err
+	}
+	forEachSpan(t, func(span ptrace.Span) {
+		span.SetStartTimestamp(shift(span.StartTimestamp(), offset))
+		span.SetEndTimestamp(shift(span.EndTimestamp(), offset))
+		for i := 0; i < span.Events().Len(); i++ {
+			event := span.Events().At(i)
+			event.SetTimestamp(shift(event.Timestamp(), offset))
+		}
+	})
+	return nil
+}
+
+// earlier returns the earliest of the timestamps which are set.
+func earlier(a, b pcommon.Timestamp) pcommon.Timestamp {
+	if a == 0 || (b != 0 && b < a) {
+		return b
+	}
+	return a
+}
+
+// shift shifts a timestamp by the offset, unless it is not set.
+func shift(ts pcommon.Timestamp, offset time.Duration) pcommon.Timestamp {
+	if ts == 0 {
+		return 0
+	}
+	return pcommon.NewTimestampFromTime(ts.AsTime().Add(offset))
+}
+
+func forEachLogRecord(l plog.Logs, f func(plog.LogRecord)) {
+	for i := 0; i < l.ResourceLogs().Len(); i++ {
+		sls := l.ResourceLogs().At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				f(lrs.At(k))
+			}
+		}
+	}
+}
+
+func forEachSpan(t ptrace.Traces, f func(ptrace.Span)) {
+	for i := 0; i < t.ResourceSpans().Len(); i++ {
+		sss := t.ResourceSpans().At(i).ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			spans := sss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				f(spans.At(k))
+			}
+		}
+	}
+}
+
+// dataPoint is implemented by the data points of all the metric types.
+type dataPoint interface {
+	StartTimestamp() pcommon.Timestamp
+	Timestamp() pcommon.Timestamp
+	SetStartTimestamp(pcommon.Timestamp)
+	SetTimestamp(pcommon.Timestamp)
+}
+
+// forEachDataPoint calls f with every data point and its exemplars.
+func forEachDataPoint(m pmetric.Metrics, f func(dataPoint, pmetric.ExemplarSlice)) {
+	for i := 0; i < m.ResourceMetrics().Len(); i++ {
+		sms := m.ResourceMetrics().At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			metrics := sms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				switch metric.Type() {
+				case pmetric.MetricTypeGauge:
+					dps := metric.Gauge().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						f(dps.At(l), dps.At(l).Exemplars())
+					}
+				case pmetric.MetricTypeSum:
+					dps := metric.Sum().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						f(dps.At(l), dps.At(l).Exemplars())
+					}
+				case pmetric.MetricTypeHistogram:
+					dps := metric.Histogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						f(dps.At(l), dps.At(l).Exemplars())
+					}
+				case pmetric.MetricTypeExponentialHistogram:
+					dps := metric.ExponentialHistogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						f(dps.At(l), dps.At(l).Exemplars())
+					}
+				case pmetric.MetricTypeSummary:
+					dps := metric.Summary().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						f(dps.At(l), pmetric.NewExemplarSlice())
+					}
+				}
+			}
+		}
+	}
+}
+
+// replayInput reads the files from the beginning, one after the other, instead of
+// following them like the file consumer does.
+type replayInput struct {
+	logger       *zap.SugaredLogger
+	matcher      *matcher.Matcher
+	pollInterval time.Duration
+	maxLogSize   int
+	loop         bool
+	replayer     *replayer
+	emit         emit.Callback
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func (c *Config) buildReplayInput(logger *zap.SugaredLogger, r *replayer, emit emit.Callback) (*replayInput, error) {
+	fileMatcher, err := matcher.New(c.Criteria)
+	if err != nil {
+		return nil, err
+	}
+	return &replayInput{
+		logger:       logger.With("component", "replay"),
+		matcher:      fileMatcher,
+		pollInterval: c.PollInterval,
+		maxLogSize:   int(c.MaxLogSize),
+		loop:         c.Replay.Loop,
+		replayer:     r,
+		emit:         emit,
+	}, nil
+}
+
+func (r *replayInput) Start(_ operator.Persister) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		r.run(ctx)
+	}()
+	return nil
+}
+
+func (r *replayInput) run(ctx context.Context) {
+	for {
+		paths, err := r.matcher.MatchFiles()
+		if len(paths) == 0 {
+			// Wait for the files to replay
+			r.logger.Debugw("No files to replay", zap.Error(err))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(r.pollInterval):
+				continue
+			}
+		}
+		if err != nil {
+			r.logger.Warnf("finding files: %v", err)
+		}
+
+		for _, path := range paths {
+			r.replayFile(ctx, path)
+			if ctx.Err() != nil {
+				return
+			}
+		}
+		if !r.loop {
+			r.logger.Infow("Replay completed", zap.Strings("paths", paths))
+			return
+		}
+		r.replayer.reset()
+
+		// Wait before replaying the files again, so that an empty or quickly
+		// replayed set of files does not keep the receiver busy
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.pollInterval):
+		}
+	}
+}
+
+func (r *replayInput) replayFile(ctx context.Context, path string) {
+	file, err := os.Open(path) // #nosec - operator must read in files defined by user
+	if err != nil {
+		r.logger.Errorw("Failed to open file", zap.String("path", path), zap.Error(err))
+		return
+	}
+	defer file.Close()
+
+	// Lines longer than max_log_size are skipped rather than aborting the rest
+	// of the file, since a truncated payload cannot be unmarshaled
+	reader := bufio.NewReaderSize(file, r.maxLogSize+1)
+	for {
+		line, err := reader.ReadSlice('\n')
+		if errors.Is(err, bufio.ErrBufferFull) {
+			r.logger.Warnw("Skipping line exceeding max_log_size", zap.String("path", path), zap.Int("max_log_size", r.maxLogSize))
+			for errors.Is(err, bufio.ErrBufferFull) {
+				_, err = reader.ReadSlice('\n')
+			}
+			line = nil
+		}
+		if line = bytes.TrimRight(line, "\r\n"); len(line) > 0 {
+			if emitErr := r.emit(ctx, line, nil); emitErr != nil {
+				r.logger.Errorw("Failed to emit token", zap.String("path", path), zap.Error(emitErr))
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				r.logger.Errorw("Failed to read file", zap.String("path", path), zap.Error(err))
+			}
+			return
+		}
+	}
+}
+
+func (r *replayInput) Stop() error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	return nil
+}
@@ -0,0 +1,216 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpjsonfilereceiver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+var replayStart = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func TestReplayerPacing(t *testing.T) {
+	r := newReplayer(&ReplayConfig{Speed: 10})
+
+	before := time.Now()
+	first, err := r.wait(context.Background(), pcommon.NewTimestampFromTime(replayStart))
+	require.NoError(t, err)
+	second, err := r.wait(context.Background(), pcommon.NewTimestampFromTime(replayStart.Add(time.Second)))
+	require.NoError(t, err)
+
+	// The second payload is due a tenth of its original offset after the first one
+	assert.GreaterOrEqual(t, time.Since(before), 100*time.Millisecond)
+	assert.Equal(t, first-900*time.Millisecond, second)
+	assert.WithinDuration(t, before, replayStart.Add(first), 50*time.Millisecond)
+}
+
+func TestReplayerUnthrottled(t *testing.T) {
+	r := newReplayer(&ReplayConfig{Speed: 0})
+
+	before := time.Now()
+	first, err := r.wait(context.Background(), pcommon.NewTimestampFromTime(replayStart))
+	require.NoError(t, err)
+	second, err := r.wait(context.Background(), pcommon.NewTimestampFromTime(replayStart.Add(time.Hour)))
+	require.NoError(t, err)
+
+	assert.Less(t, time.Since(before), time.Second)
+	assert.Equal(t, first, second)
+
+	offset, err := r.wait(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Zero(t, offset)
+}
+
+func TestReplayerReset(t *testing.T) {
+	r := newReplayer(&ReplayConfig{Speed: 1})
+	_, err := r.wait(context.Background(), pcommon.NewTimestampFromTime(replayStart))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = r.wait(ctx, pcommon.NewTimestampFromTime(replayStart.Add(time.Hour)))
+	require.ErrorIs(t, err, context.Canceled)
+
+	r.reset()
+	_, err = r.wait(ctx, pcommon.NewTimestampFromTime(replayStart.Add(time.Hour)))
+	require.NoError(t, err)
+}
+
+func TestReplayLogs(t *testing.T) {
+	l := plog.NewLogs()
+	lrs := l.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	lrs.AppendEmpty().SetTimestamp(pcommon.NewTimestampFromTime(replayStart.Add(time.Second)))
+	lr := lrs.AppendEmpty()
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(replayStart))
+
+	before := time.Now()
+	require.NoError(t, newReplayer(newReplayConfig()).replayLogs(context.Background(), l))
+
+	assert.WithinDuration(t, before.Add(time.Second), lrs.At(0).Timestamp().AsTime(), 50*time.Millisecond)
+	assert.Zero(t, lrs.At(0).ObservedTimestamp())
+	assert.Zero(t, lrs.At(1).Timestamp())
+	assert.WithinDuration(t, before, lrs.At(1).ObservedTimestamp().AsTime(), 50*time.Millisecond)
+}
+
+func TestReplayMetrics(t *testing.T) {
+	m := pmetric.NewMetrics()
+	metrics := m.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	sum := metrics.AppendEmpty().SetEmptySum().DataPoints().AppendEmpty()
+	sum.SetStartTimestamp(pcommon.NewTimestampFromTime(replayStart.Add(-time.Hour)))
+	sum.SetTimestamp(pcommon.NewTimestampFromTime(replayStart))
+	histogram := metrics.AppendEmpty().SetEmptyHistogram().DataPoints().AppendEmpty()
+	histogram.SetTimestamp(pcommon.NewTimestampFromTime(replayStart.Add(time.Second)))
+	histogram.Exemplars().AppendEmpty().SetTimestamp(pcommon.NewTimestampFromTime(replayStart.Add(500 * time.Millisecond)))
+
+	before := time.Now()
+	require.NoError(t, newReplayer(newReplayConfig()).replayMetrics(context.Background(), m))
+
+	assert.WithinDuration(t, before, sum.Timestamp().AsTime(), 50*time.Millisecond)
+	assert.Equal(t, time.Hour, sum.Timestamp().AsTime().Sub(sum.StartTimestamp().AsTime()))
+	assert.Equal(t, time.Second, histogram.Timestamp().AsTime().Sub(sum.Timestamp().AsTime()))
+	assert.Zero(t, histogram.StartTimestamp())
+	assert.Equal(t, 500*time.Millisecond, histogram.Timestamp().AsTime().Sub(histogram.Exemplars().At(0).Timestamp().AsTime()))
+}
+
+func TestReplayTraces(t *testing.T) {
+	td := ptrace.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetStartTimestamp(pcommon.NewTimestampFromTime(replayStart))
+	span.SetEndTimestamp(pcommon.NewTimestampFromTime(replayStart.Add(time.Second)))
+	span.Events().AppendEmpty().SetTimestamp(pcommon.NewTimestampFromTime(replayStart.Add(500 * time.Millisecond)))
+
+	before := time.Now()
+	require.NoError(t, newReplayer(newReplayConfig()).replayTraces(context.Background(), td))
+
+	assert.WithinDuration(t, before, span.StartTimestamp().AsTime(), 50*time.Millisecond)
+	assert.Equal(t, time.Second, span.EndTimestamp().AsTime().Sub(span.StartTimestamp().AsTime()))
+	assert.Equal(t, 500*time.Millisecond, span.Events().At(0).Timestamp().AsTime().Sub(span.StartTimestamp().AsTime()))
+}
+
+func TestReplayNoShift(t *testing.T) {
+	td := ptrace.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetStartTimestamp(pcommon.NewTimestampFromTime(replayStart))
+
+	require.NoError(t, newReplayer(&ReplayConfig{Speed: 1}).replayTraces(context.Background(), td))
+	assert.Equal(t, pcommon.NewTimestampFromTime(replayStart), span.StartTimestamp())
+}
+
+func TestReplayInput(t *testing.T) {
+	testCases := []struct {
+		name     string
+		loop     bool
+		expected int
+	}{
+		{
+			name:     "once",
+			expected: 3,
+		},
+		{
+			name:     "loop",
+			loop:     true,
+			expected: 9,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tempFolder := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(tempFolder, "1.json"), []byte("a\n\nb\n"), 0600))
+			require.NoError(t, os.WriteFile(filepath.Join(tempFolder, "2.json"), []byte("c"), 0600))
+
+			cfg := createDefaultConfig().(*Config)
+			cfg.Include = []string{filepath.Join(tempFolder, "*")}
+			cfg.Replay = &ReplayConfig{Loop: tc.loop}
+
+			var mu sync.Mutex
+			var tokens []string
+			input, err := cfg.buildReplayInput(zap.NewNop().Sugar(), newReplayer(cfg.Replay), func(_ context.Context, token []byte, _ map[string]any) error {
+				mu.Lock()
+				defer mu.Unlock()
+				tokens = append(tokens, string(token))
+				return nil
+			})
+			require.NoError(t, err)
+			require.NoError(t, input.Start(nil))
+
+			require.Eventually(t, func() bool {
+				mu.Lock()
+				defer mu.Unlock()
+				return len(tokens) >= tc.expected
+			}, 5*time.Second, 10*time.Millisecond)
+			require.NoError(t, input.Stop())
+
+			mu.Lock()
+			defer mu.Unlock()
+			if !tc.loop {
+				require.Len(t, tokens, tc.expected)
+			}
+			assert.Equal(t, []string{"a", "b", "c"}, tokens[:3])
+			if tc.loop {
+				assert.Equal(t, []string{"a", "b", "c"}, tokens[3:6])
+			}
+		})
+	}
+}
+
+func TestReplayInputSkipsLongLines(t *testing.T) {
+	tempFolder := t.TempDir()
+	content := "a\n" + strings.Repeat("x", 100) + "\nb\r\n" + strings.Repeat("y", 100)
+	require.NoError(t, os.WriteFile(filepath.Join(tempFolder, "1.json"), []byte(content), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(tempFolder, "2.json"), []byte("c\n"), 0600))
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Include = []string{filepath.Join(tempFolder, "*")}
+	cfg.MaxLogSize = 16
+	cfg.Replay = &ReplayConfig{}
+
+	var mu sync.Mutex
+	var tokens []string
+	input, err := cfg.buildReplayInput(zap.NewNop().Sugar(), newReplayer(cfg.Replay), func(_ context.Context, token []byte, _ map[string]any) error {
+		mu.Lock()
+		defer mu.Unlock()
+		tokens = append(tokens, string(token))
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, input.Start(nil))
+	input.wg.Wait()
+	require.NoError(t, input.Stop())
+
+	assert.Equal(t, []string{"a", "b", "c"}, tokens)
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/histogram"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/model/value"
 	"github.com/prometheus/prometheus/scrape"
@@ -51,6 +52,9 @@ type metricGroup struct {
 	value        float64
 	complexValue []*dataPoint
 	exemplars    pmetric.ExemplarSlice
+	// hValue and fhValue hold the native histogram of exponential histograms.
+	hValue  *histogram.Histogram
+	fhValue *histogram.FloatHistogram
 }
 
 func newMetricFamily(metricName string, mc scrape.MetricMetadataStore, logger *zap.Logger) *metricFamily {
@@ -156,6 +160,98 @@ func (mg *metricGroup) toDistributionPoint(dest pmetric.HistogramDataPointSlice)
 	mg.setExemplars(point.Exemplars())
 }
 
+func (mg *metricGroup) toExponentialHistogramDataPoint(dest pmetric.ExponentialHistogramDataPointSlice) {
+	if !mg.hasCount {
+		return
+	}
+
+	point := dest.AppendEmpty()
+	pointIsStale := value.IsStaleNaN(mg.sum)
+	switch {
+	case pointIsStale:
+		point.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+	case mg.fhValue != nil:
+		fh := mg.fhValue
+		point.SetScale(fh.Schema)
+		point.SetCount(uint64(fh.Count))
+		point.SetSum(fh.Sum)
+		point.SetZeroThreshold(fh.ZeroThreshold)
+		point.SetZeroCount(uint64(fh.ZeroCount))
+		convertAbsoluteBuckets(fh.PositiveSpans, fh.PositiveBuckets, point.Positive())
+		convertAbsoluteBuckets(fh.NegativeSpans, fh.NegativeBuckets, point.Negative())
+	case mg.hValue != nil:
+		h := mg.hValue
+		point.SetScale(h.Schema)
+		point.SetCount(h.Count)
+		point.SetSum(h.Sum)
+		point.SetZeroThreshold(h.ZeroThreshold)
+		point.SetZeroCount(h.ZeroCount)
+		convertDeltaBuckets(h.PositiveSpans, h.PositiveBuckets, point.Positive())
+		convertDeltaBuckets(h.NegativeSpans, h.NegativeBuckets, point.Negative())
+	}
+
+	// The timestamp MUST be in retrieved from milliseconds and converted to nanoseconds.
+	tsNanos := timestampFromMs(mg.ts)
+	if mg.created != 0 {
+		point.SetStartTimestamp(timestampFromFloat64(mg.created))
+	} else {
+		// metrics_adjuster adjusts the startTimestamp to the initial scrape timestamp
+		point.SetStartTimestamp(tsNanos)
+	}
+	point.SetTimestamp(tsNanos)
+	populateAttributes(pmetric.MetricTypeExponentialHistogram, mg.ls, point.Attributes())
+	mg.setExemplars(point.Exemplars())
+}
+
+// convertDeltaBuckets converts the delta encoded buckets of a native histogram into the bucket counts
+// of an exponential histogram.
+// A native histogram bucket with index i covers (base^(i-1), base^i] while an exponential histogram
+// bucket with the same index covers (base^i, base^(i+1)], hence the offset is shifted by one.
+// The spans are flattened by filling the gaps between them with empty buckets.
+func convertDeltaBuckets(spans []histogram.Span, deltas []int64, buckets pmetric.ExponentialHistogramDataPointBuckets) {
+	if len(spans) == 0 {
+		return
+	}
+	buckets.SetOffset(spans[0].Offset - 1)
+	bucketCounts := buckets.BucketCounts()
+	var count int64
+	bucketIdx := 0
+	for spanIdx, span := range spans {
+		if spanIdx > 0 {
+			for i := int32(0); i < span.Offset; i++ {
+				bucketCounts.Append(0)
+			}
+		}
+		for i := uint32(0); i < span.Length && bucketIdx < len(deltas); i++ {
+			count += deltas[bucketIdx]
+			bucketCounts.Append(uint64(count))
+			bucketIdx++
+		}
+	}
+}
+
+// convertAbsoluteBuckets converts the buckets of a native float histogram, which hold absolute counts,
+// into the bucket counts of an exponential histogram, see convertDeltaBuckets.
+func convertAbsoluteBuckets(spans []histogram.Span, counts []float64, buckets pmetric.ExponentialHistogramDataPointBuckets) {
+	if len(spans) == 0 {
+		return
+	}
+	buckets.SetOffset(spans[0].Offset - 1)
+	bucketCounts := buckets.BucketCounts()
+	bucketIdx := 0
+	for spanIdx, span := range spans {
+		if spanIdx > 0 {
+			for i := int32(0); i < span.Offset; i++ {
+				bucketCounts.Append(0)
+			}
+		}
+		for i := uint32(0); i < span.Length && bucketIdx < len(counts); i++ {
+			bucketCounts.Append(uint64(counts[bucketIdx]))
+			bucketIdx++
+		}
+	}
+}
+
 func (mg *metricGroup) setExemplars(exemplars pmetric.ExemplarSlice) {
 	if mg == nil {
 		return
@@ -271,6 +367,45 @@ func (mf *metricFamily) loadMetricGroupOrCreate(groupKey uint64, ls labels.Label
 	return mg
 }
 
+// acceptsNativeHistogram returns true if the native histograms of metricName can be added to the family.
+// A histogram family is turned into an exponential histogram family by its first native histogram, as long
+// as no classic histogram series has been added to it yet.
+func (mf *metricFamily) acceptsNativeHistogram(metricName string) bool {
+	switch {
+	case mf.mtype == pmetric.MetricTypeExponentialHistogram:
+		return metricName == mf.name
+	case mf.mtype == pmetric.MetricTypeHistogram && len(mf.groups) == 0 && metricName == mf.name:
+		mf.mtype = pmetric.MetricTypeExponentialHistogram
+		return true
+	default:
+		return false
+	}
+}
+
+// addExponentialHistogramSeries adds a native histogram to the family. Either h or fh is set, a staleness
+// marker is added when both are nil.
+func (mf *metricFamily) addExponentialHistogramSeries(seriesRef uint64, metricName string, ls labels.Labels, t int64, h *histogram.Histogram, fh *histogram.FloatHistogram) error {
+	mg := mf.loadMetricGroupOrCreate(seriesRef, ls, t)
+	if mg.ts != t {
+		return fmt.Errorf("inconsistent timestamps on metric points for metric %v", metricName)
+	}
+	switch {
+	case fh != nil:
+		mg.fhValue = fh
+		mg.count = fh.Count
+		mg.sum = fh.Sum
+	case h != nil:
+		mg.hValue = h
+		mg.count = float64(h.Count)
+		mg.sum = h.Sum
+	default:
+		mg.sum = math.Float64frombits(value.StaleNaN)
+	}
+	mg.hasCount = true
+	mg.hasSum = true
+	return nil
+}
+
 func (mf *metricFamily) addSeries(seriesRef uint64, metricName string, ls labels.Labels, t int64, v float64) error {
 	mg := mf.loadMetricGroupOrCreate(seriesRef, ls, t)
 	if mg.ts != t {
@@ -334,6 +469,15 @@ func (mf *metricFamily) appendMetric(metrics pmetric.MetricSlice, trimSuffixes b
 		}
 		pointCount = hdpL.Len()
 
+	case pmetric.MetricTypeExponentialHistogram:
+		expHistogram := metric.SetEmptyExponentialHistogram()
+		expHistogram.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		ehdpL := expHistogram.DataPoints()
+		for _, mg := range mf.groupOrders {
+			mg.toExponentialHistogramDataPoint(ehdpL)
+		}
+		pointCount = ehdpL.Len()
+
 	case pmetric.MetricTypeSummary:
 		summary := metric.SetEmptySummary()
 		sdpL := summary.DataPoints()
@@ -352,7 +496,7 @@ func (mf *metricFamily) appendMetric(metrics pmetric.MetricSlice, trimSuffixes b
 		}
 		pointCount = sdpL.Len()
 
-	case pmetric.MetricTypeEmpty, pmetric.MetricTypeGauge, pmetric.MetricTypeExponentialHistogram:
+	case pmetric.MetricTypeEmpty, pmetric.MetricTypeGauge:
 		fallthrough
 	default: // Everything else should be set to a Gauge.
 		gauge := metric.SetEmptyGauge()
@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/prometheus/model/histogram"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/model/textparse"
 	"github.com/prometheus/prometheus/model/value"
@@ -293,6 +294,161 @@ func TestMetricGroupData_toDistributionUnitTest(t *testing.T) {
 	}
 }
 
+func TestMetricGroupData_toExponentialHistogramUnitTest(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels labels.Labels
+		h      *histogram.Histogram
+		fh     *histogram.FloatHistogram
+		want   func() pmetric.ExponentialHistogramDataPoint
+	}{
+		{
+			name:   "integer histogram with gaps between spans",
+			labels: labels.FromMap(map[string]string{"a": "A", "b": "B"}),
+			h: &histogram.Histogram{
+				Schema:          0,
+				ZeroThreshold:   1e-128,
+				ZeroCount:       1,
+				Count:           17,
+				Sum:             127.5,
+				PositiveSpans:   []histogram.Span{{Offset: -1, Length: 2}, {Offset: 2, Length: 1}, {Offset: 0, Length: 1}},
+				PositiveBuckets: []int64{3, -1, 4, -6},
+				NegativeSpans:   []histogram.Span{{Offset: 1, Length: 1}},
+				NegativeBuckets: []int64{5},
+			},
+			want: func() pmetric.ExponentialHistogramDataPoint {
+				point := pmetric.NewExponentialHistogramDataPoint()
+				point.SetScale(0)
+				point.SetCount(17)
+				point.SetSum(127.5)
+				point.SetZeroThreshold(1e-128)
+				point.SetZeroCount(1)
+				point.Positive().SetOffset(-2)
+				point.Positive().BucketCounts().FromRaw([]uint64{3, 2, 0, 0, 6, 0})
+				point.Negative().SetOffset(0)
+				point.Negative().BucketCounts().FromRaw([]uint64{5})
+				point.SetTimestamp(pcommon.Timestamp(11 * time.Millisecond))      // the time in milliseconds -> nanoseconds.
+				point.SetStartTimestamp(pcommon.Timestamp(11 * time.Millisecond)) // the time in milliseconds -> nanoseconds.
+				attributes := point.Attributes()
+				attributes.PutStr("a", "A")
+				attributes.PutStr("b", "B")
+				return point
+			},
+		},
+		{
+			name:   "float histogram",
+			labels: labels.FromMap(map[string]string{"a": "A"}),
+			fh: &histogram.FloatHistogram{
+				Schema:          3,
+				ZeroThreshold:   0.001,
+				ZeroCount:       2,
+				Count:           9,
+				Sum:             10.25,
+				PositiveSpans:   []histogram.Span{{Offset: 5, Length: 1}, {Offset: 1, Length: 2}},
+				PositiveBuckets: []float64{4, 1, 2},
+			},
+			want: func() pmetric.ExponentialHistogramDataPoint {
+				point := pmetric.NewExponentialHistogramDataPoint()
+				point.SetScale(3)
+				point.SetCount(9)
+				point.SetSum(10.25)
+				point.SetZeroThreshold(0.001)
+				point.SetZeroCount(2)
+				point.Positive().SetOffset(4)
+				point.Positive().BucketCounts().FromRaw([]uint64{4, 0, 1, 2})
+				point.SetTimestamp(pcommon.Timestamp(11 * time.Millisecond))      // the time in milliseconds -> nanoseconds.
+				point.SetStartTimestamp(pcommon.Timestamp(11 * time.Millisecond)) // the time in milliseconds -> nanoseconds.
+				point.Attributes().PutStr("a", "A")
+				return point
+			},
+		},
+		{
+			name:   "histogram with only the zero bucket",
+			labels: labels.FromMap(map[string]string{"a": "A"}),
+			h: &histogram.Histogram{
+				Schema:        -2,
+				ZeroThreshold: 0.5,
+				ZeroCount:     7,
+				Count:         7,
+				Sum:           1.5,
+			},
+			want: func() pmetric.ExponentialHistogramDataPoint {
+				point := pmetric.NewExponentialHistogramDataPoint()
+				point.SetScale(-2)
+				point.SetCount(7)
+				point.SetSum(1.5)
+				point.SetZeroThreshold(0.5)
+				point.SetZeroCount(7)
+				point.SetTimestamp(pcommon.Timestamp(11 * time.Millisecond))      // the time in milliseconds -> nanoseconds.
+				point.SetStartTimestamp(pcommon.Timestamp(11 * time.Millisecond)) // the time in milliseconds -> nanoseconds.
+				point.Attributes().PutStr("a", "A")
+				return point
+			},
+		},
+		{
+			name:   "histogram that is stale",
+			labels: labels.FromMap(map[string]string{"a": "A", "b": "B"}),
+			want: func() pmetric.ExponentialHistogramDataPoint {
+				point := pmetric.NewExponentialHistogramDataPoint()
+				point.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+				point.SetTimestamp(pcommon.Timestamp(11 * time.Millisecond))      // the time in milliseconds -> nanoseconds.
+				point.SetStartTimestamp(pcommon.Timestamp(11 * time.Millisecond)) // the time in milliseconds -> nanoseconds.
+				attributes := point.Attributes()
+				attributes.PutStr("a", "A")
+				attributes.PutStr("b", "B")
+				return point
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			mp := newMetricFamily("histogram", mc, zap.NewNop())
+			require.True(t, mp.acceptsNativeHistogram("histogram"))
+			require.Equal(t, pmetric.MetricTypeExponentialHistogram, mp.mtype)
+
+			sRef, _ := getSeriesRef(nil, tt.labels, mp.mtype)
+			require.NoError(t, mp.addExponentialHistogramSeries(sRef, "histogram", tt.labels, 11, tt.h, tt.fh))
+			require.Len(t, mp.groups, 1)
+
+			sl := pmetric.NewMetricSlice()
+			mp.appendMetric(sl, false)
+
+			require.Equal(t, 1, sl.Len(), "Exactly one metric expected")
+			metric := sl.At(0)
+			require.Equal(t, mc["histogram"].Help, metric.Description(), "Expected help metadata in metric description")
+			require.Equal(t, mc["histogram"].Unit, metric.Unit(), "Expected unit metadata in metric")
+			require.Equal(t, pmetric.AggregationTemporalityCumulative, metric.ExponentialHistogram().AggregationTemporality())
+
+			hdpL := metric.ExponentialHistogram().DataPoints()
+			require.Equal(t, 1, hdpL.Len(), "Exactly one point expected")
+			got := hdpL.At(0)
+			want := tt.want()
+			require.Equal(t, want, got, "Expected the points to be equal")
+		})
+	}
+}
+
+func TestMetricFamilyAcceptsNativeHistogram(t *testing.T) {
+	mp := newMetricFamily("histogram", mc, zap.NewNop())
+	lbls := labels.FromMap(map[string]string{"a": "A", "le": "0.75"})
+	sRef, _ := getSeriesRef(nil, lbls, mp.mtype)
+	require.NoError(t, mp.addSeries(sRef, "histogram_bucket", lbls, 11, 33))
+	// native histograms are not mixed with the classic histograms of the family
+	require.False(t, mp.acceptsNativeHistogram("histogram"))
+	require.Equal(t, pmetric.MetricTypeHistogram, mp.mtype)
+
+	mp = newMetricFamily("summary", mc, zap.NewNop())
+	require.False(t, mp.acceptsNativeHistogram("summary"))
+
+	mp = newMetricFamily("histogram", mc, zap.NewNop())
+	require.False(t, mp.acceptsNativeHistogram("histogram_count"))
+	require.True(t, mp.acceptsNativeHistogram("histogram"))
+	require.True(t, mp.acceptsNativeHistogram("histogram"))
+	require.False(t, mp.acceptsNativeHistogram("histogram_count"))
+}
+
 func TestMetricGroupData_toSummaryUnitTest(t *testing.T) {
 	type scrape struct {
 		at     int64
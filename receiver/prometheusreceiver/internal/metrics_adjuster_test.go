@@ -25,10 +25,11 @@ var (
 	bounds0  = []float64{1, 2, 4}
 	percent0 = []float64{10, 50, 90}
 
-	sum1       = "sum1"
-	gauge1     = "gauge1"
-	histogram1 = "histogram1"
-	summary1   = "summary1"
+	sum1          = "sum1"
+	gauge1        = "gauge1"
+	histogram1    = "histogram1"
+	expHistogram1 = "expHistogram1"
+	summary1      = "summary1"
 
 	k1v1k2v2 = []*kv{
 		{"k1", "v1"},
@@ -246,6 +247,46 @@ func TestHistogramFlagNoRecordedValueFirstObservation(t *testing.T) {
 	runScript(t, NewInitialPointAdjuster(zap.NewNop(), time.Minute, true), "job", "0", script)
 }
 
+func TestExponentialHistogram(t *testing.T) {
+	script := []*metricsAdjusterTest{
+		{
+			description: "Exponential Histogram: round 1 - initial instance, start time is established",
+			metrics:     metrics(exponentialHistogramMetric(expHistogram1, exponentialHistogramPoint(k1v1k2v2, t1, t1, 3, 16.5, -1, []uint64{4, 2, 3, 7}))),
+			adjusted:    metrics(exponentialHistogramMetric(expHistogram1, exponentialHistogramPoint(k1v1k2v2, t1, t1, 3, 16.5, -1, []uint64{4, 2, 3, 7}))),
+		}, {
+			description: "Exponential Histogram: round 2 - instance adjusted based on round 1",
+			metrics:     metrics(exponentialHistogramMetric(expHistogram1, exponentialHistogramPoint(k1v1k2v2, t2, t2, 3, 20.5, -1, []uint64{6, 3, 4, 8}))),
+			adjusted:    metrics(exponentialHistogramMetric(expHistogram1, exponentialHistogramPoint(k1v1k2v2, t1, t2, 3, 20.5, -1, []uint64{6, 3, 4, 8}))),
+		}, {
+			description: "Exponential Histogram: round 3 - instance reset (value less than previous value), start time is reset",
+			metrics:     metrics(exponentialHistogramMetric(expHistogram1, exponentialHistogramPoint(k1v1k2v2, t3, t3, 1, 10.5, -1, []uint64{5, 3, 2, 7}))),
+			adjusted:    metrics(exponentialHistogramMetric(expHistogram1, exponentialHistogramPoint(k1v1k2v2, t3, t3, 1, 10.5, -1, []uint64{5, 3, 2, 7}))),
+		}, {
+			description: "Exponential Histogram: round 4 - instance adjusted based on round 3",
+			metrics:     metrics(exponentialHistogramMetric(expHistogram1, exponentialHistogramPoint(k1v1k2v2, t4, t4, 1, 16.5, -1, []uint64{7, 4, 2, 12}))),
+			adjusted:    metrics(exponentialHistogramMetric(expHistogram1, exponentialHistogramPoint(k1v1k2v2, t3, t4, 1, 16.5, -1, []uint64{7, 4, 2, 12}))),
+		},
+	}
+	runScript(t, NewInitialPointAdjuster(zap.NewNop(), time.Minute, true), "job", "0", script)
+}
+
+func TestExponentialHistogramFlagNoRecordedValue(t *testing.T) {
+	script := []*metricsAdjusterTest{
+		{
+			description: "Exponential Histogram: round 1 - initial instance, start time is established",
+			metrics:     metrics(exponentialHistogramMetric(expHistogram1, exponentialHistogramPoint(k1v1k2v2, t1, t1, 3, 16.5, -1, []uint64{7, 4, 2, 12}))),
+			adjusted:    metrics(exponentialHistogramMetric(expHistogram1, exponentialHistogramPoint(k1v1k2v2, t1, t1, 3, 16.5, -1, []uint64{7, 4, 2, 12}))),
+		},
+		{
+			description: "Exponential Histogram: round 2 - instance adjusted based on round 1",
+			metrics:     metrics(exponentialHistogramMetric(expHistogram1, exponentialHistogramPointNoValue(k1v1k2v2, tUnknown, t2))),
+			adjusted:    metrics(exponentialHistogramMetric(expHistogram1, exponentialHistogramPointNoValue(k1v1k2v2, t1, t2))),
+		},
+	}
+
+	runScript(t, NewInitialPointAdjuster(zap.NewNop(), time.Minute, true), "job", "0", script)
+}
+
 func TestSummaryFlagNoRecordedValueFirstObservation(t *testing.T) {
 	script := []*metricsAdjusterTest{
 		{
@@ -78,6 +78,57 @@ func histogramMetric(name string, points ...pmetric.HistogramDataPoint) pmetric.
 	return metric
 }
 
+func exponentialHistogramPointRaw(attributes []*kv, startTimestamp, timestamp pcommon.Timestamp) pmetric.ExponentialHistogramDataPoint {
+	hdp := pmetric.NewExponentialHistogramDataPoint()
+	hdp.SetStartTimestamp(startTimestamp)
+	hdp.SetTimestamp(timestamp)
+
+	attrs := hdp.Attributes()
+	for _, kv := range attributes {
+		attrs.PutStr(kv.Key, kv.Value)
+	}
+
+	return hdp
+}
+
+func exponentialHistogramPoint(attributes []*kv, startTimestamp, timestamp pcommon.Timestamp, zeroCount uint64, sum float64, offset int32, counts []uint64) pmetric.ExponentialHistogramDataPoint {
+	hdp := exponentialHistogramPointRaw(attributes, startTimestamp, timestamp)
+	hdp.SetZeroCount(zeroCount)
+	hdp.Positive().SetOffset(offset)
+	hdp.Positive().BucketCounts().FromRaw(counts)
+
+	count := zeroCount
+	for _, bcount := range counts {
+		count += bcount
+	}
+	hdp.SetCount(count)
+	hdp.SetSum(sum)
+
+	return hdp
+}
+
+func exponentialHistogramPointNoValue(attributes []*kv, startTimestamp, timestamp pcommon.Timestamp) pmetric.ExponentialHistogramDataPoint {
+	hdp := exponentialHistogramPointRaw(attributes, startTimestamp, timestamp)
+	hdp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+
+	return hdp
+}
+
+func exponentialHistogramMetric(name string, points ...pmetric.ExponentialHistogramDataPoint) pmetric.Metric {
+	metric := pmetric.NewMetric()
+	metric.SetName(name)
+	histogram := metric.SetEmptyExponentialHistogram()
+	histogram.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+
+	destPointL := histogram.DataPoints()
+	for _, point := range points {
+		destPoint := destPointL.AppendEmpty()
+		point.CopyTo(destPoint)
+	}
+
+	return metric
+}
+
 func doublePointRaw(attributes []*kv, startTimestamp, timestamp pcommon.Timestamp) pmetric.NumberDataPoint {
 	ndp := pmetric.NewNumberDataPoint()
 	ndp.SetStartTimestamp(startTimestamp)
@@ -50,6 +50,8 @@ type transaction struct {
 	obsrecv         *receiverhelper.ObsReport
 	// Used as buffer to calculate series ref hash.
 	bufBytes []byte
+	// Converts native histograms into exponential histograms.
+	enableNativeHistograms bool
 }
 
 var emptyScopeID scopeID
@@ -66,20 +68,22 @@ func newTransaction(
 	externalLabels labels.Labels,
 	settings receiver.CreateSettings,
 	obsrecv *receiverhelper.ObsReport,
-	trimSuffixes bool) *transaction {
+	trimSuffixes bool,
+	enableNativeHistograms bool) *transaction {
 	return &transaction{
-		ctx:             ctx,
-		families:        make(map[scopeID]map[string]*metricFamily),
-		isNew:           true,
-		trimSuffixes:    trimSuffixes,
-		sink:            sink,
-		metricAdjuster:  metricAdjuster,
-		externalLabels:  externalLabels,
-		logger:          settings.Logger,
-		buildInfo:       settings.BuildInfo,
-		obsrecv:         obsrecv,
-		bufBytes:        make([]byte, 0, 1024),
-		scopeAttributes: make(map[scopeID]pcommon.Map),
+		ctx:                    ctx,
+		families:               make(map[scopeID]map[string]*metricFamily),
+		isNew:                  true,
+		trimSuffixes:           trimSuffixes,
+		enableNativeHistograms: enableNativeHistograms,
+		sink:                   sink,
+		metricAdjuster:         metricAdjuster,
+		externalLabels:         externalLabels,
+		logger:                 settings.Logger,
+		buildInfo:              settings.BuildInfo,
+		obsrecv:                obsrecv,
+		bufBytes:               make([]byte, 0, 1024),
+		scopeAttributes:        make(map[scopeID]pcommon.Map),
 	}
 }
 
@@ -144,6 +148,23 @@ func (t *transaction) Append(_ storage.SeriesRef, ls labels.Labels, atMs int64,
 	}
 
 	curMF := t.getOrCreateMetricFamily(getScopeID(ls), metricName)
+
+	if t.enableNativeHistograms {
+		// Staleness markers of native histograms are appended as float samples of the histogram series.
+		if value.IsStaleNaN(val) && curMF.acceptsNativeHistogram(metricName) {
+			err := curMF.addExponentialHistogramSeries(t.getSeriesRef(ls, curMF.mtype), metricName, ls, atMs, nil, nil)
+			if err != nil {
+				t.logger.Warn("failed to add datapoint", zap.Error(err), zap.String("metric_name", metricName), zap.Any("labels", ls))
+			}
+			return 0, nil
+		}
+		// The classic series of a native histogram, scraped when scrape_classic_histograms is enabled,
+		// are dropped in favor of the native histogram.
+		if curMF.mtype == pmetric.MetricTypeExponentialHistogram {
+			return 0, nil
+		}
+	}
+
 	err := curMF.addSeries(t.getSeriesRef(ls, curMF.mtype), metricName, ls, atMs, val)
 	if err != nil {
 		t.logger.Warn("failed to add datapoint", zap.Error(err), zap.String("metric_name", metricName), zap.Any("labels", ls))
@@ -203,9 +224,57 @@ func (t *transaction) AppendExemplar(_ storage.SeriesRef, l labels.Labels, e exe
 	return 0, nil
 }
 
-func (t *transaction) AppendHistogram(_ storage.SeriesRef, _ labels.Labels, _ int64, _ *histogram.Histogram, _ *histogram.FloatHistogram) (storage.SeriesRef, error) {
-	//TODO: implement this func
-	return 0, nil
+// AppendHistogram converts native histograms into exponential histograms when enabled, and drops them otherwise.
+// It always returns 0 to disable label caching.
+func (t *transaction) AppendHistogram(_ storage.SeriesRef, ls labels.Labels, atMs int64, h *histogram.Histogram, fh *histogram.FloatHistogram) (storage.SeriesRef, error) {
+	if !t.enableNativeHistograms {
+		return 0, nil
+	}
+
+	select {
+	case <-t.ctx.Done():
+		return 0, errTransactionAborted
+	default:
+	}
+
+	if len(t.externalLabels) != 0 {
+		ls = append(ls, t.externalLabels...)
+		sort.Sort(ls)
+	}
+
+	if t.isNew {
+		if err := t.initTransaction(ls); err != nil {
+			return 0, err
+		}
+	}
+
+	if dupLabel, hasDup := ls.HasDuplicateLabelNames(); hasDup {
+		return 0, fmt.Errorf("invalid sample: non-unique label names: %q", dupLabel)
+	}
+
+	metricName := ls.Get(model.MetricNameLabel)
+	if metricName == "" {
+		return 0, errMetricNameNotFound
+	}
+
+	// Gauge histograms are not supported, like their classic counterpart.
+	if (h != nil && h.CounterResetHint == histogram.GaugeType) || (fh != nil && fh.CounterResetHint == histogram.GaugeType) {
+		t.logger.Debug("dropping unsupported gauge histogram datapoint", zap.String("metric_name", metricName), zap.Any("labels", ls))
+		return 0, nil
+	}
+
+	curMF := t.getOrCreateMetricFamily(getScopeID(ls), metricName)
+	if !curMF.acceptsNativeHistogram(metricName) {
+		t.logger.Debug("dropping native histogram datapoint of a classic histogram family", zap.String("metric_name", metricName), zap.Any("labels", ls))
+		return 0, nil
+	}
+
+	err := curMF.addExponentialHistogramSeries(t.getSeriesRef(ls, curMF.mtype), metricName, ls, atMs, h, fh)
+	if err != nil {
+		t.logger.Warn("failed to add datapoint", zap.Error(err), zap.String("metric_name", metricName), zap.Any("labels", ls))
+	}
+
+	return 0, nil // never return errors, as that fails the whole scrape
 }
 
 func (t *transaction) getSeriesRef(ls labels.Labels, mtype pmetric.MetricType) uint64 {
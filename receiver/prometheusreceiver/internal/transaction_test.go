@@ -6,13 +6,16 @@ package internal
 import (
 	"context"
 	"errors"
+	"math"
 	"testing"
 	"time"
 
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/histogram"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/model/metadata"
+	"github.com/prometheus/prometheus/model/value"
 	"github.com/prometheus/prometheus/scrape"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -53,24 +56,24 @@ var (
 )
 
 func TestTransactionCommitWithoutAdding(t *testing.T) {
-	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, consumertest.NewNop(), nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), false)
+	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, consumertest.NewNop(), nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), false, false)
 	assert.NoError(t, tr.Commit())
 }
 
 func TestTransactionRollbackDoesNothing(t *testing.T) {
-	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, consumertest.NewNop(), nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), false)
+	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, consumertest.NewNop(), nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), false, false)
 	assert.NoError(t, tr.Rollback())
 }
 
 func TestTransactionUpdateMetadataDoesNothing(t *testing.T) {
-	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, consumertest.NewNop(), nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), false)
+	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, consumertest.NewNop(), nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), false, false)
 	_, err := tr.UpdateMetadata(0, labels.New(), metadata.Metadata{})
 	assert.NoError(t, err)
 }
 
 func TestTransactionAppendNoTarget(t *testing.T) {
 	badLabels := labels.FromStrings(model.MetricNameLabel, "counter_test")
-	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, consumertest.NewNop(), nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), false)
+	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, consumertest.NewNop(), nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), false, false)
 	_, err := tr.Append(0, badLabels, time.Now().Unix()*1000, 1.0)
 	assert.Error(t, err)
 }
@@ -80,7 +83,7 @@ func TestTransactionAppendNoMetricName(t *testing.T) {
 		model.InstanceLabel: "localhost:8080",
 		model.JobLabel:      "test2",
 	})
-	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, consumertest.NewNop(), nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), false)
+	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, consumertest.NewNop(), nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), false, false)
 	_, err := tr.Append(0, jobNotFoundLb, time.Now().Unix()*1000, 1.0)
 	assert.ErrorIs(t, err, errMetricNameNotFound)
 
@@ -88,7 +91,7 @@ func TestTransactionAppendNoMetricName(t *testing.T) {
 }
 
 func TestTransactionAppendEmptyMetricName(t *testing.T) {
-	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, consumertest.NewNop(), nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), false)
+	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, consumertest.NewNop(), nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), false, false)
 	_, err := tr.Append(0, labels.FromMap(map[string]string{
 		model.InstanceLabel:   "localhost:8080",
 		model.JobLabel:        "test2",
@@ -99,7 +102,7 @@ func TestTransactionAppendEmptyMetricName(t *testing.T) {
 
 func TestTransactionAppendResource(t *testing.T) {
 	sink := new(consumertest.MetricsSink)
-	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), false)
+	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), false, false)
 	_, err := tr.Append(0, labels.FromMap(map[string]string{
 		model.InstanceLabel:   "localhost:8080",
 		model.JobLabel:        "test",
@@ -122,7 +125,7 @@ func TestTransactionAppendResource(t *testing.T) {
 
 func TestReceiverVersionAndNameAreAttached(t *testing.T) {
 	sink := new(consumertest.MetricsSink)
-	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), false)
+	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), false, false)
 	_, err := tr.Append(0, labels.FromMap(map[string]string{
 		model.InstanceLabel:   "localhost:8080",
 		model.JobLabel:        "test",
@@ -150,7 +153,7 @@ func TestTransactionCommitErrorWhenAdjusterError(t *testing.T) {
 	})
 	sink := new(consumertest.MetricsSink)
 	adjusterErr := errors.New("adjuster error")
-	tr := newTransaction(scrapeCtx, &errorAdjuster{err: adjusterErr}, sink, nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), false)
+	tr := newTransaction(scrapeCtx, &errorAdjuster{err: adjusterErr}, sink, nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), false, false)
 	_, err := tr.Append(0, goodLabels, time.Now().Unix()*1000, 1.0)
 	assert.NoError(t, err)
 	assert.ErrorIs(t, tr.Commit(), adjusterErr)
@@ -159,7 +162,7 @@ func TestTransactionCommitErrorWhenAdjusterError(t *testing.T) {
 // Ensure that we reject duplicate label keys. See https://github.com/open-telemetry/wg-prometheus/issues/44.
 func TestTransactionAppendDuplicateLabels(t *testing.T) {
 	sink := new(consumertest.MetricsSink)
-	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), false)
+	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), false, false)
 
 	dupLabels := labels.FromStrings(
 		model.InstanceLabel, "0.0.0.0:8855",
@@ -188,6 +191,7 @@ func TestTransactionAppendHistogramNoLe(t *testing.T) {
 		receiverSettings,
 		nopObsRecv(t),
 		false,
+		false,
 	)
 
 	goodLabels := labels.FromStrings(
@@ -218,6 +222,7 @@ func TestTransactionAppendSummaryNoQuantile(t *testing.T) {
 		receiverSettings,
 		nopObsRecv(t),
 		false,
+		false,
 	)
 
 	goodLabels := labels.FromStrings(
@@ -248,6 +253,7 @@ func TestTransactionAppendValidAndInvalid(t *testing.T) {
 		receiverSettings,
 		nopObsRecv(t),
 		false,
+		false,
 	)
 
 	// a valid counter
@@ -282,7 +288,7 @@ func TestTransactionAppendValidAndInvalid(t *testing.T) {
 
 func TestAppendExemplarWithNoMetricName(t *testing.T) {
 	sink := new(consumertest.MetricsSink)
-	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), false)
+	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), false, false)
 
 	labels := labels.FromStrings(
 		model.InstanceLabel, "0.0.0.0:8855",
@@ -295,7 +301,7 @@ func TestAppendExemplarWithNoMetricName(t *testing.T) {
 
 func TestAppendExemplarWithEmptyMetricName(t *testing.T) {
 	sink := new(consumertest.MetricsSink)
-	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), false)
+	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), false, false)
 
 	labels := labels.FromStrings(
 		model.InstanceLabel, "0.0.0.0:8855",
@@ -308,7 +314,7 @@ func TestAppendExemplarWithEmptyMetricName(t *testing.T) {
 
 func TestAppendExemplarWithDuplicateLabels(t *testing.T) {
 	sink := new(consumertest.MetricsSink)
-	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), false)
+	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), false, false)
 
 	labels := labels.FromStrings(
 		model.InstanceLabel, "0.0.0.0:8855",
@@ -324,7 +330,7 @@ func TestAppendExemplarWithDuplicateLabels(t *testing.T) {
 
 func TestAppendExemplarWithoutAddingMetric(t *testing.T) {
 	sink := new(consumertest.MetricsSink)
-	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), false)
+	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), false, false)
 
 	labels := labels.FromStrings(
 		model.InstanceLabel, "0.0.0.0:8855",
@@ -338,7 +344,7 @@ func TestAppendExemplarWithoutAddingMetric(t *testing.T) {
 
 func TestAppendExemplarWithNoLabels(t *testing.T) {
 	sink := new(consumertest.MetricsSink)
-	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), false)
+	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), false, false)
 
 	_, err := tr.AppendExemplar(0, nil, exemplar.Exemplar{Value: 0})
 	assert.Equal(t, errNoJobInstance, err)
@@ -346,7 +352,7 @@ func TestAppendExemplarWithNoLabels(t *testing.T) {
 
 func TestAppendExemplarWithEmptyLabelArray(t *testing.T) {
 	sink := new(consumertest.MetricsSink)
-	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), false)
+	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), false, false)
 
 	_, err := tr.AppendExemplar(0, []labels.Label{}, exemplar.Exemplar{Value: 0})
 	assert.Equal(t, errNoJobInstance, err)
@@ -1318,6 +1324,218 @@ func TestMetricBuilderHistogram(t *testing.T) {
 	}
 }
 
+func TestMetricBuilderNativeHistogram(t *testing.T) {
+	// buckets (1, 1.41], (1.41, 2] and (2.83, 4] on the positive side and (-2.83, -2] on the negative side
+	h := &histogram.Histogram{
+		Schema:          1,
+		ZeroThreshold:   0.001,
+		ZeroCount:       2,
+		Count:           10,
+		Sum:             42.5,
+		PositiveSpans:   []histogram.Span{{Offset: 1, Length: 2}, {Offset: 1, Length: 1}},
+		PositiveBuckets: []int64{1, 2, -1},
+		NegativeSpans:   []histogram.Span{{Offset: 3, Length: 1}},
+		NegativeBuckets: []int64{2},
+	}
+	gaugeHistogram := h.Copy()
+	gaugeHistogram.CounterResetHint = histogram.GaugeType
+
+	wantExponentialHistogram := func() pmetric.Metrics {
+		md0 := pmetric.NewMetrics()
+		mL0 := md0.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+		m0 := mL0.AppendEmpty()
+		m0.SetName("hist_test")
+		hist0 := m0.SetEmptyExponentialHistogram()
+		hist0.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		pt0 := hist0.DataPoints().AppendEmpty()
+		pt0.SetScale(1)
+		pt0.SetCount(10)
+		pt0.SetSum(42.5)
+		pt0.SetZeroThreshold(0.001)
+		pt0.SetZeroCount(2)
+		pt0.Positive().SetOffset(0)
+		pt0.Positive().BucketCounts().FromRaw([]uint64{1, 3, 0, 2})
+		pt0.Negative().SetOffset(2)
+		pt0.Negative().BucketCounts().FromRaw([]uint64{2})
+		pt0.SetTimestamp(tsNanos)
+		pt0.SetStartTimestamp(startTimestamp)
+		pt0.Attributes().PutStr("foo", "bar")
+		return md0
+	}
+
+	tests := []buildTestData{
+		{
+			name: "integer histogram",
+			inputs: []*testScrapedPage{
+				{
+					pts: []*testDataPoint{
+						createHistogramDataPoint("hist_test", h, nil, nil, "foo", "bar"),
+					},
+				},
+			},
+			wants: func() []pmetric.Metrics {
+				return []pmetric.Metrics{wantExponentialHistogram()}
+			},
+			enableNativeHistograms: true,
+		},
+		{
+			name: "float histogram",
+			inputs: []*testScrapedPage{
+				{
+					pts: []*testDataPoint{
+						createHistogramDataPoint("hist_test", nil, h.ToFloat(), nil, "foo", "bar"),
+					},
+				},
+			},
+			wants: func() []pmetric.Metrics {
+				return []pmetric.Metrics{wantExponentialHistogram()}
+			},
+			enableNativeHistograms: true,
+		},
+		{
+			name: "histogram with exemplars",
+			inputs: []*testScrapedPage{
+				{
+					pts: []*testDataPoint{
+						createHistogramDataPoint(
+							"hist_test",
+							h,
+							nil,
+							[]exemplar.Exemplar{
+								{
+									Value:  1.5,
+									Ts:     1663113420863,
+									Labels: []labels.Label{{Name: "foo", Value: "bar"}, {Name: "trace_id", Value: "174137cab66dc8807f46f8ecd5b2ed9b"}, {Name: "span_id", Value: "dfa4597a9d2eb1a6"}},
+								},
+							},
+							"foo", "bar"),
+					},
+				},
+			},
+			wants: func() []pmetric.Metrics {
+				md0 := wantExponentialHistogram()
+				pt0 := md0.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).ExponentialHistogram().DataPoints().At(0)
+				e0 := pt0.Exemplars().AppendEmpty()
+				e0.SetTimestamp(timestampFromMs(1663113420863))
+				e0.SetDoubleValue(1.5)
+				e0.SetTraceID([16]byte{0x17, 0x41, 0x37, 0xca, 0xb6, 0x6d, 0xc8, 0x80, 0x7f, 0x46, 0xf8, 0xec, 0xd5, 0xb2, 0xed, 0x9b})
+				e0.SetSpanID([8]byte{0xdf, 0xa4, 0x59, 0x7a, 0x9d, 0x2e, 0xb1, 0xa6})
+				e0.FilteredAttributes().PutStr("foo", "bar")
+				return []pmetric.Metrics{md0}
+			},
+			enableNativeHistograms: true,
+		},
+		{
+			name: "native histogram with classic histogram",
+			inputs: []*testScrapedPage{
+				{
+					pts: []*testDataPoint{
+						createHistogramDataPoint("hist_test", h, nil, nil, "foo", "bar"),
+						createDataPoint("hist_test_bucket", 8, nil, "foo", "bar", "le", "2"),
+						createDataPoint("hist_test_bucket", 10, nil, "foo", "bar", "le", "+inf"),
+						createDataPoint("hist_test_sum", 42.5, nil, "foo", "bar"),
+						createDataPoint("hist_test_count", 10, nil, "foo", "bar"),
+					},
+				},
+			},
+			wants: func() []pmetric.Metrics {
+				return []pmetric.Metrics{wantExponentialHistogram()}
+			},
+			enableNativeHistograms: true,
+		},
+		{
+			name: "native histograms disabled",
+			inputs: []*testScrapedPage{
+				{
+					pts: []*testDataPoint{
+						createHistogramDataPoint("hist_test", h, nil, nil, "foo", "bar"),
+						createDataPoint("hist_test_bucket", 8, nil, "foo", "bar", "le", "2"),
+						createDataPoint("hist_test_bucket", 10, nil, "foo", "bar", "le", "+inf"),
+						createDataPoint("hist_test_sum", 42.5, nil, "foo", "bar"),
+						createDataPoint("hist_test_count", 10, nil, "foo", "bar"),
+					},
+				},
+			},
+			wants: func() []pmetric.Metrics {
+				md0 := pmetric.NewMetrics()
+				mL0 := md0.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+				m0 := mL0.AppendEmpty()
+				m0.SetName("hist_test")
+				hist0 := m0.SetEmptyHistogram()
+				hist0.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				pt0 := hist0.DataPoints().AppendEmpty()
+				pt0.SetCount(10)
+				pt0.SetSum(42.5)
+				pt0.ExplicitBounds().FromRaw([]float64{2})
+				pt0.BucketCounts().FromRaw([]uint64{8, 2})
+				pt0.SetTimestamp(tsNanos)
+				pt0.SetStartTimestamp(startTimestamp)
+				pt0.Attributes().PutStr("foo", "bar")
+				return []pmetric.Metrics{md0}
+			},
+		},
+		{
+			name: "gauge histogram",
+			inputs: []*testScrapedPage{
+				{
+					pts: []*testDataPoint{
+						createHistogramDataPoint("ghist_test", gaugeHistogram, nil, nil, "foo", "bar"),
+						createDataPoint("gauge_test", 1, nil, "foo", "bar"),
+					},
+				},
+			},
+			wants: func() []pmetric.Metrics {
+				md0 := pmetric.NewMetrics()
+				mL0 := md0.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+				m0 := mL0.AppendEmpty()
+				m0.SetName("gauge_test")
+				pt0 := m0.SetEmptyGauge().DataPoints().AppendEmpty()
+				pt0.SetDoubleValue(1)
+				pt0.SetTimestamp(tsNanos)
+				pt0.Attributes().PutStr("foo", "bar")
+				return []pmetric.Metrics{md0}
+			},
+			enableNativeHistograms: true,
+		},
+		{
+			name: "stale histogram",
+			inputs: []*testScrapedPage{
+				{
+					pts: []*testDataPoint{
+						createHistogramDataPoint("hist_test", h, nil, nil, "foo", "bar"),
+					},
+				},
+				{
+					pts: []*testDataPoint{
+						createDataPoint("hist_test", math.Float64frombits(value.StaleNaN), nil, "foo", "bar"),
+					},
+				},
+			},
+			wants: func() []pmetric.Metrics {
+				md1 := pmetric.NewMetrics()
+				mL1 := md1.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+				m1 := mL1.AppendEmpty()
+				m1.SetName("hist_test")
+				hist1 := m1.SetEmptyExponentialHistogram()
+				hist1.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				pt1 := hist1.DataPoints().AppendEmpty()
+				pt1.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+				pt1.SetTimestamp(tsPlusIntervalNanos)
+				pt1.SetStartTimestamp(startTimestamp)
+				pt1.Attributes().PutStr("foo", "bar")
+				return []pmetric.Metrics{wantExponentialHistogram(), md1}
+			},
+			enableNativeHistograms: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t)
+		})
+	}
+}
+
 func TestMetricBuilderSummary(t *testing.T) {
 	tests := []buildTestData{
 		{
@@ -1462,9 +1680,10 @@ func TestMetricBuilderSummary(t *testing.T) {
 }
 
 type buildTestData struct {
-	name   string
-	inputs []*testScrapedPage
-	wants  func() []pmetric.Metrics
+	name                   string
+	inputs                 []*testScrapedPage
+	wants                  func() []pmetric.Metrics
+	enableNativeHistograms bool
 }
 
 func (tt buildTestData) run(t *testing.T) {
@@ -1473,11 +1692,16 @@ func (tt buildTestData) run(t *testing.T) {
 	st := ts
 	for i, page := range tt.inputs {
 		sink := new(consumertest.MetricsSink)
-		tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), false)
+		tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), false, tt.enableNativeHistograms)
 		for _, pt := range page.pts {
 			// set ts for testing
 			pt.t = st
-			_, err := tr.Append(0, pt.lb, pt.t, pt.v)
+			var err error
+			if pt.h != nil || pt.fh != nil {
+				_, err = tr.AppendHistogram(0, pt.lb, pt.t, pt.h, pt.fh)
+			} else {
+				_, err = tr.Append(0, pt.lb, pt.t, pt.v)
+			}
 			assert.NoError(t, err)
 
 			for _, e := range pt.exemplars {
@@ -1534,7 +1758,12 @@ func (s *startTimeAdjuster) AdjustMetrics(metrics pmetric.Metrics) error {
 					for l := 0; l < dps.Len(); l++ {
 						dps.At(l).SetStartTimestamp(s.startTime)
 					}
-				case pmetric.MetricTypeEmpty, pmetric.MetricTypeGauge, pmetric.MetricTypeExponentialHistogram:
+				case pmetric.MetricTypeExponentialHistogram:
+					dps := metric.ExponentialHistogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dps.At(l).SetStartTimestamp(s.startTime)
+					}
+				case pmetric.MetricTypeEmpty, pmetric.MetricTypeGauge:
 				}
 			}
 		}
@@ -1546,6 +1775,8 @@ type testDataPoint struct {
 	lb        labels.Labels
 	t         int64
 	v         float64
+	h         *histogram.Histogram
+	fh        *histogram.FloatHistogram
 	exemplars []exemplar.Exemplar
 }
 
@@ -1568,6 +1799,13 @@ func createDataPoint(mname string, value float64, es []exemplar.Exemplar, tagPai
 	}
 }
 
+func createHistogramDataPoint(mname string, h *histogram.Histogram, fh *histogram.FloatHistogram, es []exemplar.Exemplar, tagPairs ...string) *testDataPoint {
+	dataPoint := createDataPoint(mname, 0, es, tagPairs...)
+	dataPoint.h = h
+	dataPoint.fh = fh
+	return dataPoint
+}
+
 func assertEquivalentMetrics(t *testing.T, want, got pmetric.Metrics) {
 	require.Equal(t, want.ResourceMetrics().Len(), got.ResourceMetrics().Len())
 	if want.ResourceMetrics().Len() == 0 {
@@ -268,6 +268,7 @@ func (r *pReceiver) initPrometheusComponents(ctx context.Context, logger log.Log
 		useCreatedMetricGate.IsEnabled(),
 		r.cfg.PrometheusConfig.GlobalConfig.ExternalLabels,
 		r.cfg.TrimMetricSuffixes,
+		enableNativeHistogramsGate.IsEnabled(),
 	)
 	if err != nil {
 		return err
@@ -8,6 +8,8 @@ import (
 	"testing"
 
 	dto "github.com/prometheus/prometheus/prompb/io/prometheus/client"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 )
 
@@ -156,3 +158,182 @@ func TestScrapeViaProtobuf(t *testing.T) {
 		c.EnableProtobufNegotiation = true
 	})
 }
+
+func TestNativeHistogramScrapeViaProtobuf(t *testing.T) {
+	classicBuckets := []dto.Bucket{
+		{
+			UpperBound:      0.5,
+			CumulativeCount: 7,
+		},
+		{
+			UpperBound:      10,
+			CumulativeCount: 11,
+		},
+		{
+			UpperBound:      math.Inf(1),
+			CumulativeCount: 12,
+		},
+	}
+
+	tests := []struct {
+		name                    string
+		enableNativeHistograms  bool
+		scrapeClassicHistograms bool
+		expectations            []testExpectation
+	}{
+		{
+			name:                   "native histograms enabled",
+			enableNativeHistograms: true,
+			expectations: []testExpectation{
+				assertMetricPresent(
+					"test_native_histogram",
+					compareMetricType(pmetric.MetricTypeExponentialHistogram),
+					compareMetricUnit(""),
+					[]dataPointExpectation{{
+						exponentialHistogramPointComparator: []exponentialHistogramPointComparator{
+							compareExponentialHistogram(-1, 12, 50, 2, 0, []uint64{1, 0, 2}, -1, []uint64{1, 3, 2, 1}),
+						},
+					}},
+				),
+				assertMetricPresent(
+					"test_classic_histogram",
+					compareMetricType(pmetric.MetricTypeHistogram),
+					compareMetricUnit(""),
+					[]dataPointExpectation{{
+						histogramPointComparator: []histogramPointComparator{
+							compareHistogram(12, 50, []float64{0.5, 10}, []uint64{7, 4, 1}),
+						},
+					}},
+				),
+			},
+		},
+		{
+			name:                    "native histograms enabled with classic histograms",
+			enableNativeHistograms:  true,
+			scrapeClassicHistograms: true,
+			expectations: []testExpectation{
+				assertMetricPresent(
+					"test_native_histogram",
+					compareMetricType(pmetric.MetricTypeExponentialHistogram),
+					compareMetricUnit(""),
+					[]dataPointExpectation{{
+						exponentialHistogramPointComparator: []exponentialHistogramPointComparator{
+							compareExponentialHistogram(-1, 12, 50, 2, 0, []uint64{1, 0, 2}, -1, []uint64{1, 3, 2, 1}),
+						},
+					}},
+				),
+				assertMetricPresent(
+					"test_classic_histogram",
+					compareMetricType(pmetric.MetricTypeHistogram),
+					compareMetricUnit(""),
+					[]dataPointExpectation{{
+						histogramPointComparator: []histogramPointComparator{
+							compareHistogram(12, 50, []float64{0.5, 10}, []uint64{7, 4, 1}),
+						},
+					}},
+				),
+			},
+		},
+		{
+			name: "native histograms disabled",
+			expectations: []testExpectation{
+				assertMetricAbsent("test_native_histogram"),
+				assertMetricPresent(
+					"test_classic_histogram",
+					compareMetricType(pmetric.MetricTypeHistogram),
+					compareMetricUnit(""),
+					[]dataPointExpectation{{
+						histogramPointComparator: []histogramPointComparator{
+							compareHistogram(12, 50, []float64{0.5, 10}, []uint64{7, 4, 1}),
+						},
+					}},
+				),
+			},
+		},
+		{
+			name:                    "native histograms disabled with classic histograms",
+			scrapeClassicHistograms: true,
+			expectations: []testExpectation{
+				assertMetricPresent(
+					"test_native_histogram",
+					compareMetricType(pmetric.MetricTypeHistogram),
+					compareMetricUnit(""),
+					[]dataPointExpectation{{
+						histogramPointComparator: []histogramPointComparator{
+							compareHistogram(12, 50, []float64{0.5, 10}, []uint64{7, 4, 1}),
+						},
+					}},
+				),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, featuregate.GlobalRegistry().Set(enableNativeHistogramsGate.ID(), tt.enableNativeHistograms))
+			defer func() {
+				require.NoError(t, featuregate.GlobalRegistry().Set(enableNativeHistogramsGate.ID(), false))
+			}()
+
+			// buckets (0.25, 1], (1, 4], (4, 16] and (16, 64] on the positive side and
+			// [-4, -1) and [-64, -16) on the negative side
+			mf := &dto.MetricFamily{
+				Name: "test_native_histogram",
+				Type: dto.MetricType_HISTOGRAM,
+				Metric: []dto.Metric{
+					{
+						Histogram: &dto.Histogram{
+							SampleCount:   12,
+							SampleSum:     50,
+							Bucket:        classicBuckets,
+							Schema:        -1,
+							ZeroThreshold: 0.001,
+							ZeroCount:     2,
+							NegativeSpan:  []dto.BucketSpan{{Offset: 1, Length: 1}, {Offset: 1, Length: 1}},
+							NegativeDelta: []int64{1, 1},
+							PositiveSpan:  []dto.BucketSpan{{Offset: 0, Length: 4}},
+							PositiveDelta: []int64{1, 2, -1, -1},
+						},
+					},
+				},
+			}
+			buffer := prometheusMetricFamilyToProtoBuf(t, nil, mf)
+
+			mf = &dto.MetricFamily{
+				Name: "test_classic_histogram",
+				Type: dto.MetricType_HISTOGRAM,
+				Metric: []dto.Metric{
+					{
+						Histogram: &dto.Histogram{
+							SampleCount: 12,
+							SampleSum:   50,
+							Bucket:      classicBuckets,
+						},
+					},
+				},
+			}
+			prometheusMetricFamilyToProtoBuf(t, buffer, mf)
+
+			targets := []*testData{
+				{
+					name: "target1",
+					pages: []mockPrometheusResponse{
+						{code: 200, useProtoBuf: true, buf: buffer.Bytes()},
+					},
+					validateFunc: func(t *testing.T, td *testData, result []pmetric.ResourceMetrics) {
+						verifyNumValidScrapeResults(t, td, result)
+						doCompare(t, "target1", td.attributes, result[0], tt.expectations)
+					},
+				},
+			}
+
+			testComponent(t, targets, func(c *Config) {
+				c.EnableProtobufNegotiation = true
+			}, func(cfg *PromConfig) {
+				for _, sc := range cfg.ScrapeConfigs {
+					sc.ScrapeClassicHistograms = tt.scrapeClassicHistograms
+				}
+			})
+		})
+	}
+}
@@ -37,6 +37,9 @@ var service = observer.K8sService{
 	Annotations: map[string]string{
 		"scrape": "true",
 	},
+	Ports: []observer.K8sServicePort{
+		{Name: "http", Port: 8080, Transport: observer.ProtocolTCP},
+	},
 }
 
 var serviceEndpoint = observer.Endpoint{
@@ -45,6 +48,24 @@ var serviceEndpoint = observer.Endpoint{
 	Details: &service,
 }
 
+var ingressEndpoint = observer.Endpoint{
+	ID:     "ingress-1",
+	Target: "https://example.com/api",
+	Details: &observer.K8sIngress{
+		UID:       "uid-1",
+		Namespace: "default",
+		Name:      "ingress-1",
+		Labels: map[string]string{
+			"app": "api",
+		},
+		Scheme:      "https",
+		Host:        "example.com",
+		Path:        "/api",
+		ServiceName: "api",
+		ServicePort: "http",
+	},
+}
+
 var portEndpoint = observer.Endpoint{
 	ID:     "port-1",
 	Target: "localhost:1234",
@@ -111,6 +132,16 @@ var k8sNodeEndpoint = observer.Endpoint{
 	},
 }
 
+var staticEndpoint = observer.Endpoint{
+	ID:     "orders-db",
+	Target: "10.0.0.5:5432",
+	Details: &observer.Static{
+		Name:       "orders-db",
+		Labels:     map[string]string{"role": "postgres"},
+		Attributes: map[string]any{"database": "orders", "replicas": 2},
+	},
+}
+
 var unsupportedEndpoint = observer.Endpoint{
 	ID:      "endpoint-1",
 	Target:  "localhost:1234",
@@ -29,9 +29,12 @@ func Test_ruleEval(t *testing.T) {
 		{"basic hostport", args{`type == "hostport" && port == 1234 && process_name == "splunk"`, hostportEndpoint}, true, false},
 		{"basic pod", args{`type == "pod" && labels["region"] == "west-1"`, podEndpoint}, true, false},
 		{"basic service", args{`type == "k8s.service" && labels["region"] == "west-1"`, serviceEndpoint}, true, false},
+		{"service ports", args{`type == "k8s.service" && any(ports, {.name == "http" && .port == 8080})`, serviceEndpoint}, true, false},
+		{"basic ingress", args{`type == "k8s.ingress" && host == "example.com" && scheme == "https"`, ingressEndpoint}, true, false},
 		{"annotations", args{`type == "pod" && annotations["scrape"] == "true"`, podEndpoint}, true, false},
 		{"basic container", args{`type == "container" && labels["region"] == "east-1"`, containerEndpoint}, true, false},
 		{"basic k8s.node", args{`type == "k8s.node" && kubelet_endpoint_port == 10250`, k8sNodeEndpoint}, true, false},
+		{"basic static", args{`type == "static" && labels["role"] == "postgres" && attributes["replicas"] > 1`, staticEndpoint}, true, false},
 		{"relocated type builtin", args{`type == "k8s.node" && typeOf("some string") == "string"`, k8sNodeEndpoint}, true, false},
 	}
 	for _, tt := range tests {
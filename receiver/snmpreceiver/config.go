@@ -11,6 +11,8 @@ import (
 
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver/internal/mib"
 )
 
 // Config Defaults
@@ -23,6 +25,8 @@ const (
 	defaultSecurityLevel      = "no_auth_no_priv"
 	defaultAuthType           = "MD5"
 	defaultPrivacyType        = "DES"
+	defaultTrapsEndpoint      = "udp://0.0.0.0:162"
+	defaultTrapsExpiration    = time.Hour
 )
 
 var (
@@ -52,6 +56,9 @@ var (
 	errMsgMultipleKeysSetOnResourceAttribute        = `resource attribute '%s' must have only one of oid, scalar_oid, or indexed_value_prefix`
 	errScalarOIDResourceAttributeEndsInNonzeroDigit = `resource attribute '%s' has scalar_oid '%s' that ends in a nonzero digit (scalar oids should not be indexed)`
 	errColumnOIDResourceAttributeEndsInZero         = `resource attribute '%s' has oid '%s' that ends in a zero (column oids should be indexed)`
+	errMsgTrapMetricNoOID                           = `traps metric '%s' must have a trap_oid`
+	errMsgSymbolicOIDNoMIBs                         = `oid '%s' is not numeric and requires mibs to be configured`
+	errMsgWalkNoOID                                 = `walk %d must contain an oid`
 
 	// Config errors
 	errEmptyEndpoint        = errors.New("endpoint must be specified")
@@ -68,6 +75,11 @@ var (
 	errBadPrivacyType       = errors.New("privacy_type must be either DES, AES, AES192, AES192C, AES256, AES256C")
 	errEmptyPrivacyPassword = errors.New("privacy_password must be specified when security_level is auth_priv")
 	errMetricRequired       = errors.New("must have at least one config under metrics")
+	errTrapsEndpointScheme  = errors.New("traps endpoint scheme must be either tcp or udp")
+	errTrapsRequired        = errors.New("traps must be configured to receive logs")
+	errTrapsExpiration      = errors.New("traps metrics_expiration must not be negative")
+	errEmptyMIBPaths        = errors.New("mibs paths must contain at least one file or directory")
+	errWalksRequireMIBs     = errors.New("mibs must be configured to use walks")
 )
 
 // Config defines the configuration for the various elements of the receiver.
@@ -131,6 +143,61 @@ type Config struct {
 	// Metrics defines what SNMP metrics will be collected for this receiver and is composed of metric
 	// names along with their metric configurations
 	Metrics map[string]*MetricConfig `mapstructure:"metrics"`
+
+	// Traps enables a listener for SNMP traps and informs. Incoming messages are
+	// authenticated with the version, community and v3 security settings above.
+	// Metrics are optional when Traps is set.
+	Traps *TrapsConfig `mapstructure:"traps"`
+
+	// MIBs loads MIB modules to resolve symbolic OIDs, such as IF-MIB::ifHCInOctets or
+	// SNMPv2-MIB::sysUpTime.0, anywhere an OID is expected in this config.
+	MIBs *MIBsConfig `mapstructure:"mibs"`
+
+	// Walks creates a metric for every numeric table column defined in the loaded MIBs
+	// under the configured OIDs. Metrics are optional when Walks is set.
+	Walks []WalkConfig `mapstructure:"walks"`
+}
+
+// MIBsConfig contains config info about the MIB modules to load
+type MIBsConfig struct {
+	// Paths is required and contains MIB files, or directories containing MIB files.
+	// Directories are not walked recursively.
+	Paths []string `mapstructure:"paths"`
+}
+
+// WalkConfig contains config info about a MIB subtree whose table columns are collected
+type WalkConfig struct {
+	// OID is required and is the subtree of the table columns, such as IF-MIB::ifXTable
+	OID string `mapstructure:"oid"`
+	// MetricPrefix is optional and is prepended to the MIB names of the columns to name
+	// the metrics
+	MetricPrefix string `mapstructure:"metric_prefix"`
+}
+
+// TrapsConfig contains config info about the SNMP trap listener
+type TrapsConfig struct {
+	// Endpoint is the address to listen on for traps and informs. Must be formatted as [udp|tcp]://{host}:{port}.
+	// Default: udp://0.0.0.0:162
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Metrics is optional and defines counters that are incremented every time a trap with
+	// the configured trap OID is received. It is composed of metric names along with their
+	// trap metric configurations
+	Metrics map[string]*TrapMetricConfig `mapstructure:"metrics"`
+
+	// MetricsExpiration is the time after which the counts of a source that sent no
+	// matching trap are dropped. A count that is dropped starts again from 0.
+	// Default: 1h
+	MetricsExpiration time.Duration `mapstructure:"metrics_expiration"`
+}
+
+// TrapMetricConfig contains config info about a metric counting traps
+type TrapMetricConfig struct {
+	// Description is optional and describes what this metric represents
+	Description string `mapstructure:"description"`
+	// TrapOID is required and is the snmpTrapOID of the traps this metric counts.
+	// For SNMPv1 traps, the OID is derived from the enterprise and trap type as per RFC 3584.
+	TrapOID string `mapstructure:"trap_oid"`
 }
 
 // ResourceAttributeConfig contains config info about all of the resource attributes that will be used by this receiver.
@@ -169,6 +236,10 @@ type AttributeConfig struct {
 	// IndexedValuePrefix is required only if Enum and OID are not defined.
 	// This is used alongside metrics with ColumnOIDs to assign attribute values using this prefix + the OID index of the metric value
 	IndexedValuePrefix string `mapstructure:"indexed_value_prefix"`
+
+	// mibIndex is set on the attributes created by walks and decodes the attribute value
+	// from the OID index of the metric value
+	mibIndex *mibIndex
 }
 
 // MetricConfig contains config info about a given metric
@@ -250,6 +321,70 @@ func (cfg *Config) Validate() error {
 		combinedErr = errors.Join(combinedErr, validateSecurity(cfg))
 	}
 	combinedErr = errors.Join(combinedErr, validateMetricConfigs(cfg))
+	if cfg.Traps != nil {
+		combinedErr = errors.Join(combinedErr, validateTraps(cfg.Traps))
+	}
+	combinedErr = errors.Join(combinedErr, validateMIBs(cfg))
+
+	return combinedErr
+}
+
+// validateMIBs validates the MIBs and Walks configs, and that OIDs are numeric when no
+// MIBs are loaded to resolve them
+func validateMIBs(cfg *Config) error {
+	var combinedErr error
+
+	if cfg.MIBs != nil && len(cfg.MIBs.Paths) == 0 {
+		combinedErr = errors.Join(combinedErr, errEmptyMIBPaths)
+	}
+
+	if len(cfg.Walks) > 0 && cfg.MIBs == nil {
+		combinedErr = errors.Join(combinedErr, errWalksRequireMIBs)
+	}
+	for i, walk := range cfg.Walks {
+		if walk.OID == "" {
+			combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgWalkNoOID, i))
+		}
+	}
+
+	if cfg.MIBs != nil {
+		return combinedErr
+	}
+	for _, oid := range configOIDs(cfg) {
+		if *oid != "" && !mib.IsNumericOID(*oid) {
+			combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgSymbolicOIDNoMIBs, *oid))
+		}
+	}
+
+	return combinedErr
+}
+
+// validateTraps validates the trap listener configs
+func validateTraps(traps *TrapsConfig) error {
+	var combinedErr error
+
+	// An empty endpoint is replaced by the default one when the receiver is created
+	if traps.Endpoint != "" {
+		u, err := url.Parse(traps.Endpoint)
+		switch {
+		case err != nil:
+			combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgInvalidEndpointWError, traps.Endpoint, err))
+		case u.Host == "" || u.Port() == "":
+			combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgInvalidEndpoint, traps.Endpoint))
+		case u.Scheme != "udp" && u.Scheme != "tcp":
+			combinedErr = errors.Join(combinedErr, errTrapsEndpointScheme)
+		}
+	}
+
+	for metricName, metricCfg := range traps.Metrics {
+		if metricCfg == nil || metricCfg.TrapOID == "" {
+			combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgTrapMetricNoOID, metricName))
+		}
+	}
+
+	if traps.MetricsExpiration < 0 {
+		combinedErr = errors.Join(combinedErr, errTrapsExpiration)
+	}
 
 	return combinedErr
 }
@@ -378,9 +513,12 @@ func validateMetricConfigs(cfg *Config) error {
 	combinedErr = errors.Join(combinedErr, validateAttributeConfigs(cfg))
 	combinedErr = errors.Join(combinedErr, validateResourceAttributeConfigs(cfg))
 
-	// Ensure there is at least one MetricConfig
+	// Ensure there is at least one MetricConfig, unless traps are received or metrics are walked
 	metrics := cfg.Metrics
 	if len(metrics) == 0 {
+		if cfg.Traps != nil || len(cfg.Walks) > 0 {
+			return combinedErr
+		}
 		return errors.Join(combinedErr, errMetricRequired)
 	}
 
@@ -574,7 +712,7 @@ func validateAttributeConfigs(cfg *Config) error {
 
 	// Make sure each Attribute has either an OID, Enum, or IndexedValuePrefix
 	for attrName, attrCfg := range attributes {
-		if len(attrCfg.Enum) == 0 && attrCfg.OID == "" && attrCfg.IndexedValuePrefix == "" {
+		if len(attrCfg.Enum) == 0 && attrCfg.OID == "" && attrCfg.IndexedValuePrefix == "" && attrCfg.mibIndex == nil {
 			combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgAttributeConfigNoEnumOIDOrPrefix, attrName))
 		}
 	}
@@ -169,6 +169,16 @@ func (h configHelper) getAttributeConfigIndexedValuePrefix(name string) string {
 	return attrConfig.IndexedValuePrefix
 }
 
+// getAttributeConfigMIBIndex returns the MIB index decoder of an attribute config created by a walk
+func (h configHelper) getAttributeConfigMIBIndex(name string) *mibIndex {
+	attrConfig := h.cfg.Attributes[name]
+	if attrConfig == nil {
+		return nil
+	}
+
+	return attrConfig.mibIndex
+}
+
 // getAttributeConfigOID returns the column OID of an attribute config
 func (h configHelper) getAttributeConfigOID(name string) string {
 	attrConfig := h.cfg.Attributes[name]
@@ -4,9 +4,11 @@
 package snmpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver"
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -1141,3 +1143,173 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadConfigTraps(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	factory := NewFactory()
+
+	expectedConfigTrapsOnly := factory.CreateDefaultConfig().(*Config)
+	expectedConfigTrapsOnly.Traps = &TrapsConfig{
+		Endpoint: "udp://0.0.0.0:1162",
+		Metrics: map[string]*TrapMetricConfig{
+			"snmp.link_down": {
+				Description: "Number of linkDown traps received",
+				TrapOID:     ".1.3.6.1.6.3.1.1.5.3",
+			},
+		},
+	}
+
+	testCases := []struct {
+		name        string
+		nameVal     string
+		expectedCfg *Config
+		expectedErr string
+	}{
+		{
+			name:        "TrapsWithoutMetricsNoErrors",
+			nameVal:     "traps_only",
+			expectedCfg: expectedConfigTrapsOnly,
+		},
+		{
+			name:    "TrapsBadSchemeErrors",
+			nameVal: "traps_bad_scheme",
+			expectedCfg: func() *Config {
+				cfg := factory.CreateDefaultConfig().(*Config)
+				cfg.Traps = &TrapsConfig{Endpoint: "udp4://0.0.0.0:1162"}
+				return cfg
+			}(),
+			expectedErr: errTrapsEndpointScheme.Error(),
+		},
+		{
+			name:    "TrapsMetricNoOIDErrors",
+			nameVal: "traps_metric_no_oid",
+			expectedCfg: func() *Config {
+				cfg := factory.CreateDefaultConfig().(*Config)
+				cfg.Traps = &TrapsConfig{
+					Endpoint: "udp://0.0.0.0:1162",
+					Metrics: map[string]*TrapMetricConfig{
+						"snmp.link_down": {Description: "Number of linkDown traps received"},
+					},
+				}
+				return cfg
+			}(),
+			expectedErr: fmt.Sprintf(errMsgTrapMetricNoOID, "snmp.link_down"),
+		},
+		{
+			name:    "TrapsNegativeExpirationErrors",
+			nameVal: "traps_negative_expiration",
+			expectedCfg: func() *Config {
+				cfg := factory.CreateDefaultConfig().(*Config)
+				cfg.Traps = &TrapsConfig{
+					Endpoint:          "udp://0.0.0.0:1162",
+					MetricsExpiration: -time.Minute,
+				}
+				return cfg
+			}(),
+			expectedErr: errTrapsExpiration.Error(),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			sub, err := cm.Sub(component.NewIDWithName(metadata.Type, test.nameVal).String())
+			require.NoError(t, err)
+
+			cfg := factory.CreateDefaultConfig()
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+			if test.expectedErr == "" {
+				require.NoError(t, component.ValidateConfig(cfg))
+			} else {
+				require.ErrorContains(t, component.ValidateConfig(cfg), test.expectedErr)
+			}
+
+			require.Equal(t, test.expectedCfg, cfg)
+		})
+	}
+}
+
+func TestLoadConfigMIBs(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	factory := NewFactory()
+
+	uptimeMetric := func() map[string]*MetricConfig {
+		return map[string]*MetricConfig{
+			"snmp.uptime": {
+				Unit:       "s",
+				Gauge:      &GaugeMetric{ValueType: "int"},
+				ScalarOIDs: []ScalarOID{{OID: "SNMPv2-MIB::sysUpTime.0"}},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name        string
+		nameVal     string
+		expectedCfg *Config
+		expectedErr string
+	}{
+		{
+			name:    "MIBsWithWalksNoErrors",
+			nameVal: "mibs",
+			expectedCfg: func() *Config {
+				cfg := factory.CreateDefaultConfig().(*Config)
+				cfg.MIBs = &MIBsConfig{Paths: []string{"/usr/share/snmp/mibs"}}
+				cfg.Walks = []WalkConfig{{OID: "IF-MIB::ifXTable", MetricPrefix: "snmp."}}
+				cfg.Metrics = uptimeMetric()
+				return cfg
+			}(),
+		},
+		{
+			name:    "SymbolicOIDWithoutMIBsErrors",
+			nameVal: "symbolic_oid_without_mibs",
+			expectedCfg: func() *Config {
+				cfg := factory.CreateDefaultConfig().(*Config)
+				cfg.Metrics = uptimeMetric()
+				return cfg
+			}(),
+			expectedErr: fmt.Sprintf(errMsgSymbolicOIDNoMIBs, "SNMPv2-MIB::sysUpTime.0"),
+		},
+		{
+			name:    "WalksWithoutMIBsErrors",
+			nameVal: "walks_without_mibs",
+			expectedCfg: func() *Config {
+				cfg := factory.CreateDefaultConfig().(*Config)
+				cfg.Walks = []WalkConfig{{OID: "IF-MIB::ifXTable"}}
+				return cfg
+			}(),
+			expectedErr: errWalksRequireMIBs.Error(),
+		},
+		{
+			name:    "MIBsNoPathsErrors",
+			nameVal: "mibs_no_paths",
+			expectedCfg: func() *Config {
+				cfg := factory.CreateDefaultConfig().(*Config)
+				cfg.MIBs = &MIBsConfig{Paths: []string{}}
+				cfg.Walks = []WalkConfig{{MetricPrefix: "snmp."}}
+				return cfg
+			}(),
+			expectedErr: errors.Join(errEmptyMIBPaths, fmt.Errorf(errMsgWalkNoOID, 0)).Error(),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			sub, err := cm.Sub(component.NewIDWithName(metadata.Type, test.nameVal).String())
+			require.NoError(t, err)
+
+			cfg := factory.CreateDefaultConfig()
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+			if test.expectedErr == "" {
+				require.NoError(t, component.ValidateConfig(cfg))
+			} else {
+				require.ErrorContains(t, component.ValidateConfig(cfg), test.expectedErr)
+			}
+
+			require.Equal(t, test.expectedCfg, cfg)
+		})
+	}
+}
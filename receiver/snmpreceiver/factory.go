@@ -15,6 +15,7 @@ import (
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver/internal/metadata"
 )
 
@@ -25,7 +26,8 @@ func NewFactory() receiver.Factory {
 	return receiver.NewFactory(
 		metadata.Type,
 		createDefaultConfig,
-		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability))
+		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability),
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability))
 }
 
 // createDefaultConfig creates a config for SNMP with as many default values as possible
@@ -56,17 +58,87 @@ func createMetricsReceiver(
 		return nil, errConfigNotSNMP
 	}
 
+	if err := resolveMIBs(snmpConfig); err != nil {
+		return nil, err
+	}
+
 	if err := addMissingConfigDefaults(snmpConfig); err != nil {
 		return nil, fmt.Errorf("failed to validate added config defaults: %w", err)
 	}
 
+	var traps *sharedcomponent.SharedComponent
+	if snmpConfig.Traps != nil && len(snmpConfig.Traps.Metrics) > 0 {
+		traps = trapReceivers.GetOrAdd(snmpConfig, func() component.Component {
+			return newTrapReceiver(snmpConfig, params)
+		})
+		traps.Unwrap().(*trapReceiver).metricsConsumer = consumer
+		// Only traps are received
+		if len(snmpConfig.Metrics) == 0 {
+			return traps, nil
+		}
+	}
+
 	snmpScraper := newScraper(params.Logger, snmpConfig, params)
 	scraper, err := scraperhelper.NewScraper(metadata.Type.String(), snmpScraper.scrape, scraperhelper.WithStart(snmpScraper.start))
 	if err != nil {
 		return nil, err
 	}
 
-	return scraperhelper.NewScraperControllerReceiver(&snmpConfig.ScraperControllerSettings, params, consumer, scraperhelper.AddScraper(scraper))
+	scraperReceiver, err := scraperhelper.NewScraperControllerReceiver(&snmpConfig.ScraperControllerSettings, params, consumer, scraperhelper.AddScraper(scraper))
+	if err != nil || traps == nil {
+		return scraperReceiver, err
+	}
+	return &metricsReceiver{Metrics: scraperReceiver, traps: traps}, nil
+}
+
+// createLogsReceiver creates the logs receiver for SNMP traps and informs
+func createLogsReceiver(
+	_ context.Context,
+	params receiver.CreateSettings,
+	config component.Config,
+	consumer consumer.Logs,
+) (receiver.Logs, error) {
+	snmpConfig, ok := config.(*Config)
+	if !ok {
+		return nil, errConfigNotSNMP
+	}
+
+	if snmpConfig.Traps == nil {
+		return nil, errTrapsRequired
+	}
+
+	if err := addMissingConfigDefaults(snmpConfig); err != nil {
+		return nil, fmt.Errorf("failed to validate added config defaults: %w", err)
+	}
+
+	traps := trapReceivers.GetOrAdd(snmpConfig, func() component.Component {
+		return newTrapReceiver(snmpConfig, params)
+	})
+	traps.Unwrap().(*trapReceiver).logsConsumer = consumer
+	return traps, nil
+}
+
+// trapReceivers shares a single trap listener between the logs and metrics receivers
+var trapReceivers = sharedcomponent.NewSharedComponents()
+
+// metricsReceiver runs the scraper alongside the trap listener that counts traps
+type metricsReceiver struct {
+	receiver.Metrics
+	traps component.Component
+}
+
+func (r *metricsReceiver) Start(ctx context.Context, host component.Host) error {
+	if err := r.Metrics.Start(ctx, host); err != nil {
+		return err
+	}
+	if err := r.traps.Start(ctx, host); err != nil {
+		return errors.Join(err, r.Metrics.Shutdown(ctx))
+	}
+	return nil
+}
+
+func (r *metricsReceiver) Shutdown(ctx context.Context) error {
+	return errors.Join(r.Metrics.Shutdown(ctx), r.traps.Shutdown(ctx))
 }
 
 // addMissingConfigDefaults adds any missing config parameters that have defaults
@@ -86,6 +158,14 @@ func addMissingConfigDefaults(cfg *Config) error {
 		cfg.Endpoint += portSuffix
 	}
 
+	// Set the default trap listener endpoint and expiration
+	if cfg.Traps != nil && cfg.Traps.Endpoint == "" {
+		cfg.Traps.Endpoint = defaultTrapsEndpoint
+	}
+	if cfg.Traps != nil && cfg.Traps.MetricsExpiration == 0 {
+		cfg.Traps.MetricsExpiration = defaultTrapsExpiration
+	}
+
 	// Set defaults for metric configs
 	for _, metricCfg := range cfg.Metrics {
 		if metricCfg.Unit == "" {
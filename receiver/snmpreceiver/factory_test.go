@@ -13,6 +13,7 @@ import (
 	"go.opentelemetry.io/collector/receiver/receivertest"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver/internal/metadata"
 )
 
@@ -260,6 +261,68 @@ func TestNewFactory(t *testing.T) {
 				require.Equal(t, "1", snmpCfg.Metrics["m1"].Unit)
 			},
 		},
+		{
+			desc: "CreateLogsReceiver returns error without traps config",
+			testFunc: func(t *testing.T) {
+				factory := NewFactory()
+				_, err := factory.CreateLogsReceiver(
+					context.Background(),
+					receivertest.NewNopCreateSettings(),
+					factory.CreateDefaultConfig(),
+					consumertest.NewNop(),
+				)
+				require.ErrorIs(t, err, errTrapsRequired)
+			},
+		},
+		{
+			desc: "CreateLogsReceiver adds missing traps endpoint",
+			testFunc: func(t *testing.T) {
+				factory := NewFactory()
+				cfg := factory.CreateDefaultConfig()
+				snmpCfg := cfg.(*Config)
+				snmpCfg.Traps = &TrapsConfig{}
+				_, err := factory.CreateLogsReceiver(
+					context.Background(),
+					receivertest.NewNopCreateSettings(),
+					cfg,
+					consumertest.NewNop(),
+				)
+				require.NoError(t, err)
+				require.Equal(t, defaultTrapsEndpoint, snmpCfg.Traps.Endpoint)
+			},
+		},
+		{
+			desc: "CreateMetricsReceiver and CreateLogsReceiver share the trap listener",
+			testFunc: func(t *testing.T) {
+				factory := NewFactory()
+				cfg := factory.CreateDefaultConfig()
+				snmpCfg := cfg.(*Config)
+				snmpCfg.Traps = &TrapsConfig{
+					Metrics: map[string]*TrapMetricConfig{
+						"snmp.link_down": {TrapOID: ".1.3.6.1.6.3.1.1.5.3"},
+					},
+				}
+				metricsReceiver, err := factory.CreateMetricsReceiver(
+					context.Background(),
+					receivertest.NewNopCreateSettings(),
+					cfg,
+					consumertest.NewNop(),
+				)
+				require.NoError(t, err)
+				logsReceiver, err := factory.CreateLogsReceiver(
+					context.Background(),
+					receivertest.NewNopCreateSettings(),
+					cfg,
+					consumertest.NewNop(),
+				)
+				require.NoError(t, err)
+				require.Same(t, metricsReceiver, logsReceiver)
+
+				traps := logsReceiver.(*sharedcomponent.SharedComponent).Unwrap().(*trapReceiver)
+				require.NotNil(t, traps.logsConsumer)
+				require.NotNil(t, traps.metricsConsumer)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
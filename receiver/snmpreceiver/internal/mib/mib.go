@@ -0,0 +1,601 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package mib loads SMIv1 and SMIv2 MIB modules to resolve symbolic OIDs such as
+// IF-MIB::ifHCInOctets and to describe the objects of MIB tables.
+package mib // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver/internal/mib"
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Base syntaxes of objects, after resolving textual conventions
+const (
+	SyntaxInteger          = "INTEGER"
+	SyntaxInteger32        = "Integer32"
+	SyntaxUnsigned32       = "Unsigned32"
+	SyntaxGauge32          = "Gauge32"
+	SyntaxCounter32        = "Counter32"
+	SyntaxCounter64        = "Counter64"
+	SyntaxTimeTicks        = "TimeTicks"
+	SyntaxOctetString      = "OCTET STRING"
+	SyntaxObjectIdentifier = "OBJECT IDENTIFIER"
+	SyntaxIPAddress        = "IpAddress"
+	SyntaxOpaque           = "Opaque"
+	SyntaxBits             = "BITS"
+)
+
+// builtinOIDs are the nodes defined by the SMI modules, which are mostly made of macro
+// definitions and therefore don't need to be loaded
+var builtinOIDs = map[string]string{
+	"ccitt":           ".0",
+	"zeroDotZero":     ".0.0",
+	"iso":             ".1",
+	"org":             ".1.3",
+	"dod":             ".1.3.6",
+	"internet":        ".1.3.6.1",
+	"directory":       ".1.3.6.1.1",
+	"mgmt":            ".1.3.6.1.2",
+	"mib-2":           ".1.3.6.1.2.1",
+	"transmission":    ".1.3.6.1.2.1.10",
+	"experimental":    ".1.3.6.1.3",
+	"private":         ".1.3.6.1.4",
+	"enterprises":     ".1.3.6.1.4.1",
+	"security":        ".1.3.6.1.5",
+	"snmpV2":          ".1.3.6.1.6",
+	"snmpDomains":     ".1.3.6.1.6.1",
+	"snmpProxys":      ".1.3.6.1.6.2",
+	"snmpModules":     ".1.3.6.1.6.3",
+	"joint-iso-ccitt": ".2",
+}
+
+// smiTypes are the base types of the SMI. They are checked before the type assignments of
+// the loaded modules, which define them as tagged INTEGER or OCTET STRING types.
+var smiTypes = map[string]string{
+	"Integer32":      SyntaxInteger32,
+	"Unsigned32":     SyntaxUnsigned32,
+	"Gauge":          SyntaxGauge32,
+	"Gauge32":        SyntaxGauge32,
+	"Counter":        SyntaxCounter32,
+	"Counter32":      SyntaxCounter32,
+	"Counter64":      SyntaxCounter64,
+	"TimeTicks":      SyntaxTimeTicks,
+	"IpAddress":      SyntaxIPAddress,
+	"NetworkAddress": SyntaxIPAddress,
+	"Opaque":         SyntaxOpaque,
+}
+
+// textualConventions are the base syntaxes of common textual conventions, so that the
+// modules defining them don't need to be loaded
+var textualConventions = map[string]string{
+	"DisplayString":        SyntaxOctetString,
+	"PhysAddress":          SyntaxOctetString,
+	"MacAddress":           SyntaxOctetString,
+	"DateAndTime":          SyntaxOctetString,
+	"TAddress":             SyntaxOctetString,
+	"SnmpAdminString":      SyntaxOctetString,
+	"TruthValue":           SyntaxInteger,
+	"TestAndIncr":          SyntaxInteger,
+	"TimeInterval":         SyntaxInteger,
+	"RowStatus":            SyntaxInteger,
+	"StorageType":          SyntaxInteger,
+	"TimeStamp":            SyntaxTimeTicks,
+	"TDomain":              SyntaxObjectIdentifier,
+	"AutonomousType":       SyntaxObjectIdentifier,
+	"InstancePointer":      SyntaxObjectIdentifier,
+	"VariablePointer":      SyntaxObjectIdentifier,
+	"RowPointer":           SyntaxObjectIdentifier,
+	"ObjectName":           SyntaxObjectIdentifier,
+	"InterfaceIndex":       SyntaxInteger32,
+	"InterfaceIndexOrZero": SyntaxInteger32,
+}
+
+// maxTypeDepth bounds the resolution of textual conventions defined from one another
+const maxTypeDepth = 16
+
+var numericOIDRegex = regexp.MustCompile(`^\.?\d+(\.\d+)*$`)
+
+// IsNumericOID returns whether the OID is made of numbers only, such as .1.3.6.1.2.1.1.3.0
+func IsNumericOID(oid string) bool {
+	return numericOIDRegex.MatchString(oid)
+}
+
+// IndexPart is an object of the INDEX clause of a table entry
+type IndexPart struct {
+	Name string
+	// Implied is set when the index is the last one and its length is not encoded
+	Implied bool
+}
+
+// Object is a resolved MIB object
+type Object struct {
+	Module string
+	Name   string
+	// OID is the numeric OID of the object, with a leading dot
+	OID string
+	// Macro is the macro the object was defined with, such as OBJECT-TYPE
+	Macro string
+	// Syntax is the base syntax of OBJECT-TYPE objects, after resolving textual conventions
+	Syntax string
+	Units  string
+	Access string
+	// Description is the description of the object, with whitespace collapsed
+	Description string
+	// Index is set on table entries, and is resolved from AUGMENTS when needed
+	Index []IndexPart
+
+	augments string
+	parent   *Object
+}
+
+// Readable returns whether the value of the object can be retrieved
+func (o *Object) Readable() bool {
+	switch o.Access {
+	case "read-only", "read-write", "read-create":
+		return true
+	default:
+		return false
+	}
+}
+
+// IsColumn returns whether the object is a column of a table
+func (o *Object) IsColumn() bool {
+	return o.Macro == "OBJECT-TYPE" && o.parent != nil && len(o.parent.Index) > 0
+}
+
+// Entry returns the table entry of a column, or nil if the object is not a column
+func (o *Object) Entry() *Object {
+	if !o.IsColumn() {
+		return nil
+	}
+	return o.parent
+}
+
+// Tree holds the objects of the loaded MIB modules
+type Tree struct {
+	modules map[string]*module
+	objects map[string]*Object
+	// byName holds the objects by name, across modules
+	byName map[string][]*Object
+	byOID  map[string]*Object
+}
+
+// Load parses the MIB modules found in the given files and directories. Directories are
+// not walked recursively.
+func Load(paths []string) (*Tree, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+			files = append(files, filepath.Join(path, entry.Name()))
+		}
+	}
+
+	var modules []*module
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := parseModules(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse MIB file '%s': %w", file, err)
+		}
+		modules = append(modules, parsed...)
+	}
+	return newTree(modules)
+}
+
+// newTree resolves the definitions of the modules to numeric OIDs
+func newTree(modules []*module) (*Tree, error) {
+	t := &Tree{
+		modules: map[string]*module{},
+		objects: map[string]*Object{},
+		byName:  map[string][]*Object{},
+		byOID:   map[string]*Object{},
+	}
+	for _, m := range modules {
+		t.modules[m.name] = m
+	}
+
+	r := &resolver{tree: t, resolving: map[string]bool{}}
+	var errs error
+	for _, m := range modules {
+		for _, def := range m.definitions {
+			if _, err := r.resolve(m, def); err != nil {
+				errs = errors.Join(errs, fmt.Errorf("%s::%s: %w", m.name, def.name, err))
+			}
+		}
+	}
+	if errs != nil {
+		return nil, errs
+	}
+
+	// Link the objects to their parent and resolve the index of augmenting entries
+	for _, object := range t.objects {
+		parentOID := object.OID[:strings.LastIndexByte(object.OID, '.')]
+		object.parent = t.byOID[parentOID]
+	}
+	for _, object := range t.objects {
+		if object.augments == "" {
+			continue
+		}
+		augmented, err := t.lookup(object.Module, object.augments)
+		if err != nil {
+			return nil, fmt.Errorf("%s::%s: %w", object.Module, object.Name, err)
+		}
+		object.Index = augmented.Index
+	}
+	return t, nil
+}
+
+type resolver struct {
+	tree      *Tree
+	resolving map[string]bool
+}
+
+func (r *resolver) resolve(m *module, def *definition) (*Object, error) {
+	key := m.name + "::" + def.name
+	if object, ok := r.tree.objects[key]; ok {
+		return object, nil
+	}
+	if r.resolving[key] {
+		return nil, errors.New("circular OID definition")
+	}
+	r.resolving[key] = true
+	defer delete(r.resolving, key)
+
+	var oid string
+	if def.macro == "TRAP-TYPE" {
+		enterprise := def.enterprise
+		if enterprise == "" && len(def.value) == 1 {
+			enterprise = def.value[0].name
+		}
+		enterpriseOID, err := r.resolveName(m, enterprise)
+		if err != nil {
+			return nil, err
+		}
+		// SNMPv1 enterprise specific traps map to enterprise.0.n as per RFC 3584
+		oid = fmt.Sprintf("%s.0.%d", enterpriseOID, def.trapNumber)
+	} else {
+		var err error
+		if oid, err = r.resolveValue(m, def.value); err != nil {
+			return nil, err
+		}
+	}
+
+	object := &Object{
+		Module:      m.name,
+		Name:        def.name,
+		OID:         oid,
+		Macro:       def.macro,
+		Syntax:      r.tree.baseSyntax(m, def.syntax),
+		Units:       def.units,
+		Access:      def.access,
+		Description: def.description,
+		Index:       def.index,
+		augments:    def.augments,
+	}
+	r.tree.objects[key] = object
+	r.tree.byName[def.name] = append(r.tree.byName[def.name], object)
+	// Definitions of the same OID in several modules are expected, the object types win
+	if existing, ok := r.tree.byOID[oid]; !ok || existing.Macro != "OBJECT-TYPE" {
+		r.tree.byOID[oid] = object
+	}
+	return object, nil
+}
+
+// resolveValue resolves an OBJECT IDENTIFIER value such as "{ mib-2 2 }"
+func (r *resolver) resolveValue(m *module, value []oidComponent) (string, error) {
+	var sb strings.Builder
+	for i, component := range value {
+		if component.hasNumber {
+			sb.WriteString("." + strconv.Itoa(component.number))
+			continue
+		}
+		if i != 0 {
+			return "", fmt.Errorf("unexpected '%s' in OBJECT IDENTIFIER value", component.name)
+		}
+		oid, err := r.resolveName(m, component.name)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(oid)
+	}
+	return sb.String(), nil
+}
+
+// resolveName resolves the OID of a name visible from the module
+func (r *resolver) resolveName(m *module, name string) (string, error) {
+	if def, ok := m.definitions[name]; ok {
+		object, err := r.resolve(m, def)
+		if err != nil {
+			return "", err
+		}
+		return object.OID, nil
+	}
+	if from, ok := m.imports[name]; ok {
+		if imported, ok := r.tree.modules[from]; ok {
+			if def, ok := imported.definitions[name]; ok {
+				object, err := r.resolve(imported, def)
+				if err != nil {
+					return "", err
+				}
+				return object.OID, nil
+			}
+		}
+	}
+	if oid, ok := builtinOIDs[name]; ok {
+		return oid, nil
+	}
+	// Fallback to a definition of any module, as imports are sometimes incomplete
+	for _, other := range r.tree.modules {
+		if def, ok := other.definitions[name]; ok {
+			object, err := r.resolve(other, def)
+			if err != nil {
+				return "", err
+			}
+			return object.OID, nil
+		}
+	}
+	if from, ok := m.imports[name]; ok {
+		return "", fmt.Errorf("unknown object '%s', MIB module %s might not be loaded", name, from)
+	}
+	return "", fmt.Errorf("unknown object '%s'", name)
+}
+
+// baseSyntax resolves textual conventions to their base syntax
+func (t *Tree) baseSyntax(m *module, syntax string) string {
+	for depth := 0; depth < maxTypeDepth; depth++ {
+		switch syntax {
+		case "", SyntaxInteger, SyntaxOctetString, SyntaxObjectIdentifier, SyntaxBits:
+			return syntax
+		}
+		if smiType, ok := smiTypes[syntax]; ok {
+			return smiType
+		}
+		owner, next := t.findType(m, syntax)
+		if owner == nil {
+			if tc, ok := textualConventions[syntax]; ok {
+				return tc
+			}
+			return syntax
+		}
+		if next == syntax {
+			return syntax
+		}
+		m, syntax = owner, next
+	}
+	return syntax
+}
+
+// findType finds the module defining a type visible from the module, and its syntax
+func (t *Tree) findType(m *module, name string) (*module, string) {
+	if syntax, ok := m.types[name]; ok {
+		return m, syntax
+	}
+	if imported, ok := t.modules[m.imports[name]]; ok {
+		if syntax, ok := imported.types[name]; ok {
+			return imported, syntax
+		}
+	}
+	for _, other := range t.modules {
+		if syntax, ok := other.types[name]; ok {
+			return other, syntax
+		}
+	}
+	return nil, ""
+}
+
+// lookup finds an object by name, preferring the given module
+func (t *Tree) lookup(moduleName string, name string) (*Object, error) {
+	if moduleName != "" {
+		if object, ok := t.objects[moduleName+"::"+name]; ok {
+			return object, nil
+		}
+		if m, ok := t.modules[moduleName]; ok {
+			if from, ok := m.imports[name]; ok {
+				if object, ok := t.objects[from+"::"+name]; ok {
+					return object, nil
+				}
+			}
+		}
+	}
+	objects := t.byName[name]
+	switch len(objects) {
+	case 0:
+		if moduleName != "" {
+			return nil, fmt.Errorf("unknown object '%s::%s'", moduleName, name)
+		}
+		return nil, fmt.Errorf("unknown object '%s'", name)
+	case 1:
+		return objects[0], nil
+	default:
+		// The same name in several modules is fine as long as it is the same object
+		for _, object := range objects[1:] {
+			if object.OID != objects[0].OID {
+				return nil, fmt.Errorf("object '%s' is ambiguous, use MODULE::%s", name, name)
+			}
+		}
+		return objects[0], nil
+	}
+}
+
+// Lookup finds an object by its symbolic name, in the form MODULE::name or name
+func (t *Tree) Lookup(name string) (*Object, error) {
+	moduleName, objectName, found := strings.Cut(name, "::")
+	if !found {
+		moduleName, objectName = "", name
+	} else if _, ok := t.modules[moduleName]; !ok {
+		return nil, fmt.Errorf("unknown MIB module '%s'", moduleName)
+	}
+	return t.lookup(moduleName, objectName)
+}
+
+// Resolve resolves a symbolic OID such as IF-MIB::ifHCInOctets or SNMPv2-MIB::sysUpTime.0
+// to its numeric form with a leading dot. Numeric OIDs are returned as is.
+func (t *Tree) Resolve(oid string) (string, error) {
+	if IsNumericOID(oid) {
+		return oid, nil
+	}
+	nameStart := 0
+	if i := strings.LastIndex(oid, "::"); i >= 0 {
+		nameStart = i + 2
+	}
+	name, suffix := oid, ""
+	// The object name is followed by an optional numeric suffix
+	if i := strings.IndexByte(oid[nameStart:], '.'); i >= 0 {
+		name, suffix = oid[:nameStart+i], oid[nameStart+i:]
+		if !IsNumericOID(suffix) {
+			return "", fmt.Errorf("invalid OID '%s': the suffix must be numeric", oid)
+		}
+	}
+	object, err := t.Lookup(name)
+	if err != nil {
+		return "", err
+	}
+	return object.OID + suffix, nil
+}
+
+// Object returns the object with the given numeric OID, if any
+func (t *Tree) Object(oid string) *Object {
+	return t.byOID["."+strings.TrimPrefix(oid, ".")]
+}
+
+// Columns returns the readable table columns under or at the given numeric OID, ordered by OID
+func (t *Tree) Columns(oid string) []*Object {
+	oid = "." + strings.TrimPrefix(oid, ".")
+	var columns []*Object
+	for objectOID, object := range t.byOID {
+		if objectOID != oid && !strings.HasPrefix(objectOID, oid+".") {
+			continue
+		}
+		if object.IsColumn() && object.Readable() {
+			columns = append(columns, object)
+		}
+	}
+	sort.Slice(columns, func(i, j int) bool {
+		return compareOIDs(columns[i].OID, columns[j].OID) < 0
+	})
+	return columns
+}
+
+// compareOIDs compares numeric OIDs by their sub-identifiers
+func compareOIDs(a, b string) int {
+	as := strings.Split(strings.TrimPrefix(a, "."), ".")
+	bs := strings.Split(strings.TrimPrefix(b, "."), ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, _ := strconv.Atoi(as[i])
+		bn, _ := strconv.Atoi(bs[i])
+		if an != bn {
+			return an - bn
+		}
+	}
+	return len(as) - len(bs)
+}
+
+// DecodeIndex decodes the index of a row of the table entry, such as ".1" or
+// ".4.192.168.1.1", into the values of the index objects of the entry as per the
+// encoding rules of RFC 2578 section 7.7
+func (t *Tree) DecodeIndex(entry *Object, index string) (map[string]string, error) {
+	var subIDs []uint64
+	for _, s := range strings.Split(strings.TrimPrefix(index, "."), ".") {
+		n, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid index '%s'", index)
+		}
+		subIDs = append(subIDs, n)
+	}
+
+	values := map[string]string{}
+	for _, part := range entry.Index {
+		indexObject, err := t.lookup(entry.Module, part.Name)
+		if err != nil {
+			return nil, err
+		}
+		if len(subIDs) == 0 {
+			return nil, fmt.Errorf("index '%s' is too short for %s", index, entry.Name)
+		}
+
+		switch indexObject.Syntax {
+		case SyntaxOctetString, SyntaxObjectIdentifier, SyntaxOpaque, SyntaxBits:
+			length := len(subIDs)
+			if !part.Implied {
+				length = int(subIDs[0])
+				subIDs = subIDs[1:]
+				if length > len(subIDs) {
+					return nil, fmt.Errorf("index '%s' is too short for %s", index, entry.Name)
+				}
+			}
+			if indexObject.Syntax == SyntaxObjectIdentifier {
+				values[indexObject.Name] = joinSubIDs(subIDs[:length])
+			} else {
+				values[indexObject.Name] = octetsToString(subIDs[:length])
+			}
+			subIDs = subIDs[length:]
+		case SyntaxIPAddress:
+			if len(subIDs) < 4 {
+				return nil, fmt.Errorf("index '%s' is too short for %s", index, entry.Name)
+			}
+			values[indexObject.Name] = strings.TrimPrefix(joinSubIDs(subIDs[:4]), ".")
+			subIDs = subIDs[4:]
+		default:
+			values[indexObject.Name] = strconv.FormatUint(subIDs[0], 10)
+			subIDs = subIDs[1:]
+		}
+	}
+	if len(subIDs) != 0 {
+		return nil, fmt.Errorf("index '%s' is too long for %s", index, entry.Name)
+	}
+	return values, nil
+}
+
+func joinSubIDs(subIDs []uint64) string {
+	var sb strings.Builder
+	for _, subID := range subIDs {
+		sb.WriteString("." + strconv.FormatUint(subID, 10))
+	}
+	return sb.String()
+}
+
+// octetsToString returns printable octets as a string and other octets in hexadecimal
+func octetsToString(subIDs []uint64) string {
+	octets := make([]byte, len(subIDs))
+	printable := true
+	for i, subID := range subIDs {
+		octets[i] = byte(subID)
+		if subID > unicode.MaxASCII || !unicode.IsPrint(rune(subID)) {
+			printable = false
+		}
+	}
+	if printable {
+		return string(octets)
+	}
+	hex := make([]string, len(octets))
+	for i, octet := range octets {
+		hex[i] = fmt.Sprintf("%02x", octet)
+	}
+	return strings.Join(hex, ":")
+}
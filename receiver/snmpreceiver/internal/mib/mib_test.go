@@ -0,0 +1,218 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mib
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadTestTree(t *testing.T) *Tree {
+	tree, err := Load([]string{"testdata"})
+	require.NoError(t, err)
+	return tree
+}
+
+func TestResolve(t *testing.T) {
+	tree := loadTestTree(t)
+
+	testCases := []struct {
+		desc        string
+		oid         string
+		expected    string
+		expectedErr string
+	}{
+		{
+			desc:     "numeric OID",
+			oid:      "1.3.6.1.2.1.1.3.0",
+			expected: "1.3.6.1.2.1.1.3.0",
+		},
+		{
+			desc:     "module and name",
+			oid:      "IF-MIB::ifHCInOctets",
+			expected: ".1.3.6.1.2.1.31.1.1.1.6",
+		},
+		{
+			desc:     "module, name and suffix",
+			oid:      "SNMPv2-MIB::sysUpTime.0",
+			expected: ".1.3.6.1.2.1.1.3.0",
+		},
+		{
+			desc:     "name only",
+			oid:      "ifDescr",
+			expected: ".1.3.6.1.2.1.2.2.1.2",
+		},
+		{
+			desc:     "notification",
+			oid:      "IF-MIB::linkDown",
+			expected: ".1.3.6.1.6.3.1.1.5.3",
+		},
+		{
+			desc:     "SMIv1 trap",
+			oid:      "EXAMPLE-MIB::exampleOverload",
+			expected: ".1.3.6.1.4.1.99999.0.1",
+		},
+		{
+			desc:        "unknown module",
+			oid:         "FOO-MIB::ifDescr",
+			expectedErr: "unknown MIB module 'FOO-MIB'",
+		},
+		{
+			desc:        "unknown object",
+			oid:         "IF-MIB::ifFoo",
+			expectedErr: "unknown object 'IF-MIB::ifFoo'",
+		},
+		{
+			desc:        "non numeric suffix",
+			oid:         "IF-MIB::ifDescr.eth0",
+			expectedErr: "invalid OID 'IF-MIB::ifDescr.eth0': the suffix must be numeric",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			oid, err := tree.Resolve(tc.oid)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, oid)
+		})
+	}
+}
+
+func TestObjects(t *testing.T) {
+	tree := loadTestTree(t)
+
+	ifIndex, err := tree.Lookup("IF-MIB::ifIndex")
+	require.NoError(t, err)
+	assert.Equal(t, SyntaxInteger32, ifIndex.Syntax)
+	assert.True(t, ifIndex.IsColumn())
+	assert.Equal(t, []IndexPart{{Name: "ifIndex"}}, ifIndex.Entry().Index)
+
+	ifHighSpeed := tree.Object("1.3.6.1.2.1.31.1.1.1.15")
+	require.NotNil(t, ifHighSpeed)
+	assert.Equal(t, "ifHighSpeed", ifHighSpeed.Name)
+	assert.Equal(t, SyntaxGauge32, ifHighSpeed.Syntax)
+	assert.Equal(t, "Mb/s", ifHighSpeed.Units)
+	assert.Equal(t, "An estimate of the interface's current bandwidth in units of 1,000,000 bits per second.", ifHighSpeed.Description)
+	// ifXEntry augments ifEntry
+	assert.Equal(t, []IndexPart{{Name: "ifIndex"}}, ifHighSpeed.Entry().Index)
+
+	ifType, err := tree.Lookup("ifType")
+	require.NoError(t, err)
+	// The syntax of compliance statements doesn't override the object syntax
+	assert.Equal(t, SyntaxInteger, ifType.Syntax)
+
+	sysUpTime, err := tree.Lookup("sysUpTime")
+	require.NoError(t, err)
+	assert.False(t, sysUpTime.IsColumn())
+	assert.Nil(t, sysUpTime.Entry())
+}
+
+func TestColumns(t *testing.T) {
+	tree := loadTestTree(t)
+
+	var names []string
+	for _, column := range tree.Columns(".1.3.6.1.2.1.2") {
+		names = append(names, column.Name)
+	}
+	assert.Equal(t, []string{"ifIndex", "ifDescr", "ifType", "ifMtu", "ifInOctets"}, names)
+
+	names = nil
+	for _, column := range tree.Columns(".1.3.6.1.4.1.99999") {
+		names = append(names, column.Name)
+	}
+	// Index objects that are not accessible are not columns that can be walked
+	assert.Equal(t, []string{"exampleQueueDepth"}, names)
+
+	assert.Empty(t, tree.Columns(".1.3.6.1.2.1.1"))
+}
+
+func TestDecodeIndex(t *testing.T) {
+	tree := loadTestTree(t)
+
+	ifEntry, err := tree.Lookup("ifEntry")
+	require.NoError(t, err)
+	queueEntry, err := tree.Lookup("exampleQueueEntry")
+	require.NoError(t, err)
+	userEntry, err := tree.Lookup("exampleUserEntry")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc        string
+		entry       *Object
+		index       string
+		expected    map[string]string
+		expectedErr string
+	}{
+		{
+			desc:     "integer",
+			entry:    ifEntry,
+			index:    ".2",
+			expected: map[string]string{"ifIndex": "2"},
+		},
+		{
+			desc:     "address and string",
+			entry:    queueEntry,
+			index:    ".10.0.0.1.4.109.97.105.108",
+			expected: map[string]string{"exampleQueueHost": "10.0.0.1", "exampleQueueName": "mail"},
+		},
+		{
+			desc:     "implied string",
+			entry:    userEntry,
+			index:    ".111.116.101.108",
+			expected: map[string]string{"exampleUserName": "otel"},
+		},
+		{
+			desc:     "binary string",
+			entry:    userEntry,
+			index:    ".0.255",
+			expected: map[string]string{"exampleUserName": "00:ff"},
+		},
+		{
+			desc:        "too short",
+			entry:       queueEntry,
+			index:       ".10.0.0.1.4.109",
+			expectedErr: "index '.10.0.0.1.4.109' is too short for exampleQueueEntry",
+		},
+		{
+			desc:        "too long",
+			entry:       ifEntry,
+			index:       ".2.1",
+			expectedErr: "index '.2.1' is too long for ifEntry",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			values, err := tree.DecodeIndex(tc.entry, tc.index)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, values)
+		})
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	_, err := Load([]string{filepath.Join("testdata", "MISSING-MIB.txt")})
+	require.Error(t, err)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "BROKEN-MIB.txt")
+	require.NoError(t, os.WriteFile(path, []byte("BROKEN-MIB DEFINITIONS ::= BEGIN\nfoo OBJECT IDENTIFIER ::= { bar 1 }\n"), 0600))
+	_, err = Load([]string{path})
+	require.ErrorContains(t, err, "failed to parse MIB file")
+	require.ErrorContains(t, err, "missing END")
+
+	require.NoError(t, os.WriteFile(path, []byte("BROKEN-MIB DEFINITIONS ::= BEGIN\nIMPORTS bar FROM OTHER-MIB;\nfoo OBJECT IDENTIFIER ::= { bar 1 }\nEND\n"), 0600))
+	_, err = Load([]string{path})
+	require.EqualError(t, err, "BROKEN-MIB::foo: unknown object 'bar', MIB module OTHER-MIB might not be loaded")
+}
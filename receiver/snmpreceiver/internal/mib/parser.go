@@ -0,0 +1,591 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mib // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver/internal/mib"
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// tokenKind is the kind of a lexical token of a MIB module
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenSymbol
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	line  int
+}
+
+// tokenize splits the source of MIB modules into tokens, dropping comments
+func tokenize(src string) ([]token, error) {
+	var tokens []token
+	line := 1
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case unicode.IsSpace(rune(c)):
+			i++
+		case strings.HasPrefix(src[i:], "--"):
+			// Comments end at the end of the line or at the next "--"
+			i += 2
+			for i < len(src) && src[i] != '\n' {
+				if strings.HasPrefix(src[i:], "--") {
+					i += 2
+					break
+				}
+				i++
+			}
+		case c == '"':
+			start := line
+			end := strings.IndexByte(src[i+1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated string", start)
+			}
+			value := src[i+1 : i+1+end]
+			line += strings.Count(value, "\n")
+			tokens = append(tokens, token{kind: tokenString, value: value, line: start})
+			i += end + 2
+		case c == '\'':
+			// Binary and hexadecimal strings such as '00'H
+			end := strings.IndexByte(src[i+1:], '\'')
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated quoted string", line)
+			}
+			i += end + 2
+			if i < len(src) && (src[i] == 'H' || src[i] == 'h' || src[i] == 'B' || src[i] == 'b') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenString, value: "", line: line})
+		case isDigit(c):
+			start := i
+			for i < len(src) && isDigit(src[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, value: src[start:i], line: line})
+		case isLetter(c):
+			start := i
+			for i < len(src) && (isLetter(src[i]) || isDigit(src[i]) || src[i] == '-' || src[i] == '_') {
+				// Identifiers can't contain "--" as it starts a comment
+				if src[i] == '-' && i+1 < len(src) && src[i+1] == '-' {
+					break
+				}
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, value: strings.TrimSuffix(src[start:i], "-"), line: line})
+		case strings.HasPrefix(src[i:], "::="):
+			tokens = append(tokens, token{kind: tokenSymbol, value: "::=", line: line})
+			i += 3
+		case strings.HasPrefix(src[i:], ".."):
+			tokens = append(tokens, token{kind: tokenSymbol, value: "..", line: line})
+			i += 2
+		default:
+			tokens = append(tokens, token{kind: tokenSymbol, value: string(c), line: line})
+			i++
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, line: line}), nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// module is a parsed MIB module
+type module struct {
+	name string
+	// imports maps imported names to the module they are imported from
+	imports     map[string]string
+	definitions map[string]*definition
+	// types maps the textual conventions and type assignments of the module to their syntax
+	types map[string]string
+}
+
+// oidComponent is a component of an OBJECT IDENTIFIER value, such as "mib-2", "2" or "org(3)"
+type oidComponent struct {
+	name   string
+	number int
+	// hasNumber is false for components that refer to another definition
+	hasNumber bool
+}
+
+// definition is an OBJECT IDENTIFIER value assignment or a macro invocation such as OBJECT-TYPE
+type definition struct {
+	name  string
+	macro string
+	value []oidComponent
+	// enterprise and trapNumber hold the value of SMIv1 TRAP-TYPE definitions
+	enterprise string
+	trapNumber int
+
+	syntax      string
+	units       string
+	description string
+	access      string
+	index       []IndexPart
+	augments    string
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// parseModules parses the MIB modules contained in the source
+func parseModules(src string) ([]*module, error) {
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+
+	var modules []*module
+	for p.peek().kind != tokenEOF {
+		m, err := p.parseModule()
+		if err != nil {
+			return nil, err
+		}
+		modules = append(modules, m)
+	}
+	if len(modules) == 0 {
+		return nil, errors.New("no MIB module found")
+	}
+	return modules, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) peekAt(offset int) token {
+	if p.pos+offset >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.pos+offset]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) expect(value string) error {
+	t := p.next()
+	if t.value != value || t.kind == tokenString {
+		return fmt.Errorf("line %d: expected '%s' but found '%s'", t.line, value, t.value)
+	}
+	return nil
+}
+
+func (p *parser) expectIdent() (string, error) {
+	t := p.next()
+	if t.kind != tokenIdent {
+		return "", fmt.Errorf("line %d: expected an identifier but found '%s'", t.line, t.value)
+	}
+	return t.value, nil
+}
+
+// skipGroup skips a group opened by the current token, such as "{ ... }" or "( ... )"
+func (p *parser) skipGroup() error {
+	open := p.next()
+	closing := map[string]string{"{": "}", "(": ")", "[": "]"}[open.value]
+	depth := 1
+	for depth > 0 {
+		t := p.next()
+		switch {
+		case t.kind == tokenEOF:
+			return fmt.Errorf("line %d: unterminated '%s'", open.line, open.value)
+		case t.kind != tokenSymbol:
+		case t.value == open.value:
+			depth++
+		case t.value == closing:
+			depth--
+		}
+	}
+	return nil
+}
+
+// parseModule parses "NAME DEFINITIONS ::= BEGIN ... END"
+func (p *parser) parseModule() (*module, error) {
+	name, err := p.expectIdent()
+	if err != nil {
+		return nil, err
+	}
+	m := &module{
+		name:        name,
+		imports:     map[string]string{},
+		definitions: map[string]*definition{},
+		types:       map[string]string{},
+	}
+	// Skip the optional module OID
+	if p.peek().value == "{" {
+		if err = p.skipGroup(); err != nil {
+			return nil, err
+		}
+	}
+	if err = p.expect("DEFINITIONS"); err != nil {
+		return nil, err
+	}
+	// Skip tagging defaults such as IMPLICIT TAGS
+	for p.peek().kind == tokenIdent {
+		p.next()
+	}
+	if err = p.expect("::="); err != nil {
+		return nil, err
+	}
+	if err = p.expect("BEGIN"); err != nil {
+		return nil, err
+	}
+
+	for {
+		t := p.peek()
+		switch {
+		case t.kind == tokenEOF:
+			return nil, fmt.Errorf("module %s: missing END", m.name)
+		case t.value == "END" && t.kind == tokenIdent:
+			p.next()
+			return m, nil
+		case t.value == "IMPORTS":
+			if err = p.parseImports(m); err != nil {
+				return nil, fmt.Errorf("module %s: %w", m.name, err)
+			}
+		case t.value == "EXPORTS":
+			for p.peek().value != ";" && p.peek().kind != tokenEOF {
+				p.next()
+			}
+			p.next()
+		case t.kind == tokenIdent:
+			if err = p.parseAssignment(m); err != nil {
+				return nil, fmt.Errorf("module %s: %w", m.name, err)
+			}
+		default:
+			return nil, fmt.Errorf("module %s: line %d: unexpected '%s'", m.name, t.line, t.value)
+		}
+	}
+}
+
+// parseImports parses "IMPORTS a, b FROM MODULE-A c FROM MODULE-B ;"
+func (p *parser) parseImports(m *module) error {
+	p.next()
+	var names []string
+	for {
+		t := p.next()
+		switch {
+		case t.kind == tokenEOF:
+			return errors.New("unterminated IMPORTS")
+		case t.value == ";":
+			return nil
+		case t.value == ",":
+		case t.value == "FROM":
+			from, err := p.expectIdent()
+			if err != nil {
+				return err
+			}
+			for _, name := range names {
+				m.imports[name] = from
+			}
+			names = nil
+		case t.kind == tokenIdent:
+			names = append(names, t.value)
+		}
+	}
+}
+
+// parseAssignment parses a type assignment, a macro definition, an OBJECT IDENTIFIER value
+// assignment or a macro invocation such as OBJECT-TYPE
+func (p *parser) parseAssignment(m *module) error {
+	name := p.next().value
+	next := p.peek()
+
+	switch {
+	case next.value == "MACRO":
+		// Macro definitions (only found in SMI modules) are skipped
+		for {
+			t := p.next()
+			if t.kind == tokenEOF {
+				return fmt.Errorf("line %d: unterminated MACRO %s", next.line, name)
+			}
+			if t.kind == tokenIdent && t.value == "END" {
+				return nil
+			}
+		}
+	case next.value == "::=":
+		p.next()
+		syntax, err := p.parseTypeAssignment()
+		if err != nil {
+			return err
+		}
+		m.types[name] = syntax
+		return nil
+	case next.value == "OBJECT" && p.peekAt(1).value == "IDENTIFIER":
+		p.next()
+		p.next()
+		if err := p.expect("::="); err != nil {
+			return err
+		}
+		value, err := p.parseOIDValue()
+		if err != nil {
+			return err
+		}
+		m.definitions[name] = &definition{name: name, macro: "OBJECT IDENTIFIER", value: value}
+		return nil
+	case next.kind == tokenIdent:
+		def, err := p.parseMacroInvocation(name)
+		if err != nil {
+			return err
+		}
+		m.definitions[name] = def
+		return nil
+	default:
+		return fmt.Errorf("line %d: unexpected '%s' after '%s'", next.line, next.value, name)
+	}
+}
+
+// parseTypeAssignment parses the right hand side of "Name ::= ..."
+func (p *parser) parseTypeAssignment() (string, error) {
+	if p.peek().value != "TEXTUAL-CONVENTION" {
+		return p.parseType()
+	}
+	p.next()
+	for {
+		t := p.peek()
+		switch {
+		case t.kind == tokenEOF:
+			return "", fmt.Errorf("line %d: TEXTUAL-CONVENTION without SYNTAX", t.line)
+		case t.value == "SYNTAX":
+			p.next()
+			return p.parseType()
+		case t.value == "{" || t.value == "(":
+			if err := p.skipGroup(); err != nil {
+				return "", err
+			}
+		default:
+			p.next()
+		}
+	}
+}
+
+// parseType parses a type such as "INTEGER { up(1), down(2) }", "OCTET STRING (SIZE(0..255))"
+// or "[APPLICATION 1] IMPLICIT INTEGER (0..4294967295)" and returns its name
+func (p *parser) parseType() (string, error) {
+	if p.peek().value == "[" {
+		if err := p.skipGroup(); err != nil {
+			return "", err
+		}
+	}
+	if p.peek().value == "IMPLICIT" {
+		p.next()
+	}
+
+	t := p.next()
+	if t.kind != tokenIdent {
+		return "", fmt.Errorf("line %d: expected a type but found '%s'", t.line, t.value)
+	}
+	syntax := t.value
+	switch t.value {
+	case "OCTET":
+		if err := p.expect("STRING"); err != nil {
+			return "", err
+		}
+		syntax = "OCTET STRING"
+	case "OBJECT":
+		if err := p.expect("IDENTIFIER"); err != nil {
+			return "", err
+		}
+		syntax = "OBJECT IDENTIFIER"
+	case "SEQUENCE":
+		if p.peek().value == "OF" {
+			p.next()
+			entry, err := p.expectIdent()
+			if err != nil {
+				return "", err
+			}
+			return "SEQUENCE OF " + entry, nil
+		}
+	}
+
+	// Skip enumerations, named bits, sequence members, and size or range constraints
+	for p.peek().value == "{" || p.peek().value == "(" {
+		if err := p.skipGroup(); err != nil {
+			return "", err
+		}
+	}
+	return syntax, nil
+}
+
+// parseMacroInvocation parses the clauses of a macro invocation up to "::=" and its value
+func (p *parser) parseMacroInvocation(name string) (*definition, error) {
+	def := &definition{name: name, macro: p.next().value}
+	for {
+		t := p.peek()
+		switch {
+		case t.kind == tokenEOF:
+			return nil, fmt.Errorf("line %d: unterminated %s %s", t.line, def.macro, name)
+		case t.value == "::=" && t.kind == tokenSymbol:
+			p.next()
+			if def.macro == "TRAP-TYPE" {
+				number := p.next()
+				n, err := strconv.Atoi(number.value)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: invalid trap number '%s'", number.line, number.value)
+				}
+				def.trapNumber = n
+				return def, nil
+			}
+			value, err := p.parseOIDValue()
+			if err != nil {
+				return nil, err
+			}
+			def.value = value
+			return def, nil
+		case t.value == "SYNTAX" && t.kind == tokenIdent:
+			p.next()
+			syntax, err := p.parseType()
+			if err != nil {
+				return nil, err
+			}
+			// Compliance statements refine the syntax of other objects, only keep the first one
+			if def.syntax == "" {
+				def.syntax = syntax
+			}
+		case t.value == "DESCRIPTION" && t.kind == tokenIdent:
+			p.next()
+			// Compliance statements and revisions have descriptions of their own, only keep the first one
+			if description := p.next(); description.kind == tokenString && def.description == "" {
+				def.description = strings.Join(strings.Fields(description.value), " ")
+			}
+		case t.value == "UNITS" && t.kind == tokenIdent:
+			p.next()
+			def.units = p.next().value
+		case (t.value == "MAX-ACCESS" || t.value == "ACCESS") && t.kind == tokenIdent:
+			p.next()
+			def.access = p.next().value
+		case t.value == "ENTERPRISE" && t.kind == tokenIdent:
+			p.next()
+			if p.peek().value == "{" {
+				value, err := p.parseOIDValue()
+				if err != nil {
+					return nil, err
+				}
+				def.value = value
+			} else {
+				def.enterprise = p.next().value
+			}
+		case t.value == "INDEX" && t.kind == tokenIdent:
+			p.next()
+			index, err := p.parseIndex()
+			if err != nil {
+				return nil, err
+			}
+			def.index = index
+		case t.value == "AUGMENTS" && t.kind == tokenIdent:
+			p.next()
+			if err := p.expect("{"); err != nil {
+				return nil, err
+			}
+			augments, err := p.expectIdent()
+			if err != nil {
+				return nil, err
+			}
+			def.augments = augments
+			if err := p.expect("}"); err != nil {
+				return nil, err
+			}
+		case t.value == "{" || t.value == "(":
+			if err := p.skipGroup(); err != nil {
+				return nil, err
+			}
+		default:
+			p.next()
+		}
+	}
+}
+
+// parseIndex parses "{ [IMPLIED] name, ... }"
+func (p *parser) parseIndex() ([]IndexPart, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var index []IndexPart
+	implied := false
+	for {
+		t := p.next()
+		switch {
+		case t.kind == tokenEOF:
+			return nil, fmt.Errorf("line %d: unterminated INDEX", t.line)
+		case t.value == "}":
+			return index, nil
+		case t.value == ",":
+		case t.value == "IMPLIED":
+			implied = true
+		case t.kind == tokenIdent:
+			index = append(index, IndexPart{Name: t.value, Implied: implied})
+			implied = false
+		}
+	}
+}
+
+// parseOIDValue parses "{ parent 1 2 }" or "{ iso org(3) dod(6) 1 }"
+func (p *parser) parseOIDValue() ([]oidComponent, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var components []oidComponent
+	for {
+		t := p.next()
+		switch {
+		case t.kind == tokenEOF:
+			return nil, fmt.Errorf("line %d: unterminated OBJECT IDENTIFIER value", t.line)
+		case t.value == "}" && t.kind == tokenSymbol:
+			if len(components) == 0 {
+				return nil, fmt.Errorf("line %d: empty OBJECT IDENTIFIER value", t.line)
+			}
+			return components, nil
+		case t.kind == tokenNumber:
+			n, err := strconv.Atoi(t.value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", t.line, err)
+			}
+			components = append(components, oidComponent{number: n, hasNumber: true})
+		case t.kind == tokenIdent:
+			component := oidComponent{name: t.value}
+			if p.peek().value == "(" {
+				p.next()
+				number := p.next()
+				n, err := strconv.Atoi(number.value)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: invalid number '%s'", number.line, number.value)
+				}
+				component.number = n
+				component.hasNumber = true
+				if err = p.expect(")"); err != nil {
+					return nil, err
+				}
+			}
+			components = append(components, component)
+		default:
+			return nil, fmt.Errorf("line %d: unexpected '%s' in OBJECT IDENTIFIER value", t.line, t.value)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package snmpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver"
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver/internal/mib"
+)
+
+var errMsgWalkNoColumns = `walk oid '%s' contains no readable table columns in the loaded MIBs`
+
+// mibIndex decodes the value of an index object of a table entry from the OID index of
+// the rows of the table
+type mibIndex struct {
+	tree  *mib.Tree
+	entry *mib.Object
+	name  string
+}
+
+// value returns the value of the index object for the OID index of a row
+func (i *mibIndex) value(index string) (string, error) {
+	values, err := i.tree.DecodeIndex(i.entry, index)
+	if err != nil {
+		return "", err
+	}
+	return values[i.name], nil
+}
+
+// resolveMIBs loads the configured MIB modules, replaces the symbolic OIDs of the config
+// with numeric ones, and adds the metrics of the table columns found by walks
+func resolveMIBs(cfg *Config) error {
+	if cfg.MIBs == nil {
+		return nil
+	}
+
+	tree, err := mib.Load(cfg.MIBs.Paths)
+	if err != nil {
+		return fmt.Errorf("failed to load MIBs: %w", err)
+	}
+
+	var combinedErr error
+	for _, oid := range configOIDs(cfg) {
+		resolved, err := tree.Resolve(*oid)
+		if err != nil {
+			combinedErr = errors.Join(combinedErr, fmt.Errorf("failed to resolve oid '%s': %w", *oid, err))
+			continue
+		}
+		*oid = resolved
+	}
+	if combinedErr != nil {
+		return combinedErr
+	}
+
+	for _, walk := range cfg.Walks {
+		combinedErr = errors.Join(combinedErr, addWalkMetrics(cfg, tree, walk))
+	}
+	return combinedErr
+}
+
+// configOIDs returns the OIDs found in the config so that they can be resolved in place
+func configOIDs(cfg *Config) []*string {
+	var oids []*string
+	for _, metricCfg := range cfg.Metrics {
+		for i := range metricCfg.ScalarOIDs {
+			oids = append(oids, &metricCfg.ScalarOIDs[i].OID)
+		}
+		for i := range metricCfg.ColumnOIDs {
+			oids = append(oids, &metricCfg.ColumnOIDs[i].OID)
+		}
+	}
+	for _, attributeCfg := range cfg.Attributes {
+		oids = append(oids, &attributeCfg.OID)
+	}
+	for _, resourceAttributeCfg := range cfg.ResourceAttributes {
+		oids = append(oids, &resourceAttributeCfg.OID, &resourceAttributeCfg.ScalarOID)
+	}
+	if cfg.Traps != nil {
+		for _, metricCfg := range cfg.Traps.Metrics {
+			if metricCfg != nil {
+				oids = append(oids, &metricCfg.TrapOID)
+			}
+		}
+	}
+	for i := range cfg.Walks {
+		oids = append(oids, &cfg.Walks[i].OID)
+	}
+
+	// Unset OIDs are left alone
+	nonEmpty := oids[:0]
+	for _, oid := range oids {
+		if *oid != "" {
+			nonEmpty = append(nonEmpty, oid)
+		}
+	}
+	return nonEmpty
+}
+
+// addWalkMetrics adds a metric for every numeric table column under the walk OID. Each
+// metric has an attribute per index object of the table, decoded from the row index.
+// Metrics that are already configured are left untouched.
+func addWalkMetrics(cfg *Config, tree *mib.Tree, walk WalkConfig) error {
+	columns := tree.Columns(walk.OID)
+	if len(columns) == 0 {
+		return fmt.Errorf(errMsgWalkNoColumns, walk.OID)
+	}
+
+	if cfg.Metrics == nil {
+		cfg.Metrics = map[string]*MetricConfig{}
+	}
+	if cfg.Attributes == nil {
+		cfg.Attributes = map[string]*AttributeConfig{}
+	}
+
+	for _, column := range columns {
+		metricName := walk.MetricPrefix + column.Name
+		if _, ok := cfg.Metrics[metricName]; ok {
+			continue
+		}
+		metricCfg := newWalkMetricConfig(column)
+		if metricCfg == nil {
+			continue
+		}
+
+		entry := column.Entry()
+		columnOID := ColumnOID{OID: column.OID}
+		for _, part := range entry.Index {
+			// Attribute configs are specific to the table entry, as the position of an index
+			// object in the OID index depends on the table
+			attributeName := fmt.Sprintf("%s::%s.%s", entry.Module, entry.Name, part.Name)
+			if _, ok := cfg.Attributes[attributeName]; !ok {
+				cfg.Attributes[attributeName] = &AttributeConfig{
+					Value:       part.Name,
+					Description: fmt.Sprintf("The %s index of %s", part.Name, entry.Name),
+					mibIndex:    &mibIndex{tree: tree, entry: entry, name: part.Name},
+				}
+			}
+			columnOID.Attributes = append(columnOID.Attributes, Attribute{Name: attributeName})
+		}
+		metricCfg.ColumnOIDs = []ColumnOID{columnOID}
+		cfg.Metrics[metricName] = metricCfg
+	}
+	return nil
+}
+
+// newWalkMetricConfig creates the metric config of a table column based on its syntax.
+// Counters are monotonic cumulative sums and other numbers are gauges, while columns
+// that aren't numbers have no metric.
+func newWalkMetricConfig(column *mib.Object) *MetricConfig {
+	metricCfg := &MetricConfig{
+		Description: column.Description,
+		Unit:        column.Units,
+	}
+	if metricCfg.Unit == "" {
+		metricCfg.Unit = "1"
+	}
+
+	switch column.Syntax {
+	case mib.SyntaxCounter32, mib.SyntaxCounter64:
+		metricCfg.Sum = &SumMetric{
+			Aggregation: "cumulative",
+			Monotonic:   true,
+			ValueType:   "int",
+		}
+	case mib.SyntaxInteger, mib.SyntaxInteger32, mib.SyntaxUnsigned32, mib.SyntaxGauge32, mib.SyntaxTimeTicks:
+		metricCfg.Gauge = &GaugeMetric{ValueType: "int"}
+	default:
+		return nil
+	}
+	return metricCfg
+}
@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package snmpreceiver
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.uber.org/zap"
+)
+
+var testMIBsPath = filepath.Join("internal", "mib", "testdata")
+
+func TestResolveMIBs(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MIBs = &MIBsConfig{Paths: []string{testMIBsPath}}
+	cfg.Walks = []WalkConfig{{OID: "IF-MIB::ifXTable", MetricPrefix: "snmp."}}
+	cfg.ResourceAttributes = map[string]*ResourceAttributeConfig{
+		"system.description": {ScalarOID: "SNMPv2-MIB::sysDescr.0"},
+	}
+	cfg.Attributes = map[string]*AttributeConfig{
+		"interface": {OID: "IF-MIB::ifDescr"},
+	}
+	cfg.Metrics = map[string]*MetricConfig{
+		"snmp.uptime": {
+			Unit:       "s",
+			Gauge:      &GaugeMetric{ValueType: "int"},
+			ScalarOIDs: []ScalarOID{{OID: "SNMPv2-MIB::sysUpTime.0", ResourceAttributes: []string{"system.description"}}},
+		},
+		"snmp.in_octets": {
+			Unit:       "By",
+			Sum:        &SumMetric{Aggregation: "cumulative", Monotonic: true, ValueType: "int"},
+			ColumnOIDs: []ColumnOID{{OID: "ifInOctets", Attributes: []Attribute{{Name: "interface"}}}},
+		},
+		// Configured metrics take precedence over the walked ones
+		"snmp.ifHighSpeed": {
+			Unit:       "bit/s",
+			Gauge:      &GaugeMetric{ValueType: "double"},
+			ColumnOIDs: []ColumnOID{{OID: "1.3.6.1.2.1.31.1.1.1.15", Attributes: []Attribute{{Name: "interface"}}}},
+		},
+	}
+	cfg.Traps = &TrapsConfig{
+		Metrics: map[string]*TrapMetricConfig{
+			"snmp.link_down": {TrapOID: "IF-MIB::linkDown"},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	require.NoError(t, resolveMIBs(cfg))
+	require.NoError(t, cfg.Validate())
+
+	assert.Equal(t, ".1.3.6.1.2.1.1.1.0", cfg.ResourceAttributes["system.description"].ScalarOID)
+	assert.Equal(t, ".1.3.6.1.2.1.2.2.1.2", cfg.Attributes["interface"].OID)
+	assert.Equal(t, ".1.3.6.1.2.1.1.3.0", cfg.Metrics["snmp.uptime"].ScalarOIDs[0].OID)
+	assert.Equal(t, ".1.3.6.1.2.1.2.2.1.10", cfg.Metrics["snmp.in_octets"].ColumnOIDs[0].OID)
+	assert.Equal(t, ".1.3.6.1.6.3.1.1.5.3", cfg.Traps.Metrics["snmp.link_down"].TrapOID)
+	assert.Equal(t, ".1.3.6.1.2.1.31.1.1", cfg.Walks[0].OID)
+
+	// ifName is not a number and has no metric
+	assert.NotContains(t, cfg.Metrics, "snmp.ifName")
+	assert.Equal(t, "bit/s", cfg.Metrics["snmp.ifHighSpeed"].Unit)
+
+	inOctets := cfg.Metrics["snmp.ifHCInOctets"]
+	require.NotNil(t, inOctets)
+	assert.Equal(t, "1", inOctets.Unit)
+	assert.Equal(t, &SumMetric{Aggregation: "cumulative", Monotonic: true, ValueType: "int"}, inOctets.Sum)
+	assert.Equal(t, []ColumnOID{{
+		OID:        ".1.3.6.1.2.1.31.1.1.1.6",
+		Attributes: []Attribute{{Name: "IF-MIB::ifXEntry.ifIndex"}},
+	}}, inOctets.ColumnOIDs)
+
+	index := cfg.Attributes["IF-MIB::ifXEntry.ifIndex"]
+	require.NotNil(t, index)
+	assert.Equal(t, "ifIndex", index.Value)
+	value, err := index.mibIndex.value(".3")
+	require.NoError(t, err)
+	assert.Equal(t, "3", value)
+
+	// Resolving again leaves the config untouched
+	before := len(cfg.Metrics)
+	require.NoError(t, resolveMIBs(cfg))
+	assert.Len(t, cfg.Metrics, before)
+}
+
+func TestResolveMIBsErrors(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		cfg         func(cfg *Config)
+		expectedErr string
+	}{
+		{
+			desc:        "missing MIB path",
+			cfg:         func(cfg *Config) { cfg.MIBs.Paths = []string{filepath.Join("testdata", "missing")} },
+			expectedErr: "failed to load MIBs",
+		},
+		{
+			desc: "unknown object",
+			cfg: func(cfg *Config) {
+				cfg.Metrics = map[string]*MetricConfig{
+					"snmp.unknown": {
+						Unit:       "1",
+						Gauge:      &GaugeMetric{ValueType: "int"},
+						ScalarOIDs: []ScalarOID{{OID: "IF-MIB::ifUnknown.0"}},
+					},
+				}
+			},
+			expectedErr: "failed to resolve oid 'IF-MIB::ifUnknown.0': unknown object 'IF-MIB::ifUnknown'",
+		},
+		{
+			desc:        "walk without columns",
+			cfg:         func(cfg *Config) { cfg.Walks = []WalkConfig{{OID: "SNMPv2-MIB::system"}} },
+			expectedErr: fmt.Sprintf(errMsgWalkNoColumns, ".1.3.6.1.2.1.1"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.MIBs = &MIBsConfig{Paths: []string{testMIBsPath}}
+			tc.cfg(cfg)
+			require.ErrorContains(t, resolveMIBs(cfg), tc.expectedErr)
+		})
+	}
+}
+
+func TestScrapeWalkMetrics(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MIBs = &MIBsConfig{Paths: []string{testMIBsPath}}
+	cfg.Walks = []WalkConfig{{OID: "IF-MIB::ifHCInOctets"}}
+	require.NoError(t, resolveMIBs(cfg))
+
+	mockClient := new(MockClient)
+	mockClient.On("Connect").Return(nil)
+	mockClient.On("Close").Return(nil)
+	mockClient.On("GetIndexedData", mock.Anything, mock.Anything).Return([]SNMPData{
+		{columnOID: ".1.3.6.1.2.1.31.1.1.1.6", oid: ".1.3.6.1.2.1.31.1.1.1.6.1", value: int64(100), valueType: integerVal},
+		{columnOID: ".1.3.6.1.2.1.31.1.1.1.6", oid: ".1.3.6.1.2.1.31.1.1.1.6.2", value: int64(200), valueType: integerVal},
+	})
+	scraper := &snmpScraper{
+		cfg:      cfg,
+		settings: receivertest.NewNopCreateSettings(),
+		client:   mockClient,
+		logger:   zap.NewNop(),
+	}
+
+	metrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, metrics.MetricCount())
+
+	metric := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "ifHCInOctets", metric.Name())
+	assert.Equal(t, pmetric.MetricTypeSum, metric.Type())
+	assert.True(t, metric.Sum().IsMonotonic())
+
+	values := map[string]int64{}
+	for i := 0; i < metric.Sum().DataPoints().Len(); i++ {
+		dp := metric.Sum().DataPoints().At(i)
+		index, ok := dp.Attributes().Get("ifIndex")
+		require.True(t, ok)
+		values[index.Str()] = dp.IntValue()
+	}
+	assert.Equal(t, map[string]int64{"1": 100, "2": 200}, values)
+}
@@ -299,6 +299,8 @@ func getScalarDataPointAttributes(configHelper *configHelper, oid string) map[st
 // config's prefix value
 // Indexed OID attribute value - comes from the previously collected indexed attribute data
 // using the current index and attribute config to access the correct value
+// MIB index attribute value - decoded from the current SNMP data's index using the MIB
+// definition of the table
 func getIndexedDataPointAttributes(
 	configHelper *configHelper,
 	columnOID string,
@@ -319,7 +321,13 @@ func getIndexedDataPointAttributes(
 		var attributeValue string
 		prefix := configHelper.getAttributeConfigIndexedValuePrefix(attributeName)
 		oid := configHelper.getAttributeConfigOID(attributeName)
+		index := configHelper.getAttributeConfigMIBIndex(attributeName)
 		switch {
+		case index != nil:
+			var err error
+			if attributeValue, err = index.value(indexString); err != nil {
+				return nil, err
+			}
 		case prefix != "":
 			attributeValue = prefix + indexString
 		case oid != "":
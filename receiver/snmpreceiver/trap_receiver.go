@@ -0,0 +1,319 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package snmpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver"
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+)
+
+const (
+	// snmpTrapOID is the varbind holding the trap OID of SNMPv2c and SNMPv3 traps (RFC 3416)
+	snmpTrapOID = ".1.3.6.1.6.3.1.1.4.1.0"
+	// snmpTrapsPrefix is the prefix of the generic trap OIDs (RFC 3584)
+	snmpTrapsPrefix = ".1.3.6.1.6.3.1.1.5."
+	// enterpriseSpecificTrap is the SNMPv1 generic trap type of enterprise specific traps
+	enterpriseSpecificTrap = 6
+
+	trapFormat = "snmp"
+
+	attributeVersion       = "snmp.version"
+	attributePDUType       = "snmp.pdu_type"
+	attributeTrapOID       = "snmp.trap.oid"
+	attributeSourceAddress = "snmp.source.address"
+	attributeVarbinds      = "snmp.varbinds"
+)
+
+// trapReceiver listens for SNMP traps and informs. Every message is emitted as a log
+// record with its varbinds as attributes, and messages matching the trap OID of a
+// configured trap metric increment that metric. Informs are acknowledged by gosnmp
+// once the message has been handed to the consumers.
+type trapReceiver struct {
+	cfg       *Config
+	settings  receiver.CreateSettings
+	converter *snmpClient
+	obsrecv   *receiverhelper.ObsReport
+
+	logsConsumer    consumer.Logs
+	metricsConsumer consumer.Metrics
+
+	listener  *gosnmp.TrapListener
+	listenErr chan error
+
+	mu sync.Mutex
+	// lastExpiry is the last time idle counts were dropped, or the start time of the
+	// receiver. Counts created since then started from 0 at that time.
+	lastExpiry pcommon.Timestamp
+	// counts holds the number of traps per metric name and source address
+	counts map[trapSeries]*trapCount
+}
+
+// trapSeries identifies the count of a trap metric for a source address
+type trapSeries struct {
+	metric string
+	source string
+}
+
+// trapCount is the cumulative count of a trap series
+type trapCount struct {
+	value     int64
+	startTime pcommon.Timestamp
+	lastSeen  pcommon.Timestamp
+}
+
+// newTrapReceiver creates a trap receiver. The consumers are set by the factory.
+func newTrapReceiver(cfg *Config, settings receiver.CreateSettings) *trapReceiver {
+	return &trapReceiver{
+		cfg:       cfg,
+		settings:  settings,
+		converter: &snmpClient{logger: settings.Logger},
+		counts:    map[trapSeries]*trapCount{},
+	}
+}
+
+// Start starts listening for traps and informs
+func (r *trapReceiver) Start(_ context.Context, _ component.Host) error {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             r.settings.ID,
+		Transport:              strings.SplitN(r.cfg.Traps.Endpoint, "://", 2)[0],
+		ReceiverCreateSettings: r.settings,
+	})
+	if err != nil {
+		return err
+	}
+	r.obsrecv = obsrecv
+	r.lastExpiry = pcommon.NewTimestampFromTime(time.Now())
+
+	listener := gosnmp.NewTrapListener()
+	listener.Params = newTrapParams(r.cfg)
+	listener.OnNewTrap = r.handleTrap
+
+	r.listenErr = make(chan error, 1)
+	go func() {
+		r.listenErr <- listener.Listen(r.cfg.Traps.Endpoint)
+	}()
+
+	select {
+	case <-listener.Listening():
+		r.listener = listener
+		return nil
+	case err = <-r.listenErr:
+		return fmt.Errorf("failed to listen for traps on '%s': %w", r.cfg.Traps.Endpoint, err)
+	}
+}
+
+// Shutdown stops listening for traps and informs
+func (r *trapReceiver) Shutdown(context.Context) error {
+	if r.listener == nil {
+		return nil
+	}
+	r.listener.Close()
+	r.listener = nil
+	return nil
+}
+
+// newTrapParams creates the gosnmp parameters used to decode and authenticate
+// incoming messages based on config
+func newTrapParams(cfg *Config) *gosnmp.GoSNMP {
+	wrapper := &otelGoSNMPWrapper{}
+	switch strings.ToUpper(cfg.Version) {
+	case "V3":
+		wrapper.SetVersion(gosnmp.Version3)
+		setV3ClientConfigs(wrapper, cfg)
+	case "V1":
+		wrapper.SetVersion(gosnmp.Version1)
+		wrapper.SetCommunity(cfg.Community)
+	default:
+		wrapper.SetVersion(gosnmp.Version2c)
+		wrapper.SetCommunity(cfg.Community)
+	}
+	return &wrapper.GoSNMP
+}
+
+// handleTrap is called by the listener for every decoded trap or inform
+func (r *trapReceiver) handleTrap(packet *gosnmp.SnmpPacket, addr *net.UDPAddr) {
+	// SNMPv3 messages are authenticated by gosnmp, while the community of SNMPv1 and
+	// SNMPv2c messages has to be checked here
+	if packet.Version != gosnmp.Version3 && packet.Community != r.cfg.Community {
+		r.settings.Logger.Debug("Dropping trap with unexpected community", zap.Stringer("source", addr))
+		return
+	}
+
+	ctx := context.Background()
+	now := pcommon.NewTimestampFromTime(time.Now())
+	trapOID := getTrapOID(packet)
+	source := ""
+	if packet.PDUType == gosnmp.Trap && packet.AgentAddress != "" {
+		// SNMPv1 traps carry the address of the agent that generated them
+		source = packet.AgentAddress
+	} else if addr != nil {
+		source = addr.IP.String()
+	}
+
+	if r.logsConsumer != nil {
+		logs := r.trapToLogs(packet, trapOID, source, now)
+		ctx = r.obsrecv.StartLogsOp(ctx)
+		err := r.logsConsumer.ConsumeLogs(ctx, logs)
+		r.obsrecv.EndLogsOp(ctx, trapFormat, logs.LogRecordCount(), err)
+		if err != nil {
+			r.settings.Logger.Error("Failed to consume trap", zap.String("trap_oid", trapOID), zap.Error(err))
+		}
+	}
+
+	if r.metricsConsumer != nil {
+		metrics := r.countTrap(trapOID, source, now)
+		if metrics.DataPointCount() == 0 {
+			return
+		}
+		ctx = r.obsrecv.StartMetricsOp(ctx)
+		err := r.metricsConsumer.ConsumeMetrics(ctx, metrics)
+		r.obsrecv.EndMetricsOp(ctx, trapFormat, metrics.DataPointCount(), err)
+		if err != nil {
+			r.settings.Logger.Error("Failed to consume trap metrics", zap.String("trap_oid", trapOID), zap.Error(err))
+		}
+	}
+}
+
+// getTrapOID returns the trap OID of the message. For SNMPv1 traps it is derived from
+// the generic and specific trap types as per RFC 3584 section 3.1.
+func getTrapOID(packet *gosnmp.SnmpPacket) string {
+	if packet.PDUType == gosnmp.Trap {
+		if packet.GenericTrap == enterpriseSpecificTrap {
+			return fmt.Sprintf("%s.0.%d", packet.Enterprise, packet.SpecificTrap)
+		}
+		return fmt.Sprintf("%s%d", snmpTrapsPrefix, packet.GenericTrap+1)
+	}
+	for _, variable := range packet.Variables {
+		if variable.Name == snmpTrapOID {
+			return toString(variable.Value)
+		}
+	}
+	return ""
+}
+
+// trapToLogs converts a trap or inform into a log record
+func (r *trapReceiver) trapToLogs(packet *gosnmp.SnmpPacket, trapOID string, source string, now pcommon.Timestamp) plog.Logs {
+	logs := plog.NewLogs()
+	resourceLogs := logs.ResourceLogs().AppendEmpty()
+	scopeLogs := resourceLogs.ScopeLogs().AppendEmpty()
+	scopeLogs.Scope().SetName("otelcol/snmpreceiver")
+	scopeLogs.Scope().SetVersion(r.settings.BuildInfo.Version)
+
+	record := scopeLogs.LogRecords().AppendEmpty()
+	record.SetTimestamp(now)
+	record.SetObservedTimestamp(now)
+	record.Body().SetStr(trapOID)
+
+	attrs := record.Attributes()
+	attrs.PutStr(attributeVersion, "v"+packet.Version.String())
+	attrs.PutStr(attributePDUType, pduTypeName(packet.PDUType))
+	attrs.PutStr(attributeTrapOID, trapOID)
+	if source != "" {
+		attrs.PutStr(attributeSourceAddress, source)
+	}
+
+	varbinds := attrs.PutEmptyMap(attributeVarbinds)
+	for _, variable := range packet.Variables {
+		data := r.converter.convertSnmpPDUToSnmpData(variable)
+		switch data.valueType {
+		case integerVal:
+			varbinds.PutInt(data.oid, data.value.(int64))
+		case floatVal:
+			varbinds.PutDouble(data.oid, data.value.(float64))
+		case stringVal:
+			varbinds.PutStr(data.oid, data.value.(string))
+		default:
+			if variable.Value != nil {
+				varbinds.PutStr(data.oid, fmt.Sprint(variable.Value))
+			}
+		}
+	}
+	return logs
+}
+
+// pduTypeName returns whether the message is a trap or an inform
+func pduTypeName(pduType gosnmp.PDUType) string {
+	if pduType == gosnmp.InformRequest {
+		return "inform"
+	}
+	return "trap"
+}
+
+// countTrap increments the trap metrics matching the trap OID and returns their
+// cumulative values for the source of the trap
+func (r *trapReceiver) countTrap(trapOID string, source string, now pcommon.Timestamp) pmetric.Metrics {
+	metrics := pmetric.NewMetrics()
+	scopeMetrics := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	scopeMetrics.Scope().SetName("otelcol/snmpreceiver")
+	scopeMetrics.Scope().SetVersion(r.settings.BuildInfo.Version)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.expireCounts(now)
+
+	for name, metricCfg := range r.cfg.Traps.Metrics {
+		if normalizeOID(metricCfg.TrapOID) != normalizeOID(trapOID) {
+			continue
+		}
+		series := trapSeries{metric: name, source: source}
+		count := r.counts[series]
+		if count == nil {
+			count = &trapCount{startTime: r.lastExpiry}
+			r.counts[series] = count
+		}
+		count.value++
+		count.lastSeen = now
+
+		metric := scopeMetrics.Metrics().AppendEmpty()
+		metric.SetName(name)
+		metric.SetDescription(metricCfg.Description)
+		metric.SetUnit("{traps}")
+		sum := metric.SetEmptySum()
+		sum.SetIsMonotonic(true)
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		dp := sum.DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(count.startTime)
+		dp.SetTimestamp(now)
+		dp.SetIntValue(count.value)
+		if source != "" {
+			dp.Attributes().PutStr(attributeSourceAddress, source)
+		}
+	}
+	return metrics
+}
+
+// expireCounts drops the counts of the sources that sent no matching trap for
+// metrics_expiration, so that the counts do not grow with every source ever seen.
+// Counts are checked at most once per metrics_expiration.
+func (r *trapReceiver) expireCounts(now pcommon.Timestamp) {
+	expiration := r.cfg.Traps.MetricsExpiration
+	if expiration <= 0 || now.AsTime().Sub(r.lastExpiry.AsTime()) < expiration {
+		return
+	}
+	for series, count := range r.counts {
+		if now.AsTime().Sub(count.lastSeen.AsTime()) >= expiration {
+			delete(r.counts, series)
+		}
+	}
+	r.lastExpiry = now
+}
+
+// normalizeOID makes OIDs with and without a leading dot comparable
+func normalizeOID(oid string) string {
+	return "." + strings.TrimPrefix(oid, ".")
+}
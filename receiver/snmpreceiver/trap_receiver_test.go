@@ -0,0 +1,219 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package snmpreceiver
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+)
+
+func TestGetTrapOID(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		packet   *gosnmp.SnmpPacket
+		expected string
+	}{
+		{
+			desc: "v1 generic trap",
+			packet: &gosnmp.SnmpPacket{
+				PDUType: gosnmp.Trap,
+				SnmpTrap: gosnmp.SnmpTrap{
+					Enterprise:  ".1.3.6.1.4.1.8072",
+					GenericTrap: 2,
+				},
+			},
+			expected: ".1.3.6.1.6.3.1.1.5.3",
+		},
+		{
+			desc: "v1 enterprise specific trap",
+			packet: &gosnmp.SnmpPacket{
+				PDUType: gosnmp.Trap,
+				SnmpTrap: gosnmp.SnmpTrap{
+					Enterprise:   ".1.3.6.1.4.1.8072",
+					GenericTrap:  enterpriseSpecificTrap,
+					SpecificTrap: 5,
+				},
+			},
+			expected: ".1.3.6.1.4.1.8072.0.5",
+		},
+		{
+			desc: "v2c trap",
+			packet: &gosnmp.SnmpPacket{
+				PDUType: gosnmp.SNMPv2Trap,
+				Variables: []gosnmp.SnmpPDU{
+					{Name: ".1.3.6.1.2.1.1.3.0", Type: gosnmp.TimeTicks, Value: uint32(100)},
+					{Name: snmpTrapOID, Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.6.3.1.1.5.4"},
+				},
+			},
+			expected: ".1.3.6.1.6.3.1.1.5.4",
+		},
+		{
+			desc:     "v2c trap without trap OID",
+			packet:   &gosnmp.SnmpPacket{PDUType: gosnmp.SNMPv2Trap},
+			expected: "",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			require.Equal(t, tc.expected, getTrapOID(tc.packet))
+		})
+	}
+}
+
+func TestTrapReceiver(t *testing.T) {
+	endpoint := testutil.GetAvailableLocalNetworkAddress(t, "udp")
+	host, portStr, err := net.SplitHostPort(endpoint)
+	require.NoError(t, err)
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	require.NoError(t, err)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Traps = &TrapsConfig{
+		Endpoint: "udp://" + endpoint,
+		Metrics: map[string]*TrapMetricConfig{
+			"snmp.link_down": {TrapOID: "1.3.6.1.6.3.1.1.5.3"},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	logsSink := new(consumertest.LogsSink)
+	metricsSink := new(consumertest.MetricsSink)
+	r := newTrapReceiver(cfg, receivertest.NewNopCreateSettings())
+	r.logsConsumer = logsSink
+	r.metricsConsumer = metricsSink
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, r.Shutdown(context.Background()))
+	}()
+
+	send := func(community string, inform bool) error {
+		client := &gosnmp.GoSNMP{
+			Target:    host,
+			Port:      uint16(port),
+			Transport: "udp",
+			Community: community,
+			Version:   gosnmp.Version2c,
+			Timeout:   time.Second,
+			MaxOids:   gosnmp.Default.MaxOids,
+		}
+		if err := client.Connect(); err != nil {
+			return err
+		}
+		defer client.Conn.Close()
+		_, err := client.SendTrap(gosnmp.SnmpTrap{
+			IsInform: inform,
+			Variables: []gosnmp.SnmpPDU{
+				{Name: snmpTrapOID, Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.6.3.1.1.5.3"},
+				{Name: ".1.3.6.1.2.1.2.2.1.1.2", Type: gosnmp.Integer, Value: 2},
+				{Name: ".1.3.6.1.2.1.2.2.1.2.2", Type: gosnmp.OctetString, Value: []byte("eth0")},
+			},
+		})
+		return err
+	}
+
+	// Traps with another community are dropped
+	require.NoError(t, send("private", false))
+	require.NoError(t, send(defaultCommunity, false))
+	// The inform is only answered once it has been consumed
+	require.NoError(t, send(defaultCommunity, true))
+
+	require.Eventually(t, func() bool {
+		return logsSink.LogRecordCount() == 2 && metricsSink.DataPointCount() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	logs := logsSink.AllLogs()
+	record := logs[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, ".1.3.6.1.6.3.1.1.5.3", record.Body().Str())
+	attrs := record.Attributes().AsRaw()
+	assert.Equal(t, "v2c", attrs[attributeVersion])
+	assert.Equal(t, "trap", attrs[attributePDUType])
+	assert.Equal(t, ".1.3.6.1.6.3.1.1.5.3", attrs[attributeTrapOID])
+	assert.NotEmpty(t, attrs[attributeSourceAddress])
+	varbinds := attrs[attributeVarbinds].(map[string]any)
+	assert.Equal(t, int64(2), varbinds[".1.3.6.1.2.1.2.2.1.1.2"])
+	assert.Equal(t, "eth0", varbinds[".1.3.6.1.2.1.2.2.1.2.2"])
+
+	inform := logs[1].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	pduType, _ := inform.Attributes().Get(attributePDUType)
+	assert.Equal(t, "inform", pduType.Str())
+
+	metrics := metricsSink.AllMetrics()
+	last := metrics[len(metrics)-1].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "snmp.link_down", last.Name())
+	assert.True(t, last.Sum().IsMonotonic())
+	assert.Equal(t, int64(2), last.Sum().DataPoints().At(0).IntValue())
+}
+
+func TestTrapParams(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Version = "v3"
+	cfg.User = "otel"
+	cfg.SecurityLevel = "auth_priv"
+	cfg.AuthType = "SHA"
+	cfg.AuthPassword = "authpass"
+	cfg.PrivacyType = "AES"
+	cfg.PrivacyPassword = "privpass"
+
+	params := newTrapParams(cfg)
+	assert.Equal(t, gosnmp.Version3, params.Version)
+	assert.Equal(t, gosnmp.UserSecurityModel, params.SecurityModel)
+	assert.Equal(t, gosnmp.AuthPriv, params.MsgFlags)
+	usm := params.SecurityParameters.(*gosnmp.UsmSecurityParameters)
+	assert.Equal(t, "otel", usm.UserName)
+	assert.Equal(t, gosnmp.SHA, usm.AuthenticationProtocol)
+	assert.Equal(t, gosnmp.AES, usm.PrivacyProtocol)
+
+	cfg.Version = "v1"
+	params = newTrapParams(cfg)
+	assert.Equal(t, gosnmp.Version1, params.Version)
+	assert.Equal(t, defaultCommunity, params.Community)
+}
+
+func TestCountTrapExpiration(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Traps = &TrapsConfig{
+		Metrics: map[string]*TrapMetricConfig{
+			"snmp.link_down": {TrapOID: ".1.3.6.1.6.3.1.1.5.3"},
+		},
+		MetricsExpiration: time.Minute,
+	}
+	r := newTrapReceiver(cfg, receivertest.NewNopCreateSettings())
+	start := time.Now()
+	r.lastExpiry = pcommon.NewTimestampFromTime(start)
+
+	count := func(source string, at time.Duration) pmetric.NumberDataPoint {
+		metrics := r.countTrap("1.3.6.1.6.3.1.1.5.3", source, pcommon.NewTimestampFromTime(start.Add(at)))
+		require.Equal(t, 1, metrics.DataPointCount())
+		return metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	}
+
+	assert.Equal(t, int64(1), count("10.0.0.1", 0).IntValue())
+	assert.Equal(t, int64(1), count("10.0.0.2", 0).IntValue())
+	assert.Equal(t, int64(2), count("10.0.0.1", 30*time.Second).IntValue())
+
+	// 10.0.0.2 has been idle for a minute, its count is dropped while the one of
+	// 10.0.0.1 is kept
+	dp := count("10.0.0.1", 70*time.Second)
+	assert.Equal(t, int64(3), dp.IntValue())
+	assert.Equal(t, pcommon.NewTimestampFromTime(start), dp.StartTimestamp())
+	assert.Len(t, r.counts, 1)
+
+	dp = count("10.0.0.2", 80*time.Second)
+	assert.Equal(t, int64(1), dp.IntValue())
+	assert.Equal(t, pcommon.NewTimestampFromTime(start.Add(70*time.Second)), dp.StartTimestamp())
+}
@@ -22,6 +22,10 @@ type Config struct {
 	EnableSimpleTags      bool                             `mapstructure:"enable_simple_tags"`
 	IsMonotonicCounter    bool                             `mapstructure:"is_monotonic_counter"`
 	TimerHistogramMapping []protocol.TimerHistogramMapping `mapstructure:"timer_histogram_mapping"`
+	// PartitionByPeer aggregates the metrics of each client separately, identified by its IP
+	// address or, for unix sockets on Linux, by its process credentials. The client is set
+	// as resource attributes.
+	PartitionByPeer bool `mapstructure:"partition_by_peer"`
 }
 
 func (c *Config) Validate() error {
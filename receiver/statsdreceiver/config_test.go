@@ -64,6 +64,18 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "unixgram"),
+			expected: &Config{
+				NetAddr: confignet.AddrConfig{
+					Endpoint:  "/var/run/statsd/dsd.socket",
+					Transport: confignet.TransportTypeUnixgram,
+				},
+				AggregationInterval:   defaultAggregationInterval,
+				TimerHistogramMapping: defaultTimerHistogramMapping,
+				PartitionByPeer:       true,
+			},
+		},
 	}
 
 	for _, tt := range tests {
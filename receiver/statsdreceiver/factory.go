@@ -12,6 +12,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/protocol"
 )
@@ -24,7 +25,7 @@ const (
 )
 
 var (
-	defaultTimerHistogramMapping = []protocol.TimerHistogramMapping{{StatsdType: "timer", ObserverType: "gauge"}, {StatsdType: "histogram", ObserverType: "gauge"}, {StatsdType: "distribution", ObserverType: "gauge"}}
+	defaultTimerHistogramMapping = []protocol.TimerHistogramMapping{{StatsdType: "timer", ObserverType: "gauge"}, {StatsdType: "histogram", ObserverType: "gauge"}, {StatsdType: "distribution", ObserverType: "histogram"}}
 )
 
 // NewFactory creates a factory for the StatsD receiver.
@@ -33,6 +34,7 @@ func NewFactory() receiver.Factory {
 		metadata.Type,
 		createDefaultConfig,
 		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability),
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability),
 	)
 }
 
@@ -55,6 +57,40 @@ func createMetricsReceiver(
 	cfg component.Config,
 	consumer consumer.Metrics,
 ) (receiver.Metrics, error) {
+	var err error
 	c := cfg.(*Config)
-	return newReceiver(params, *c, consumer)
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		var rcv receiver.Metrics
+		rcv, err = newReceiver(params, *c, nil)
+		return rcv
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().(*statsdReceiver).nextConsumer = consumer
+	return r, nil
 }
+
+// createLogsReceiver creates a receiver for DogStatsD events and service checks.
+func createLogsReceiver(
+	_ context.Context,
+	params receiver.CreateSettings,
+	cfg component.Config,
+	consumer consumer.Logs,
+) (receiver.Logs, error) {
+	var err error
+	c := cfg.(*Config)
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		var rcv receiver.Metrics
+		rcv, err = newReceiver(params, *c, nil)
+		return rcv
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().(*statsdReceiver).logsConsumer = consumer
+	return r, nil
+}
+
+// receivers shares a single listener between the metrics and logs receivers
+var receivers = sharedcomponent.NewSharedComponents()
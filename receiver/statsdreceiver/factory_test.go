@@ -8,9 +8,12 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 )
 
 func TestCreateDefaultConfig(t *testing.T) {
@@ -29,3 +32,21 @@ func TestCreateReceiver(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, tReceiver, "receiver creation failed")
 }
+
+func TestCreateLogsReceiver(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.NetAddr.Endpoint = "localhost:0"
+
+	params := receivertest.NewNopCreateSettings()
+	logsReceiver, err := createLogsReceiver(context.Background(), params, cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	metricsReceiver, err := createMetricsReceiver(context.Background(), params, cfg, consumertest.NewNop())
+	require.NoError(t, err)
+
+	// The metrics and logs receivers share the listener
+	assert.Same(t, logsReceiver, metricsReceiver)
+	r := logsReceiver.(*sharedcomponent.SharedComponent).Unwrap().(*statsdReceiver)
+	assert.NotNil(t, r.nextConsumer)
+	assert.NotNil(t, r.logsConsumer)
+	assert.NoError(t, logsReceiver.Shutdown(context.Background()))
+}
@@ -23,6 +23,13 @@ func TestComponentLifecycle(t *testing.T) {
 		createFn func(ctx context.Context, set receiver.CreateSettings, cfg component.Config) (component.Component, error)
 	}{
 
+		{
+			name: "logs",
+			createFn: func(ctx context.Context, set receiver.CreateSettings, cfg component.Config) (component.Component, error) {
+				return factory.CreateLogsReceiver(ctx, set, cfg, consumertest.NewNop())
+			},
+		},
+
 		{
 			name: "metrics",
 			createFn: func(ctx context.Context, set receiver.CreateSettings, cfg component.Config) (component.Component, error) {
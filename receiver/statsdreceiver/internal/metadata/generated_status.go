@@ -14,6 +14,7 @@ var (
 
 const (
 	MetricsStability = component.StabilityLevelBeta
+	LogsStability    = component.StabilityLevelDevelopment
 )
 
 func Meter(settings component.TelemetrySettings) metric.Meter {
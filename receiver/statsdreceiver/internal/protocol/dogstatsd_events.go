@@ -0,0 +1,219 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package protocol // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/protocol"
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	semconv "go.opentelemetry.io/collector/semconv/v1.22.0"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	eventPrefix        = "_e{"
+	serviceCheckPrefix = "_sc|"
+
+	attributeEventTitle          = "dogstatsd.event.title"
+	attributeEventPriority       = "dogstatsd.event.priority"
+	attributeEventAlertType      = "dogstatsd.event.alert_type"
+	attributeEventAggregationKey = "dogstatsd.event.aggregation_key"
+	attributeEventSourceType     = "dogstatsd.event.source_type_name"
+	attributeServiceCheckName    = "dogstatsd.service_check.name"
+	attributeServiceCheckStatus  = "dogstatsd.service_check.status"
+)
+
+// addressLogs holds the log records received from a client during an aggregation interval.
+type addressLogs struct {
+	addr    net.Addr
+	records plog.LogRecordSlice
+}
+
+func (p *StatsDParser) addLogRecord(record plog.LogRecord, addr net.Addr) {
+	addrKey := newNetAddr(addr)
+	logs, ok := p.logsByAddress[addrKey]
+	if !ok {
+		logs = &addressLogs{addr: addr, records: plog.NewLogRecordSlice()}
+		p.logsByAddress[addrKey] = logs
+	}
+	record.MoveTo(logs.records.AppendEmpty())
+}
+
+// parseEvent parses a DogStatsD event:
+// _e{<TITLE_LENGTH>,<TEXT_LENGTH>}:<TITLE>|<TEXT>|d:<TIMESTAMP>|h:<HOSTNAME>|p:<PRIORITY>|t:<ALERT_TYPE>|k:<AGGREGATION_KEY>|s:<SOURCE_TYPE_NAME>|#<TAGS>|c:<CONTAINER_ID>
+// See https://docs.datadoghq.com/developers/dogstatsd/datagram_shell/?tab=events
+func parseEvent(line string, enableSimpleTags bool) (plog.LogRecord, error) {
+	record := plog.NewLogRecord()
+
+	header, rest, ok := strings.Cut(strings.TrimPrefix(line, eventPrefix), "}:")
+	if !ok {
+		return record, fmt.Errorf("invalid event format: %s", line)
+	}
+	titleLenStr, textLenStr, ok := strings.Cut(header, ",")
+	if !ok {
+		return record, fmt.Errorf("invalid event lengths: %s", header)
+	}
+	titleLen, err := strconv.Atoi(titleLenStr)
+	if err != nil || titleLen <= 0 {
+		return record, fmt.Errorf("invalid event title length: %s", titleLenStr)
+	}
+	textLen, err := strconv.Atoi(textLenStr)
+	if err != nil || textLen < 0 {
+		return record, fmt.Errorf("invalid event text length: %s", textLenStr)
+	}
+	// The lengths are checked one at a time so that large values cannot overflow
+	if titleLen > len(rest)-1 || rest[titleLen] != '|' || textLen > len(rest)-titleLen-1 {
+		return record, fmt.Errorf("event title and text do not match their lengths: %s", line)
+	}
+	title := rest[:titleLen]
+	text := rest[titleLen+1 : titleLen+1+textLen]
+	rest = rest[titleLen+1+textLen:]
+	if rest != "" && rest[0] != '|' {
+		return record, fmt.Errorf("event title and text do not match their lengths: %s", line)
+	}
+
+	record.Body().SetStr(strings.ReplaceAll(text, `\n`, "\n"))
+	record.SetObservedTimestamp(pcommon.NewTimestampFromTime(timeNowFunc()))
+	record.SetSeverityNumber(plog.SeverityNumberInfo)
+	record.SetSeverityText("info")
+	attrs := record.Attributes()
+	attrs.PutStr(attributeEventTitle, title)
+
+	var kvs []attribute.KeyValue
+	for _, part := range strings.Split(rest, "|")[1:] {
+		switch {
+		case strings.HasPrefix(part, "d:"):
+			timestamp, err := parseTimestamp(strings.TrimPrefix(part, "d:"))
+			if err != nil {
+				return record, err
+			}
+			record.SetTimestamp(timestamp)
+		case strings.HasPrefix(part, "h:"):
+			attrs.PutStr(semconv.AttributeHostName, strings.TrimPrefix(part, "h:"))
+		case strings.HasPrefix(part, "p:"):
+			priority := strings.TrimPrefix(part, "p:")
+			if priority != "normal" && priority != "low" {
+				return record, fmt.Errorf("unsupported event priority: %s", priority)
+			}
+			attrs.PutStr(attributeEventPriority, priority)
+		case strings.HasPrefix(part, "t:"):
+			alertType := strings.TrimPrefix(part, "t:")
+			switch alertType {
+			case "error":
+				record.SetSeverityNumber(plog.SeverityNumberError)
+			case "warning":
+				record.SetSeverityNumber(plog.SeverityNumberWarn)
+			case "info", "success":
+				record.SetSeverityNumber(plog.SeverityNumberInfo)
+			default:
+				return record, fmt.Errorf("unsupported event alert type: %s", alertType)
+			}
+			record.SetSeverityText(alertType)
+			attrs.PutStr(attributeEventAlertType, alertType)
+		case strings.HasPrefix(part, "k:"):
+			attrs.PutStr(attributeEventAggregationKey, strings.TrimPrefix(part, "k:"))
+		case strings.HasPrefix(part, "s:"):
+			attrs.PutStr(attributeEventSourceType, strings.TrimPrefix(part, "s:"))
+		case strings.HasPrefix(part, "#"):
+			tags, err := parseTags(strings.TrimPrefix(part, "#"), enableSimpleTags)
+			if err != nil {
+				return record, err
+			}
+			kvs = append(kvs, tags...)
+		case strings.HasPrefix(part, "c:"):
+			if containerID := strings.TrimPrefix(part, "c:"); containerID != "" {
+				attrs.PutStr(semconv.AttributeContainerID, containerID)
+			}
+		default:
+			return record, fmt.Errorf("unrecognized event part: %s", part)
+		}
+	}
+	putTags(attrs, kvs)
+	return record, nil
+}
+
+// parseServiceCheck parses a DogStatsD service check:
+// _sc|<NAME>|<STATUS>|d:<TIMESTAMP>|h:<HOSTNAME>|#<TAGS>|c:<CONTAINER_ID>|m:<MESSAGE>
+// See https://docs.datadoghq.com/developers/dogstatsd/datagram_shell/?tab=servicechecks
+func parseServiceCheck(line string, enableSimpleTags bool) (plog.LogRecord, error) {
+	record := plog.NewLogRecord()
+
+	// The message is the last field and may contain any character
+	fields, message, _ := strings.Cut(strings.TrimPrefix(line, serviceCheckPrefix), "|m:")
+	parts := strings.Split(fields, "|")
+	if len(parts) < 2 || parts[0] == "" {
+		return record, fmt.Errorf("invalid service check format: %s", line)
+	}
+
+	record.Body().SetStr(strings.ReplaceAll(message, `\n`, "\n"))
+	record.SetObservedTimestamp(pcommon.NewTimestampFromTime(timeNowFunc()))
+	attrs := record.Attributes()
+	attrs.PutStr(attributeServiceCheckName, parts[0])
+
+	var status string
+	switch parts[1] {
+	case "0":
+		status = "ok"
+		record.SetSeverityNumber(plog.SeverityNumberInfo)
+	case "1":
+		status = "warning"
+		record.SetSeverityNumber(plog.SeverityNumberWarn)
+	case "2":
+		status = "critical"
+		record.SetSeverityNumber(plog.SeverityNumberError)
+	case "3":
+		status = "unknown"
+	default:
+		return record, fmt.Errorf("unsupported service check status: %s", parts[1])
+	}
+	record.SetSeverityText(status)
+	attrs.PutStr(attributeServiceCheckStatus, status)
+
+	var kvs []attribute.KeyValue
+	for _, part := range parts[2:] {
+		switch {
+		case strings.HasPrefix(part, "d:"):
+			timestamp, err := parseTimestamp(strings.TrimPrefix(part, "d:"))
+			if err != nil {
+				return record, err
+			}
+			record.SetTimestamp(timestamp)
+		case strings.HasPrefix(part, "h:"):
+			attrs.PutStr(semconv.AttributeHostName, strings.TrimPrefix(part, "h:"))
+		case strings.HasPrefix(part, "#"):
+			tags, err := parseTags(strings.TrimPrefix(part, "#"), enableSimpleTags)
+			if err != nil {
+				return record, err
+			}
+			kvs = append(kvs, tags...)
+		case strings.HasPrefix(part, "c:"):
+			if containerID := strings.TrimPrefix(part, "c:"); containerID != "" {
+				attrs.PutStr(semconv.AttributeContainerID, containerID)
+			}
+		default:
+			return record, fmt.Errorf("unrecognized service check part: %s", part)
+		}
+	}
+	putTags(attrs, kvs)
+	return record, nil
+}
+
+func parseTimestamp(timestampStr string) (pcommon.Timestamp, error) {
+	timestampSeconds, err := strconv.ParseInt(timestampStr, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timestamp: %s", timestampStr)
+	}
+	return pcommon.NewTimestampFromTime(time.Unix(timestampSeconds, 0)), nil
+}
+
+func putTags(attrs pcommon.Map, kvs []attribute.KeyValue) {
+	for _, kv := range kvs {
+		attrs.PutStr(string(kv.Key), kv.Value.AsString())
+	}
+}
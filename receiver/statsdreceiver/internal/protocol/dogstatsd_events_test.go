@@ -0,0 +1,264 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package protocol
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func Test_ParseEvent(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		body         string
+		severity     plog.SeverityNumber
+		severityText string
+		timestamp    pcommon.Timestamp
+		attributes   map[string]any
+		err          string
+	}{
+		{
+			name:         "title and text",
+			input:        "_e{5,4}:title|text",
+			body:         "text",
+			severity:     plog.SeverityNumberInfo,
+			severityText: "info",
+			attributes: map[string]any{
+				"dogstatsd.event.title": "title",
+			},
+		},
+		{
+			name:         "all fields",
+			input:        `_e{8,11}:disk|ful|line\nline2|d:1700000000|h:host1|p:low|t:error|k:agg|s:source|#env:prod,team:a|c:abc123`,
+			body:         "line\nline2",
+			severity:     plog.SeverityNumberError,
+			severityText: "error",
+			timestamp:    pcommon.NewTimestampFromTime(time.Unix(1700000000, 0)),
+			attributes: map[string]any{
+				"dogstatsd.event.title":            "disk|ful",
+				"dogstatsd.event.priority":         "low",
+				"dogstatsd.event.alert_type":       "error",
+				"dogstatsd.event.aggregation_key":  "agg",
+				"dogstatsd.event.source_type_name": "source",
+				"host.name":                        "host1",
+				"container.id":                     "abc123",
+				"env":                              "prod",
+				"team":                             "a",
+			},
+		},
+		{
+			name:         "empty text",
+			input:        "_e{5,0}:title||t:warning",
+			body:         "",
+			severity:     plog.SeverityNumberWarn,
+			severityText: "warning",
+			attributes: map[string]any{
+				"dogstatsd.event.title":      "title",
+				"dogstatsd.event.alert_type": "warning",
+			},
+		},
+		{
+			name:  "missing lengths",
+			input: "_e{5}:title|text",
+			err:   "invalid event lengths: 5",
+		},
+		{
+			name:  "invalid title length",
+			input: "_e{0,4}:|text",
+			err:   "invalid event title length: 0",
+		},
+		{
+			name:  "text longer than its length",
+			input: "_e{5,2}:title|text",
+			err:   "event title and text do not match their lengths: _e{5,2}:title|text",
+		},
+		{
+			name:  "text shorter than its length",
+			input: "_e{5,10}:title|text",
+			err:   "event title and text do not match their lengths: _e{5,10}:title|text",
+		},
+		{
+			name:  "title length overflowing the event length",
+			input: "_e{9223372036854775807,1}:a|b",
+			err:   "event title and text do not match their lengths: _e{9223372036854775807,1}:a|b",
+		},
+		{
+			name:  "text length overflowing the event length",
+			input: "_e{1,9223372036854775807}:a|b",
+			err:   "event title and text do not match their lengths: _e{1,9223372036854775807}:a|b",
+		},
+		{
+			name:  "invalid alert type",
+			input: "_e{5,4}:title|text|t:fatal",
+			err:   "unsupported event alert type: fatal",
+		},
+		{
+			name:  "invalid priority",
+			input: "_e{5,4}:title|text|p:high",
+			err:   "unsupported event priority: high",
+		},
+		{
+			name:  "invalid timestamp",
+			input: "_e{5,4}:title|text|d:now",
+			err:   "invalid timestamp: now",
+		},
+		{
+			name:  "simple tag",
+			input: "_e{5,4}:title|text|#env",
+			err:   `invalid tag format: "env"`,
+		},
+		{
+			name:  "unknown part",
+			input: "_e{5,4}:title|text|x:y",
+			err:   "unrecognized event part: x:y",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record, err := parseEvent(tt.input, false)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.body, record.Body().Str())
+			assert.Equal(t, tt.severity, record.SeverityNumber())
+			assert.Equal(t, tt.severityText, record.SeverityText())
+			assert.Equal(t, tt.timestamp, record.Timestamp())
+			assert.NotZero(t, record.ObservedTimestamp())
+			assert.Equal(t, tt.attributes, record.Attributes().AsRaw())
+		})
+	}
+}
+
+func Test_ParseServiceCheck(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		body         string
+		severity     plog.SeverityNumber
+		severityText string
+		timestamp    pcommon.Timestamp
+		attributes   map[string]any
+		err          string
+	}{
+		{
+			name:         "name and status",
+			input:        "_sc|app.health|0",
+			severity:     plog.SeverityNumberInfo,
+			severityText: "ok",
+			attributes: map[string]any{
+				"dogstatsd.service_check.name":   "app.health",
+				"dogstatsd.service_check.status": "ok",
+			},
+		},
+		{
+			name:         "all fields",
+			input:        "_sc|app.health|2|d:1700000000|h:host1|#env:prod|c:abc123|m:down|since:10m",
+			body:         "down|since:10m",
+			severity:     plog.SeverityNumberError,
+			severityText: "critical",
+			timestamp:    pcommon.NewTimestampFromTime(time.Unix(1700000000, 0)),
+			attributes: map[string]any{
+				"dogstatsd.service_check.name":   "app.health",
+				"dogstatsd.service_check.status": "critical",
+				"host.name":                      "host1",
+				"container.id":                   "abc123",
+				"env":                            "prod",
+			},
+		},
+		{
+			name:         "warning",
+			input:        "_sc|app.health|1|m:slow",
+			body:         "slow",
+			severity:     plog.SeverityNumberWarn,
+			severityText: "warning",
+			attributes: map[string]any{
+				"dogstatsd.service_check.name":   "app.health",
+				"dogstatsd.service_check.status": "warning",
+			},
+		},
+		{
+			name:         "unknown",
+			input:        "_sc|app.health|3",
+			severity:     plog.SeverityNumberUnspecified,
+			severityText: "unknown",
+			attributes: map[string]any{
+				"dogstatsd.service_check.name":   "app.health",
+				"dogstatsd.service_check.status": "unknown",
+			},
+		},
+		{
+			name:  "missing status",
+			input: "_sc|app.health",
+			err:   "invalid service check format: _sc|app.health",
+		},
+		{
+			name:  "missing name",
+			input: "_sc||0",
+			err:   "invalid service check format: _sc||0",
+		},
+		{
+			name:  "invalid status",
+			input: "_sc|app.health|4",
+			err:   "unsupported service check status: 4",
+		},
+		{
+			name:  "unknown part",
+			input: "_sc|app.health|0|p:low",
+			err:   "unrecognized service check part: p:low",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record, err := parseServiceCheck(tt.input, false)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.body, record.Body().Str())
+			assert.Equal(t, tt.severity, record.SeverityNumber())
+			assert.Equal(t, tt.severityText, record.SeverityText())
+			assert.Equal(t, tt.timestamp, record.Timestamp())
+			assert.Equal(t, tt.attributes, record.Attributes().AsRaw())
+		})
+	}
+}
+
+func TestStatsDParser_GetLogs(t *testing.T) {
+	p := &StatsDParser{}
+	require.NoError(t, p.Initialize(false, false, false, nil))
+
+	addr1, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
+	addr2, _ := net.ResolveUDPAddr("udp", "1.2.3.4:8765")
+	require.NoError(t, p.Aggregate("_e{5,4}:title|text", addr1))
+	require.NoError(t, p.Aggregate("_sc|app.health|0", addr1))
+	require.NoError(t, p.Aggregate("_sc|app.health|1", addr2))
+	require.NoError(t, p.Aggregate("test.metric:42|c", addr1))
+	require.Error(t, p.Aggregate("_sc|app.health|9", addr1))
+
+	// Metrics are flushed independently of logs
+	assert.Len(t, p.GetMetrics(), 1)
+
+	batches := p.GetLogs()
+	require.Len(t, batches, 2)
+	counts := map[string]int{}
+	for _, batch := range batches {
+		require.Equal(t, 1, batch.Logs.ResourceLogs().Len())
+		sl := batch.Logs.ResourceLogs().At(0).ScopeLogs().At(0)
+		assert.Equal(t, receiverName, sl.Scope().Name())
+		counts[batch.Info.Addr.String()] = sl.LogRecords().Len()
+	}
+	assert.Equal(t, map[string]int{"1.2.3.4:5678": 2, "1.2.3.4:8765": 1}, counts)
+
+	assert.Empty(t, p.GetLogs())
+}
@@ -7,13 +7,16 @@ import (
 	"net"
 
 	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 )
 
-// Parser is something that can map input StatsD strings to OTLP Metric representations.
+// Parser is something that can map input StatsD strings to OTLP Metric representations,
+// and DogStatsD events and service checks to OTLP Log representations.
 type Parser interface {
 	Initialize(enableMetricType bool, enableSimpleTags bool, isMonotonicCounter bool, sendTimerHistogram []TimerHistogramMapping) error
 	GetMetrics() []BatchMetrics
+	GetLogs() []BatchLogs
 	Aggregate(line string, addr net.Addr) error
 }
 
@@ -21,3 +24,8 @@ type BatchMetrics struct {
 	Info    client.Info
 	Metrics pmetric.Metrics
 }
+
+type BatchLogs struct {
+	Info client.Info
+	Logs plog.Logs
+}
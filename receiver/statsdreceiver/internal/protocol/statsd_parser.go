@@ -15,6 +15,7 @@ import (
 	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	semconv "go.opentelemetry.io/collector/semconv/v1.22.0"
 	"go.opentelemetry.io/otel/attribute"
@@ -84,6 +85,8 @@ type StatsDParser struct {
 	isMonotonicCounter   bool
 	timerEvents          ObserverCategory
 	histogramEvents      ObserverCategory
+	distributionEvents   ObserverCategory
+	logsByAddress        map[netAddr]*addressLogs
 	lastIntervalTime     time.Time
 	BuildInfo            component.BuildInfo
 }
@@ -162,23 +165,33 @@ func (p *StatsDParser) resetState(when time.Time) {
 func (p *StatsDParser) Initialize(enableMetricType bool, enableSimpleTags bool, isMonotonicCounter bool, sendTimerHistogram []TimerHistogramMapping) error {
 	p.resetState(timeNowFunc())
 
+	p.logsByAddress = make(map[netAddr]*addressLogs)
 	p.histogramEvents = defaultObserverCategory
 	p.timerEvents = defaultObserverCategory
 	p.enableMetricType = enableMetricType
 	p.enableSimpleTags = enableSimpleTags
 	p.isMonotonicCounter = isMonotonicCounter
+	hasDistributionMapping := false
 	// Note: validation occurs in ("../".Config).validate()
 	for _, eachMap := range sendTimerHistogram {
 		switch eachMap.StatsdType {
-		case HistogramTypeName, DistributionTypeName:
+		case HistogramTypeName:
 			p.histogramEvents.method = eachMap.ObserverType
 			p.histogramEvents.histogramConfig = expoHistogramConfig(eachMap.Histogram)
+		case DistributionTypeName:
+			p.distributionEvents.method = eachMap.ObserverType
+			p.distributionEvents.histogramConfig = expoHistogramConfig(eachMap.Histogram)
+			hasDistributionMapping = true
 		case TimingTypeName, TimingAltTypeName:
 			p.timerEvents.method = eachMap.ObserverType
 			p.timerEvents.histogramConfig = expoHistogramConfig(eachMap.Histogram)
 		case CounterTypeName, GaugeTypeName:
 		}
 	}
+	// Distributions are aggregated like histograms unless they have a mapping of their own
+	if !hasDistributionMapping {
+		p.distributionEvents = p.histogramEvents
+	}
 	return nil
 }
 
@@ -248,6 +261,26 @@ func (p *StatsDParser) GetMetrics() []BatchMetrics {
 	return batchMetrics
 }
 
+// GetLogs gets the log records of the events and service checks preparing for flushing and
+// resets them.
+func (p *StatsDParser) GetLogs() []BatchLogs {
+	batchLogs := make([]BatchLogs, 0, len(p.logsByAddress))
+	for _, logs := range p.logsByAddress {
+		batch := BatchLogs{
+			Info: client.Info{
+				Addr: logs.addr,
+			},
+			Logs: plog.NewLogs(),
+		}
+		sl := batch.Logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+		p.setVersionAndNameScope(sl.Scope())
+		logs.records.MoveAndAppendTo(sl.LogRecords())
+		batchLogs = append(batchLogs, batch)
+	}
+	p.logsByAddress = make(map[netAddr]*addressLogs)
+	return batchLogs
+}
+
 func (p *StatsDParser) copyMetricAndScope(rm pmetric.ResourceMetrics, metric pmetric.ScopeMetrics) {
 	ilm := rm.ScopeMetrics().AppendEmpty()
 	metric.CopyTo(ilm)
@@ -263,8 +296,10 @@ var timeNowFunc = time.Now
 
 func (p *StatsDParser) observerCategoryFor(t MetricType) ObserverCategory {
 	switch t {
-	case HistogramType, DistributionType:
+	case HistogramType:
 		return p.histogramEvents
+	case DistributionType:
+		return p.distributionEvents
 	case TimingType:
 		return p.timerEvents
 	case CounterType, GaugeType:
@@ -272,8 +307,25 @@ func (p *StatsDParser) observerCategoryFor(t MetricType) ObserverCategory {
 	return defaultObserverCategory
 }
 
-// Aggregate for each metric line.
+// Aggregate for each metric line. DogStatsD events and service checks are kept as log records.
 func (p *StatsDParser) Aggregate(line string, addr net.Addr) error {
+	switch {
+	case strings.HasPrefix(line, eventPrefix):
+		record, err := parseEvent(line, p.enableSimpleTags)
+		if err != nil {
+			return err
+		}
+		p.addLogRecord(record, addr)
+		return nil
+	case strings.HasPrefix(line, serviceCheckPrefix):
+		record, err := parseServiceCheck(line, p.enableSimpleTags)
+		if err != nil {
+			return err
+		}
+		p.addLogRecord(record, addr)
+		return nil
+	}
+
 	parsedMetric, err := parseMessageToMetric(line, p.enableMetricType, p.enableSimpleTags)
 	if err != nil {
 		return err
@@ -402,36 +454,11 @@ func parseMessageToMetric(line string, enableMetricType bool, enableSimpleTags b
 
 			result.sampleRate = f
 		case strings.HasPrefix(part, "#"):
-			tagsStr := strings.TrimPrefix(part, "#")
-
-			// handle an empty tag set
-			// where the tags part was still sent (some clients do this)
-			if len(tagsStr) == 0 {
-				continue
-			}
-
-			tagSets := strings.Split(tagsStr, ",")
-
-			for _, tagSet := range tagSets {
-				tagParts := strings.SplitN(tagSet, ":", 2)
-				k := tagParts[0]
-				if k == "" {
-					return result, fmt.Errorf("invalid tag format: %q", tagSet)
-				}
-
-				// support both simple tags (w/o value) and dimension tags (w/ value).
-				// dogstatsd notably allows simple tags.
-				var v string
-				if len(tagParts) == 2 {
-					v = tagParts[1]
-				}
-
-				if v == "" && !enableSimpleTags {
-					return result, fmt.Errorf("invalid tag format: %q", tagSet)
-				}
-
-				kvs = append(kvs, attribute.String(k, v))
+			tags, err := parseTags(strings.TrimPrefix(part, "#"), enableSimpleTags)
+			if err != nil {
+				return result, err
 			}
+			kvs = append(kvs, tags...)
 		case strings.HasPrefix(part, "c:"):
 			// As per DogStatD protocol v1.2:
 			// https://docs.datadoghq.com/developers/dogstatsd/datagram_shell/?tab=metrics#dogstatsd-protocol-v12
@@ -478,6 +505,39 @@ func parseMessageToMetric(line string, enableMetricType bool, enableSimpleTags b
 	return result, nil
 }
 
+// parseTags parses DogStatsD tags such as "key1:value1,key2:value2". Simple tags without
+// value are only accepted when enableSimpleTags is set.
+func parseTags(tagsStr string, enableSimpleTags bool) ([]attribute.KeyValue, error) {
+	// handle an empty tag set
+	// where the tags part was still sent (some clients do this)
+	if len(tagsStr) == 0 {
+		return nil, nil
+	}
+
+	var kvs []attribute.KeyValue
+	for _, tagSet := range strings.Split(tagsStr, ",") {
+		tagParts := strings.SplitN(tagSet, ":", 2)
+		k := tagParts[0]
+		if k == "" {
+			return nil, fmt.Errorf("invalid tag format: %q", tagSet)
+		}
+
+		// support both simple tags (w/o value) and dimension tags (w/ value).
+		// dogstatsd notably allows simple tags.
+		var v string
+		if len(tagParts) == 2 {
+			v = tagParts[1]
+		}
+
+		if v == "" && !enableSimpleTags {
+			return nil, fmt.Errorf("invalid tag format: %q", tagSet)
+		}
+
+		kvs = append(kvs, attribute.String(k, v))
+	}
+	return kvs, nil
+}
+
 type netAddr struct {
 	Network string
 	String  string
@@ -1623,6 +1623,62 @@ func TestStatsDParser_Mappings(t *testing.T) {
 	}
 }
 
+func TestStatsDParser_DistributionMappings(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		mapping []TimerHistogramMapping
+		expect  pmetric.MetricType
+	}{
+		{
+			name: "distribution-histogram",
+			mapping: []TimerHistogramMapping{
+				{StatsdType: "histogram", ObserverType: "gauge"},
+				{StatsdType: "distribution", ObserverType: "histogram"},
+			},
+			expect: pmetric.MetricTypeExponentialHistogram,
+		},
+		{
+			name: "distribution-before-histogram",
+			mapping: []TimerHistogramMapping{
+				{StatsdType: "distribution", ObserverType: "summary"},
+				{StatsdType: "histogram", ObserverType: "gauge"},
+			},
+			expect: pmetric.MetricTypeSummary,
+		},
+		{
+			name: "distribution-like-histogram",
+			mapping: []TimerHistogramMapping{
+				{StatsdType: "histogram", ObserverType: "summary"},
+			},
+			expect: pmetric.MetricTypeSummary,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &StatsDParser{}
+
+			assert.NoError(t, p.Initialize(false, false, false, tc.mapping))
+
+			addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
+			assert.NoError(t, p.Aggregate("D:10|d|@0.5|c:container", addr))
+			assert.NoError(t, p.Aggregate("D:20|d|@0.5|c:container", addr))
+
+			metrics := p.GetMetrics()[0].Metrics
+			require.Equal(t, 1, metrics.MetricCount())
+			m := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+			assert.Equal(t, "D", m.Name())
+			require.Equal(t, tc.expect, m.Type())
+			if tc.expect == pmetric.MetricTypeExponentialHistogram {
+				dp := m.ExponentialHistogram().DataPoints().At(0)
+				// Both samples are counted twice given the sample rate
+				assert.Equal(t, uint64(4), dp.Count())
+				assert.Equal(t, float64(60), dp.Sum())
+				containerID, _ := dp.Attributes().Get(semconv.AttributeContainerID)
+				assert.Equal(t, "container", containerID.Str())
+			}
+		})
+	}
+}
+
 func TestStatsDParser_ScopeIsIncluded(t *testing.T) {
 
 	const devVersion = "dev-0.0.1"
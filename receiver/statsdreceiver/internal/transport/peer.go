@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package transport // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/transport"
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"strings"
+)
+
+// UnixPeerAddr identifies the process on the other end of a unix socket by its credentials.
+type UnixPeerAddr struct {
+	Net string
+	PID int32
+	UID uint32
+	GID uint32
+}
+
+// Ensure that UnixPeerAddr is a net.Addr.
+var _ net.Addr = (*UnixPeerAddr)(nil)
+
+// Network returns the network of the unix socket.
+func (a *UnixPeerAddr) Network() string {
+	return a.Net
+}
+
+// String returns the credentials of the process.
+func (a *UnixPeerAddr) String() string {
+	return fmt.Sprintf("pid=%d,uid=%d,gid=%d", a.PID, a.UID, a.GID)
+}
+
+// ipPeer returns the IP address of a network client, without its port, so that the sockets
+// of a client are identified as the same peer.
+func ipPeer(addr net.Addr) net.Addr {
+	switch a := addr.(type) {
+	case *net.UDPAddr:
+		return &net.IPAddr{IP: a.IP, Zone: a.Zone}
+	case *net.TCPAddr:
+		return &net.IPAddr{IP: a.IP, Zone: a.Zone}
+	}
+	return nil
+}
+
+// removeStaleSocket removes the socket file left at the path by a previous listener.
+func removeStaleSocket(path string) error {
+	// Abstract sockets have no file
+	if strings.HasPrefix(path, "@") {
+		return nil
+	}
+	fi, err := os.Lstat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if fi.Mode()&fs.ModeSocket == 0 {
+		return fmt.Errorf("%s already exists and is not a unix socket", path)
+	}
+	return os.Remove(path)
+}
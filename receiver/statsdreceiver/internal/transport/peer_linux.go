@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+
+package transport // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/transport"
+
+import (
+	"net"
+	"syscall"
+)
+
+// credentialsOOBSize is the size of the control message holding the credentials of the sender
+// of a datagram.
+var credentialsOOBSize = syscall.CmsgSpace(syscall.SizeofUcred)
+
+// enablePassCred makes the kernel attach the credentials of the sender to each datagram.
+func enablePassCred(conn *net.UnixConn) error {
+	rawConn, err := conn.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var sockErr error
+	if err = rawConn.Control(func(fd uintptr) {
+		sockErr = syscall.SetsockoptInt(int(fd), syscall.SOL_SOCKET, syscall.SO_PASSCRED, 1)
+	}); err != nil {
+		return err
+	}
+	return sockErr
+}
+
+// oobCredentials returns the credentials of the sender of a datagram found in its control
+// messages, or nil when there are none.
+func oobCredentials(network string, oob []byte) *UnixPeerAddr {
+	msgs, err := syscall.ParseSocketControlMessage(oob)
+	if err != nil {
+		return nil
+	}
+	for i := range msgs {
+		cred, err := syscall.ParseUnixCredentials(&msgs[i])
+		if err == nil {
+			return &UnixPeerAddr{Net: network, PID: cred.Pid, UID: cred.Uid, GID: cred.Gid}
+		}
+	}
+	return nil
+}
+
+// connCredentials returns the credentials of the peer of a unix stream connection, or nil
+// when they can't be retrieved.
+func connCredentials(conn net.Conn) *UnixPeerAddr {
+	unixConn, ok := conn.(*net.UnixConn)
+	if !ok {
+		return nil
+	}
+	rawConn, err := unixConn.SyscallConn()
+	if err != nil {
+		return nil
+	}
+	var cred *syscall.Ucred
+	var credErr error
+	if err = rawConn.Control(func(fd uintptr) {
+		cred, credErr = syscall.GetsockoptUcred(int(fd), syscall.SOL_SOCKET, syscall.SO_PEERCRED)
+	}); err != nil || credErr != nil {
+		return nil
+	}
+	return &UnixPeerAddr{Net: string(Unix), PID: cred.Pid, UID: cred.Uid, GID: cred.Gid}
+}
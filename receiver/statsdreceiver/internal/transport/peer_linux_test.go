@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+
+package transport
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/transport/client"
+)
+
+func TestUnixPeerCredentials(t *testing.T) {
+	tests := []struct {
+		name          string
+		transport     Transport
+		buildServerFn func(transport Transport, addr string) (Server, error)
+	}{
+		{
+			name:          "unixgram",
+			transport:     Unixgram,
+			buildServerFn: NewUnixgramServer,
+		},
+		{
+			name:          "unix",
+			transport:     Unix,
+			buildServerFn: NewTCPServer,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := getAvailableSocketPath(t, tt.name)
+			srv, err := tt.buildServerFn(tt.transport, addr)
+			require.NoError(t, err)
+
+			transferChan := make(chan Metric, 10)
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				assert.Error(t, srv.ListenAndServe(new(consumertest.MetricsSink), NewMockReporter(1), transferChan))
+			}()
+
+			gc, err := client.NewStatsD(tt.transport.String(), addr)
+			require.NoError(t, err)
+			require.NoError(t, gc.SendMetric(client.Metric{Name: "test.metric", Value: "42", Type: "c"}))
+
+			var metric Metric
+			select {
+			case metric = <-transferChan:
+			case <-time.After(10 * time.Second):
+				require.Fail(t, "no metric received")
+			}
+			require.NoError(t, gc.Disconnect())
+			require.NoError(t, srv.Close())
+			<-done
+
+			assert.Equal(t, "test.metric:42|c", metric.Raw)
+			assert.Equal(t, tt.transport.String(), metric.Addr.Network())
+			assert.Equal(t, &UnixPeerAddr{
+				Net: tt.transport.String(),
+				PID: int32(os.Getpid()),
+				UID: uint32(os.Getuid()),
+				GID: uint32(os.Getgid()),
+			}, metric.Peer)
+			assert.NoFileExists(t, addr)
+		})
+	}
+}
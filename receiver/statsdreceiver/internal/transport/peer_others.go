@@ -0,0 +1,26 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !linux
+
+package transport // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/transport"
+
+import (
+	"net"
+)
+
+// Peer credentials are only supported on Linux, unix socket peers are identified by the
+// address of the socket on other platforms.
+var credentialsOOBSize = 0
+
+func enablePassCred(_ *net.UnixConn) error {
+	return nil
+}
+
+func oobCredentials(_ string, _ []byte) *UnixPeerAddr {
+	return nil
+}
+
+func connCredentials(_ net.Conn) *UnixPeerAddr {
+	return nil
+}
@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package transport
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIPPeer(t *testing.T) {
+	udpPeer := ipPeer(&net.UDPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 5678})
+	tcpPeer := ipPeer(&net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 8765})
+	assert.Equal(t, "10.0.0.1", udpPeer.String())
+	assert.Equal(t, udpPeer, tcpPeer)
+	assert.Nil(t, ipPeer(&net.UnixAddr{Name: "statsd.sock", Net: "unix"}))
+}
+
+func TestRemoveStaleSocket(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "statsd.sock")
+	assert.NoError(t, removeStaleSocket(path))
+	ln, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	require.NoError(t, err)
+	require.NoError(t, ln.Close())
+	assert.NoError(t, removeStaleSocket(path))
+	assert.NoFileExists(t, path)
+
+	file := filepath.Join(dir, "statsd.txt")
+	require.NoError(t, os.WriteFile(file, nil, 0600))
+	assert.EqualError(t, removeStaleSocket(file), file+" already exists and is not a unix socket")
+	assert.FileExists(t, file)
+}
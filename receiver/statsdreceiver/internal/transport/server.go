@@ -32,6 +32,9 @@ type Server interface {
 type Metric struct {
 	Raw  string
 	Addr net.Addr
+	// Peer identifies the client that sent the metric: its IP address for network
+	// transports, or its process credentials for unix sockets when they are available.
+	Peer net.Addr
 }
 
 // Reporter is used to report (via zPages, logs, metrics, etc) the events
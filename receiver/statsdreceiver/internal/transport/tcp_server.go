@@ -28,7 +28,7 @@ type tcpServer struct {
 // Ensure that Server is implemented on TCP Server.
 var _ Server = (*tcpServer)(nil)
 
-// NewTCPServer creates a transport.Server using TCP as its transport.
+// NewTCPServer creates a transport.Server using TCP, or a unix stream socket, as its transport.
 func NewTCPServer(transport Transport, address string) (Server, error) {
 	var tsrv tcpServer
 	var err error
@@ -37,6 +37,12 @@ func NewTCPServer(transport Transport, address string) (Server, error) {
 		return nil, fmt.Errorf("NewTCPServer with %s: %w", transport.String(), ErrUnsupportedStreamTransport)
 	}
 
+	if transport == Unix {
+		if err = removeStaleSocket(address); err != nil {
+			return nil, err
+		}
+	}
+
 	tsrv.transport = transport
 	tsrv.listener, err = net.Listen(transport.String(), address)
 	if err != nil {
@@ -60,7 +66,8 @@ LOOP:
 		go func() {
 			c, err := t.listener.Accept()
 			if err != nil {
-				t.reporter.OnDebugf("TCP Transport - Accept error: %v",
+				t.reporter.OnDebugf("%s Transport - Accept error: %v",
+					t.transport,
 					err)
 			} else {
 				connChan <- c
@@ -80,12 +87,21 @@ LOOP:
 
 // handleConn is helper that parses the buffer and split it line by line to be parsed upstream.
 func (t *tcpServer) handleConn(c net.Conn, transferChan chan<- Metric) {
+	var peer net.Addr
+	if t.transport == Unix {
+		if credentials := connCredentials(c); credentials != nil {
+			peer = credentials
+		}
+	} else {
+		peer = ipPeer(c.RemoteAddr())
+	}
+
 	payload := make([]byte, 4096)
 	var remainder []byte
 	for {
 		n, err := c.Read(payload)
 		if err != nil {
-			t.reporter.OnDebugf("TCP transport (%s) Error reading payload: %v", c.LocalAddr(), err)
+			t.reporter.OnDebugf("%s transport (%s) Error reading payload: %v", t.transport, c.LocalAddr(), err)
 			t.wg.Done()
 			return
 		}
@@ -100,7 +116,7 @@ func (t *tcpServer) handleConn(c net.Conn, transferChan chan<- Metric) {
 			}
 			line := strings.TrimSpace(string(bytes))
 			if line != "" {
-				transferChan <- Metric{line, c.LocalAddr()}
+				transferChan <- Metric{Raw: line, Addr: c.LocalAddr(), Peer: peer}
 			}
 		}
 	}
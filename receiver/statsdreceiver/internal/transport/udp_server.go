@@ -55,7 +55,7 @@ func (u *udpServer) ListenAndServe(
 		if n > 0 {
 			bufCopy := make([]byte, n)
 			copy(bufCopy, buf)
-			u.handlePacket(bufCopy, addr, transferChan)
+			handlePacket(bufCopy, addr, ipPeer(addr), transferChan)
 		}
 		if err != nil {
 			reporter.OnDebugf("%s Transport (%s) - ReadFrom error: %v",
@@ -79,9 +79,10 @@ func (u *udpServer) Close() error {
 }
 
 // handlePacket is helper that parses the buffer and split it line by line to be parsed upstream.
-func (u *udpServer) handlePacket(
+func handlePacket(
 	data []byte,
 	addr net.Addr,
+	peer net.Addr,
 	transferChan chan<- Metric,
 ) {
 	buf := bytes.NewBuffer(data)
@@ -95,7 +96,7 @@ func (u *udpServer) handlePacket(
 		}
 		line := strings.TrimSpace(string(bytes))
 		if line != "" {
-			transferChan <- Metric{line, addr}
+			transferChan <- Metric{Raw: line, Addr: addr, Peer: peer}
 		}
 	}
 }
@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package transport // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/transport"
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer"
+)
+
+type unixgramServer struct {
+	conn *net.UnixConn
+	path string
+}
+
+// Ensure that Server is implemented on Unixgram Server.
+var _ Server = (*unixgramServer)(nil)
+
+// NewUnixgramServer creates a transport.Server using a unix datagram socket as its transport.
+// On Linux, the credentials of the sender of each datagram identify it as a peer.
+func NewUnixgramServer(transport Transport, address string) (Server, error) {
+	if transport != Unixgram {
+		return nil, fmt.Errorf("NewUnixgramServer with %s: %w", transport.String(), ErrUnsupportedPacketTransport)
+	}
+
+	if err := removeStaleSocket(address); err != nil {
+		return nil, err
+	}
+
+	conn, err := net.ListenUnixgram(transport.String(), &net.UnixAddr{Name: address, Net: transport.String()})
+	if err != nil {
+		return nil, fmt.Errorf("starting to listen %s socket: %w", transport.String(), err)
+	}
+
+	if err = enablePassCred(conn); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("enabling credentials on %s socket: %w", transport.String(), err)
+	}
+
+	return &unixgramServer{
+		conn: conn,
+		path: address,
+	}, nil
+}
+
+// ListenAndServe starts the server ready to receive metrics.
+func (u *unixgramServer) ListenAndServe(
+	nextConsumer consumer.Metrics,
+	reporter Reporter,
+	transferChan chan<- Metric,
+) error {
+	if nextConsumer == nil || reporter == nil {
+		return errNilListenAndServeParameters
+	}
+
+	buf := make([]byte, 65536)
+	oob := make([]byte, credentialsOOBSize)
+	for {
+		n, oobn, _, addr, err := u.conn.ReadMsgUnix(buf, oob)
+		if n > 0 {
+			bufCopy := make([]byte, n)
+			copy(bufCopy, buf)
+			var peer net.Addr
+			if credentials := oobCredentials(Unixgram.String(), oob[:oobn]); credentials != nil {
+				peer = credentials
+			}
+			handlePacket(bufCopy, u.senderAddr(addr), peer, transferChan)
+		}
+		if err != nil {
+			reporter.OnDebugf("%s Transport (%s) - ReadMsgUnix error: %v",
+				Unixgram,
+				u.conn.LocalAddr(),
+				err)
+			var netErr net.Error
+			if errors.As(err, &netErr) {
+				if netErr.Timeout() {
+					continue
+				}
+			}
+			return err
+		}
+	}
+}
+
+// senderAddr returns the address of the sender of a datagram, or the address of the server
+// when the sender socket is unnamed.
+func (u *unixgramServer) senderAddr(addr *net.UnixAddr) net.Addr {
+	if addr == nil || addr.Name == "" {
+		return u.conn.LocalAddr()
+	}
+	return addr
+}
+
+// Close closes the server and removes its socket file.
+func (u *unixgramServer) Close() error {
+	err := u.conn.Close()
+	if !strings.HasPrefix(u.path, "@") {
+		if removeErr := os.Remove(u.path); removeErr != nil && !errors.Is(removeErr, fs.ErrNotExist) {
+			err = errors.Join(err, removeErr)
+		}
+	}
+	return err
+}
@@ -14,17 +14,31 @@ import (
 	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver"
+	semconv "go.opentelemetry.io/collector/semconv/v1.22.0"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/protocol"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/transport"
 )
 
-var _ receiver.Metrics = (*statsdReceiver)(nil)
+// Attributes of the client from later semantic conventions
+const (
+	attributeClientAddress  = "client.address"
+	attributeProcessUserID  = "process.user.id"
+	attributeProcessGroupID = "process.group.id"
+)
+
+var (
+	_ receiver.Metrics = (*statsdReceiver)(nil)
+	_ receiver.Logs    = (*statsdReceiver)(nil)
+)
 
-// statsdReceiver implements the receiver.Metrics for StatsD protocol.
+// statsdReceiver implements the receiver.Metrics for StatsD protocol, and the receiver.Logs
+// for DogStatsD events and service checks.
 type statsdReceiver struct {
 	settings receiver.CreateSettings
 	config   *Config
@@ -33,6 +47,7 @@ type statsdReceiver struct {
 	reporter     transport.Reporter
 	parser       protocol.Parser
 	nextConsumer consumer.Metrics
+	logsConsumer consumer.Logs
 	cancel       context.CancelFunc
 }
 
@@ -65,13 +80,14 @@ func newReceiver(
 }
 
 func buildTransportServer(config Config) (transport.Server, error) {
-	// TODO: Add unix socket transport implementations
 	trans := transport.NewTransport(strings.ToLower(string(config.NetAddr.Transport)))
 	switch trans {
 	case transport.UDP, transport.UDP4, transport.UDP6:
 		return transport.NewUDPServer(trans, config.NetAddr.Endpoint)
-	case transport.TCP, transport.TCP4, transport.TCP6:
+	case transport.TCP, transport.TCP4, transport.TCP6, transport.Unix:
 		return transport.NewTCPServer(trans, config.NetAddr.Endpoint)
+	case transport.Unixgram:
+		return transport.NewUnixgramServer(trans, config.NetAddr.Endpoint)
 	}
 
 	return nil, fmt.Errorf("unsupported transport %q", string(config.NetAddr.Transport))
@@ -96,8 +112,13 @@ func (r *statsdReceiver) Start(ctx context.Context, _ component.Host) error {
 	if err != nil {
 		return err
 	}
+	metricsConsumer := r.nextConsumer
+	if metricsConsumer == nil {
+		// Only logs are consumed, but the server requires a metrics consumer
+		metricsConsumer = nopMetricsConsumer{}
+	}
 	go func() {
-		if err := r.server.ListenAndServe(r.nextConsumer, r.reporter, transferChan); err != nil {
+		if err := r.server.ListenAndServe(metricsConsumer, r.reporter, transferChan); err != nil {
 			if !errors.Is(err, net.ErrClosed) {
 				r.settings.TelemetrySettings.ReportStatus(component.NewFatalErrorEvent(err))
 			}
@@ -107,16 +128,13 @@ func (r *statsdReceiver) Start(ctx context.Context, _ component.Host) error {
 		for {
 			select {
 			case <-ticker.C:
-				batchMetrics := r.parser.GetMetrics()
-				for _, batch := range batchMetrics {
-					batchCtx := client.NewContext(ctx, batch.Info)
-
-					if err := r.Flush(batchCtx, batch.Metrics, r.nextConsumer); err != nil {
-						r.reporter.OnDebugf("Error flushing metrics", zap.Error(err))
-					}
-				}
+				r.flushAll(ctx)
 			case metric := <-transferChan:
-				if err := r.parser.Aggregate(metric.Raw, metric.Addr); err != nil {
+				addr := metric.Addr
+				if r.config.PartitionByPeer && metric.Peer != nil {
+					addr = metric.Peer
+				}
+				if err := r.parser.Aggregate(metric.Raw, addr); err != nil {
 					r.reporter.OnDebugf("Error aggregating metric", zap.Error(err))
 				}
 			case <-ctx.Done():
@@ -139,6 +157,65 @@ func (r *statsdReceiver) Shutdown(context.Context) error {
 	return err
 }
 
+// flushAll sends the metrics and logs aggregated during the interval to the consumers of the
+// receiver. Signals without a consumer are dropped.
+func (r *statsdReceiver) flushAll(ctx context.Context) {
+	batchMetrics := r.parser.GetMetrics()
+	if r.nextConsumer != nil {
+		for _, batch := range batchMetrics {
+			if r.config.PartitionByPeer {
+				setPeerAttributes(batch.Metrics.ResourceMetrics().At(0).Resource().Attributes(), batch.Info.Addr)
+			}
+			batchCtx := client.NewContext(ctx, batch.Info)
+
+			if err := r.Flush(batchCtx, batch.Metrics, r.nextConsumer); err != nil {
+				r.reporter.OnDebugf("Error flushing metrics", zap.Error(err))
+			}
+		}
+	}
+
+	batchLogs := r.parser.GetLogs()
+	if r.logsConsumer != nil {
+		for _, batch := range batchLogs {
+			if r.config.PartitionByPeer {
+				setPeerAttributes(batch.Logs.ResourceLogs().At(0).Resource().Attributes(), batch.Info.Addr)
+			}
+			batchCtx := client.NewContext(ctx, batch.Info)
+
+			if err := r.FlushLogs(batchCtx, batch.Logs, r.logsConsumer); err != nil {
+				r.reporter.OnDebugf("Error flushing logs", zap.Error(err))
+			}
+		}
+	}
+}
+
+// setPeerAttributes sets the client that sent the metrics or logs of a batch as resource attributes.
+func setPeerAttributes(attrs pcommon.Map, addr net.Addr) {
+	switch peer := addr.(type) {
+	case *net.IPAddr:
+		attrs.PutStr(attributeClientAddress, peer.IP.String())
+	case *transport.UnixPeerAddr:
+		attrs.PutInt(semconv.AttributeProcessPID, int64(peer.PID))
+		attrs.PutInt(attributeProcessUserID, int64(peer.UID))
+		attrs.PutInt(attributeProcessGroupID, int64(peer.GID))
+	}
+}
+
 func (r *statsdReceiver) Flush(ctx context.Context, metrics pmetric.Metrics, nextConsumer consumer.Metrics) error {
 	return nextConsumer.ConsumeMetrics(ctx, metrics)
 }
+
+func (r *statsdReceiver) FlushLogs(ctx context.Context, logs plog.Logs, nextConsumer consumer.Logs) error {
+	return nextConsumer.ConsumeLogs(ctx, logs)
+}
+
+// nopMetricsConsumer drops the metrics of a receiver that only has a logs pipeline
+type nopMetricsConsumer struct{}
+
+func (nopMetricsConsumer) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{}
+}
+
+func (nopMetricsConsumer) ConsumeMetrics(context.Context, pmetric.Metrics) error {
+	return nil
+}
@@ -6,6 +6,7 @@ package statsdreceiver
 import (
 	"context"
 	"errors"
+	"net"
 	"testing"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 
@@ -163,3 +165,56 @@ func Test_statsdreceiver_EndToEnd(t *testing.T) {
 		})
 	}
 }
+
+func TestStatsdReceiver_FlushLogs(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	rcv, err := newReceiver(receivertest.NewNopCreateSettings(), *cfg, nil)
+	require.NoError(t, err)
+	r := rcv.(*statsdReceiver)
+	sink := new(consumertest.LogsSink)
+	r.logsConsumer = sink
+
+	require.NoError(t, r.parser.Initialize(false, false, false, cfg.TimerHistogramMapping))
+	addr, err := net.ResolveUDPAddr("udp", "127.0.0.1:8125")
+	require.NoError(t, err)
+	require.NoError(t, r.parser.Aggregate("_e{5,4}:title|text|t:error", addr))
+	require.NoError(t, r.parser.Aggregate("_sc|app.health|0", addr))
+	// Metrics are dropped without a metrics consumer
+	require.NoError(t, r.parser.Aggregate("test.metric:42|c", addr))
+
+	r.flushAll(context.Background())
+	require.Len(t, sink.AllLogs(), 1)
+	logs := sink.AllLogs()[0]
+	assert.Equal(t, 2, logs.LogRecordCount())
+	record := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, "text", record.Body().Str())
+	assert.Equal(t, plog.SeverityNumberError, record.SeverityNumber())
+}
+
+func TestStatsdReceiver_PartitionByPeer(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.PartitionByPeer = true
+	rcv, err := newReceiver(receivertest.NewNopCreateSettings(), *cfg, nil)
+	require.NoError(t, err)
+	r := rcv.(*statsdReceiver)
+	sink := new(consumertest.MetricsSink)
+	r.nextConsumer = sink
+
+	require.NoError(t, r.parser.Initialize(false, false, false, cfg.TimerHistogramMapping))
+	ipPeer := &net.IPAddr{IP: net.IPv4(10, 0, 0, 1)}
+	unixPeer := &transport.UnixPeerAddr{Net: "unixgram", PID: 42, UID: 1000, GID: 2000}
+	require.NoError(t, r.parser.Aggregate("test.metric:1|c", ipPeer))
+	require.NoError(t, r.parser.Aggregate("test.metric:2|c", unixPeer))
+
+	r.flushAll(context.Background())
+	attributes := map[int64]map[string]any{}
+	for _, metrics := range sink.AllMetrics() {
+		rm := metrics.ResourceMetrics().At(0)
+		value := rm.ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).IntValue()
+		attributes[value] = rm.Resource().Attributes().AsRaw()
+	}
+	assert.Equal(t, map[int64]map[string]any{
+		1: {"client.address": "10.0.0.1"},
+		2: {"process.pid": int64(42), "process.user.id": int64(1000), "process.group.id": int64(2000)},
+	}, attributes)
+}
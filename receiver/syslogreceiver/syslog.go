@@ -11,6 +11,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/consumerretry"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/relp"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/syslog"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/tcp"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/udp"
@@ -68,6 +69,10 @@ func (cfg *SysLogConfig) Unmarshal(componentParser *confmap.Conf) error {
 		cfg.InputConfig.TCP = &tcp.NewConfig().BaseConfig
 	} else if componentParser.IsSet("udp") {
 		cfg.InputConfig.UDP = &udp.NewConfig().BaseConfig
+	} else if componentParser.IsSet("tls") {
+		cfg.InputConfig.TLS = syslog.NewTLSConfig()
+	} else if componentParser.IsSet("relp") {
+		cfg.InputConfig.RELP = &relp.NewConfig().BaseConfig
 	}
 
 	return componentParser.Unmarshal(cfg)
@@ -4,7 +4,9 @@
 package syslogreceiver
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"path/filepath"
@@ -16,6 +18,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/receiver/receivertest"
@@ -23,6 +26,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/consumerretry"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/relp"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/syslog"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/tcp"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/udp"
@@ -78,6 +82,45 @@ func testSyslog(t *testing.T, cfg *SysLogConfig) {
 	}
 }
 
+func TestSyslogWithRELP(t *testing.T) {
+	for _, consumerErr := range []error{nil, errors.New("rejected")} {
+		cfg := testdataRELPConfig()
+		sink := new(consumertest.LogsSink)
+		var next consumer.Logs = sink
+		if consumerErr != nil {
+			next = consumertest.NewErr(consumerErr)
+		}
+
+		rcvr, err := NewFactory().CreateLogsReceiver(context.Background(), receivertest.NewNopCreateSettings(), cfg, next)
+		require.NoError(t, err)
+		require.NoError(t, rcvr.Start(context.Background(), componenttest.NewNopHost()))
+
+		conn, err := net.Dial("tcp", "127.0.0.1:29019")
+		require.NoError(t, err)
+		msg := "<86>1 2021-02-28T00:00:02.003Z 192.168.1.1 SecureAuth0 23108 ID52020 [SecureAuth@27389] test msg"
+		_, err = fmt.Fprintf(conn, "1 open 0\n2 syslog %d %s\n", len(msg), msg)
+		require.NoError(t, err)
+
+		// The message is only acknowledged once the consumer accepted it
+		reader := bufio.NewReader(conn)
+		var responses []string
+		for len(responses) < 5 {
+			line, err := reader.ReadString('\n')
+			require.NoError(t, err)
+			responses = append(responses, line)
+		}
+		if consumerErr == nil {
+			assert.Equal(t, "2 rsp 6 200 OK\n", responses[4])
+			assert.Equal(t, 1, sink.LogRecordCount())
+		} else {
+			assert.Equal(t, "2 rsp 12 500 rejected\n", responses[4])
+		}
+
+		require.NoError(t, conn.Close())
+		require.NoError(t, rcvr.Shutdown(context.Background()))
+	}
+}
+
 func TestLoadConfig(t *testing.T) {
 	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
 	require.NoError(t, err)
@@ -123,6 +166,21 @@ func testdataUDPConfig() *SysLogConfig {
 	}
 }
 
+func testdataRELPConfig() *SysLogConfig {
+	return &SysLogConfig{
+		BaseConfig: adapter.BaseConfig{
+			Operators: []operator.Config{},
+		},
+		InputConfig: func() syslog.Config {
+			c := syslog.NewConfig()
+			c.RELP = &relp.NewConfig().BaseConfig
+			c.RELP.ListenAddress = "127.0.0.1:29019"
+			c.Protocol = "rfc5424"
+			return *c
+		}(),
+	}
+}
+
 func TestDecodeInputConfigFailure(t *testing.T) {
 	sink := new(consumertest.LogsSink)
 	factory := NewFactory()